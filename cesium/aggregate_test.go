@@ -0,0 +1,107 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Aggregation", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *cesium.DB
+				fs      xfs.FS
+				cleanUp func() error
+				index   cesium.ChannelKey
+				data    cesium.ChannelKey
+				uuid    cesium.ChannelKey
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				db = openDBOnFS(fs)
+				index = testutil.GenerateChannelKey()
+				data = testutil.GenerateChannelKey()
+				uuid = testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: data, Index: index, DataType: telem.Float32T},
+					cesium.Channel{Key: uuid, Index: index, DataType: telem.UUIDT},
+				)).To(Succeed())
+				Expect(db.Write(ctx, 0, cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{
+						telem.NewSecondsTSV(0, 1, 2, 3, 4, 5, 6, 7),
+						telem.NewSeriesV[float32](1, -2, 3, -4, 5, -6, 7, -8),
+					},
+				))).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			It("Should return the aggregate value of each bucket", func() {
+				i := MustSucceed(db.OpenIterator(cesium.IteratorConfig{
+					Bounds:      telem.TimeRangeMax,
+					Channels:    []cesium.ChannelKey{data},
+					Aggregation: cesium.Aggregation{Span: 2 * telem.Second, Func: cesium.AggregateMin},
+				}))
+				Expect(i.SeekFirst()).To(BeTrue())
+				Expect(i.Next(telem.TimeSpanMax)).To(BeTrue())
+				s := i.Value().Get(data)
+				Expect(s).To(HaveLen(1))
+				Expect(s[0].Data).To(Equal(telem.NewSeriesV[float32](-2, -4, -6, -8).Data))
+				Expect(s[0].TimeRange).To(Equal(telem.TimeRange{Start: 0, End: 8 * telem.SecondTS}))
+				Expect(i.Next(telem.TimeSpanMax)).To(BeFalse())
+				Expect(i.Close()).To(Succeed())
+			})
+
+			It("Should compute the mean of each bucket as a float64", func() {
+				i := MustSucceed(db.OpenIterator(cesium.IteratorConfig{
+					Bounds:      (2 * telem.SecondTS).Range(6 * telem.SecondTS),
+					Channels:    []cesium.ChannelKey{data},
+					Aggregation: cesium.Aggregation{Span: 4 * telem.Second, Func: cesium.AggregateMean},
+				}))
+				Expect(i.SeekFirst()).To(BeTrue())
+				Expect(i.Next(cesium.AutoSpan)).To(BeTrue())
+				s := i.Value().Get(data)
+				Expect(s).To(HaveLen(1))
+				Expect(s[0].DataType).To(Equal(telem.Float64T))
+				Expect(s[0].Data).To(Equal(telem.NewSeriesV[float64](-0.5).Data))
+				Expect(i.Close()).To(Succeed())
+			})
+
+			It("Should not allow aggregating channels with a non-numeric data type", func() {
+				Expect(db.OpenIterator(cesium.IteratorConfig{
+					Bounds:      telem.TimeRangeMax,
+					Channels:    []cesium.ChannelKey{uuid},
+					Aggregation: cesium.Aggregation{Span: telem.Second, Func: cesium.AggregateMax},
+				})).Error().To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not allow a non-positive aggregation span", func() {
+				Expect(db.OpenIterator(cesium.IteratorConfig{
+					Bounds:      telem.TimeRangeMax,
+					Channels:    []cesium.ChannelKey{data},
+					Aggregation: cesium.Aggregation{Span: -1 * telem.Second, Func: cesium.AggregateMax},
+				})).Error().To(HaveOccurredAs(validate.Error))
+			})
+		})
+	}
+})
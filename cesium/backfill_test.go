@@ -0,0 +1,159 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	"github.com/synnaxlabs/x/config"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Backfill", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *cesium.DB
+				fs      xfs.FS
+				cleanUp func() error
+				index   cesium.ChannelKey
+				data    cesium.ChannelKey
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				db = openDBOnFS(fs)
+				index = testutil.GenerateChannelKey()
+				data = testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: data, Index: index, DataType: telem.Int64T},
+				)).To(Succeed())
+				Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{telem.NewSecondsTSV(10, 11, 12), telem.NewSeriesV[int64](10, 11, 12)},
+				))).To(Succeed())
+				Expect(db.Write(ctx, 20*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{telem.NewSecondsTSV(20, 21, 22), telem.NewSeriesV[int64](20, 21, 22)},
+				))).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			backfill := func(policy cesium.OverlapPolicy, seconds ...int64) error {
+				w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{
+					Start:         telem.SecondTS,
+					Channels:      []cesium.ChannelKey{index, data},
+					Backfill:      config.True(),
+					OverlapPolicy: policy,
+				}))
+				timestamps := make([]telem.TimeStamp, len(seconds))
+				for i, s := range seconds {
+					timestamps[i] = telem.TimeStamp(s) * telem.SecondTS
+				}
+				if w.Write(cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{telem.NewSeries(timestamps), telem.NewSeries(seconds)},
+				)) {
+					w.Commit()
+				}
+				err := w.Error()
+				Expect(w.Close()).To(Succeed())
+				return err
+			}
+
+			read := func(key cesium.ChannelKey) []int64 {
+				var values []int64
+				for _, s := range MustSucceed(db.Read(ctx, telem.TimeRangeMax, key)).Get(key) {
+					for _, v := range telem.UnmarshalSlice[int64](s.Data, s.DataType) {
+						if key == index {
+							v /= int64(telem.Second)
+						}
+						values = append(values, v)
+					}
+				}
+				return values
+			}
+
+			It("Should write samples into the gaps around existing data", func() {
+				Expect(backfill(cesium.OverlapError, 5, 6, 15, 16, 25)).To(Succeed())
+				Expect(read(data)).To(Equal([]int64{5, 6, 10, 11, 12, 15, 16, 20, 21, 22, 25}))
+				Expect(read(index)).To(Equal([]int64{5, 6, 10, 11, 12, 15, 16, 20, 21, 22, 25}))
+			})
+
+			It("Should write into gaps over multiple commits", func() {
+				w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{
+					Start:    telem.SecondTS,
+					Channels: []cesium.ChannelKey{index, data},
+					Backfill: config.True(),
+				}))
+				for _, s := range []int64{5, 13, 14, 23} {
+					Expect(w.Write(cesium.NewFrame(
+						[]cesium.ChannelKey{index, data},
+						[]telem.Series{telem.NewSecondsTSV(telem.TimeStamp(s)), telem.NewSeriesV(s)},
+					))).To(BeTrue())
+					end, ok := w.Commit()
+					Expect(ok).To(BeTrue())
+					Expect(end).To(Equal(telem.TimeStamp(s)*telem.SecondTS + 1))
+				}
+				Expect(w.Close()).To(Succeed())
+				Expect(read(data)).To(Equal([]int64{5, 10, 11, 12, 13, 14, 20, 21, 22, 23}))
+			})
+
+			It("Should reject samples that overlap with existing data", func() {
+				Expect(backfill(cesium.OverlapError, 5, 11)).To(HaveOccurredAs(cesium.ErrWriteConflict))
+				Expect(read(data)).To(Equal([]int64{10, 11, 12, 20, 21, 22}))
+			})
+
+			It("Should keep existing data when samples overlap with it", func() {
+				Expect(backfill(cesium.OverlapKeepExisting, 9, 10, 11, 13, 20, 30)).To(Succeed())
+				Expect(read(data)).To(Equal([]int64{9, 10, 11, 12, 13, 20, 21, 22, 30}))
+			})
+
+			It("Should overwrite existing data when samples overlap with it", func() {
+				Expect(backfill(cesium.OverlapOverwrite, 11, 13, 21)).To(Succeed())
+				Expect(read(data)).To(Equal([]int64{10, 11, 13, 21, 22}))
+				Expect(read(index)).To(Equal([]int64{10, 11, 13, 21, 22}))
+			})
+
+			It("Should not overwrite an index that other channels depend on", func() {
+				other := testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(ctx, cesium.Channel{Key: other, Index: index, DataType: telem.Int64T})).To(Succeed())
+				Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{other},
+					[]telem.Series{telem.NewSeriesV[int64](1, 2, 3)},
+				))).To(Succeed())
+				Expect(backfill(cesium.OverlapOverwrite, 11)).To(HaveOccurredAs(validate.Error))
+				Expect(read(data)).To(Equal([]int64{10, 11, 12, 20, 21, 22}))
+			})
+
+			It("Should not allow timestamps that are not strictly increasing", func() {
+				Expect(backfill(cesium.OverlapError, 5, 4)).To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not open a backfilling writer without the index", func() {
+				_, err := db.OpenWriter(ctx, cesium.WriterConfig{
+					Start:    telem.SecondTS,
+					Channels: []cesium.ChannelKey{data},
+					Backfill: config.True(),
+				})
+				Expect(err).To(HaveOccurredAs(validate.Error))
+			})
+		})
+	}
+})
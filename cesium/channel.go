@@ -162,6 +162,9 @@ func (db *DB) validateNewChannel(ch Channel) error {
 	v := validate.New("cesium")
 	validate.Positive(v, "key", ch.Key)
 	validate.NotEmptyString(v, "data_type", ch.DataType)
+	v.Exec(ch.ValidateCompression)
+	v.Exec(ch.ValidateRetention)
+	v.Exec(ch.ValidateRollups)
 	v.Exec(func() error {
 		_, uOk := db.mu.unaryDBs[ch.Key]
 		_, vOk := db.mu.virtualDBs[ch.Key]
@@ -174,7 +177,6 @@ func (db *DB) validateNewChannel(ch Channel) error {
 		v.Ternaryf("index", ch.Index != 0, "virtual channel cannot be indexed")
 		v.Ternaryf("index", ch.Rate != 0, "virtual channel cannot have a rate")
 	} else {
-		v.Ternary("index", ch.DataType == telem.StringT, "persisted channels cannot have string data types")
 		if ch.IsIndex {
 			v.Ternary("data_type", ch.DataType != telem.TimeStampT, "index channel must be of type timestamp")
 			v.Ternaryf("index", ch.Index != 0 && ch.Index != ch.Key, "index channel cannot be indexed by another channel")
@@ -217,6 +219,11 @@ func (db *DB) RekeyChannel(oldKey ChannelKey, newKey core.ChannelKey) error {
 		if err := db.fs.Rename(oldDir, newDir); err != nil {
 			return err
 		}
+		if db.coldCfg != nil {
+			if err := db.coldCfg.FS.Rename(oldDir, newDir); err != nil {
+				return err
+			}
+		}
 		newFS, err := db.fs.Sub(keyToDirName(newKey))
 		if err != nil {
 			return err
@@ -226,12 +233,11 @@ func (db *DB) RekeyChannel(oldKey ChannelKey, newKey core.ChannelKey) error {
 		if newCh.IsIndex {
 			newCh.Index = newKey
 		}
-		_udb, err := unary.Open(unary.Config{
-			Instrumentation: db.Instrumentation,
-			MetaCodec:       db.metaCodec,
-			Channel:         newCh,
-			FS:              newFS,
-		})
+		cfg, err := db.unaryConfig(newCh, newFS)
+		if err != nil {
+			return err
+		}
+		_udb, err := unary.Open(cfg)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,202 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium
+
+import (
+	"math/rand"
+	"strconv"
+
+	"github.com/synnaxlabs/cesium/internal/domain"
+	"github.com/synnaxlabs/cesium/internal/meta"
+	"github.com/synnaxlabs/cesium/internal/unary"
+	"github.com/synnaxlabs/x/errors"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"go.uber.org/zap"
+)
+
+type (
+	Inconsistency     = domain.Inconsistency
+	InconsistencyKind = domain.InconsistencyKind
+)
+
+const (
+	InconsistencyIndexTruncated     = domain.InconsistencyIndexTruncated
+	InconsistencyInvalidPointer     = domain.InconsistencyInvalidPointer
+	InconsistencyOverlappingPointer = domain.InconsistencyOverlappingPointer
+	InconsistencyPointerOutOfBounds = domain.InconsistencyPointerOutOfBounds
+	InconsistencyUncoveredTail      = domain.InconsistencyUncoveredTail
+	InconsistencyCounter            = domain.InconsistencyCounter
+)
+
+// ChannelCheck is the result of checking the files of a single channel.
+type ChannelCheck struct {
+	// Key is the key of the channel, parsed from the name of its directory.
+	Key ChannelKey
+	// Meta is the error encountered while reading or validating the channel's meta
+	// file, if any. The channel's data is not checked if its meta file is invalid.
+	Meta error
+	// Inconsistencies are the problems found in the channel's index and domain files.
+	Inconsistencies []Inconsistency
+	// Quarantined is true if Repair moved the channel's directory aside because its
+	// meta file could not be recovered.
+	Quarantined bool
+}
+
+// Healthy returns true if no problems were found in the channel's files.
+func (c ChannelCheck) Healthy() bool { return c.Meta == nil && len(c.Inconsistencies) == 0 }
+
+// CheckReport is the result of checking the files of a database.
+type CheckReport struct {
+	// Channels contains the result of checking each channel in the database, ordered
+	// by the name of the channel's directory.
+	Channels []ChannelCheck
+}
+
+// Healthy returns true if no problems were found in any channel.
+func (r CheckReport) Healthy() bool {
+	for _, c := range r.Channels {
+		if !c.Healthy() {
+			return false
+		}
+	}
+	return true
+}
+
+// Check walks every channel directory of the database in the specified directory,
+// validating its meta file, index, domain files, and file counter, and reports any
+// inconsistencies found. Check accepts the same options as Open, and does not modify
+// any files. Check must not be called while the database is open.
+func Check(dirname string, opts ...Option) (CheckReport, error) {
+	return check(false, dirname, opts...)
+}
+
+// Repair checks the database in the specified directory in the same manner as Check,
+// and repairs the inconsistencies found so that Open succeeds:
+//   - Pointers that are invalid, overlap, or reference missing data are removed from
+//     the index, and the data they reference is no longer readable.
+//   - Data at the end of domain files not covered by any pointer is truncated.
+//   - File counters that are behind the largest file key are advanced.
+//   - Channels with a missing or invalid meta file are quarantined by renaming their
+//     directory, so that Open skips them. Their data is left in place for manual
+//     recovery.
+//
+// Repair returns a report of the problems found and repaired. Repair must not be
+// called while the database is open.
+func Repair(dirname string, opts ...Option) (CheckReport, error) {
+	return check(true, dirname, opts...)
+}
+
+func check(repair bool, dirname string, opts ...Option) (CheckReport, error) {
+	var report CheckReport
+	o := newOptions(dirname, opts...)
+	if err := openFS(o); err != nil {
+		return report, err
+	}
+	info, err := o.fs.List("")
+	if err != nil {
+		return report, err
+	}
+	for _, i := range info {
+		if !i.IsDir() {
+			continue
+		}
+		key, err := strconv.Atoi(i.Name())
+		if err != nil {
+			continue
+		}
+		c, err := checkChannel(o, ChannelKey(key), repair)
+		if err != nil {
+			return report, err
+		}
+		if !c.Healthy() {
+			o.L.Warn(
+				"found inconsistencies in channel",
+				zap.Uint32("channel", uint32(c.Key)),
+				zap.NamedError("meta", c.Meta),
+				zap.Stringers("inconsistencies", c.Inconsistencies),
+				zap.Bool("repaired", repair),
+			)
+		}
+		report.Channels = append(report.Channels, c)
+	}
+	return report, nil
+}
+
+func checkChannel(o *options, key ChannelKey, repair bool) (ChannelCheck, error) {
+	c := ChannelCheck{Key: key}
+	dirname := keyToDirName(key)
+	fs, err := o.fs.Sub(dirname)
+	if err != nil {
+		return c, err
+	}
+	ch, err := readMeta(fs, o, key)
+	if err != nil {
+		c.Meta = err
+		if !repair {
+			return c, nil
+		}
+		c.Quarantined = true
+		return c, o.fs.Rename(dirname, dirname+"-CORRUPT-"+strconv.Itoa(rand.Int()))
+	}
+	if ch.Virtual {
+		return c, nil
+	}
+	cfg := domain.Config{FS: fs}
+	if o.coldCfg != nil {
+		exists, err := o.coldCfg.FS.Exists(dirname)
+		if err != nil {
+			return c, err
+		}
+		if exists {
+			if cfg.ColdFS, err = o.coldCfg.FS.Sub(dirname); err != nil {
+				return c, err
+			}
+		}
+	}
+	if c.Inconsistencies, err = checkDomain(cfg, repair); err != nil || !ch.DataType.IsVariable() {
+		return c, err
+	}
+	// Channels with variable density data types store the offset tables of their
+	// domains in a separate domain database.
+	offsetsFS, err := fs.Sub(unary.OffsetsDirname)
+	if err != nil {
+		return c, err
+	}
+	inconsistencies, err := checkDomain(domain.Config{FS: offsetsFS}, repair)
+	c.Inconsistencies = append(c.Inconsistencies, inconsistencies...)
+	return c, err
+}
+
+func checkDomain(cfg domain.Config, repair bool) ([]domain.Inconsistency, error) {
+	if repair {
+		return domain.Repair(cfg)
+	}
+	return domain.Check(cfg)
+}
+
+// readMeta reads and validates the meta file of the channel with the given key
+// without creating it if it does not exist.
+func readMeta(fs xfs.FS, o *options, key ChannelKey) (Channel, error) {
+	exists, err := meta.Exists(fs)
+	if err != nil {
+		return Channel{}, err
+	}
+	if !exists {
+		return Channel{}, errors.New("meta file does not exist")
+	}
+	ch, err := meta.ReadOrCreate(fs, Channel{}, o.metaCodec)
+	if err != nil {
+		return ch, err
+	}
+	if ch.Key != key {
+		return ch, errors.Newf("meta file has key %d, but is stored in the directory for channel %d", ch.Key, key)
+	}
+	return ch, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Check", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				fs      xfs.FS
+				cleanUp func() error
+				index   cesium.ChannelKey
+				data    cesium.ChannelKey
+				virtual cesium.ChannelKey
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				index = testutil.GenerateChannelKey()
+				data = testutil.GenerateChannelKey()
+				virtual = testutil.GenerateChannelKey()
+				db := openDBOnFS(fs)
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: data, Index: index, DataType: telem.Int64T},
+					cesium.Channel{Key: virtual, Virtual: true, DataType: telem.Int64T},
+				)).To(Succeed())
+				Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{
+						telem.NewSecondsTSV(10, 11, 12),
+						telem.NewSeriesV[int64](1, 2, 3),
+					},
+				))).To(Succeed())
+				Expect(db.Close()).To(Succeed())
+			})
+			AfterEach(func() { Expect(cleanUp()).To(Succeed()) })
+
+			It("Should report a healthy database", func() {
+				report := MustSucceed(cesium.Check("", cesium.WithFS(fs)))
+				Expect(report.Healthy()).To(BeTrue())
+				Expect(report.Channels).To(HaveLen(3))
+			})
+
+			It("Should report and repair data not covered by the index", func() {
+				f := MustSucceed(fs.Open(path.Join(channelKeyToPath(data), "1.domain"), os.O_WRONLY))
+				MustSucceed(f.WriteAt([]byte{1, 2, 3, 4, 5, 6, 7, 8}, 24))
+				Expect(f.Close()).To(Succeed())
+
+				report := MustSucceed(cesium.Check("", cesium.WithFS(fs)))
+				Expect(report.Healthy()).To(BeFalse())
+				for _, c := range report.Channels {
+					if c.Key != data {
+						Expect(c.Healthy()).To(BeTrue())
+						continue
+					}
+					Expect(c.Inconsistencies).To(HaveLen(1))
+					Expect(c.Inconsistencies[0].Kind).To(Equal(cesium.InconsistencyUncoveredTail))
+				}
+
+				Expect(MustSucceed(cesium.Repair("", cesium.WithFS(fs))).Healthy()).To(BeFalse())
+				Expect(MustSucceed(cesium.Check("", cesium.WithFS(fs))).Healthy()).To(BeTrue())
+				db := openDBOnFS(fs)
+				f2 := MustSucceed(db.Read(ctx, telem.TimeRangeMax, data))
+				Expect(f2.Get(data)[0].Data).To(Equal(telem.NewSeriesV[int64](1, 2, 3).Data))
+				Expect(db.Close()).To(Succeed())
+			})
+
+			It("Should quarantine channels with an invalid meta file", func() {
+				Expect(fs.Remove(path.Join(channelKeyToPath(virtual), "meta.json"))).To(Succeed())
+				_, err := cesium.Open("", cesium.WithFS(fs), cesium.WithInstrumentation(PanicLogger()))
+				Expect(err).To(HaveOccurred())
+
+				report := MustSucceed(cesium.Check("", cesium.WithFS(fs)))
+				Expect(report.Healthy()).To(BeFalse())
+				Expect(MustSucceed(fs.Exists(channelKeyToPath(virtual)))).To(BeTrue())
+
+				report = MustSucceed(cesium.Repair("", cesium.WithFS(fs)))
+				for _, c := range report.Channels {
+					Expect(c.Quarantined).To(Equal(c.Key == virtual))
+				}
+				Expect(MustSucceed(fs.Exists(channelKeyToPath(virtual)))).To(BeFalse())
+				db := openDBOnFS(fs)
+				Expect(db.RetrieveChannel(ctx, virtual)).Error().To(HaveOccurredAs(cesium.ErrChannelNotFound))
+				Expect(MustSucceed(db.RetrieveChannel(ctx, data)).Key).To(Equal(data))
+				Expect(db.Close()).To(Succeed())
+			})
+		})
+	}
+})
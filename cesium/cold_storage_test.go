@@ -0,0 +1,120 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	"math"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Cold Storage", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, Ordered, func() {
+			var (
+				db          *cesium.DB
+				fs, coldFS  xfs.FS
+				cleanUp     func() error
+				coldCleanUp func() error
+				index       = testutil.GenerateChannelKey()
+				data        = testutil.GenerateChannelKey()
+				keys        = []cesium.ChannelKey{index, data}
+			)
+			openDB := func() *cesium.DB {
+				return MustSucceed(cesium.Open("",
+					cesium.WithGC(&cesium.GCConfig{
+						MaxGoroutine:  10,
+						GCTryInterval: 10 * telem.Millisecond.Duration(),
+						GCThreshold:   math.SmallestNonzeroFloat32,
+					}),
+					cesium.WithColdStorage(cesium.ColdStorageConfig{FS: coldFS, CacheSize: 1}),
+					cesium.WithFS(fs),
+					cesium.WithFileSize(40*telem.ByteSize),
+					cesium.WithInstrumentation(PanicLogger())))
+			}
+			BeforeAll(func() {
+				fs, cleanUp = makeFS()
+				coldFS, coldCleanUp = makeFS()
+				db = openDB()
+			})
+			AfterAll(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+				Expect(coldCleanUp()).To(Succeed())
+			})
+
+			It("Should return an error if the cold storage file system is not set", func() {
+				Expect(cesium.Open("", cesium.WithFS(fs), cesium.WithColdStorage(cesium.ColdStorageConfig{}))).
+					Error().To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should relocate full files to cold storage and read them back", func() {
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: data, Index: index, DataType: telem.Int64T},
+				)).To(Succeed())
+				for i := 0; i < 3; i++ {
+					start := telem.TimeStamp(i*10) * telem.SecondTS
+					Expect(db.Write(ctx, start, cesium.NewFrame(keys, []telem.Series{
+						telem.NewSeriesV[telem.TimeStamp](start, start+telem.SecondTS, start+2*telem.SecondTS, start+3*telem.SecondTS, start+4*telem.SecondTS),
+						telem.NewSeriesV[int64](int64(i), int64(i), int64(i), int64(i), int64(i)),
+					}))).To(Succeed())
+				}
+
+				Eventually(func(g Gomega) {
+					for _, key := range keys {
+						g.Expect(MustSucceed(coldFS.Exists(path.Join(channelKeyToPath(key), "1.domain")))).To(BeTrue())
+						g.Expect(MustSucceed(fs.Exists(path.Join(channelKeyToPath(key), "1.domain")))).To(BeFalse())
+					}
+				}).Should(Succeed())
+
+				f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, data))
+				Expect(f.Get(data)).To(HaveLen(3))
+				for i, s := range f.Get(data) {
+					Expect(s.Data).To(Equal(telem.NewSeriesV[int64](int64(i), int64(i), int64(i), int64(i), int64(i)).Data))
+				}
+			})
+
+			It("Should read relocated files after the database is reopened", func() {
+				Expect(db.Close()).To(Succeed())
+				db = openDB()
+				f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, index, data))
+				Expect(f.Get(index)).To(HaveLen(3))
+				Expect(f.Get(data)).To(HaveLen(3))
+			})
+
+			It("Should convert the data in relocated files", func() {
+				Expect(db.ConvertChannel(ctx, data, telem.Float64T)).To(Succeed())
+				f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, data))
+				Expect(f.Get(data)).To(HaveLen(3))
+				for i, s := range f.Get(data) {
+					v := float64(i)
+					Expect(s.Data).To(Equal(telem.NewSeriesV(v, v, v, v, v).Data))
+				}
+			})
+
+			It("Should remove relocated files when the channel is deleted", func() {
+				Expect(db.DeleteChannels([]cesium.ChannelKey{data, index})).To(Succeed())
+				for _, key := range keys {
+					Expect(MustSucceed(coldFS.Exists(channelKeyToPath(key)))).To(BeFalse())
+				}
+			})
+		})
+	}
+})
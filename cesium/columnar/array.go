@@ -0,0 +1,84 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package columnar
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// newArray returns an array of type t holding the samples in series. The caller is
+// responsible for releasing the array.
+func newArray(mem memory.Allocator, t arrow.DataType, series telem.Series) arrow.Array {
+	switch t.ID() {
+	case arrow.STRING:
+		b := array.NewStringBuilder(mem)
+		defer b.Release()
+		b.AppendValues(telem.UnmarshalStrings(series.Data), nil)
+		return b.NewArray()
+	case arrow.BINARY:
+		b := array.NewBinaryBuilder(mem, arrow.BinaryTypes.Binary)
+		defer b.Release()
+		for _, s := range telem.UnmarshalStrings(series.Data) {
+			b.Append([]byte(s))
+		}
+		return b.NewArray()
+	}
+	// Arrow stores fixed width values in little endian byte order, which is the same
+	// as telem.ByteOrder, so the series data can be used as the array's buffer as is.
+	data := array.NewData(
+		t,
+		int(series.Len()),
+		[]*memory.Buffer{nil, memory.NewBufferBytes(series.Data)},
+		nil,
+		0,
+		0,
+	)
+	defer data.Release()
+	return array.MakeFromData(data)
+}
+
+// newSeries returns a series of the given data type holding the values in arr.
+func newSeries(dt telem.DataType, arr arrow.Array) (telem.Series, error) {
+	if arr.NullN() > 0 {
+		return telem.Series{}, errors.Wrapf(
+			validate.Error,
+			"column of data type %s contains %d null values",
+			dt,
+			arr.NullN(),
+		)
+	}
+	switch a := arr.(type) {
+	case *array.String:
+		values := make([]string, a.Len())
+		for i := range values {
+			values[i] = a.Value(i)
+		}
+		return telem.Series{DataType: dt, Data: telem.MarshalStrings(values, dt)}, nil
+	case *array.Binary:
+		values := make([]string, a.Len())
+		for i := range values {
+			values[i] = string(a.Value(i))
+		}
+		return telem.Series{DataType: dt, Data: telem.MarshalStrings(values, dt)}, nil
+	}
+	var (
+		density = int(dt.Density())
+		start   = arr.Data().Offset() * density
+		buf     = arr.Data().Buffers()[1].Bytes()
+	)
+	series := telem.Series{DataType: dt, Data: make([]byte, arr.Len()*density)}
+	copy(series.Data, buf[start:start+len(series.Data)])
+	return series, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package columnar exports cesium channels to, and imports them from, columnar file
+// formats that can be read by analysis tools such as pandas and Polars: Apache Arrow
+// IPC streams and Apache Parquet files.
+//
+// A file holds the samples of an index channel and of any number of channels indexed
+// by it, with one column per channel. The index is always the first column, and is
+// stored as a nanosecond precision timestamp column. The key, data type, and index of
+// each channel are stored in the metadata of its column, so a file can be imported
+// into a cesium database without losing any information about its samples.
+package columnar
+
+import (
+	"strconv"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Format is a columnar file format.
+type Format uint8
+
+const (
+	// FormatIPC is the Apache Arrow IPC streaming format.
+	FormatIPC Format = iota
+	// FormatParquet is the Apache Parquet file format.
+	FormatParquet
+)
+
+// String implements fmt.Stringer.
+func (f Format) String() string {
+	switch f {
+	case FormatIPC:
+		return "ipc"
+	case FormatParquet:
+		return "parquet"
+	}
+	return "unknown"
+}
+
+const (
+	// keyMetadata is the column metadata key holding the key of a channel.
+	keyMetadata = "cesium.key"
+	// dataTypeMetadata is the column metadata key holding the data type of a channel.
+	dataTypeMetadata = "cesium.data_type"
+	// indexMetadata is the column metadata key holding the key of a channel's index.
+	indexMetadata = "cesium.index"
+)
+
+// timestampType is the arrow type of timestamp columns. Timestamps are stored in
+// nanoseconds since the unix epoch, so they are the same as telem.TimeStamp values.
+var timestampType = &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+
+// arrowType returns the arrow type of the column holding samples of the given data
+// type.
+func arrowType(dt telem.DataType) (arrow.DataType, error) {
+	switch dt {
+	case telem.TimeStampT:
+		return timestampType, nil
+	case telem.Float64T:
+		return arrow.PrimitiveTypes.Float64, nil
+	case telem.Float32T:
+		return arrow.PrimitiveTypes.Float32, nil
+	case telem.Int64T:
+		return arrow.PrimitiveTypes.Int64, nil
+	case telem.Int32T:
+		return arrow.PrimitiveTypes.Int32, nil
+	case telem.Int16T:
+		return arrow.PrimitiveTypes.Int16, nil
+	case telem.Int8T:
+		return arrow.PrimitiveTypes.Int8, nil
+	case telem.Uint64T:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case telem.Uint32T:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case telem.Uint16T:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case telem.Uint8T:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case telem.UUIDT:
+		return &arrow.FixedSizeBinaryType{ByteWidth: 16}, nil
+	case telem.StringT, telem.JSONT:
+		return arrow.BinaryTypes.String, nil
+	case telem.BytesT:
+		return arrow.BinaryTypes.Binary, nil
+	}
+	return nil, errors.Wrapf(validate.Error, "data type %s cannot be stored in a columnar file", dt)
+}
+
+// field returns the column of the given channel, which is indexed by the channel with
+// key index.
+func field(ch cesium.Channel, index cesium.ChannelKey) (arrow.Field, error) {
+	t, err := arrowType(ch.DataType)
+	if err != nil {
+		return arrow.Field{}, err
+	}
+	name := ch.Name
+	if name == "" {
+		name = strconv.FormatUint(uint64(ch.Key), 10)
+	}
+	return arrow.Field{
+		Name: name,
+		Type: t,
+		Metadata: arrow.NewMetadata(
+			[]string{keyMetadata, dataTypeMetadata, indexMetadata},
+			[]string{
+				strconv.FormatUint(uint64(ch.Key), 10),
+				string(ch.DataType),
+				strconv.FormatUint(uint64(index), 10),
+			},
+		),
+	}, nil
+}
+
+// channel returns the channel whose samples are stored in the given column.
+func channel(f arrow.Field) (cesium.Channel, error) {
+	key, err := keyFromMetadata(f, keyMetadata)
+	if err != nil {
+		return cesium.Channel{}, err
+	}
+	index, err := keyFromMetadata(f, indexMetadata)
+	if err != nil {
+		return cesium.Channel{}, err
+	}
+	dt, ok := f.Metadata.GetValue(dataTypeMetadata)
+	if !ok {
+		return cesium.Channel{}, errors.Wrapf(
+			validate.Error,
+			"column %s is missing the %s metadata",
+			f.Name,
+			dataTypeMetadata,
+		)
+	}
+	ch := cesium.Channel{
+		Key:      key,
+		Name:     f.Name,
+		DataType: telem.DataType(dt),
+		IsIndex:  key == index,
+	}
+	if !ch.IsIndex {
+		ch.Index = index
+	}
+	t, err := arrowType(ch.DataType)
+	if err != nil {
+		return cesium.Channel{}, err
+	}
+	if !arrow.TypeEqual(t, f.Type) {
+		return cesium.Channel{}, errors.Wrapf(
+			validate.Error,
+			"column %s has type %s, but channel data type %s requires type %s",
+			f.Name,
+			f.Type,
+			ch.DataType,
+			t,
+		)
+	}
+	return ch, nil
+}
+
+func keyFromMetadata(f arrow.Field, metadataKey string) (cesium.ChannelKey, error) {
+	v, ok := f.Metadata.GetValue(metadataKey)
+	if !ok {
+		return 0, errors.Wrapf(
+			validate.Error,
+			"column %s is missing the %s metadata",
+			f.Name,
+			metadataKey,
+		)
+	}
+	key, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(
+			validate.Error,
+			"column %s has invalid %s metadata %q",
+			f.Name,
+			metadataKey,
+			v,
+		)
+	}
+	return cesium.ChannelKey(key), nil
+}
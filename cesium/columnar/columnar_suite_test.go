@@ -0,0 +1,29 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package columnar_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+)
+
+var (
+	ctx         = context.Background()
+	fileSystems = testutil.FileSystems
+)
+
+func TestColumnar(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Columnar Suite")
+}
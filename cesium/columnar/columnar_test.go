@@ -0,0 +1,205 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package columnar_test
+
+import (
+	"bytes"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/columnar"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+func newUUIDs(ids ...uuid.UUID) telem.Series {
+	s := telem.Series{DataType: telem.UUIDT}
+	for _, id := range ids {
+		s.Data = append(s.Data, id[:]...)
+	}
+	return s
+}
+
+func openDB(fs xfs.FS) *cesium.DB {
+	return MustSucceed(cesium.Open(
+		"",
+		cesium.WithFS(fs),
+		cesium.WithInstrumentation(PanicLogger()),
+	))
+}
+
+var _ = Describe("Columnar", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				src, dst     *cesium.DB
+				cleanUp      func() error
+				index        cesium.ChannelKey
+				values       cesium.ChannelKey
+				counts       cesium.ChannelKey
+				labels       cesium.ChannelKey
+				ids          cesium.ChannelKey
+				id1, id2     = uuid.New(), uuid.New()
+				exportedKeys []cesium.ChannelKey
+			)
+			BeforeEach(func() {
+				var fs xfs.FS
+				fs, cleanUp = makeFS()
+				src = openDB(MustSucceed(fs.Sub("src")))
+				dst = openDB(MustSucceed(fs.Sub("dst")))
+				index = testutil.GenerateChannelKey()
+				values = testutil.GenerateChannelKey()
+				counts = testutil.GenerateChannelKey()
+				labels = testutil.GenerateChannelKey()
+				ids = testutil.GenerateChannelKey()
+				exportedKeys = []cesium.ChannelKey{values, counts, labels, ids}
+				Expect(src.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, Name: "time", IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: values, Name: "values", Index: index, DataType: telem.Float64T},
+					cesium.Channel{Key: counts, Index: index, DataType: telem.Uint64T},
+					cesium.Channel{Key: labels, Name: "labels", Index: index, DataType: telem.StringT},
+					cesium.Channel{Key: ids, Name: "ids", Index: index, DataType: telem.UUIDT},
+				)).To(Succeed())
+				Expect(src.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, values, counts, labels, ids},
+					[]telem.Series{
+						telem.NewSecondsTSV(10, 11),
+						telem.NewSeriesV(1.5, -2.5),
+						telem.NewSeriesV[uint64](1<<63, 7),
+						telem.NewStringsV("a", "bb"),
+						newUUIDs(id1, id2),
+					},
+				))).To(Succeed())
+				Expect(src.Write(ctx, 20*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, values, counts, labels, ids},
+					[]telem.Series{
+						telem.NewSecondsTSV(20),
+						telem.NewSeriesV(3.5),
+						telem.NewSeriesV[uint64](0),
+						telem.NewStringsV(""),
+						newUUIDs(id1),
+					},
+				))).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(src.Close()).To(Succeed())
+				Expect(dst.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			read := func(db *cesium.DB, key cesium.ChannelKey) telem.Series {
+				series := telem.Series{}
+				for _, s := range MustSucceed(db.Read(ctx, telem.TimeRangeMax, key)).Get(key) {
+					series.DataType = s.DataType
+					series.Data = append(series.Data, s.Data...)
+				}
+				return series
+			}
+
+			for _, format := range []columnar.Format{columnar.FormatIPC, columnar.FormatParquet} {
+				It("Should export and import channels without losing samples: "+format.String(), func() {
+					var buf bytes.Buffer
+					Expect(columnar.Export(ctx, src, &buf, columnar.ExportConfig{
+						Channels:  exportedKeys,
+						Format:    format,
+						ChunkSize: 2,
+					})).To(Succeed())
+					Expect(columnar.Import(ctx, dst, bytes.NewReader(buf.Bytes()), columnar.ImportConfig{
+						Format: format,
+					})).To(Succeed())
+					for _, key := range append([]cesium.ChannelKey{index}, exportedKeys...) {
+						srcCh := MustSucceed(src.RetrieveChannel(ctx, key))
+						dstCh := MustSucceed(dst.RetrieveChannel(ctx, key))
+						Expect(dstCh.DataType).To(Equal(srcCh.DataType))
+						Expect(dstCh.IsIndex).To(Equal(srcCh.IsIndex))
+						Expect(read(dst, key)).To(Equal(read(src, key)))
+					}
+					Expect(telem.UnmarshalStrings(read(dst, labels).Data)).To(Equal([]string{"a", "bb", ""}))
+				})
+			}
+
+			It("Should store the index as the first column with nanosecond timestamps", func() {
+				var buf bytes.Buffer
+				Expect(columnar.Export(ctx, src, &buf, columnar.ExportConfig{
+					Channels: []cesium.ChannelKey{values},
+				})).To(Succeed())
+				r := MustSucceed(ipc.NewReader(&buf))
+				defer r.Release()
+				fields := r.Schema().Fields()
+				Expect(fields).To(HaveLen(2))
+				Expect(fields[0].Name).To(Equal("time"))
+				Expect(fields[0].Type).To(Equal(arrow.DataType(&arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"})))
+				Expect(fields[1].Name).To(Equal("values"))
+				Expect(fields[1].Type).To(Equal(arrow.PrimitiveTypes.Float64))
+			})
+
+			It("Should only export samples in the configured time range", func() {
+				var buf bytes.Buffer
+				Expect(columnar.Export(ctx, src, &buf, columnar.ExportConfig{
+					Channels: []cesium.ChannelKey{values},
+					Bounds:   (11 * telem.SecondTS).Range(30 * telem.SecondTS),
+				})).To(Succeed())
+				Expect(columnar.Import(ctx, dst, bytes.NewReader(buf.Bytes()))).To(Succeed())
+				Expect(telem.UnmarshalSlice[float64](read(dst, values).Data, telem.Float64T)).
+					To(Equal([]float64{-2.5, 3.5}))
+			})
+
+			It("Should not export channels with different indexes", func() {
+				otherIndex := testutil.GenerateChannelKey()
+				other := testutil.GenerateChannelKey()
+				Expect(src.CreateChannel(
+					ctx,
+					cesium.Channel{Key: otherIndex, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: other, Index: otherIndex, DataType: telem.Float64T},
+				)).To(Succeed())
+				var buf bytes.Buffer
+				Expect(columnar.Export(ctx, src, &buf, columnar.ExportConfig{
+					Channels: []cesium.ChannelKey{values, other},
+				})).To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not import into a channel with a different data type", func() {
+				var buf bytes.Buffer
+				Expect(columnar.Export(ctx, src, &buf, columnar.ExportConfig{
+					Channels: []cesium.ChannelKey{values},
+				})).To(Succeed())
+				Expect(dst.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: values, Index: index, DataType: telem.Float32T},
+				)).To(Succeed())
+				Expect(columnar.Import(ctx, dst, bytes.NewReader(buf.Bytes()))).
+					To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should import into existing channels", func() {
+				var buf bytes.Buffer
+				Expect(columnar.Export(ctx, src, &buf, columnar.ExportConfig{
+					Channels: []cesium.ChannelKey{values},
+				})).To(Succeed())
+				Expect(dst.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: values, Index: index, DataType: telem.Float64T},
+				)).To(Succeed())
+				Expect(columnar.Import(ctx, dst, bytes.NewReader(buf.Bytes()))).To(Succeed())
+				Expect(read(dst, values)).To(Equal(read(src, values)))
+			})
+		})
+	}
+})
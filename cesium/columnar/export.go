@@ -0,0 +1,251 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package columnar
+
+import (
+	"context"
+	"io"
+	"slices"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// ExportConfig is the configuration for exporting channels to a columnar file.
+type ExportConfig struct {
+	// Channels are the keys of the channels to export. All channels must be indexed
+	// by the same index channel, which is exported along with them even if it is not
+	// in Channels.
+	// [REQUIRED]
+	Channels []cesium.ChannelKey
+	// Bounds is the time range of the samples to export.
+	// [OPTIONAL] - Defaults to telem.TimeRangeMax.
+	Bounds telem.TimeRange
+	// Format is the format of the exported file.
+	// [OPTIONAL] - Defaults to FormatIPC.
+	Format Format
+	// ChunkSize is the maximum number of rows in each record batch of an IPC stream,
+	// or in each row group of a Parquet file.
+	// [OPTIONAL] - Defaults to 100000.
+	ChunkSize int64
+	// Allocator is the allocator used for arrow buffers.
+	// [OPTIONAL] - Defaults to memory.DefaultAllocator.
+	Allocator memory.Allocator
+}
+
+var (
+	_ config.Config[ExportConfig] = ExportConfig{}
+	// DefaultExportConfig is the default configuration for Export.
+	DefaultExportConfig = ExportConfig{
+		Bounds:    telem.TimeRangeMax,
+		Format:    FormatIPC,
+		ChunkSize: 1e5,
+		Allocator: memory.DefaultAllocator,
+	}
+)
+
+// Override implements config.Config.
+func (c ExportConfig) Override(other ExportConfig) ExportConfig {
+	c.Channels = override.Slice(c.Channels, other.Channels)
+	c.Bounds = override.Zero(c.Bounds, other.Bounds)
+	c.Format = override.Numeric(c.Format, other.Format)
+	c.ChunkSize = override.Numeric(c.ChunkSize, other.ChunkSize)
+	c.Allocator = override.Nil(c.Allocator, other.Allocator)
+	return c
+}
+
+// Validate implements config.Config.
+func (c ExportConfig) Validate() error {
+	v := validate.New("columnar.export")
+	validate.NotEmptySlice(v, "channels", c.Channels)
+	validate.Positive(v, "chunk_size", c.ChunkSize)
+	validate.NotNil(v, "allocator", c.Allocator)
+	v.Ternaryf("format", c.Format > FormatParquet, "unknown format %d", c.Format)
+	return v.Error()
+}
+
+// Export writes the samples of the configured channels in the configured time range to
+// w. The index of the channels is written as the first column, followed by the
+// channels in the order they were provided.
+func Export(ctx context.Context, db *cesium.DB, w io.Writer, cfgs ...ExportConfig) (err error) {
+	cfg, err := config.New(DefaultExportConfig, cfgs...)
+	if err != nil {
+		return err
+	}
+	channels, err := exportedChannels(ctx, db, cfg.Channels)
+	if err != nil {
+		return err
+	}
+	schema, err := exportSchema(channels)
+	if err != nil {
+		return err
+	}
+	rw, err := openRecordWriter(w, schema, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Combine(err, rw.Close()) }()
+	keys := make([]cesium.ChannelKey, len(channels))
+	for i, ch := range channels {
+		keys[i] = ch.Key
+	}
+	i, err := db.OpenIterator(cesium.IteratorConfig{
+		Bounds:        cfg.Bounds,
+		Channels:      keys,
+		AutoChunkSize: cfg.ChunkSize,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Combine(err, i.Close()) }()
+	if i.SeekFirst() {
+		for i.Next(cesium.AutoSpan) {
+			if err = writeRecord(rw, schema, channels, i.Value(), cfg.Allocator); err != nil {
+				return err
+			}
+		}
+	}
+	return i.Error()
+}
+
+// exportedChannels retrieves the channels with the given keys, returning them preceded
+// by their common index.
+func exportedChannels(
+	ctx context.Context,
+	db *cesium.DB,
+	keys []cesium.ChannelKey,
+) ([]cesium.Channel, error) {
+	channels, err := db.RetrieveChannels(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+	var index cesium.ChannelKey
+	for _, ch := range channels {
+		if ch.Virtual {
+			return nil, errors.Wrapf(validate.Error, "cannot export virtual channel %v", ch)
+		}
+		chIndex := ch.Index
+		if ch.IsIndex {
+			chIndex = ch.Key
+		}
+		if chIndex == 0 {
+			return nil, errors.Wrapf(
+				validate.Error,
+				"cannot export channel %v because it does not have an index",
+				ch,
+			)
+		}
+		if index != 0 && chIndex != index {
+			return nil, errors.Wrapf(
+				validate.Error,
+				"cannot export channel %v because it is not indexed by channel <%d>",
+				ch,
+				index,
+			)
+		}
+		index = chIndex
+	}
+	idx, err := db.RetrieveChannel(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	exported := []cesium.Channel{idx}
+	for _, ch := range channels {
+		if !slices.ContainsFunc(exported, func(e cesium.Channel) bool { return e.Key == ch.Key }) {
+			exported = append(exported, ch)
+		}
+	}
+	return exported, nil
+}
+
+func exportSchema(channels []cesium.Channel) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, len(channels))
+	for i, ch := range channels {
+		f, err := field(ch, channels[0].Key)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = f
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// recordWriter writes arrow records to a file of a particular format.
+type recordWriter interface {
+	Write(rec arrow.Record) error
+	Close() error
+}
+
+func openRecordWriter(w io.Writer, schema *arrow.Schema, cfg ExportConfig) (recordWriter, error) {
+	if cfg.Format == FormatParquet {
+		return pqarrow.NewFileWriter(
+			schema,
+			w,
+			parquet.NewWriterProperties(
+				parquet.WithAllocator(cfg.Allocator),
+				parquet.WithMaxRowGroupLength(cfg.ChunkSize),
+			),
+			pqarrow.NewArrowWriterProperties(
+				pqarrow.WithStoreSchema(),
+				pqarrow.WithAllocator(cfg.Allocator),
+			),
+		)
+	}
+	return ipc.NewWriter(w, ipc.WithSchema(schema), ipc.WithAllocator(cfg.Allocator)), nil
+}
+
+// writeRecord writes the samples of each channel in frame as a single record.
+func writeRecord(
+	rw recordWriter,
+	schema *arrow.Schema,
+	channels []cesium.Channel,
+	frame cesium.Frame,
+	mem memory.Allocator,
+) error {
+	columns := make([]arrow.Array, len(channels))
+	defer func() {
+		for _, c := range columns {
+			if c != nil {
+				c.Release()
+			}
+		}
+	}()
+	for i, ch := range channels {
+		series := telem.Series{DataType: ch.DataType}
+		for _, s := range frame.Get(ch.Key) {
+			series.Data = append(series.Data, s.Data...)
+		}
+		columns[i] = newArray(mem, schema.Field(i).Type, series)
+		if columns[i].Len() != columns[0].Len() {
+			return errors.Newf(
+				"channel %v has %d samples, but its index has %d samples",
+				ch,
+				columns[i].Len(),
+				columns[0].Len(),
+			)
+		}
+	}
+	if columns[0].Len() == 0 {
+		return nil
+	}
+	rec := array.NewRecord(schema, columns, int64(columns[0].Len()))
+	defer rec.Release()
+	return rw.Write(rec)
+}
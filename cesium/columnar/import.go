@@ -0,0 +1,235 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package columnar
+
+import (
+	"context"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Source is a columnar file to import. *os.File and *bytes.Reader both implement
+// Source.
+type Source interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+}
+
+// ImportConfig is the configuration for importing a columnar file.
+type ImportConfig struct {
+	// Format is the format of the imported file.
+	// [OPTIONAL] - Defaults to FormatIPC.
+	Format Format
+	// ChunkSize is the maximum number of rows read from a Parquet file at a time.
+	// [OPTIONAL] - Defaults to 100000.
+	ChunkSize int64
+	// Allocator is the allocator used for arrow buffers.
+	// [OPTIONAL] - Defaults to memory.DefaultAllocator.
+	Allocator memory.Allocator
+}
+
+var (
+	_ config.Config[ImportConfig] = ImportConfig{}
+	// DefaultImportConfig is the default configuration for Import.
+	DefaultImportConfig = ImportConfig{
+		Format:    FormatIPC,
+		ChunkSize: 1e5,
+		Allocator: memory.DefaultAllocator,
+	}
+)
+
+// Override implements config.Config.
+func (c ImportConfig) Override(other ImportConfig) ImportConfig {
+	c.Format = override.Numeric(c.Format, other.Format)
+	c.ChunkSize = override.Numeric(c.ChunkSize, other.ChunkSize)
+	c.Allocator = override.Nil(c.Allocator, other.Allocator)
+	return c
+}
+
+// Validate implements config.Config.
+func (c ImportConfig) Validate() error {
+	v := validate.New("columnar.import")
+	validate.Positive(v, "chunk_size", c.ChunkSize)
+	validate.NotNil(v, "allocator", c.Allocator)
+	v.Ternaryf("format", c.Format > FormatParquet, "unknown format %d", c.Format)
+	return v.Error()
+}
+
+// Import writes the samples in a file created by Export to db through a single
+// cesium.Writer. Channels in the file that do not exist in db are created with the
+// key, data type, and index stored in the file. Channels that already exist must have
+// the same data type and index as in the file, and must not have any samples in the
+// time range of the file.
+func Import(ctx context.Context, db *cesium.DB, r Source, cfgs ...ImportConfig) (err error) {
+	cfg, err := config.New(DefaultImportConfig, cfgs...)
+	if err != nil {
+		return err
+	}
+	rr, err := openRecordReader(ctx, r, cfg)
+	if err != nil {
+		return err
+	}
+	defer rr.Release()
+	channels, err := importedChannels(rr.Schema())
+	if err != nil {
+		return err
+	}
+	if err = createChannels(ctx, db, channels); err != nil {
+		return err
+	}
+	keys := make([]cesium.ChannelKey, len(channels))
+	for i, ch := range channels {
+		keys[i] = ch.Key
+	}
+	var w *cesium.Writer
+	defer func() {
+		if w != nil {
+			err = errors.Combine(err, w.Close())
+		}
+	}()
+	for rr.Next() {
+		rec := rr.Record()
+		if rec.NumRows() == 0 {
+			continue
+		}
+		var frame cesium.Frame
+		if frame, err = recordFrame(rec, channels); err != nil {
+			return err
+		}
+		if w == nil {
+			start := telem.UnmarshalSlice[telem.TimeStamp](frame.Series[0].Data, telem.TimeStampT)[0]
+			if w, err = db.OpenWriter(ctx, cesium.WriterConfig{Start: start, Channels: keys}); err != nil {
+				return err
+			}
+		}
+		if !w.Write(frame) {
+			return w.Error()
+		}
+	}
+	// Parquet record readers report io.EOF once they have been exhausted.
+	if err = rr.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if w != nil {
+		if _, ok := w.Commit(); !ok {
+			return w.Error()
+		}
+	}
+	return nil
+}
+
+func openRecordReader(ctx context.Context, r Source, cfg ImportConfig) (array.RecordReader, error) {
+	if cfg.Format == FormatIPC {
+		return ipc.NewReader(r, ipc.WithAllocator(cfg.Allocator))
+	}
+	// The parquet reader is not closed, as closing it would also close r, which is
+	// owned by the caller.
+	pf, err := file.NewParquetReader(r)
+	if err != nil {
+		return nil, err
+	}
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: cfg.ChunkSize}, cfg.Allocator)
+	if err != nil {
+		return nil, err
+	}
+	return fr.GetRecordReader(ctx, nil, nil)
+}
+
+// importedChannels returns the channels stored in the columns of a file, checking
+// that the first column holds the index of all other columns.
+func importedChannels(schema *arrow.Schema) ([]cesium.Channel, error) {
+	if schema.NumFields() == 0 {
+		return nil, errors.Wrapf(validate.Error, "cannot import a file without columns")
+	}
+	channels := make([]cesium.Channel, schema.NumFields())
+	for i, f := range schema.Fields() {
+		ch, err := channel(f)
+		if err != nil {
+			return nil, err
+		}
+		channels[i] = ch
+	}
+	if !channels[0].IsIndex {
+		return nil, errors.Wrapf(
+			validate.Error,
+			"the first column of an imported file must hold an index channel, but column %s holds channel %v",
+			schema.Field(0).Name,
+			channels[0],
+		)
+	}
+	for _, ch := range channels[1:] {
+		if ch.Index != channels[0].Key {
+			return nil, errors.Wrapf(
+				validate.Error,
+				"channel %v in an imported file is not indexed by channel %v",
+				ch,
+				channels[0],
+			)
+		}
+	}
+	return channels, nil
+}
+
+// createChannels creates the channels that do not exist in db, and checks that the
+// data type and index of the channels that do exist match the channels in a file.
+func createChannels(ctx context.Context, db *cesium.DB, channels []cesium.Channel) error {
+	for _, ch := range channels {
+		existing, err := db.RetrieveChannel(ctx, ch.Key)
+		if errors.Is(err, cesium.ErrChannelNotFound) {
+			if err = db.CreateChannel(ctx, ch); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if existing.DataType != ch.DataType ||
+			existing.IsIndex != ch.IsIndex ||
+			(!ch.IsIndex && existing.Index != ch.Index) {
+			return errors.Wrapf(
+				validate.Error,
+				"channel %v in an imported file does not match existing channel %v",
+				ch,
+				existing,
+			)
+		}
+	}
+	return nil
+}
+
+// recordFrame returns a frame holding the samples in each column of rec.
+func recordFrame(rec arrow.Record, channels []cesium.Channel) (cesium.Frame, error) {
+	var (
+		keys   = make([]cesium.ChannelKey, len(channels))
+		series = make([]telem.Series, len(channels))
+		err    error
+	)
+	for i, ch := range channels {
+		keys[i] = ch.Key
+		if series[i], err = newSeries(ch.DataType, rec.Column(i)); err != nil {
+			return cesium.Frame{}, err
+		}
+	}
+	return cesium.NewFrame(keys, series), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Compression", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, Ordered, func() {
+			var (
+				db      *cesium.DB
+				fs      xfs.FS
+				cleanUp func() error
+				index   = testutil.GenerateChannelKey()
+				float   = testutil.GenerateChannelKey()
+				boolean = testutil.GenerateChannelKey()
+				keys    = []cesium.ChannelKey{index, float, boolean}
+			)
+			BeforeAll(func() {
+				fs, cleanUp = makeFS()
+				db = openDBOnFS(fs)
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT, Compression: cesium.CompressionDeltaOfDelta},
+					cesium.Channel{Key: float, Index: index, DataType: telem.Float64T, Compression: cesium.CompressionXOR},
+					cesium.Channel{Key: boolean, Index: index, DataType: telem.Uint8T, Compression: cesium.CompressionRLE},
+				)).To(Succeed())
+			})
+			AfterAll(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			DescribeTable("Validation", func(ch cesium.Channel) {
+				ch.Key = testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(ctx, ch)).To(HaveOccurredAs(validate.Error))
+			},
+				Entry("XOR on an integer channel", cesium.Channel{Rate: 1 * telem.Hz, DataType: telem.Int32T, Compression: cesium.CompressionXOR}),
+				Entry("Delta of delta on a float channel", cesium.Channel{Rate: 1 * telem.Hz, DataType: telem.Float32T, Compression: cesium.CompressionDeltaOfDelta}),
+				Entry("RLE on a uint16 channel", cesium.Channel{Rate: 1 * telem.Hz, DataType: telem.Uint16T, Compression: cesium.CompressionRLE}),
+				Entry("Compressed virtual channel", cesium.Channel{Virtual: true, DataType: telem.Float64T, Compression: cesium.CompressionXOR}),
+			)
+
+			It("Should transparently compress and decompress data across commits", func() {
+				By("Writing data in multiple commits")
+				w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{Start: 10 * telem.SecondTS, Channels: keys}))
+				Expect(w.Write(cesium.NewFrame(keys, []telem.Series{
+					telem.NewSecondsTSV(10, 11, 12, 13, 14),
+					telem.NewSeriesV[float64](1.5, 1.5, 1.75, 2, 2.25),
+					telem.NewSeriesV[uint8](0, 0, 1, 1, 1),
+				}))).To(BeTrue())
+				_, ok := w.Commit()
+				Expect(ok).To(BeTrue())
+				Expect(w.Write(cesium.NewFrame(keys, []telem.Series{
+					telem.NewSecondsTSV(15, 16, 17, 18, 19),
+					telem.NewSeriesV[float64](2.5, 2.75, 3, 3.25, 3.5),
+					telem.NewSeriesV[uint8](0, 0, 0, 1, 0),
+				}))).To(BeTrue())
+				_, ok = w.Commit()
+				Expect(ok).To(BeTrue())
+				Expect(w.Close()).To(Succeed())
+
+				By("Reading the data back")
+				f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, keys...))
+				Expect(f.Get(index)).To(HaveLen(1))
+				Expect(f.Get(index)[0].Data).To(Equal(telem.NewSecondsTSV(10, 11, 12, 13, 14, 15, 16, 17, 18, 19).Data))
+				Expect(f.Get(float)[0].Data).To(Equal(telem.NewSeriesV[float64](1.5, 1.5, 1.75, 2, 2.25, 2.5, 2.75, 3, 3.25, 3.5).Data))
+				Expect(f.Get(boolean)[0].Data).To(Equal(telem.NewSeriesV[uint8](0, 0, 1, 1, 1, 0, 0, 0, 1, 0).Data))
+
+				By("Reading a sub-range of the data")
+				f = MustSucceed(db.Read(ctx, (12 * telem.SecondTS).Range(17*telem.SecondTS), keys...))
+				Expect(f.Get(index)[0].Data).To(Equal(telem.NewSecondsTSV(12, 13, 14, 15, 16).Data))
+				Expect(f.Get(float)[0].Data).To(Equal(telem.NewSeriesV[float64](1.75, 2, 2.25, 2.5, 2.75).Data))
+			})
+
+			It("Should delete data from within a compressed domain", func() {
+				Expect(db.DeleteTimeRange(ctx, []cesium.ChannelKey{float, boolean}, (12 * telem.SecondTS).Range(16*telem.SecondTS))).To(Succeed())
+				f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, float, boolean))
+				Expect(f.Get(float)).To(HaveLen(2))
+				Expect(f.Get(float)[0].Data).To(Equal(telem.NewSeriesV[float64](1.5, 1.5).Data))
+				Expect(f.Get(float)[1].Data).To(Equal(telem.NewSeriesV[float64](2.75, 3, 3.25, 3.5).Data))
+				Expect(f.Get(boolean)[0].Data).To(Equal(telem.NewSeriesV[uint8](0, 0).Data))
+				Expect(f.Get(boolean)[1].Data).To(Equal(telem.NewSeriesV[uint8](0, 0, 1, 0).Data))
+			})
+
+			It("Should persist the compression setting and data across restarts", func() {
+				Expect(db.Close()).To(Succeed())
+				db = openDBOnFS(fs)
+				ch := MustSucceed(db.RetrieveChannel(ctx, float))
+				Expect(ch.Compression).To(Equal(cesium.CompressionXOR))
+				f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, index, float))
+				Expect(f.Get(index)[0].Data).To(Equal(telem.NewSecondsTSV(10, 11, 12, 13, 14, 15, 16, 17, 18, 19).Data))
+				Expect(f.Get(float)[1].Data).To(Equal(telem.NewSeriesV[float64](2.75, 3, 3.25, 3.5).Data))
+			})
+
+			It("Should reject writes to an RLE channel that are not 0 or 1", func() {
+				w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{Start: 100 * telem.SecondTS, Channels: keys}))
+				Expect(w.Write(cesium.NewFrame(keys, []telem.Series{
+					telem.NewSecondsTSV(100, 101),
+					telem.NewSeriesV[float64](1, 2),
+					telem.NewSeriesV[uint8](0, 2),
+				}))).To(BeTrue())
+				_, ok := w.Commit()
+				Expect(ok).To(BeFalse())
+				Expect(w.Close()).ToNot(Succeed())
+			})
+		})
+	}
+})
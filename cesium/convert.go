@@ -0,0 +1,320 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium
+
+import (
+	"context"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/cesium/internal/unary"
+	"github.com/synnaxlabs/cesium/internal/version"
+	"github.com/synnaxlabs/x/errors"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+	"go.uber.org/zap"
+)
+
+const (
+	// convertSuffix is appended to the directory name of a channel while its
+	// converted data is being written, so that a partially converted channel is never
+	// opened.
+	convertSuffix = "-CONVERT"
+	// replacedSuffix is appended to the directory name of a channel once it has been
+	// replaced by its converted directory, until it is removed.
+	replacedSuffix = "-REPLACED"
+	// convertedMarker is the name of the file written to the converted directory of a
+	// channel once all of its data has been converted. The marker is removed once the
+	// converted directory has replaced the channel's directory and the channel's cold
+	// storage has been removed.
+	convertedMarker = "CONVERTED"
+)
+
+// ConvertChannel changes the data type of the channel with the given key to dataType,
+// converting all of its existing samples using Go's numeric conversion rules. Only
+// channels with numeric data types can be converted, and index channels cannot be
+// converted, as they must always have the timestamp data type.
+//
+// The converted data is written to new domain files in the current version.Version
+// format, with each domain occupying the same time range as the domain it was
+// converted from. The channel keeps its key, index, and other properties, so data in
+// the channel remains aligned with its index. The channel's files are only replaced
+// once all of its data has been converted, so a failed conversion leaves the channel
+// unchanged. If the database crashes after the channel's data has been converted, the
+// conversion is completed when the database is reopened.
+//
+// ConvertChannel returns an error if the channel is being written to or read from.
+// Converting a channel to the data type it already has is a no-op.
+func (db *DB) ConvertChannel(ctx context.Context, key ChannelKey, dataType telem.DataType) (err error) {
+	if db.closed.Load() {
+		return errDBClosed
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	defer func() {
+		if err != nil {
+			db.L.Error(
+				"failed to convert channel",
+				zap.Uint32("key", key),
+				zap.String("data_type", string(dataType)),
+				zap.Error(err),
+			)
+		}
+	}()
+	return db.convertChannel(ctx, key, dataType)
+}
+
+func (db *DB) convertChannel(ctx context.Context, key ChannelKey, dataType telem.DataType) error {
+	if _, ok := db.mu.virtualDBs[key]; ok {
+		return errors.Wrapf(validate.Error, "cannot convert the data type of virtual channel <%d>", key)
+	}
+	udb, ok := db.mu.unaryDBs[key]
+	if !ok {
+		return core.NewErrChannelNotFound(key)
+	}
+	ch := udb.Channel()
+	if ch.DataType == dataType {
+		return nil
+	}
+	newCh := ch
+	newCh.DataType = dataType
+	newCh.Version = version.Current
+	if err := db.validateConversion(ch, newCh); err != nil {
+		return err
+	}
+	// Closing the database fails if it has any open writers or iterators, which
+	// guarantees that nothing modifies the channel while it is being converted.
+	if err := udb.Close(); err != nil {
+		return err
+	}
+	delete(db.mu.unaryDBs, key)
+	dirName := keyToDirName(key)
+	if err := db.writeConversion(ctx, ch, newCh); err != nil {
+		// Reopen the channel on its original files, which have not been modified.
+		return errors.Combine(
+			err,
+			errors.Combine(db.fs.Remove(dirName+convertSuffix), db.openVirtualOrUnary(ch)),
+		)
+	}
+	if err := db.markConverted(key); err != nil {
+		return errors.Combine(err, db.abortConversion(ch, newCh))
+	}
+	if err := db.finishConversion(key); err != nil {
+		return errors.Combine(err, db.abortConversion(ch, newCh))
+	}
+	if err := db.openVirtualOrUnary(newCh); err != nil {
+		return err
+	}
+	db.L.Info(
+		"converted channel",
+		zap.Uint32("key", key),
+		zap.String("from", string(ch.DataType)),
+		zap.String("to", string(dataType)),
+	)
+	return nil
+}
+
+func (db *DB) validateConversion(ch, newCh Channel) error {
+	if ch.IsIndex {
+		return errors.Wrapf(
+			validate.Error,
+			"cannot convert the data type of index channel %v, which must be %s",
+			ch,
+			telem.TimeStampT,
+		)
+	}
+	if !unary.Convertible(ch.DataType) || !unary.Convertible(newCh.DataType) {
+		return errors.Wrapf(
+			validate.Error,
+			"cannot convert channel %v from data type %s to %s",
+			ch,
+			ch.DataType,
+			newCh.DataType,
+		)
+	}
+	if err := newCh.ValidateCompression(); err != nil {
+		return err
+	}
+	return newCh.ValidateRollups()
+}
+
+// writeConversion opens the channel's data on its current files, and writes it
+// converted to newCh's data type into a temporary directory that replaces the
+// channel's directory once the conversion is complete.
+func (db *DB) writeConversion(ctx context.Context, ch, newCh Channel) (err error) {
+	fs, err := db.fs.Sub(keyToDirName(ch.Key))
+	if err != nil {
+		return err
+	}
+	// Remove any partially converted channel left behind by a previous conversion.
+	if err = db.fs.Remove(keyToDirName(ch.Key) + convertSuffix); err != nil {
+		return err
+	}
+	dstFS, err := db.fs.Sub(keyToDirName(ch.Key) + convertSuffix)
+	if err != nil {
+		return err
+	}
+	srcCfg, err := db.unaryConfig(ch, fs)
+	if err != nil {
+		return err
+	}
+	src, err := unary.Open(srcCfg)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Combine(err, src.Close()) }()
+	dstCfg, err := db.unaryConfig(newCh, dstFS)
+	if err != nil {
+		return err
+	}
+	// The converted data is written to hot storage, and relocated to cold storage by
+	// tiering once the channel is reopened.
+	dstCfg.ColdFS = nil
+	dst, err := unary.Open(dstCfg)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Combine(err, dst.Close()) }()
+	if ch.Index != 0 {
+		if idx, ok := db.mu.unaryDBs[ch.Index]; ok {
+			src.SetIndex(idx.Index())
+			dst.SetIndex(idx.Index())
+		}
+	}
+	return src.ConvertTo(ctx, dst)
+}
+
+// markConverted writes the marker that indicates that all of the data of the channel
+// with the given key has been converted, so that the conversion can be completed by
+// finishConversion if it is interrupted.
+func (db *DB) markConverted(key ChannelKey) error {
+	dirName := keyToDirName(key) + convertSuffix
+	f, err := db.fs.Open(path.Join(dirName, convertedMarker), os.O_CREATE|os.O_WRONLY)
+	if err != nil {
+		return err
+	}
+	if err = errors.Combine(f.Sync(), f.Close()); err != nil {
+		return err
+	}
+	return xfs.SyncDir(db.fs, dirName)
+}
+
+// finishConversion replaces the directory of the channel with the given key with its
+// converted directory, and then removes the channel's cold storage, which holds data in
+// the channel's old data type. Each step can be safely repeated, so finishConversion
+// completes a conversion that was interrupted at any point. A converted directory that
+// was not marked by markConverted holds partially converted data, and is removed.
+func (db *DB) finishConversion(key ChannelKey) error {
+	var (
+		dirName      = keyToDirName(key)
+		convertName  = dirName + convertSuffix
+		replacedName = dirName + replacedSuffix
+	)
+	converting, err := db.fs.Exists(convertName)
+	if err != nil {
+		return err
+	}
+	if converting {
+		converted, err := db.fs.Exists(path.Join(convertName, convertedMarker))
+		if err != nil {
+			return err
+		}
+		if !converted {
+			return db.fs.Remove(convertName)
+		}
+		exists, err := db.fs.Exists(dirName)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if err = db.fs.Rename(dirName, replacedName); err != nil {
+				return err
+			}
+		}
+		if err = db.fs.Rename(convertName, dirName); err != nil {
+			return err
+		}
+	}
+	marker := path.Join(dirName, convertedMarker)
+	marked, err := db.fs.Exists(marker)
+	if err != nil {
+		return err
+	}
+	if marked {
+		if err = db.removeColdStorage(key); err != nil {
+			return err
+		}
+		if err = db.fs.Remove(marker); err != nil {
+			return err
+		}
+	}
+	return db.fs.Remove(replacedName)
+}
+
+// abortConversion reopens a channel whose conversion failed to complete. If the
+// converted directory has not replaced the channel's directory yet, the conversion is
+// rolled back and the channel is reopened on its original data. Otherwise, the channel
+// is reopened on its converted data, and the rest of the conversion is completed when
+// the database is reopened.
+func (db *DB) abortConversion(ch, newCh Channel) error {
+	var (
+		dirName      = keyToDirName(ch.Key)
+		convertName  = dirName + convertSuffix
+		replacedName = dirName + replacedSuffix
+	)
+	converting, err := db.fs.Exists(convertName)
+	if err != nil {
+		return err
+	}
+	if !converting {
+		return db.openVirtualOrUnary(newCh)
+	}
+	exists, err := db.fs.Exists(dirName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err = db.fs.Rename(replacedName, dirName); err != nil {
+			return err
+		}
+	}
+	if err = db.fs.Remove(convertName); err != nil {
+		return err
+	}
+	return db.openVirtualOrUnary(ch)
+}
+
+// recoverConversions completes or rolls back any channel conversions that were
+// interrupted by a crash, given the entries in the root directory of the database.
+func (db *DB) recoverConversions(entries []os.FileInfo) error {
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		name, ok := strings.CutSuffix(e.Name(), convertSuffix)
+		if !ok {
+			if name, ok = strings.CutSuffix(e.Name(), replacedSuffix); !ok {
+				continue
+			}
+		}
+		key, err := strconv.Atoi(name)
+		if err != nil {
+			continue
+		}
+		if err = db.finishConversion(ChannelKey(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
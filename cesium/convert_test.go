@@ -0,0 +1,224 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Convert", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *cesium.DB
+				fs      xfs.FS
+				cleanUp func() error
+				index   cesium.ChannelKey
+				data    cesium.ChannelKey
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				db = openDBOnFS(fs)
+				index = testutil.GenerateChannelKey()
+				data = testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: data, Index: index, DataType: telem.Int32T},
+				)).To(Succeed())
+				Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{telem.NewSecondsTSV(10, 11, 12), telem.NewSeriesV[int32](-1, 2, 3)},
+				))).To(Succeed())
+				Expect(db.Write(ctx, 20*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{telem.NewSecondsTSV(20, 21), telem.NewSeriesV[int32](4, 5)},
+				))).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			read := func(tr telem.TimeRange) []float64 {
+				var values []float64
+				for _, s := range MustSucceed(db.Read(ctx, tr, data)).Get(data) {
+					Expect(s.DataType).To(Equal(telem.Float64T))
+					values = append(values, telem.UnmarshalSlice[float64](s.Data, s.DataType)...)
+				}
+				return values
+			}
+
+			It("Should convert the samples in the channel to the new data type", func() {
+				Expect(db.ConvertChannel(ctx, data, telem.Float64T)).To(Succeed())
+				ch := MustSucceed(db.RetrieveChannel(ctx, data))
+				Expect(ch.DataType).To(Equal(telem.Float64T))
+				Expect(ch.Index).To(Equal(index))
+				Expect(read(telem.TimeRangeMax)).To(Equal([]float64{-1, 2, 3, 4, 5}))
+			})
+
+			It("Should keep the converted samples aligned with the index", func() {
+				Expect(db.ConvertChannel(ctx, data, telem.Float64T)).To(Succeed())
+				Expect(read((11 * telem.SecondTS).Range(21 * telem.SecondTS))).To(Equal([]float64{2, 3, 4}))
+			})
+
+			It("Should allow writing samples of the new data type after conversion", func() {
+				Expect(db.ConvertChannel(ctx, data, telem.Float64T)).To(Succeed())
+				Expect(db.Write(ctx, 30*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{telem.NewSecondsTSV(30), telem.NewSeriesV[float64](6.5)},
+				))).To(Succeed())
+				Expect(read(telem.TimeRangeMax)).To(Equal([]float64{-1, 2, 3, 4, 5, 6.5}))
+			})
+
+			It("Should persist the conversion across reopening the database", func() {
+				Expect(db.ConvertChannel(ctx, data, telem.Float64T)).To(Succeed())
+				Expect(db.Close()).To(Succeed())
+				db = openDBOnFS(fs)
+				Expect(MustSucceed(db.RetrieveChannel(ctx, data)).DataType).To(Equal(telem.Float64T))
+				Expect(read(telem.TimeRangeMax)).To(Equal([]float64{-1, 2, 3, 4, 5}))
+			})
+
+			Describe("Recovery", func() {
+				var dir string
+				BeforeEach(func() { dir = channelKeyToPath(data) })
+				readInt32 := func() []int32 {
+					var values []int32
+					for _, s := range MustSucceed(db.Read(ctx, telem.TimeRangeMax, data)).Get(data) {
+						values = append(values, telem.UnmarshalSlice[int32](s.Data, s.DataType)...)
+					}
+					return values
+				}
+				// convertAndRestore converts the channel and closes the database, leaving
+				// the converted data in the channel's directory and a copy of the
+				// channel's original data in the returned directory.
+				convertAndRestore := func() string {
+					original := dir + "-ORIGINAL"
+					Expect(db.Close()).To(Succeed())
+					Expect(xfs.CopyDir(
+						MustSucceed(fs.Sub(dir)),
+						MustSucceed(fs.Sub(original)),
+					)).To(Succeed())
+					db = openDBOnFS(fs)
+					Expect(db.ConvertChannel(ctx, data, telem.Float64T)).To(Succeed())
+					Expect(db.Close()).To(Succeed())
+					return original
+				}
+				mark := func(dir string) {
+					f := MustSucceed(fs.Open(path.Join(dir, "CONVERTED"), os.O_CREATE|os.O_WRONLY))
+					Expect(f.Close()).To(Succeed())
+				}
+
+				It("Should complete a conversion interrupted before the directories were swapped", func() {
+					original := convertAndRestore()
+					Expect(fs.Rename(dir, dir+"-CONVERT")).To(Succeed())
+					mark(dir + "-CONVERT")
+					Expect(fs.Rename(original, dir)).To(Succeed())
+					db = openDBOnFS(fs)
+					Expect(read(telem.TimeRangeMax)).To(Equal([]float64{-1, 2, 3, 4, 5}))
+					Expect(MustSucceed(fs.Exists(dir + "-CONVERT"))).To(BeFalse())
+					Expect(MustSucceed(fs.Exists(dir + "-REPLACED"))).To(BeFalse())
+				})
+
+				It("Should complete a conversion interrupted while the directories were swapped", func() {
+					original := convertAndRestore()
+					Expect(fs.Rename(dir, dir+"-CONVERT")).To(Succeed())
+					mark(dir + "-CONVERT")
+					Expect(fs.Rename(original, dir+"-REPLACED")).To(Succeed())
+					db = openDBOnFS(fs)
+					Expect(read(telem.TimeRangeMax)).To(Equal([]float64{-1, 2, 3, 4, 5}))
+					Expect(MustSucceed(fs.Exists(dir + "-REPLACED"))).To(BeFalse())
+				})
+
+				It("Should complete a conversion interrupted after the directories were swapped", func() {
+					original := convertAndRestore()
+					mark(dir)
+					Expect(fs.Rename(original, dir+"-REPLACED")).To(Succeed())
+					db = openDBOnFS(fs)
+					Expect(read(telem.TimeRangeMax)).To(Equal([]float64{-1, 2, 3, 4, 5}))
+					Expect(MustSucceed(fs.Exists(path.Join(dir, "CONVERTED")))).To(BeFalse())
+					Expect(MustSucceed(fs.Exists(dir + "-REPLACED"))).To(BeFalse())
+				})
+
+				It("Should discard a partially converted channel", func() {
+					Expect(db.Close()).To(Succeed())
+					Expect(xfs.CopyDir(
+						MustSucceed(fs.Sub(dir)),
+						MustSucceed(fs.Sub(dir+"-CONVERT")),
+					)).To(Succeed())
+					db = openDBOnFS(fs)
+					Expect(readInt32()).To(Equal([]int32{-1, 2, 3, 4, 5}))
+					Expect(MustSucceed(fs.Exists(dir + "-CONVERT"))).To(BeFalse())
+				})
+			})
+
+			It("Should truncate samples converted to a narrower data type", func() {
+				Expect(db.ConvertChannel(ctx, data, telem.Float64T)).To(Succeed())
+				Expect(db.Write(ctx, 30*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{telem.NewSecondsTSV(30), telem.NewSeriesV[float64](6.7)},
+				))).To(Succeed())
+				Expect(db.ConvertChannel(ctx, data, telem.Int8T)).To(Succeed())
+				frame := MustSucceed(db.Read(ctx, telem.TimeRangeMax, data))
+				var values []int8
+				for _, s := range frame.Get(data) {
+					values = append(values, telem.UnmarshalSlice[int8](s.Data, s.DataType)...)
+				}
+				Expect(values).To(Equal([]int8{-1, 2, 3, 4, 5, 6}))
+			})
+
+			It("Should do nothing when converting to the channel's data type", func() {
+				Expect(db.ConvertChannel(ctx, data, telem.Int32T)).To(Succeed())
+				Expect(MustSucceed(db.RetrieveChannel(ctx, data)).DataType).To(Equal(telem.Int32T))
+			})
+
+			It("Should not convert an index channel", func() {
+				Expect(db.ConvertChannel(ctx, index, telem.Int64T)).To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not convert a channel to a variable density data type", func() {
+				Expect(db.ConvertChannel(ctx, data, telem.StringT)).To(HaveOccurredAs(validate.Error))
+				Expect(MustSucceed(db.RetrieveChannel(ctx, data)).DataType).To(Equal(telem.Int32T))
+			})
+
+			It("Should not convert a virtual channel", func() {
+				key := testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(ctx, cesium.Channel{Key: key, Virtual: true, DataType: telem.Int32T})).To(Succeed())
+				Expect(db.ConvertChannel(ctx, key, telem.Float64T)).To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should return an error if the channel does not exist", func() {
+				Expect(db.ConvertChannel(ctx, testutil.GenerateChannelKey(), telem.Float64T)).
+					To(HaveOccurredAs(cesium.ErrChannelNotFound))
+			})
+
+			It("Should not convert a channel that is being written to", func() {
+				w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{
+					Start:    30 * telem.SecondTS,
+					Channels: []cesium.ChannelKey{index, data},
+				}))
+				Expect(db.ConvertChannel(ctx, data, telem.Float64T)).To(HaveOccurred())
+				Expect(w.Close()).To(Succeed())
+				Expect(MustSucceed(db.RetrieveChannel(ctx, data)).DataType).To(Equal(telem.Int32T))
+				Expect(db.ConvertChannel(ctx, data, telem.Float64T)).To(Succeed())
+			})
+		})
+	}
+})
@@ -12,6 +12,7 @@ package cesium
 import (
 	"context"
 	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/cesium/internal/domain"
 	"github.com/synnaxlabs/cesium/internal/unary"
 	"github.com/synnaxlabs/cesium/internal/virtual"
 	"github.com/synnaxlabs/x/confluence"
@@ -23,9 +24,18 @@ import (
 )
 
 type (
-	Channel    = core.Channel
-	ChannelKey = core.ChannelKey
-	Frame      = core.Frame
+	Channel         = core.Channel
+	ChannelKey      = core.ChannelKey
+	Frame           = core.Frame
+	Compression     = core.Compression
+	RetentionPolicy = core.RetentionPolicy
+)
+
+const (
+	CompressionNone         = core.CompressionNone
+	CompressionXOR          = core.CompressionXOR
+	CompressionDeltaOfDelta = core.CompressionDeltaOfDelta
+	CompressionRLE          = core.CompressionRLE
 )
 
 func NewFrame(keys []core.ChannelKey, series []telem.Series) Frame {
@@ -35,6 +45,8 @@ func NewFrame(keys []core.ChannelKey, series []telem.Series) Frame {
 var (
 	errDBClosed        = core.EntityClosed("cesium.db")
 	ErrChannelNotFound = core.ErrChannelNotFound
+	// ErrWriteConflict is returned when a write overlaps with existing data.
+	ErrWriteConflict = domain.ErrWriteConflict
 )
 
 type DB struct {
@@ -51,6 +63,14 @@ type DB struct {
 			outlet   confluence.Outlet[WriterResponse]
 		}
 	}
+	retention struct {
+		sync.Mutex
+		metrics RetentionMetrics
+	}
+	// commitMu is held for reading by writers while committing, and for writing while
+	// capturing a snapshot, ensuring that snapshots are aligned to commit boundaries
+	// across all channels.
+	commitMu sync.RWMutex
 	closed   *atomic.Bool
 	shutdown io.Closer
 }
@@ -16,9 +16,12 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/cesium/internal/unary"
 	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/signal"
 	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
 	"go.uber.org/zap"
 	"golang.org/x/sync/semaphore"
 )
@@ -78,7 +81,15 @@ func (db *DB) DeleteChannel(ch ChannelKey) error {
 	})(); err != nil {
 		return err
 	}
-	return db.fs.Remove(newName)
+	return errors.Combine(db.fs.Remove(newName), db.removeColdStorage(ch))
+}
+
+// removeColdStorage removes any files relocated to cold storage for the given channel.
+func (db *DB) removeColdStorage(ch ChannelKey) error {
+	if db.coldCfg == nil {
+		return nil
+	}
+	return db.coldCfg.FS.Remove(keyToDirName(ch))
 }
 
 // DeleteChannels deletes many channels by their keys.
@@ -91,6 +102,7 @@ func (db *DB) DeleteChannels(chs []ChannelKey) (err error) {
 	var (
 		indexChannels       = make([]ChannelKey, 0, len(chs))
 		directoriesToRemove = make([]string, 0, len(chs))
+		removedChannels     = make([]ChannelKey, 0, len(chs))
 	)
 	db.mu.Lock()
 	// This 'defer' statement does a best-effort removal of all renamed directories
@@ -102,6 +114,9 @@ func (db *DB) DeleteChannels(chs []ChannelKey) (err error) {
 		for _, name := range directoriesToRemove {
 			c.Exec(func() error { return db.fs.Remove(name) })
 		}
+		for _, ch := range removedChannels {
+			c.Exec(func() error { return db.removeColdStorage(ch) })
+		}
 		err = errors.Combine(err, c.Error())
 	}()
 
@@ -131,6 +146,7 @@ func (db *DB) DeleteChannels(chs []ChannelKey) (err error) {
 		}
 
 		directoriesToRemove = append(directoriesToRemove, newName)
+		removedChannels = append(removedChannels, ch)
 	}
 
 	// Do another pass to remove all index channels
@@ -148,6 +164,7 @@ func (db *DB) DeleteChannels(chs []ChannelKey) (err error) {
 		}
 
 		directoriesToRemove = append(directoriesToRemove, newName)
+		removedChannels = append(removedChannels, ch)
 	}
 
 	return
@@ -265,9 +282,146 @@ func (db *DB) DeleteTimeRange(
 	return nil
 }
 
+// SetChannelRetention sets the retention policy of the channel with the given key. The
+// policy is enforced during the next run of garbage collection.
+func (db *DB) SetChannelRetention(_ context.Context, key ChannelKey, policy RetentionPolicy) error {
+	if db.closed.Load() {
+		return errDBClosed
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	udb, ok := db.mu.unaryDBs[key]
+	if !ok {
+		if vdb, vok := db.mu.virtualDBs[key]; vok {
+			return errors.Wrapf(
+				validate.Error,
+				"virtual channel %v cannot have a retention policy",
+				vdb.Channel(),
+			)
+		}
+		return core.NewErrChannelNotFound(key)
+	}
+	if err := udb.SetRetentionInMeta(policy); err != nil {
+		return err
+	}
+	db.mu.unaryDBs[key] = udb
+	return nil
+}
+
+// RetentionStats describes the data expired by retention policies.
+type RetentionStats struct {
+	// Domains is the number of domains that were expired.
+	Domains int
+	// Samples is the number of samples that were expired.
+	Samples int64
+	// Size is the logical (i.e. uncompressed) size of the expired data.
+	Size telem.Size
+}
+
+func (s *RetentionStats) add(res unary.ExpireResult) {
+	s.Domains += res.Domains
+	s.Samples += res.Samples
+	s.Size += res.Size
+}
+
+// RetentionMetrics describes the data expired by retention policies since the DB was
+// opened.
+type RetentionMetrics struct {
+	// RetentionStats is the total of all data expired across all channels.
+	RetentionStats
+	// Channels holds the data expired from each channel that has had data expired.
+	Channels map[ChannelKey]RetentionStats
+}
+
+// RetentionMetrics returns a snapshot of the data that has been expired by retention
+// policies since the DB was opened.
+func (db *DB) RetentionMetrics() RetentionMetrics {
+	db.retention.Lock()
+	defer db.retention.Unlock()
+	m := db.retention.metrics
+	m.Channels = make(map[ChannelKey]RetentionStats, len(db.retention.metrics.Channels))
+	for k, v := range db.retention.metrics.Channels {
+		m.Channels[k] = v
+	}
+	return m
+}
+
+func (db *DB) recordExpiry(ch Channel, res unary.ExpireResult) {
+	db.L.Info(
+		"expired data from channel by retention policy",
+		zap.Stringer("channel", ch),
+		zap.Int("domains", res.Domains),
+		zap.Int64("samples", res.Samples),
+		zap.Stringer("size", res.Size),
+		zap.Stringer("time_range", res.TimeRange),
+	)
+	db.retention.Lock()
+	defer db.retention.Unlock()
+	m := &db.retention.metrics
+	if m.Channels == nil {
+		m.Channels = make(map[ChannelKey]RetentionStats)
+	}
+	m.add(res)
+	chStats := m.Channels[ch.Key]
+	chStats.add(res)
+	m.Channels[ch.Key] = chStats
+}
+
+// enforceRetention expires data from all channels that have a retention policy. Data
+// channels are expired before index channels so that an index channel can expire the
+// data its dependents no longer need within the same run. An index channel never
+// expires data that a dependent channel still holds.
+func (db *DB) enforceRetention(ctx context.Context, now telem.TimeStamp) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	var indexDBs []unary.DB
+	for _, udb := range db.mu.unaryDBs {
+		if udb.Channel().IsIndex {
+			indexDBs = append(indexDBs, udb)
+			continue
+		}
+		if err := db.expire(ctx, udb, now, telem.TimeStampMax); err != nil {
+			return err
+		}
+	}
+	for _, udb := range indexDBs {
+		limit := telem.TimeStampMax
+		for _, other := range db.mu.unaryDBs {
+			if other.Channel().Key == udb.Channel().Key || other.Channel().Index != udb.Channel().Key {
+				continue
+			}
+			if tr := other.TimeRange(); !tr.IsZero() && tr.Start < limit {
+				limit = tr.Start
+			}
+		}
+		if err := db.expire(ctx, udb, now, limit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *DB) expire(ctx context.Context, udb unary.DB, now, limit telem.TimeStamp) error {
+	if udb.Channel().Retention.IsZero() {
+		return nil
+	}
+	res, err := udb.Expire(ctx, now, limit)
+	if err != nil {
+		return err
+	}
+	if res.Domains > 0 {
+		db.recordExpiry(udb.Channel(), res)
+	}
+	return nil
+}
+
 func (db *DB) garbageCollect(ctx context.Context, maxGoRoutine int64) error {
 	_, span := db.T.Debug(ctx, "garbage_collect")
 	defer span.End()
+	now := telem.Now()
+	if err := db.enforceRetention(ctx, now); err != nil {
+		return err
+	}
 	db.mu.RLock()
 	var (
 		sem          = semaphore.NewWeighted(maxGoRoutine)
@@ -282,7 +436,18 @@ func (db *DB) garbageCollect(ctx context.Context, maxGoRoutine int64) error {
 		udb := udb
 		sCtx.Go(func(_ctx context.Context) error {
 			defer sem.Release(1)
-			return udb.GarbageCollect(_ctx)
+			if err := udb.GarbageCollect(_ctx); err != nil || db.coldCfg == nil {
+				return err
+			}
+			n, err := udb.Tier(_ctx, now.Sub(db.coldCfg.After))
+			if n > 0 {
+				db.L.Debug(
+					"relocated files to cold storage",
+					zap.Stringer("channel", udb.Channel()),
+					zap.Int("files", n),
+				)
+			}
+			return err
 		}, signal.RecoverWithErrOnPanic())
 	}
 	db.mu.RUnlock()
@@ -0,0 +1,92 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package core
+
+import (
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// AggregateFunc is a function used to reduce the samples in a bucket of time to a
+// single value.
+type AggregateFunc uint8
+
+const (
+	// AggregateMin is the smallest sample in the bucket.
+	AggregateMin AggregateFunc = iota + 1
+	// AggregateMax is the largest sample in the bucket.
+	AggregateMax
+	// AggregateMean is the arithmetic mean of the samples in the bucket, stored as a
+	// float64.
+	AggregateMean
+	// AggregateFirst is the earliest sample in the bucket.
+	AggregateFirst
+	// AggregateLast is the latest sample in the bucket.
+	AggregateLast
+	// AggregateCount is the number of samples in the bucket, stored as an int64.
+	AggregateCount
+)
+
+// Aggregation configures an iterator to return a single aggregate value for each bucket
+// of time instead of the raw samples in the bucket.
+type Aggregation struct {
+	// Span is the width of each bucket. Buckets are aligned to the start of the
+	// iterator's bounds.
+	Span telem.TimeSpan
+	// Func is the function used to compute the aggregate value of each bucket.
+	Func AggregateFunc
+}
+
+// IsZero returns true if no aggregation is configured.
+func (a Aggregation) IsZero() bool { return a == Aggregation{} }
+
+// DataType returns the data type of the aggregate values computed over samples of the
+// given data type.
+func (a Aggregation) DataType(dt telem.DataType) telem.DataType {
+	switch a.Func {
+	case AggregateMean:
+		return telem.Float64T
+	case AggregateCount:
+		return telem.Int64T
+	default:
+		return dt
+	}
+}
+
+// Validate returns an error if the aggregation cannot be computed over the samples of
+// the given channel.
+func (a Aggregation) Validate(ch Channel) error {
+	if a.IsZero() {
+		return nil
+	}
+	if a.Span <= 0 {
+		return errors.Wrapf(validate.Error, "aggregation span for channel %v must be positive", ch)
+	}
+	if a.Func < AggregateMin || a.Func > AggregateCount {
+		return errors.Wrapf(validate.Error, "unknown aggregate function %d for channel %v", a.Func, ch)
+	}
+	return validateAggregatable(ch)
+}
+
+// validateAggregatable returns an error if the samples of the given channel cannot be
+// aggregated. Only fixed density, numeric data types (i.e. not UUIDs) can be
+// aggregated.
+func validateAggregatable(ch Channel) error {
+	if d := ch.DataType.Density(); ch.DataType.IsVariable() || d == telem.DensityUnknown || d > telem.Bit64 {
+		return errors.Wrapf(
+			validate.Error,
+			"cannot aggregate channel %v with data type %s",
+			ch,
+			ch.DataType,
+		)
+	}
+	return nil
+}
@@ -11,7 +11,10 @@ package core
 
 import (
 	"fmt"
+	"slices"
+
 	"github.com/synnaxlabs/cesium/internal/version"
+	"github.com/synnaxlabs/x/binary/compress"
 	"github.com/synnaxlabs/x/control"
 	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/telem"
@@ -68,6 +71,145 @@ type Channel struct {
 	Concurrency control.Concurrency `json:"concurrency" msgpack:"concurrency"`
 	// Version specifies the format of files stored in this channel.
 	Version version.Version `json:"version" msgpack:"version"`
+	// Compression is the algorithm used to compress the channel's samples before
+	// they are persisted to disk. Compression is applied independently to the data
+	// written in each commit, and is transparently reversed when reading.
+	// [OPTIONAL] - Defaults to CompressionNone
+	Compression Compression `json:"compression" msgpack:"compression"`
+	// Retention is the policy used to automatically expire the channel's data during
+	// garbage collection.
+	// [OPTIONAL] - Defaults to retaining all data
+	Retention RetentionPolicy `json:"retention" msgpack:"retention"`
+	// Rollups are the bucket spans of the pre-computed summary tiers maintained for
+	// the channel. Each tier stores the minimum, maximum, mean, first, last, and count
+	// of the samples in every bucket, and is used by aggregating iterators to avoid
+	// reading raw samples. Rollups are derived from the channel's data, and are not
+	// included in snapshots.
+	// [OPTIONAL] - Defaults to no rollups
+	Rollups []telem.TimeSpan `json:"rollups" msgpack:"rollups"`
+}
+
+// RetentionPolicy defines how long and how much of a channel's data is kept. Data is
+// always expired in whole domains, so a channel may temporarily hold slightly more
+// data than its policy allows.
+type RetentionPolicy struct {
+	// MaxAge is the maximum age of a domain, measured from its end, before it is
+	// expired. A value of zero disables age based expiry.
+	MaxAge telem.TimeSpan `json:"max_age" msgpack:"max_age"`
+	// MaxSize is the maximum logical (i.e. uncompressed) size of the channel's data.
+	// When exceeded, the oldest domains are expired first. A value of zero disables
+	// size based expiry.
+	MaxSize telem.Size `json:"max_size" msgpack:"max_size"`
+}
+
+// IsZero returns true if the policy retains all data.
+func (r RetentionPolicy) IsZero() bool { return r.MaxAge == 0 && r.MaxSize == 0 }
+
+// Cutoff returns the timestamp before which all data is expired by the policy's
+// MaxAge relative to now, or zero if the policy has no MaxAge.
+func (r RetentionPolicy) Cutoff(now telem.TimeStamp) telem.TimeStamp {
+	if r.MaxAge == 0 {
+		return 0
+	}
+	return now.Sub(r.MaxAge)
+}
+
+// ValidateRetention validates that the channel's retention policy is well-defined.
+func (c Channel) ValidateRetention() error {
+	if c.Retention.IsZero() {
+		return nil
+	}
+	if c.Virtual {
+		return errors.Wrapf(validate.Error, "virtual channel %v cannot have a retention policy", c)
+	}
+	if c.Retention.MaxAge < 0 {
+		return errors.Wrapf(validate.Error, "retention max age for channel %v must be non-negative", c)
+	}
+	if c.Retention.MaxSize < 0 {
+		return errors.Wrapf(validate.Error, "retention max size for channel %v must be non-negative", c)
+	}
+	return nil
+}
+
+// ValidateRollups validates that the channel's rollup tiers are well-defined.
+func (c Channel) ValidateRollups() error {
+	if len(c.Rollups) == 0 {
+		return nil
+	}
+	if c.Virtual {
+		return errors.Wrapf(validate.Error, "virtual channel %v cannot have rollups", c)
+	}
+	for i, span := range c.Rollups {
+		if span <= 0 {
+			return errors.Wrapf(validate.Error, "rollup span for channel %v must be positive", c)
+		}
+		if slices.Contains(c.Rollups[:i], span) {
+			return errors.Wrapf(validate.Error, "duplicate rollup span %s for channel %v", span, c)
+		}
+	}
+	return validateAggregatable(c)
+}
+
+// Compression is an algorithm used to compress the samples persisted by a channel.
+type Compression uint8
+
+const (
+	// CompressionNone stores samples uncompressed.
+	CompressionNone Compression = iota
+	// CompressionXOR stores float32 and float64 samples using Gorilla XOR compression.
+	CompressionXOR
+	// CompressionDeltaOfDelta stores int64 and timestamp samples as variable width
+	// deltas of deltas. It is well suited for index channels.
+	CompressionDeltaOfDelta
+	// CompressionRLE run-length encodes uint8 samples whose values are 0 or 1.
+	CompressionRLE
+)
+
+// Codec returns the compressor used to compress samples of the given data type, or nil
+// if the compression is CompressionNone.
+func (c Compression) Codec(dt telem.DataType) compress.CompressorDecompressor {
+	switch c {
+	case CompressionXOR:
+		return compress.XOR{Width: int(dt.Density())}
+	case CompressionDeltaOfDelta:
+		return compress.DeltaOfDelta{}
+	case CompressionRLE:
+		return compress.RunLength{}
+	default:
+		return nil
+	}
+}
+
+// ValidateCompression validates that the channel's compression algorithm supports
+// its data type.
+func (c Channel) ValidateCompression() error {
+	if c.Compression == CompressionNone {
+		return nil
+	}
+	if c.Virtual {
+		return errors.Wrapf(validate.Error, "virtual channel %v cannot be compressed", c)
+	}
+	var ok bool
+	switch c.Compression {
+	case CompressionXOR:
+		ok = c.DataType == telem.Float32T || c.DataType == telem.Float64T
+	case CompressionDeltaOfDelta:
+		ok = c.DataType == telem.TimeStampT || c.DataType == telem.Int64T
+	case CompressionRLE:
+		ok = c.DataType == telem.Uint8T
+	default:
+		return errors.Wrapf(validate.Error, "unknown compression %d for channel %v", c.Compression, c)
+	}
+	if !ok {
+		return errors.Wrapf(
+			validate.Error,
+			"compression %d is not supported for channel %v with data type %s",
+			c.Compression,
+			c,
+			c.DataType,
+		)
+	}
+	return nil
 }
 
 func (c Channel) String() string {
@@ -0,0 +1,299 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/errors"
+	xfs "github.com/synnaxlabs/x/io/fs"
+)
+
+// InconsistencyKind is the kind of problem found in the files of a DB.
+type InconsistencyKind uint8
+
+const (
+	// InconsistencyIndexTruncated means the index file ends with a partially written
+	// pointer.
+	InconsistencyIndexTruncated InconsistencyKind = iota + 1
+	// InconsistencyInvalidPointer means a pointer has an invalid time range or file key.
+	InconsistencyInvalidPointer
+	// InconsistencyOverlappingPointer means a pointer is out of order with or overlaps
+	// the pointer before it.
+	InconsistencyOverlappingPointer
+	// InconsistencyPointerOutOfBounds means a pointer references data past the end of
+	// its file, or references a file that does not exist.
+	InconsistencyPointerOutOfBounds
+	// InconsistencyUncoveredTail means a file contains data after the end of the last
+	// pointer that references it.
+	InconsistencyUncoveredTail
+	// InconsistencyCounter means the file counter is behind the largest file key in
+	// the DB, which would cause new files to overwrite existing ones.
+	InconsistencyCounter
+)
+
+// Inconsistency is a problem found in the files of a DB by Check or Repair.
+type Inconsistency struct {
+	// Kind is the kind of problem.
+	Kind InconsistencyKind
+	// FileKey is the key of the domain file involved in the problem, or 0 if the
+	// problem does not involve a particular file.
+	FileKey uint16
+	// Message is a human-readable description of the problem.
+	Message string
+}
+
+// String implements fmt.Stringer.
+func (i Inconsistency) String() string { return i.Message }
+
+// Check verifies that the index, domain files, and file counter of the DB stored in
+// the file system of the given configuration are consistent with each other, and
+// returns any inconsistencies found. Check does not modify any files, and must not be
+// called while the DB is open.
+func Check(configs ...Config) ([]Inconsistency, error) {
+	return check(false, configs...)
+}
+
+// Repair checks the DB stored in the file system of the given configuration in the
+// same manner as Check, and repairs any inconsistencies found so that the DB can be
+// opened: pointers that are invalid, overlap, or reference missing data are removed,
+// uncovered data at the end of files is truncated, and the file counter is advanced.
+// Repair returns the inconsistencies that were repaired, and must not be called while
+// the DB is open.
+func Repair(configs ...Config) ([]Inconsistency, error) {
+	return check(true, configs...)
+}
+
+func check(repair bool, configs ...Config) ([]Inconsistency, error) {
+	cfg, err := config.New(DefaultConfig, configs...)
+	if err != nil {
+		return nil, err
+	}
+	c := checker{cfg: cfg}
+	if err = c.loadFiles(); err != nil {
+		return nil, err
+	}
+	if err = c.checkIndex(); err != nil {
+		return nil, err
+	}
+	ends := c.checkFiles()
+	if err = c.checkCounter(); err != nil {
+		return nil, err
+	}
+	if !repair || len(c.found) == 0 {
+		return c.found, nil
+	}
+	return c.found, c.repair(ends)
+}
+
+type checker struct {
+	cfg Config
+	// files maps the key of each domain file in the DB to its size and the file system
+	// it is stored in.
+	files map[uint16]checkedFile
+	// pointers are the pointers that remain valid after checking.
+	pointers []pointer
+	// rewriteIndex is true if the index must be rewritten to repair the DB.
+	rewriteIndex bool
+	found        []Inconsistency
+}
+
+type checkedFile struct {
+	fs   xfs.FS
+	size int64
+}
+
+func (c *checker) report(kind InconsistencyKind, fileKey uint16, format string, args ...any) {
+	c.found = append(c.found, Inconsistency{
+		Kind:    kind,
+		FileKey: fileKey,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// loadFiles finds all domain files in the DB. Files in the cold tier are only used if
+// there is no local copy, mirroring the behavior of the file controller.
+func (c *checker) loadFiles() error {
+	c.files = make(map[uint16]checkedFile)
+	for _, fs := range []xfs.FS{c.cfg.ColdFS, c.cfg.FS} {
+		if fs == nil {
+			continue
+		}
+		entries, err := fs.List("")
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			name, ok := strings.CutSuffix(e.Name(), extension)
+			if !ok || e.IsDir() {
+				continue
+			}
+			key, err := strconv.ParseUint(name, 10, 16)
+			if err != nil || key == 0 {
+				continue
+			}
+			c.files[uint16(key)] = checkedFile{fs: fs, size: e.Size()}
+		}
+	}
+	return nil
+}
+
+func (c *checker) checkIndex() error {
+	b, err := readFile(c.cfg.FS, indexFile)
+	if err != nil {
+		return err
+	}
+	if extra := len(b) % pointerByteSize; extra != 0 {
+		c.report(InconsistencyIndexTruncated, 0, "index ends with %d bytes of a partially written pointer", extra)
+		c.rewriteIndex = true
+	}
+	var codec pointerCodec
+	ptrs := codec.decode(b)
+	c.pointers = make([]pointer, 0, len(ptrs))
+	for i, ptr := range ptrs {
+		if ptr.End < ptr.Start || ptr.fileKey == 0 {
+			c.report(InconsistencyInvalidPointer, ptr.fileKey, "pointer %d has invalid time range %s or file key %d", i, ptr.TimeRange, ptr.fileKey)
+			c.rewriteIndex = true
+			continue
+		}
+		if n := len(c.pointers); n > 0 && ptr.Start < c.pointers[n-1].End {
+			c.report(InconsistencyOverlappingPointer, ptr.fileKey, "pointer %d with time range %s overlaps the previous pointer with time range %s", i, ptr.TimeRange, c.pointers[n-1].TimeRange)
+			c.rewriteIndex = true
+			continue
+		}
+		f, ok := c.files[ptr.fileKey]
+		if !ok {
+			c.report(InconsistencyPointerOutOfBounds, ptr.fileKey, "pointer %d references file %s, which does not exist", i, fileKeyToName(ptr.fileKey))
+			c.rewriteIndex = true
+			continue
+		}
+		if end := int64(ptr.offset) + int64(ptr.length); end > f.size {
+			c.report(InconsistencyPointerOutOfBounds, ptr.fileKey, "pointer %d ends at byte %d, past the end of file %s (%d bytes)", i, end, fileKeyToName(ptr.fileKey), f.size)
+			c.rewriteIndex = true
+			continue
+		}
+		c.pointers = append(c.pointers, ptr)
+	}
+	return nil
+}
+
+// checkFiles reports files containing data past the end of the last pointer that
+// references them, and returns the end of the covered region of each file.
+func (c *checker) checkFiles() map[uint16]int64 {
+	ends := make(map[uint16]int64, len(c.files))
+	for _, ptr := range c.pointers {
+		ends[ptr.fileKey] = max(ends[ptr.fileKey], int64(ptr.offset)+int64(ptr.length))
+	}
+	for _, key := range slices.Sorted(maps.Keys(c.files)) {
+		if f, end := c.files[key], ends[key]; f.size > end {
+			c.report(InconsistencyUncoveredTail, key, "file %s contains %d bytes after the end of its last pointer", fileKeyToName(key), f.size-end)
+		}
+	}
+	return ends
+}
+
+func (c *checker) checkCounter() error {
+	maxKey := c.maxFileKey()
+	b, err := readFile(c.cfg.FS, counterFile)
+	if err != nil {
+		return err
+	}
+	var value int64
+	if len(b) >= 4 {
+		value = int64(int32(binary.LittleEndian.Uint32(b)))
+	}
+	if value < int64(maxKey) {
+		c.report(InconsistencyCounter, maxKey, "file counter is %d, but the largest file key is %d", value, maxKey)
+	}
+	return nil
+}
+
+func (c *checker) maxFileKey() uint16 {
+	var maxKey uint16
+	for key := range c.files {
+		maxKey = max(maxKey, key)
+	}
+	return maxKey
+}
+
+func (c *checker) repair(ends map[uint16]int64) error {
+	if c.rewriteIndex {
+		var codec pointerCodec
+		if err := writeFile(c.cfg.FS, indexFile, codec.encode(0, c.pointers)); err != nil {
+			return err
+		}
+	}
+	for key, f := range c.files {
+		if end := ends[key]; f.size > end {
+			if err := truncateFile(f.fs, fileKeyToName(key), end); err != nil {
+				return err
+			}
+			// The local cache of a file in the cold tier is no longer valid once the
+			// file has been truncated.
+			if f.fs == c.cfg.ColdFS {
+				if err := c.cfg.FS.Remove(fileKeyToCacheName(key)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, inc := range c.found {
+		if inc.Kind == InconsistencyCounter {
+			b := make([]byte, 4)
+			binary.LittleEndian.PutUint32(b, uint32(c.maxFileKey()))
+			return writeFile(c.cfg.FS, counterFile, b)
+		}
+	}
+	return nil
+}
+
+// readFile reads the contents of the file with the given name, returning no content if
+// the file does not exist.
+func readFile(fs xfs.FS, name string) ([]byte, error) {
+	f, err := fs.Open(name, os.O_RDONLY)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(f)
+	return b, errors.Combine(err, f.Close())
+}
+
+func writeFile(fs xfs.FS, name string, b []byte) error {
+	f, err := fs.Open(name, os.O_CREATE|os.O_WRONLY)
+	if err != nil {
+		return err
+	}
+	if err = f.Truncate(0); err != nil {
+		return errors.Combine(err, f.Close())
+	}
+	if _, err = f.WriteAt(b, 0); err != nil {
+		return errors.Combine(err, f.Close())
+	}
+	return errors.Combine(f.Sync(), f.Close())
+}
+
+func truncateFile(fs xfs.FS, name string, size int64) error {
+	f, err := fs.Open(name, os.O_WRONLY)
+	if err != nil {
+		return err
+	}
+	return errors.Combine(f.Truncate(size), f.Close())
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/domain"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Check", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				fs      xfs.FS
+				cleanUp func() error
+				cfg     domain.Config
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				cfg = domain.Config{FS: fs, Instrumentation: PanicLogger()}
+				db := MustSucceed(domain.Open(cfg))
+				for i := 1; i <= 3; i++ {
+					start := telem.TimeStamp(10*i) * telem.SecondTS
+					Expect(domain.Write(ctx, db, start.SpanRange(5*telem.Second), []byte{1, 2, 3, 4, 5})).To(Succeed())
+				}
+				Expect(db.Close()).To(Succeed())
+			})
+			AfterEach(func() { Expect(cleanUp()).To(Succeed()) })
+
+			kinds := func(found []domain.Inconsistency) []domain.InconsistencyKind {
+				res := make([]domain.InconsistencyKind, len(found))
+				for i, f := range found {
+					res[i] = f.Kind
+				}
+				return res
+			}
+
+			expectRepaired := func(kind domain.InconsistencyKind, domains int) {
+				Expect(kinds(MustSucceed(domain.Check(cfg)))).To(Equal([]domain.InconsistencyKind{kind}))
+				Expect(kinds(MustSucceed(domain.Repair(cfg)))).To(Equal([]domain.InconsistencyKind{kind}))
+				Expect(MustSucceed(domain.Check(cfg))).To(BeEmpty())
+				db := MustSucceed(domain.Open(cfg))
+				i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+				n := 0
+				for i.SeekFirst(ctx); i.Valid(); i.Next() {
+					r := MustSucceed(i.OpenReader(ctx))
+					buf := make([]byte, r.Len())
+					MustSucceed(r.ReadAt(buf, 0))
+					Expect(buf).To(Equal([]byte{1, 2, 3, 4, 5}))
+					Expect(r.Close()).To(Succeed())
+					n++
+				}
+				Expect(i.Close()).To(Succeed())
+				Expect(n).To(Equal(domains))
+				Expect(db.Close()).To(Succeed())
+			}
+
+			It("Should not report any inconsistencies in a healthy DB", func() {
+				Expect(MustSucceed(domain.Check(cfg))).To(BeEmpty())
+				Expect(MustSucceed(domain.Repair(cfg))).To(BeEmpty())
+			})
+
+			It("Should truncate data that is not covered by any pointer", func() {
+				f := MustSucceed(fs.Open("1.domain", os.O_WRONLY))
+				MustSucceed(f.WriteAt([]byte{9, 9, 9}, 15))
+				Expect(f.Close()).To(Succeed())
+				expectRepaired(domain.InconsistencyUncoveredTail, 3)
+				Expect(MustSucceed(fs.Stat("1.domain")).Size()).To(Equal(int64(15)))
+			})
+
+			It("Should remove pointers that exceed the length of their file", func() {
+				f := MustSucceed(fs.Open("1.domain", os.O_WRONLY))
+				Expect(f.Truncate(12)).To(Succeed())
+				Expect(f.Close()).To(Succeed())
+				found := MustSucceed(domain.Check(cfg))
+				Expect(kinds(found)).To(Equal([]domain.InconsistencyKind{
+					domain.InconsistencyPointerOutOfBounds,
+					domain.InconsistencyUncoveredTail,
+				}))
+				MustSucceed(domain.Repair(cfg))
+				Expect(MustSucceed(domain.Check(cfg))).To(BeEmpty())
+				Expect(MustSucceed(fs.Stat("1.domain")).Size()).To(Equal(int64(10)))
+			})
+
+			It("Should remove a partially written pointer from the index", func() {
+				f := MustSucceed(fs.Open("index.domain", os.O_WRONLY))
+				s := MustSucceed(f.Stat())
+				MustSucceed(f.WriteAt([]byte{1, 2, 3}, s.Size()))
+				Expect(f.Close()).To(Succeed())
+				expectRepaired(domain.InconsistencyIndexTruncated, 3)
+			})
+
+			It("Should advance a file counter that is behind the largest file key", func() {
+				f := MustSucceed(fs.Open("counter.domain", os.O_WRONLY))
+				Expect(f.Truncate(0)).To(Succeed())
+				Expect(f.Close()).To(Succeed())
+				expectRepaired(domain.InconsistencyCounter, 3)
+			})
+		})
+	}
+})
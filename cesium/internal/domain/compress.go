@@ -0,0 +1,220 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain
+
+import (
+	"io"
+	"slices"
+
+	"github.com/synnaxlabs/x/binary/compress"
+	"github.com/synnaxlabs/x/errors"
+	xio "github.com/synnaxlabs/x/io"
+)
+
+// blockHeaderSize is the size of the header preceding each compressed block in a
+// file. The header contains the compressed length of the block followed by its
+// decompressed length, both as uint32s.
+const blockHeaderSize = 8
+
+// maxBlockSize is the largest number of decompressed bytes held by a block. Writers
+// compress and flush a block each time this many bytes are written, so the telemetry
+// they hold in memory is bounded. It is a multiple of the density of every data type,
+// so that each block holds whole samples.
+const maxBlockSize = 64 * 1024
+
+var errCorruptBlock = errors.New("compressed domain block is corrupt")
+
+// encodeBlock compresses the given telemetry into a block that can be appended to a
+// file. Writers write a block each time maxBlockSize bytes are written, and for the
+// remaining telemetry on each commit.
+func encodeBlock(codec compress.Compressor, raw []byte) ([]byte, error) {
+	compressed, err := codec.Compress(raw)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, blockHeaderSize, blockHeaderSize+len(compressed))
+	byteOrder.PutUint32(b[0:4], uint32(len(compressed)))
+	byteOrder.PutUint32(b[4:8], uint32(len(raw)))
+	return append(b, compressed...), nil
+}
+
+// encodeBlocks compresses the given telemetry into a sequence of contiguous blocks of
+// at most maxBlockSize decompressed bytes each.
+func encodeBlocks(codec compress.Compressor, raw []byte) ([]byte, error) {
+	var blocks []byte
+	for start := 0; start < len(raw); start += maxBlockSize {
+		b, err := encodeBlock(codec, raw[start:min(start+maxBlockSize, len(raw))])
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, b...)
+	}
+	return blocks, nil
+}
+
+// block is the location of a block written by encodeBlock within a region of a file.
+type block struct {
+	// offset is the offset of the compressed contents of the block within the region.
+	offset uint32
+	// length is the compressed length of the block.
+	length uint32
+	// rawOffset is the offset of the decompressed contents of the block within the
+	// decompressed contents of the region.
+	rawOffset uint32
+	// rawLength is the decompressed length of the block.
+	rawLength uint32
+}
+
+// indexBlocks returns the locations of the sequence of contiguous blocks written by
+// encodeBlock in the region of the given size read by r, reading only their headers.
+// Blocks are only indexed until their decompressed contents reach end.
+func indexBlocks(r io.ReaderAt, size, end uint32) ([]block, error) {
+	var (
+		blocks    []block
+		offset    uint32
+		rawOffset uint32
+		header    [blockHeaderSize]byte
+	)
+	for offset < size && rawOffset < end {
+		if size-offset < blockHeaderSize {
+			return nil, errCorruptBlock
+		}
+		if _, err := r.ReadAt(header[:], int64(offset)); err != nil {
+			return nil, err
+		}
+		b := block{
+			offset:    offset + blockHeaderSize,
+			length:    byteOrder.Uint32(header[0:4]),
+			rawOffset: rawOffset,
+			rawLength: byteOrder.Uint32(header[4:8]),
+		}
+		if size-b.offset < b.length {
+			return nil, errCorruptBlock
+		}
+		blocks = append(blocks, b)
+		offset = b.offset + b.length
+		rawOffset += b.rawLength
+	}
+	return blocks, nil
+}
+
+// blockReader serves reads of a domain stored in a region of compressed blocks.
+// Blocks are only read and decompressed when the domain's telemetry within them is
+// read, and the most recently read block is cached, so that sequential reads
+// decompress each block once.
+type blockReader struct {
+	codec compress.Decompressor
+	// region reads the region of the file holding the blocks.
+	region xio.ReaderAtCloser
+	blocks []block
+	// offset and length are the offset and length of the domain within the
+	// decompressed contents of the region.
+	offset, length int64
+	// cached is the index of the block held in cache, or -1 if no block is cached.
+	cached int
+	cache  []byte
+	// compressed holds the compressed contents of the last block read.
+	compressed []byte
+}
+
+var _ io.ReaderAt = (*blockReader)(nil)
+
+func newBlockReader(
+	codec compress.Decompressor,
+	region xio.ReaderAtCloser,
+	size uint32,
+	offset, length uint32,
+) (*blockReader, error) {
+	blocks, err := indexBlocks(region, size, offset+length)
+	if err != nil {
+		return nil, err
+	}
+	var rawLength uint32
+	if len(blocks) > 0 {
+		last := blocks[len(blocks)-1]
+		rawLength = last.rawOffset + last.rawLength
+	}
+	if rawLength < offset+length {
+		return nil, errors.Wrapf(
+			errCorruptBlock,
+			"domain references %d bytes at offset %d, but only %d are available",
+			length,
+			offset,
+			rawLength,
+		)
+	}
+	return &blockReader{
+		codec:  codec,
+		region: region,
+		blocks: blocks,
+		offset: int64(offset),
+		length: int64(length),
+		cached: -1,
+	}, nil
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *blockReader) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	for n < len(p) && off+int64(n) < r.length {
+		raw := r.offset + off + int64(n)
+		i, _ := slices.BinarySearchFunc(r.blocks, raw, func(b block, raw int64) int {
+			if raw < int64(b.rawOffset) {
+				return 1
+			}
+			if raw >= int64(b.rawOffset+b.rawLength) {
+				return -1
+			}
+			return 0
+		})
+		data, err := r.decode(i)
+		if err != nil {
+			return n, err
+		}
+		end := min(int64(len(data)), r.offset+r.length-int64(r.blocks[i].rawOffset))
+		n += copy(p[n:], data[raw-int64(r.blocks[i].rawOffset):end])
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// decode returns the decompressed contents of the ith block.
+func (r *blockReader) decode(i int) ([]byte, error) {
+	if r.cached == i {
+		return r.cache, nil
+	}
+	b := r.blocks[i]
+	r.compressed = slices.Grow(r.compressed[:0], int(b.length))[:b.length]
+	if n, err := r.region.ReadAt(r.compressed, int64(b.offset)); err != nil &&
+		!(errors.Is(err, io.EOF) && n == len(r.compressed)) {
+		return nil, err
+	}
+	data, err := r.codec.Decompress(r.compressed)
+	if err != nil {
+		return nil, err
+	}
+	if uint32(len(data)) != b.rawLength {
+		return nil, errors.Wrapf(
+			errCorruptBlock,
+			"expected %d decompressed bytes, got %d",
+			b.rawLength,
+			len(data),
+		)
+	}
+	r.cached, r.cache = i, data
+	return data, nil
+}
+
+// Close implements io.Closer.
+func (r *blockReader) Close() error { return r.region.Close() }
@@ -0,0 +1,169 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain_test
+
+import (
+	"io"
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/domain"
+	"github.com/synnaxlabs/x/binary/compress"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Compression", Ordered, func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *domain.DB
+				fs      xfs.FS
+				cleanUp func() error
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				db = MustSucceed(domain.Open(domain.Config{
+					FS:              fs,
+					FileSize:        20 * telem.ByteSize,
+					GCThreshold:     math.SmallestNonzeroFloat32,
+					Compression:     compress.RunLength{},
+					Instrumentation: PanicLogger(),
+				}))
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			readAll := func(i *domain.Iterator) []byte {
+				r := MustSucceed(i.OpenReader(ctx))
+				buf := make([]byte, r.Len())
+				MustSucceed(r.ReadAt(buf, 0))
+				Expect(r.Close()).To(Succeed())
+				return buf
+			}
+
+			It("Should compress each commit into a separate block", func() {
+				w := MustSucceed(db.OpenWriter(ctx, domain.WriterConfig{Start: 10 * telem.SecondTS}))
+				MustSucceed(w.Write([]byte{0, 0, 0, 0, 0}))
+				Expect(w.Commit(ctx, 15*telem.SecondTS)).To(Succeed())
+				MustSucceed(w.Write([]byte{1, 1, 1, 1, 1}))
+				Expect(w.Commit(ctx, 20*telem.SecondTS)).To(Succeed())
+				Expect(w.Close()).To(Succeed())
+
+				By("Asserting that the data was compressed")
+				Expect(MustSucceed(fs.Stat("1.domain")).Size()).To(BeNumerically("<", 2*8+10))
+
+				By("Reading the data back")
+				i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+				Expect(i.SeekFirst(ctx)).To(BeTrue())
+				Expect(i.Len()).To(Equal(int64(10)))
+				Expect(readAll(i)).To(Equal([]byte{0, 0, 0, 0, 0, 1, 1, 1, 1, 1}))
+				Expect(i.Close()).To(Succeed())
+			})
+
+			It("Should flush blocks to disk before committing large writes", func() {
+				data := make([]byte, 200_000)
+				for i := range data {
+					data[i] = byte(i / 1000 % 2)
+				}
+				w := MustSucceed(db.OpenWriter(ctx, domain.WriterConfig{Start: 10 * telem.SecondTS}))
+				MustSucceed(w.Write(data))
+				Expect(MustSucceed(fs.Stat("1.domain")).Size()).To(BeNumerically(">", 0))
+				Expect(w.Commit(ctx, 15*telem.SecondTS)).To(Succeed())
+				Expect(w.Close()).To(Succeed())
+
+				By("Reading the data back across blocks")
+				i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+				Expect(i.SeekFirst(ctx)).To(BeTrue())
+				Expect(readAll(i)).To(Equal(data))
+				r := MustSucceed(i.OpenReader(ctx))
+				buf := make([]byte, 10_000)
+				Expect(r.ReadAt(buf, 130_000)).To(Equal(len(buf)))
+				Expect(buf).To(Equal(data[130_000:140_000]))
+				Expect(r.Close()).To(Succeed())
+				Expect(i.Close()).To(Succeed())
+			})
+
+			It("Should read partial ranges that span multiple blocks", func() {
+				w := MustSucceed(db.OpenWriter(ctx, domain.WriterConfig{Start: 10 * telem.SecondTS}))
+				MustSucceed(w.Write([]byte{0, 0, 0, 1, 1}))
+				Expect(w.Commit(ctx, 15*telem.SecondTS)).To(Succeed())
+				MustSucceed(w.Write([]byte{1, 0, 0, 1, 1}))
+				Expect(w.Commit(ctx, 20*telem.SecondTS)).To(Succeed())
+				Expect(w.Close()).To(Succeed())
+
+				i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+				Expect(i.SeekFirst(ctx)).To(BeTrue())
+				r := MustSucceed(i.OpenReader(ctx))
+				buf := make([]byte, 4)
+				Expect(r.ReadAt(buf, 3)).To(Equal(4))
+				Expect(buf).To(Equal([]byte{1, 1, 1, 0}))
+				Expect(r.ReadAt(buf, 0)).To(Equal(4))
+				Expect(buf).To(Equal([]byte{0, 0, 0, 1}))
+				By("Returning EOF when reading past the end of the domain")
+				n, err := r.ReadAt(buf, 8)
+				Expect(n).To(Equal(2))
+				Expect(err).To(MatchError(io.EOF))
+				Expect(buf[:n]).To(Equal([]byte{1, 1}))
+				Expect(r.Close()).To(Succeed())
+				Expect(i.Close()).To(Succeed())
+			})
+
+			It("Should reference the same block from each side of a split", func() {
+				w := MustSucceed(db.OpenWriter(ctx, domain.WriterConfig{Start: 10 * telem.SecondTS}))
+				MustSucceed(w.Write([]byte{1, 1, 1}))
+				Expect(w.Split(13*telem.SecondTS, 20*telem.SecondTS)).To(Succeed())
+				MustSucceed(w.Write([]byte{0, 0}))
+				Expect(w.Commit(ctx, 22*telem.SecondTS)).To(Succeed())
+				Expect(w.Close()).To(Succeed())
+
+				i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+				Expect(i.SeekFirst(ctx)).To(BeTrue())
+				Expect(i.TimeRange()).To(Equal((10 * telem.SecondTS).Range(13 * telem.SecondTS)))
+				Expect(readAll(i)).To(Equal([]byte{1, 1, 1}))
+				Expect(i.Next()).To(BeTrue())
+				Expect(i.TimeRange()).To(Equal((20 * telem.SecondTS).Range(22 * telem.SecondTS)))
+				Expect(readAll(i)).To(Equal([]byte{0, 0}))
+				Expect(i.Close()).To(Succeed())
+			})
+
+			It("Should split compressed domains on deletion and garbage collect shared blocks once", func() {
+				Expect(domain.Write(ctx, db, (10 * telem.SecondTS).Range(19*telem.SecondTS+1), []byte{1, 1, 1, 1, 1, 0, 0, 0, 0, 0})).To(Succeed())
+				Expect(domain.Write(ctx, db, (30 * telem.SecondTS).Range(39*telem.SecondTS+1), []byte{0, 0, 1, 1, 1, 0, 0, 1, 1, 1})).To(Succeed())
+				sizeBeforeGC := MustSucceed(fs.Stat("1.domain")).Size()
+
+				By("Deleting the first domain and the middle of the second domain")
+				Expect(db.Delete(ctx, createCalcOffset(0), createCalcOffset(10), (10 * telem.SecondTS).Range(19*telem.SecondTS+1), telem.Density(1))).To(Succeed())
+				Expect(db.Delete(ctx, createCalcOffset(3), createCalcOffset(7), (32*telem.SecondTS + 1).Range(36*telem.SecondTS+1), telem.Density(1))).To(Succeed())
+
+				By("Garbage collecting the deleted block")
+				Expect(db.GarbageCollect(ctx)).To(Succeed())
+				sizeAfterGC := MustSucceed(fs.Stat("1.domain")).Size()
+				Expect(sizeAfterGC).To(BeNumerically("<", sizeBeforeGC))
+				Expect(sizeAfterGC).To(BeNumerically(">", 0))
+
+				By("Asserting that both halves of the split domain are still readable")
+				i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+				Expect(i.SeekFirst(ctx)).To(BeTrue())
+				Expect(i.TimeRange()).To(Equal((30 * telem.SecondTS).Range(32*telem.SecondTS + 1)))
+				Expect(readAll(i)).To(Equal([]byte{0, 0, 1}))
+				Expect(i.Next()).To(BeTrue())
+				Expect(i.TimeRange()).To(Equal((36*telem.SecondTS + 1).Range(39*telem.SecondTS + 1)))
+				Expect(readAll(i)).To(Equal([]byte{1, 1, 1}))
+				Expect(i.Next()).To(BeFalse())
+				Expect(i.Close()).To(Succeed())
+			})
+		})
+	}
+})
@@ -13,6 +13,7 @@ import (
 	"context"
 	"github.com/synnaxlabs/alamos"
 	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/x/binary/compress"
 	"github.com/synnaxlabs/x/config"
 	"github.com/synnaxlabs/x/errors"
 	xio "github.com/synnaxlabs/x/io"
@@ -22,6 +23,7 @@ import (
 	"github.com/synnaxlabs/x/telem"
 	"github.com/synnaxlabs/x/validate"
 	"math"
+	"sync"
 	"sync/atomic"
 )
 
@@ -75,6 +77,9 @@ type DB struct {
 	fc          *fileController
 	closed      *atomic.Bool
 	entityCount *atomic.Int64
+	// fileLock is held for reading by open snapshots, and for writing by operations
+	// that rewrite or relocate files (garbage collection and tiering).
+	fileLock *sync.RWMutex
 }
 
 // Config is the configuration for opening a DB.
@@ -101,6 +106,21 @@ type Config struct {
 	// that the exact performance impact of changing this value is still relatively unknown.
 	// [OPTIONAL] Default: 100
 	MaxDescriptors int
+	// Compression is used to compress the telemetry written to the DB in blocks before
+	// it is persisted to disk. Readers transparently decompress the telemetry. If nil,
+	// the telemetry is stored uncompressed.
+	// [OPTIONAL] Default: nil
+	Compression compress.CompressorDecompressor
+	// ColdFS is a secondary file system that full domain files are relocated to by
+	// Tier. Relocated files are fetched on demand when read. If nil, files are never
+	// relocated.
+	// [OPTIONAL] Default: nil
+	ColdFS xfs.FS
+	// ColdCacheSize is the maximum number of files relocated to ColdFS that are cached
+	// in FS after being read. Note that the cache may temporarily exceed this size if
+	// the cached files are being read from.
+	// [OPTIONAL] Default: 5
+	ColdCacheSize int
 }
 
 var (
@@ -110,6 +130,7 @@ var (
 		FileSize:       800 * telem.Megabyte,
 		GCThreshold:    0.2,
 		MaxDescriptors: 100,
+		ColdCacheSize:  5,
 	}
 )
 
@@ -119,6 +140,7 @@ func (c Config) Validate() error {
 	validate.Positive(v, "fileSize", c.FileSize)
 	validate.Positive(v, "maxDescriptors", c.MaxDescriptors)
 	validate.NotNil(v, "fs", c.FS)
+	validate.Positive(v, "coldCacheSize", c.ColdCacheSize)
 	validate.GreaterThanEq(v, "gcThreshold", c.GCThreshold, 0)
 	validate.LessThanEq(v, "gcThreshold", c.GCThreshold, 1)
 	return v.Error()
@@ -131,6 +153,9 @@ func (c Config) Override(other Config) Config {
 	c.FS = override.Nil(c.FS, other.FS)
 	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
 	c.GCThreshold = override.Numeric(c.GCThreshold, other.GCThreshold)
+	c.Compression = override.Nil(c.Compression, other.Compression)
+	c.ColdFS = override.Nil(c.ColdFS, other.ColdFS)
+	c.ColdCacheSize = override.Numeric(c.ColdCacheSize, other.ColdCacheSize)
 	// Store 0.8 * the desired maximum file size as file size since we must leave some
 	// buffer for when we stop acquiring a new writer on a file.
 	c.FileSize = telem.Size(math.Round(0.8 * float64(c.FileSize)))
@@ -164,6 +189,7 @@ func Open(configs ...Config) (*DB, error) {
 		fc:          controller,
 		closed:      &atomic.Bool{},
 		entityCount: &atomic.Int64{},
+		fileLock:    &sync.RWMutex{},
 	}, nil
 }
 
@@ -172,10 +198,21 @@ func (db *DB) newReader(ctx context.Context, ptr pointer) (*Reader, error) {
 	if err != nil {
 		return nil, err
 	}
-	reader := xio.NewSectionReaderAtCloser(internal, int64(ptr.offset), int64(ptr.length))
+	region := xio.NewSectionReaderAtCloser(internal, int64(ptr.offset), int64(ptr.length))
+	if db.cfg.Compression == nil {
+		return &Reader{ptr: ptr, ReaderAtCloser: region}, nil
+	}
+	reader, err := newBlockReader(db.cfg.Compression, region, ptr.length, ptr.rawOffset, ptr.rawLength)
+	if err != nil {
+		return nil, errors.Combine(errors.Wrapf(err, "domain %s", ptr.TimeRange), region.Close())
+	}
 	return &Reader{ptr: ptr, ReaderAtCloser: reader}, nil
 }
 
+// TimeRange returns the time range spanned by all domains in the database, or
+// telem.TimeRangeZero if the database is empty.
+func (db *DB) TimeRange() telem.TimeRange { return db.idx.timeRange() }
+
 // HasDataFor returns whether any time stamp in the time range tr exists in the database.
 func (db *DB) HasDataFor(ctx context.Context, tr telem.TimeRange) (bool, error) {
 	if db.closed.Load() {
@@ -191,6 +228,12 @@ func (db *DB) HasDataFor(ctx context.Context, tr telem.TimeRange) (bool, error)
 	return false, i.Close()
 }
 
+// Domains returns the time ranges of all domains that overlap with the time range tr,
+// in ascending order.
+func (db *DB) Domains(tr telem.TimeRange) []telem.TimeRange {
+	return db.idx.overlapping(tr)
+}
+
 // Close closes the DB. Close should not be called concurrently with any other DB methods.
 // If close fails for a reason other than unclosed writers/readers, the database will
 // still be marked closed and no read/write operations are allowed on it to protect
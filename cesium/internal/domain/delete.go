@@ -103,7 +103,7 @@ func (db *DB) Delete(
 		if err != nil {
 			return
 		}
-		endOffset = int64(end.length) - int64(den.Size(endOffset))
+		endOffset = int64(end.rawLength) - int64(den.Size(endOffset))
 	} else {
 		// Non-exact: tr.End is not contained within any domain.
 		if endPosition == -1 {
@@ -143,22 +143,25 @@ func (db *DB) Delete(
 	// Remove old pointers.
 	db.idx.mu.pointers = append(db.idx.mu.pointers[:startPosition], db.idx.mu.pointers[endPosition+1:]...)
 
+	compressed := db.cfg.Compression != nil
 	if startOffset != 0 {
-		newPointers = append(newPointers, pointer{
-			TimeRange: telem.TimeRange{Start: start.Start, End: tr.Start},
-			fileKey:   start.fileKey,
-			offset:    start.offset,
-			length:    uint32(startOffset), // length from start.Start to tr.Start
-		})
+		// length from start.Start to tr.Start
+		newPointers = append(newPointers, start.slice(
+			telem.TimeRange{Start: start.Start, End: tr.Start},
+			0,
+			uint32(startOffset),
+			compressed,
+		))
 	}
 
 	if endOffset != 0 {
-		newPointers = append(newPointers, pointer{
-			TimeRange: telem.TimeRange{Start: tr.End, End: end.End},
-			fileKey:   end.fileKey,
-			offset:    end.offset + end.length - uint32(endOffset),
-			length:    uint32(endOffset), // length from tr.End to end.End
-		})
+		// length from tr.End to end.End
+		newPointers = append(newPointers, end.slice(
+			telem.TimeRange{Start: tr.End, End: end.End},
+			end.rawLength-uint32(endOffset),
+			end.rawLength,
+			compressed,
+		))
 	}
 
 	if len(newPointers) != 0 {
@@ -173,6 +176,109 @@ func (db *DB) Delete(
 	return span.Error(persist())
 }
 
+// ExpireResult describes the domains removed from the index by a call to Expire.
+type ExpireResult struct {
+	// Domains is the number of domains that were removed.
+	Domains int
+	// Size is the logical (i.e. uncompressed) size of the removed domains.
+	Size telem.Size
+	// TimeRange is the time range spanned by the removed domains.
+	TimeRange telem.TimeRange
+}
+
+// ExpiryBoundary returns the end of the last domain that must be expired in order to
+// remove all domains ending at or before the given timestamp, and to keep the logical
+// size of the database at or below maxSize. A zero value for either before or maxSize
+// disables the respective constraint. ExpiryBoundary returns false if no domains need
+// to be expired.
+func (db *DB) ExpiryBoundary(before telem.TimeStamp, maxSize telem.Size) (telem.TimeStamp, bool) {
+	db.idx.mu.RLock()
+	defer db.idx.mu.RUnlock()
+	var (
+		ptrs = db.idx.mu.pointers
+		n    = 0
+	)
+	if before != 0 {
+		for n < len(ptrs) && ptrs[n].End <= before {
+			n++
+		}
+	}
+	if maxSize != 0 {
+		// Pointers that share a compressed region are only reclaimed together, so each
+		// region is counted once, by the decompressed extent of all of its pointers.
+		type (
+			region struct {
+				fileKey uint16
+				offset  uint32
+			}
+			extent struct{ start, end uint32 }
+		)
+		extents := make(map[region]extent, len(ptrs)-n)
+		for _, ptr := range ptrs[n:] {
+			r := region{fileKey: ptr.fileKey, offset: ptr.offset}
+			ext, ok := extents[r]
+			if !ok {
+				ext.start = ptr.rawOffset
+			}
+			ext.start = min(ext.start, ptr.rawOffset)
+			ext.end = max(ext.end, ptr.rawOffset+ptr.rawLength)
+			extents[r] = ext
+		}
+		var (
+			size    telem.Size
+			counted = make(map[region]bool, len(extents))
+		)
+		for i := len(ptrs) - 1; i >= n; i-- {
+			r := region{fileKey: ptrs[i].fileKey, offset: ptrs[i].offset}
+			if counted[r] {
+				continue
+			}
+			counted[r] = true
+			size += telem.Size(extents[r].end - extents[r].start)
+			if size > maxSize {
+				n = i + 1
+				break
+			}
+		}
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return ptrs[n-1].End, true
+}
+
+// Expire removes all domains that end at or before the given timestamp from the index.
+// Unlike Delete, Expire never splits a domain: only whole pointers are removed, and the
+// regions they occupied on disk become tombstones that are reclaimed by GarbageCollect.
+func (db *DB) Expire(ctx context.Context, end telem.TimeStamp) (res ExpireResult, err error) {
+	ctx, span := db.cfg.T.Bench(ctx, "expire")
+	defer span.End()
+
+	if db.closed.Load() {
+		return res, errDBClosed
+	}
+	db.entityCount.Add(1)
+	defer db.entityCount.Add(-1)
+
+	db.idx.deleteLock.Lock()
+	defer db.idx.deleteLock.Unlock()
+	db.idx.mu.Lock()
+	defer db.idx.mu.Unlock()
+
+	ptrs := db.idx.mu.pointers
+	for res.Domains < len(ptrs) && ptrs[res.Domains].End <= end {
+		res.Size += telem.Size(ptrs[res.Domains].rawLength)
+		res.Domains++
+	}
+	if res.Domains == 0 {
+		return res, nil
+	}
+	res.TimeRange = ptrs[0].Start.Range(ptrs[res.Domains-1].End)
+	db.idx.mu.pointers = append(make([]pointer, 0, len(ptrs)-res.Domains), ptrs[res.Domains:]...)
+	persist := db.idx.indexPersist.prepare(0)
+	return res, span.Error(persist())
+}
+
 // GarbageCollect rewrites all files that are over the size limit of a file and has
 // enough tombstones to garbage collect, as defined by GCThreshold.
 func (db *DB) GarbageCollect(ctx context.Context) error {
@@ -184,6 +290,8 @@ func (db *DB) GarbageCollect(ctx context.Context) error {
 	}
 	db.entityCount.Add(1)
 	defer db.entityCount.Add(-1)
+	db.fileLock.Lock()
+	defer db.fileLock.Unlock()
 
 	_, err := db.fc.gcWriters()
 	if err != nil {
@@ -221,6 +329,16 @@ func (db *DB) GarbageCollect(ctx context.Context) error {
 		if db.fc.hasWriter(fileKey) {
 			continue
 		}
+		if db.fc.isCold(fileKey) {
+			// Cold files are never rewritten, but they are removed from the cold tier
+			// once all of their data has been deleted.
+			if _, ok := db.idx.fileEnd(fileKey); !ok {
+				if _, err = db.fc.removeCold(fileKey); err != nil {
+					return span.Error(err)
+				}
+			}
+			continue
+		}
 		s, err := db.cfg.FS.Stat(fileKeyToName(fileKey))
 		if err != nil {
 			return span.Error(err)
@@ -276,11 +394,18 @@ func (db *DB) garbageCollectFile(key uint16, size int64) error {
 	// Find all pointers using the file: there cannot be more pointers using the file
 	// during GC since the file must be already full – however, there can be less due
 	// to deletion.
+	//
+	// Pointers into a compressed file may share the same region after a deletion
+	// splits them, so we only count each region once.
+	counted := make(map[uint32]struct{})
 	db.idx.mu.RLock()
 	for _, ptr := range db.idx.mu.pointers {
 		if ptr.fileKey == key {
 			ptrs = append(ptrs, ptr)
-			tombstoneSize -= int64(ptr.length)
+			if _, ok := counted[ptr.offset]; !ok {
+				counted[ptr.offset] = struct{}{}
+				tombstoneSize -= int64(ptr.length)
+			}
 		}
 	}
 	db.idx.mu.RUnlock()
@@ -303,7 +428,16 @@ func (db *DB) garbageCollectFile(key uint16, size int64) error {
 	}
 
 	// Find all pointers stored in the old file, and write them to the new file.
+	// newOffsets maps the offset of each region that has already been copied to its
+	// offset in the new file.
+	newOffsets := make(map[uint32]uint32, len(ptrs))
 	for _, ptr := range ptrs {
+		if copiedOffset, ok := newOffsets[ptr.offset]; ok {
+			if copiedOffset != ptr.offset {
+				offsetDeltaMap[ptr.TimeRange] = ptr.offset - copiedOffset
+			}
+			continue
+		}
 		buf := make([]byte, ptr.length)
 		_, err = r.ReadAt(buf, int64(ptr.offset))
 		if err != nil {
@@ -318,6 +452,7 @@ func (db *DB) garbageCollectFile(key uint16, size int64) error {
 		if newOffset != ptr.offset {
 			offsetDeltaMap[ptr.TimeRange] = ptr.offset - newOffset
 		}
+		newOffsets[ptr.offset] = newOffset
 		newOffset += uint32(n)
 	}
 
@@ -397,12 +532,12 @@ func validateDelete(startPosition int, endPosition int, startOffset *int64, endO
 		*endOffset = 0
 	}
 
-	if *startOffset > int64(idx.mu.pointers[startPosition].length) {
-		*startOffset = int64(idx.mu.pointers[startPosition].length)
+	if *startOffset > int64(idx.mu.pointers[startPosition].rawLength) {
+		*startOffset = int64(idx.mu.pointers[startPosition].rawLength)
 	}
 
-	if *endOffset > int64(idx.mu.pointers[endPosition].length) {
-		*endOffset = int64(idx.mu.pointers[endPosition].length)
+	if *endOffset > int64(idx.mu.pointers[endPosition].rawLength) {
+		*endOffset = int64(idx.mu.pointers[endPosition].rawLength)
 	}
 
 	// If the startPosition is greater than end position and there are samples in between.
@@ -412,12 +547,12 @@ func validateDelete(startPosition int, endPosition int, startOffset *int64, endO
 		return errors.Newf("deletion start domain %d is greater than deletion end domain %d", startPosition, endPosition), false
 	}
 
-	if startPosition == endPosition && *startOffset+*endOffset > int64(idx.mu.pointers[startPosition].length) {
-		return errors.Newf("deletion start offset %d is after end offset %d for length %d", *startOffset, *endOffset, idx.mu.pointers[startPosition].length), false
+	if startPosition == endPosition && *startOffset+*endOffset > int64(idx.mu.pointers[startPosition].rawLength) {
+		return errors.Newf("deletion start offset %d is after end offset %d for length %d", *startOffset, *endOffset, idx.mu.pointers[startPosition].rawLength), false
 	}
 
-	if (startPosition == endPosition-1 && *startOffset == int64(idx.mu.pointers[endPosition].length) && *endOffset == int64(idx.mu.pointers[endPosition].length)) ||
-		startPosition == endPosition && *startOffset+*endOffset == int64(idx.mu.pointers[startPosition].length) {
+	if (startPosition == endPosition-1 && *startOffset == int64(idx.mu.pointers[endPosition].rawLength) && *endOffset == int64(idx.mu.pointers[endPosition].rawLength)) ||
+		startPosition == endPosition && *startOffset+*endOffset == int64(idx.mu.pointers[startPosition].rawLength) {
 		return nil, false
 	}
 
@@ -0,0 +1,109 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/domain"
+	"github.com/synnaxlabs/x/binary/compress"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Expire", Ordered, func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *domain.DB
+				fs      xfs.FS
+				cleanUp func() error
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				db = MustSucceed(domain.Open(domain.Config{FS: fs, Instrumentation: PanicLogger()}))
+				Expect(domain.Write(ctx, db, (10 * telem.SecondTS).SpanRange(10*telem.Second), []byte{10, 11, 12, 13, 14})).To(Succeed())
+				Expect(domain.Write(ctx, db, (20 * telem.SecondTS).SpanRange(10*telem.Second), []byte{20, 21, 22, 23, 24})).To(Succeed())
+				Expect(domain.Write(ctx, db, (30 * telem.SecondTS).SpanRange(10*telem.Second), []byte{30, 31, 32, 33, 34})).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			DescribeTable("ExpiryBoundary", func(
+				before telem.TimeStamp,
+				maxSize telem.Size,
+				expected telem.TimeStamp,
+				expectedOk bool,
+			) {
+				boundary, ok := db.ExpiryBoundary(before, maxSize)
+				Expect(ok).To(Equal(expectedOk))
+				Expect(boundary).To(Equal(expected))
+			},
+				Entry("No constraints", telem.TimeStamp(0), telem.Size(0), telem.TimeStamp(0), false),
+				Entry("Before the first domain", 15*telem.SecondTS, telem.Size(0), telem.TimeStamp(0), false),
+				Entry("Only whole domains", 35*telem.SecondTS, telem.Size(0), 30*telem.SecondTS, true),
+				Entry("Exactly at the end of a domain", 20*telem.SecondTS, telem.Size(0), 20*telem.SecondTS, true),
+				Entry("After all domains", 50*telem.SecondTS, telem.Size(0), 40*telem.SecondTS, true),
+				Entry("Size within limits", telem.TimeStamp(0), telem.Size(15), telem.TimeStamp(0), false),
+				Entry("Size exceeded", telem.TimeStamp(0), telem.Size(12), 20*telem.SecondTS, true),
+				Entry("Size stricter than age", 25*telem.SecondTS, telem.Size(5), 30*telem.SecondTS, true),
+				Entry("Age stricter than size", 35*telem.SecondTS, telem.Size(14), 30*telem.SecondTS, true),
+			)
+
+			It("Should remove whole domains ending at or before the given timestamp", func() {
+				res := MustSucceed(db.Expire(ctx, 35*telem.SecondTS))
+				Expect(res.Domains).To(Equal(2))
+				Expect(res.Size).To(Equal(telem.Size(10)))
+				Expect(res.TimeRange).To(Equal((10 * telem.SecondTS).Range(30 * telem.SecondTS)))
+
+				i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+				Expect(i.SeekFirst(ctx)).To(BeTrue())
+				Expect(i.TimeRange()).To(Equal((30 * telem.SecondTS).SpanRange(10 * telem.Second)))
+				Expect(i.Next()).To(BeFalse())
+				Expect(i.Close()).To(Succeed())
+			})
+
+			It("Should do nothing if no domains end before the given timestamp", func() {
+				res := MustSucceed(db.Expire(ctx, 15*telem.SecondTS))
+				Expect(res.Domains).To(BeZero())
+				Expect(db.TimeRange()).To(Equal((10 * telem.SecondTS).Range(40 * telem.SecondTS)))
+			})
+
+			It("Should persist the expiry across restarts", func() {
+				MustSucceed(db.Expire(ctx, 20*telem.SecondTS))
+				Expect(db.Close()).To(Succeed())
+				db = MustSucceed(domain.Open(domain.Config{FS: fs, Instrumentation: PanicLogger()}))
+				Expect(db.TimeRange()).To(Equal((20 * telem.SecondTS).Range(40 * telem.SecondTS)))
+			})
+
+			It("Should count compressed regions shared by several domains once", func() {
+				cfs, cleanUpCompressed := makeFS()
+				cdb := MustSucceed(domain.Open(domain.Config{
+					FS:              cfs,
+					Compression:     compress.RunLength{},
+					Instrumentation: PanicLogger(),
+				}))
+				Expect(domain.Write(ctx, cdb, (10 * telem.SecondTS).Range(19*telem.SecondTS+1), []byte{1, 1, 1, 1, 1, 0, 0, 0, 0, 0})).To(Succeed())
+				By("Splitting the first domain into two domains that share its region")
+				Expect(cdb.Delete(ctx, createCalcOffset(3), createCalcOffset(7), (12*telem.SecondTS + 1).Range(16*telem.SecondTS+1), telem.Density(1))).To(Succeed())
+				Expect(domain.Write(ctx, cdb, (30 * telem.SecondTS).Range(33*telem.SecondTS+1), []byte{0, 1, 0, 1})).To(Succeed())
+
+				boundary, ok := cdb.ExpiryBoundary(0, 8)
+				Expect(ok).To(BeTrue())
+				Expect(boundary).To(Equal(19*telem.SecondTS + 1))
+				Expect(cdb.Close()).To(Succeed())
+				Expect(cleanUpCompressed()).To(Succeed())
+			})
+		})
+	}
+})
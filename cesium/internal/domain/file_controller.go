@@ -22,6 +22,7 @@ import (
 	"github.com/synnaxlabs/x/errors"
 	xio "github.com/synnaxlabs/x/io"
 	"github.com/synnaxlabs/x/telem"
+	"golang.org/x/sync/singleflight"
 )
 
 const extension = ".domain"
@@ -54,6 +55,18 @@ type fileController struct {
 		sync.RWMutex
 		files map[uint16]*fileReaders
 	}
+	// cold tracks files that have been relocated to the cold tier. When acquired
+	// alongside readers or writers, cold must be acquired first.
+	cold struct {
+		sync.Mutex
+		// files is the set of keys of files that are stored in the cold tier.
+		files map[uint16]struct{}
+		// cached holds the keys of cold files that are cached locally, ordered from
+		// least to most recently used.
+		cached []uint16
+		// fetches collapses concurrent fetches of the same file from the cold tier.
+		fetches singleflight.Group
+	}
 	release     chan struct{}
 	counter     *xio.Int32Counter
 	counterFile io.Closer
@@ -79,8 +92,10 @@ func openFileController(cfg Config) (*fileController, error) {
 	fc.readers.files = make(map[uint16]*fileReaders)
 	fc.release = make(chan struct{}, cfg.MaxDescriptors)
 
-	fc.writers.unopened, err = fc.scanUnopenedFiles()
-	return fc, err
+	if fc.writers.unopened, err = fc.scanUnopenedFiles(); err != nil {
+		return nil, err
+	}
+	return fc, fc.scanColdFiles()
 }
 
 // realFileSizeCap returns the maximum allowed size of a file – though it may be exceeded
@@ -299,10 +314,24 @@ func (fc *fileController) acquireReader(ctx context.Context, key uint16) (*contr
 func (fc *fileController) newReader(ctx context.Context, key uint16) (*controlledReader, error) {
 	ctx, span := fc.T.Bench(ctx, "newReader")
 	defer span.End()
-	file, err := fc.FS.Open(
-		fileKeyToName(key),
-		os.O_RDONLY,
-	)
+	// Hold the cold tier lock while opening the file so that it cannot be relocated
+	// or evicted from the cache before the reader is registered. Files that need to be
+	// fetched from the cold tier are copied without holding the lock.
+	fc.cold.Lock()
+	name, cold, fetched, err := fc.unprotectedLocalName(key)
+	for err == nil && !fetched {
+		fc.cold.Unlock()
+		if err = fc.fetch(key); err != nil {
+			return nil, span.Error(err)
+		}
+		fc.cold.Lock()
+		name, cold, fetched, err = fc.unprotectedLocalName(key)
+	}
+	defer fc.cold.Unlock()
+	if err != nil {
+		return nil, span.Error(err)
+	}
+	file, err := fc.FS.Open(name, os.O_RDONLY)
 	if err != nil {
 		return nil, span.Error(err)
 	}
@@ -312,6 +341,7 @@ func (fc *fileController) newReader(ctx context.Context, key uint16) (*controlle
 		controllerEntry: newPoolEntry(key, fc.release),
 	}
 	fc.readers.Lock()
+	defer fc.readers.Unlock()
 	f, ok := fc.readers.files[key]
 	if !ok {
 		fc.readers.files[key] = &fileReaders{open: []controlledReader{r}}
@@ -320,8 +350,10 @@ func (fc *fileController) newReader(ctx context.Context, key uint16) (*controlle
 		fc.readers.files[key].open = append(fc.readers.files[key].open, r)
 		f.Unlock()
 	}
-	fc.readers.Unlock()
-	return &r, err
+	if cold {
+		err = fc.unprotectedTouchCache(key)
+	}
+	return &r, span.Error(err)
 }
 
 func (fc *fileController) gcReaders() (successful bool, err error) {
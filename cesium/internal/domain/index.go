@@ -83,6 +83,23 @@ func (idx *index) overlap(tr telem.TimeRange) bool {
 	return overlap
 }
 
+func (idx *index) overlapping(tr telem.TimeRange) []telem.TimeRange {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	var ranges []telem.TimeRange
+	i, _ := idx.unprotectedSearch(tr.Start.SpanRange(0))
+	for i = max(i, 0); i < len(idx.mu.pointers); i++ {
+		ptr := idx.mu.pointers[i]
+		if ptr.Start >= tr.End {
+			break
+		}
+		if ptr.End > tr.Start {
+			ranges = append(ranges, ptr.TimeRange)
+		}
+	}
+	return ranges
+}
+
 func (idx *index) timeRange() telem.TimeRange {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
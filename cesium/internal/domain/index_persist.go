@@ -99,6 +99,8 @@ func (f *pointerCodec) encode(start int, ptrs []pointer) []byte {
 		byteOrder.PutUint16(b[base+16:base+18], ptr.fileKey)
 		byteOrder.PutUint32(b[base+18:base+22], ptr.offset)
 		byteOrder.PutUint32(b[base+22:base+26], ptr.length)
+		byteOrder.PutUint32(b[base+26:base+30], ptr.rawOffset)
+		byteOrder.PutUint32(b[base+30:base+34], ptr.rawLength)
 	}
 
 	return b
@@ -117,9 +119,11 @@ func (f *pointerCodec) decode(b []byte) []pointer {
 				Start: telem.TimeStamp(byteOrder.Uint64(b[base : base+8])),
 				End:   telem.TimeStamp(byteOrder.Uint64(b[base+8 : base+16])),
 			},
-			fileKey: byteOrder.Uint16(b[base+16 : base+18]),
-			offset:  byteOrder.Uint32(b[base+18 : base+22]),
-			length:  byteOrder.Uint32(b[base+22 : base+26]),
+			fileKey:   byteOrder.Uint16(b[base+16 : base+18]),
+			offset:    byteOrder.Uint32(b[base+18 : base+22]),
+			length:    byteOrder.Uint32(b[base+22 : base+26]),
+			rawOffset: byteOrder.Uint32(b[base+26 : base+30]),
+			rawLength: byteOrder.Uint32(b[base+30 : base+34]),
 		}
 	}
 	return pointers
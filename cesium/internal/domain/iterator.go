@@ -178,7 +178,7 @@ func (i *Iterator) OpenReader(ctx context.Context) (*Reader, error) {
 }
 
 // Len returns the number of bytes occupied by the telemetry in the current domain.
-func (i *Iterator) Len() int64 { return int64(i.value.length) }
+func (i *Iterator) Len() int64 { return int64(i.value.rawLength) }
 
 // Close closes the iterator.
 func (i *Iterator) Close() error {
@@ -0,0 +1,173 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain
+
+import (
+	"context"
+
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Overwrite replaces the telemetry in the time range tr with data, which must have
+// exactly the same length as the telemetry it replaces. The time range must overlap
+// with exactly one domain.
+//
+// Telemetry is never modified in place: data is written to the end of a file, and the
+// domain is replaced in the index by the part of the domain before tr, a new domain
+// holding data, and the part of the domain after tr. The replaced telemetry is reclaimed
+// by a subsequent GarbageCollect.
+//
+// calculateStartOffset and calculateEndOffset have the same semantics as in Delete.
+func (db *DB) Overwrite(
+	ctx context.Context,
+	calculateStartOffset func(
+		ctx context.Context,
+		domainStart telem.TimeStamp,
+		ts telem.TimeStamp,
+	) (int64, telem.TimeStamp, error),
+	calculateEndOffset func(
+		ctx context.Context,
+		domainStart telem.TimeStamp,
+		ts telem.TimeStamp,
+	) (int64, telem.TimeStamp, error),
+	tr telem.TimeRange,
+	den telem.Density,
+	data []byte,
+) (err error) {
+	ctx, span := db.cfg.T.Bench(ctx, "Overwrite")
+	defer span.End()
+
+	if db.closed.Load() {
+		return errDBClosed
+	}
+	db.entityCount.Add(1)
+	defer db.entityCount.Add(-1)
+
+	// Hold the delete lock so that the offsets resolved below remain valid until the
+	// index is updated.
+	db.idx.deleteLock.Lock()
+	defer db.idx.deleteLock.Unlock()
+
+	db.idx.mu.RLock()
+	position, exact := db.idx.unprotectedSearch(tr)
+	if !exact {
+		db.idx.mu.RUnlock()
+		return span.Error(NewErrRangeNotFound(tr))
+	}
+	ptrs := db.idx.mu.pointers
+	if (position > 0 && ptrs[position-1].OverlapsWith(tr)) ||
+		(position < len(ptrs)-1 && ptrs[position+1].OverlapsWith(tr)) {
+		db.idx.mu.RUnlock()
+		return span.Error(errors.Wrapf(
+			validate.Error,
+			"cannot overwrite time range %s because it spans multiple domains",
+			tr,
+		))
+	}
+	ptr := ptrs[position]
+	db.idx.mu.RUnlock()
+
+	var (
+		start, end       = ptr.Start, ptr.End
+		startOff, endOff = uint32(0), ptr.rawLength
+	)
+	if tr.Start.After(ptr.Start) {
+		var n int64
+		if n, start, err = calculateStartOffset(ctx, ptr.Start, tr.Start); err != nil {
+			return span.Error(err)
+		}
+		startOff = min(uint32(den.Size(n)), ptr.rawLength)
+	}
+	if tr.End.Before(ptr.End) {
+		var n int64
+		if n, end, err = calculateEndOffset(ctx, ptr.Start, tr.End); err != nil {
+			return span.Error(err)
+		}
+		endOff = min(uint32(den.Size(n)), ptr.rawLength)
+	}
+	endOff = max(startOff, endOff)
+	if int(endOff-startOff) != len(data) {
+		return span.Error(errors.Wrapf(
+			validate.Error,
+			"overwrite data has %d samples, but time range %s contains %d samples",
+			den.SampleCount(telem.Size(len(data))),
+			tr,
+			den.SampleCount(telem.Size(endOff-startOff)),
+		))
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if startOff == 0 {
+		start = ptr.Start
+	}
+	if endOff == ptr.rawLength {
+		end = ptr.End
+	}
+
+	replacement, err := db.writeReplacement(ctx, start.Range(end), data)
+	if err != nil {
+		return span.Error(err)
+	}
+
+	db.idx.mu.Lock()
+	defer db.idx.mu.Unlock()
+	// The position of the domain may have changed while the replacement was written.
+	if position >= len(db.idx.mu.pointers) || db.idx.mu.pointers[position] != ptr {
+		position, exact = db.idx.unprotectedSearch(ptr.TimeRange)
+		if !exact || db.idx.mu.pointers[position] != ptr {
+			return span.Error(errors.Newf("domain %s was modified during overwrite", ptr.TimeRange))
+		}
+	}
+	compressed := db.cfg.Compression != nil
+	newPointers := make([]pointer, 0, 3)
+	if startOff != 0 {
+		newPointers = append(newPointers, ptr.slice(ptr.Start.Range(start), 0, startOff, compressed))
+	}
+	newPointers = append(newPointers, replacement)
+	if endOff != ptr.rawLength {
+		newPointers = append(newPointers, ptr.slice(end.Range(ptr.End), endOff, ptr.rawLength, compressed))
+	}
+	db.idx.mu.pointers = append(
+		db.idx.mu.pointers[:position],
+		append(newPointers, db.idx.mu.pointers[position+1:]...)...,
+	)
+	persist := db.idx.indexPersist.prepare(position)
+	// As with Delete, keep the mutex locked while persisting to the index.
+	return span.Error(persist())
+}
+
+// writeReplacement writes data to a file, returning a pointer to it that occupies the
+// time range tr.
+func (db *DB) writeReplacement(ctx context.Context, tr telem.TimeRange, data []byte) (pointer, error) {
+	key, _, w, err := db.fc.acquireWriter(ctx)
+	if err != nil {
+		return pointer{}, err
+	}
+	block := data
+	if db.cfg.Compression != nil {
+		if block, err = encodeBlocks(db.cfg.Compression, data); err != nil {
+			return pointer{}, errors.Combine(err, w.Close())
+		}
+	}
+	if _, err = w.Write(block); err != nil {
+		return pointer{}, errors.Combine(err, w.Close())
+	}
+	ptr := pointer{
+		TimeRange: tr,
+		fileKey:   key,
+		offset:    uint32(w.Offset()),
+		length:    uint32(w.Len()),
+		rawLength: uint32(len(data)),
+	}
+	return ptr, w.Close()
+}
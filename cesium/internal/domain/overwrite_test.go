@@ -0,0 +1,112 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/domain"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// secondOffset resolves offsets for domains holding one sample per second.
+func secondOffset(
+	_ context.Context,
+	domainStart telem.TimeStamp,
+	ts telem.TimeStamp,
+) (int64, telem.TimeStamp, error) {
+	n := int64((domainStart.Span(ts) + telem.Second - 1) / telem.Second)
+	return n, domainStart.Add(telem.TimeSpan(n) * telem.Second), nil
+}
+
+var _ = Describe("Overwrite", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *domain.DB
+				fs      xfs.FS
+				cleanUp func() error
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				db = MustSucceed(domain.Open(domain.Config{FS: fs, Instrumentation: PanicLogger()}))
+				Expect(domain.Write(ctx, db, (10 * telem.SecondTS).SpanRange(10*telem.Second), []byte{10, 11, 12, 13, 14, 15, 16, 17, 18, 19})).To(Succeed())
+				Expect(domain.Write(ctx, db, (20 * telem.SecondTS).SpanRange(10*telem.Second), []byte{20, 21, 22, 23, 24, 25, 26, 27, 28, 29})).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			readDomains := func() (ranges []telem.TimeRange, data [][]byte) {
+				i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+				for i.SeekFirst(ctx); i.Valid(); i.Next() {
+					r := MustSucceed(i.OpenReader(ctx))
+					b := make([]byte, r.Len())
+					MustSucceed(r.ReadAt(b, 0))
+					Expect(r.Close()).To(Succeed())
+					ranges = append(ranges, i.TimeRange())
+					data = append(data, b)
+				}
+				Expect(i.Close()).To(Succeed())
+				return
+			}
+
+			It("Should replace the telemetry in the middle of a domain", func() {
+				Expect(db.Overwrite(ctx, secondOffset, secondOffset, (12 * telem.SecondTS).Range(15*telem.SecondTS), 1, []byte{1, 2, 3})).To(Succeed())
+				ranges, data := readDomains()
+				Expect(ranges).To(Equal([]telem.TimeRange{
+					(10 * telem.SecondTS).Range(12 * telem.SecondTS),
+					(12 * telem.SecondTS).Range(15 * telem.SecondTS),
+					(15 * telem.SecondTS).Range(20 * telem.SecondTS),
+					(20 * telem.SecondTS).Range(30 * telem.SecondTS),
+				}))
+				Expect(data[:3]).To(Equal([][]byte{{10, 11}, {1, 2, 3}, {15, 16, 17, 18, 19}}))
+			})
+
+			It("Should replace the telemetry of an entire domain", func() {
+				data := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+				Expect(db.Overwrite(ctx, secondOffset, secondOffset, (5 * telem.SecondTS).Range(20*telem.SecondTS), 1, data)).To(Succeed())
+				ranges, read := readDomains()
+				Expect(ranges[0]).To(Equal((10 * telem.SecondTS).Range(20 * telem.SecondTS)))
+				Expect(read[0]).To(Equal(data))
+			})
+
+			It("Should persist the replacement across reopening the database", func() {
+				Expect(db.Overwrite(ctx, secondOffset, secondOffset, (28 * telem.SecondTS).Range(40*telem.SecondTS), 1, []byte{1, 2})).To(Succeed())
+				Expect(db.Close()).To(Succeed())
+				db = MustSucceed(domain.Open(domain.Config{FS: fs, Instrumentation: PanicLogger()}))
+				_, data := readDomains()
+				Expect(data[1:]).To(Equal([][]byte{{20, 21, 22, 23, 24, 25, 26, 27}, {1, 2}}))
+			})
+
+			It("Should not overwrite with data of a different length", func() {
+				err := db.Overwrite(ctx, secondOffset, secondOffset, (12 * telem.SecondTS).Range(15*telem.SecondTS), 1, []byte{1, 2})
+				Expect(err).To(HaveOccurredAs(validate.Error))
+				Expect(err).To(MatchError(ContainSubstring("contains 3 samples")))
+			})
+
+			It("Should not overwrite a time range spanning multiple domains", func() {
+				Expect(db.Overwrite(ctx, secondOffset, secondOffset, (15 * telem.SecondTS).Range(25*telem.SecondTS), 1, make([]byte, 10))).
+					To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not overwrite a time range without data", func() {
+				Expect(db.Overwrite(ctx, secondOffset, secondOffset, (40 * telem.SecondTS).Range(50*telem.SecondTS), 1, []byte{1})).
+					To(HaveOccurredAs(domain.ErrRangeNotFound))
+			})
+		})
+	}
+})
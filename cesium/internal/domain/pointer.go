@@ -11,7 +11,7 @@ package domain
 
 import "github.com/synnaxlabs/x/telem"
 
-const pointerByteSize = 26
+const pointerByteSize = 34
 
 // pointer is a reference to a telemetry blob occupying a particular time domain.
 type pointer struct {
@@ -27,4 +27,35 @@ type pointer struct {
 	offset uint32
 	// length is the length of the domain within the file.
 	length uint32
+	// rawOffset is the offset of the domain's telemetry within the decompressed
+	// contents of the region referenced by offset and length. rawOffset is always
+	// zero for uncompressed databases.
+	rawOffset uint32
+	// rawLength is the number of bytes of telemetry in the domain once decompressed.
+	// For uncompressed databases, rawLength is always equal to length.
+	rawLength uint32
+}
+
+// slice returns a pointer to the telemetry in the byte range [start, end) of the
+// pointer's decompressed telemetry, occupying the time range tr. Slices of compressed
+// pointers continue to reference the same region of the file, as a compressed block
+// cannot be split.
+func (p pointer) slice(tr telem.TimeRange, start, end uint32, compressed bool) pointer {
+	if compressed {
+		return pointer{
+			TimeRange: tr,
+			fileKey:   p.fileKey,
+			offset:    p.offset,
+			length:    p.length,
+			rawOffset: p.rawOffset + start,
+			rawLength: end - start,
+		}
+	}
+	return pointer{
+		TimeRange: tr,
+		fileKey:   p.fileKey,
+		offset:    p.offset + start,
+		length:    end - start,
+		rawLength: end - start,
+	}
 }
@@ -22,7 +22,7 @@ type Reader struct {
 }
 
 // Len returns the number of bytes in the entire domain.
-func (r *Reader) Len() int64 { return int64(r.ptr.length) }
+func (r *Reader) Len() int64 { return int64(r.ptr.rawLength) }
 
 // Domain returns the time interval occupied by the domain.
 func (r *Reader) Domain() telem.TimeRange { return r.ptr.TimeRange }
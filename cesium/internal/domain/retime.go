@@ -0,0 +1,187 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain
+
+import (
+	"context"
+	"slices"
+
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Retime moves every domain in the time range tr to the time range obtained by applying
+// retime to its start and end. retime must be non-decreasing. Every domain overlapping
+// tr must be entirely contained in it, and a retimed domain must neither be empty nor
+// overlap any other domain.
+//
+// If rewrite is not nil, it is called with the retimed time range and the telemetry of
+// each domain, and returns the telemetry that replaces it, which must have the same
+// length. As with Overwrite, telemetry is never modified in place: rewritten telemetry
+// is written to the end of a file, and the replaced telemetry is reclaimed by a
+// subsequent GarbageCollect.
+func (db *DB) Retime(
+	ctx context.Context,
+	tr telem.TimeRange,
+	retime func(telem.TimeStamp) telem.TimeStamp,
+	rewrite func(tr telem.TimeRange, data []byte) ([]byte, error),
+) (err error) {
+	ctx, span := db.cfg.T.Bench(ctx, "Retime")
+	defer span.End()
+
+	if db.closed.Load() {
+		return errDBClosed
+	}
+	db.entityCount.Add(1)
+	defer db.entityCount.Add(-1)
+
+	db.idx.deleteLock.Lock()
+	defer db.idx.deleteLock.Unlock()
+
+	db.idx.mu.RLock()
+	position, ptrs, err := db.idx.retimedPointers(tr, retime)
+	db.idx.mu.RUnlock()
+	if err != nil || len(ptrs) == 0 {
+		return span.Error(err)
+	}
+	original := slices.Clone(ptrs)
+	for i, ptr := range ptrs {
+		if rewrite == nil {
+			ptrs[i].TimeRange = retimeRange(ptr.TimeRange, retime)
+			continue
+		}
+		if ptrs[i], err = db.rewrite(ctx, ptr, retimeRange(ptr.TimeRange, retime), rewrite); err != nil {
+			return span.Error(err)
+		}
+	}
+
+	db.idx.mu.Lock()
+	defer db.idx.mu.Unlock()
+	current := db.idx.mu.pointers
+	if position+len(original) > len(current) ||
+		!slices.Equal(current[position:position+len(original)], original) {
+		return span.Error(errors.Newf("domains in %s were modified during retime", tr))
+	}
+	copy(current[position:], ptrs)
+	persist := db.idx.indexPersist.prepare(position)
+	// As with Delete, keep the mutex locked while persisting to the index.
+	return span.Error(persist())
+}
+
+// ValidateRetime checks that Retime can be called with the given time range and retiming
+// function without modifying the DB.
+func (db *DB) ValidateRetime(tr telem.TimeRange, retime func(telem.TimeStamp) telem.TimeStamp) error {
+	if db.closed.Load() {
+		return errDBClosed
+	}
+	db.idx.mu.RLock()
+	defer db.idx.mu.RUnlock()
+	_, _, err := db.idx.retimedPointers(tr, retime)
+	return err
+}
+
+// rewrite writes the telemetry of ptr transformed by f to a file, returning a pointer to
+// it that occupies the time range tr.
+func (db *DB) rewrite(
+	ctx context.Context,
+	ptr pointer,
+	tr telem.TimeRange,
+	f func(tr telem.TimeRange, data []byte) ([]byte, error),
+) (pointer, error) {
+	r, err := db.newReader(ctx, ptr)
+	if err != nil {
+		return pointer{}, err
+	}
+	data := make([]byte, r.Len())
+	if _, err = r.ReadAt(data, 0); err != nil {
+		return pointer{}, errors.Combine(err, r.Close())
+	}
+	if err = r.Close(); err != nil {
+		return pointer{}, err
+	}
+	rewritten, err := f(tr, data)
+	if err != nil {
+		return pointer{}, err
+	}
+	if len(rewritten) != len(data) {
+		return pointer{}, errors.Newf(
+			"rewritten telemetry for domain %s has %d bytes, expected %d",
+			ptr.TimeRange,
+			len(rewritten),
+			len(data),
+		)
+	}
+	return db.writeReplacement(ctx, tr, rewritten)
+}
+
+// retimedPointers returns the position of the first domain in tr, along with copies of
+// the pointers to all domains in tr. It returns an error if tr partially overlaps a
+// domain, or if retiming the domains would make any of them empty or overlapping. The
+// index must be read-locked when calling retimedPointers.
+func (idx *index) retimedPointers(
+	tr telem.TimeRange,
+	retime func(telem.TimeStamp) telem.TimeStamp,
+) (int, []pointer, error) {
+	ptrs := idx.mu.pointers
+	start := 0
+	for start < len(ptrs) && !ptrs[start].OverlapsWith(tr) && ptrs[start].Start.Before(tr.Start) {
+		start++
+	}
+	end := start
+	for end < len(ptrs) && ptrs[end].OverlapsWith(tr) {
+		end++
+	}
+	selected := slices.Clone(ptrs[start:end])
+	prevEnd := telem.TimeStampMin
+	if start > 0 {
+		prevEnd = ptrs[start-1].End
+	}
+	for _, ptr := range selected {
+		if !tr.ContainsRange(ptr.TimeRange) {
+			return 0, nil, errors.Wrapf(
+				validate.Error,
+				"cannot retime time range %s because it partially overlaps domain %s",
+				tr,
+				ptr.TimeRange,
+			)
+		}
+		retimed := retimeRange(ptr.TimeRange, retime)
+		if retimed.Span() <= 0 {
+			return 0, nil, errors.Wrapf(
+				validate.Error,
+				"retiming domain %s would make it empty",
+				ptr.TimeRange,
+			)
+		}
+		if retimed.Start.Before(prevEnd) {
+			return 0, nil, errors.Wrapf(
+				validate.Error,
+				"retiming domain %s to %s would overlap the domain before it",
+				ptr.TimeRange,
+				retimed,
+			)
+		}
+		prevEnd = retimed.End
+	}
+	if end < len(ptrs) && ptrs[end].Start.Before(prevEnd) {
+		return 0, nil, errors.Wrapf(
+			validate.Error,
+			"retiming time range %s would overlap domain %s",
+			tr,
+			ptrs[end].TimeRange,
+		)
+	}
+	return start, selected, nil
+}
+
+func retimeRange(tr telem.TimeRange, retime func(telem.TimeStamp) telem.TimeStamp) telem.TimeRange {
+	return telem.TimeRange{Start: retime(tr.Start), End: retime(tr.End)}
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/domain"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Retime", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *domain.DB
+				fs      xfs.FS
+				cleanUp func() error
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				db = MustSucceed(domain.Open(domain.Config{FS: fs, Instrumentation: PanicLogger()}))
+				Expect(domain.Write(ctx, db, (10 * telem.SecondTS).SpanRange(5*telem.Second), []byte{10, 11, 12, 13, 14})).To(Succeed())
+				Expect(domain.Write(ctx, db, (20 * telem.SecondTS).SpanRange(5*telem.Second), []byte{20, 21, 22, 23, 24})).To(Succeed())
+				Expect(domain.Write(ctx, db, (40 * telem.SecondTS).SpanRange(5*telem.Second), []byte{40, 41, 42, 43, 44})).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			shift := func(span telem.TimeSpan) func(telem.TimeStamp) telem.TimeStamp {
+				return func(ts telem.TimeStamp) telem.TimeStamp { return ts.Add(span) }
+			}
+
+			readDomains := func() (ranges []telem.TimeRange, data [][]byte) {
+				i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+				for i.SeekFirst(ctx); i.Valid(); i.Next() {
+					r := MustSucceed(i.OpenReader(ctx))
+					b := make([]byte, r.Len())
+					MustSucceed(r.ReadAt(b, 0))
+					Expect(r.Close()).To(Succeed())
+					ranges = append(ranges, i.TimeRange())
+					data = append(data, b)
+				}
+				Expect(i.Close()).To(Succeed())
+				return
+			}
+
+			It("Should move the domains in the time range", func() {
+				Expect(db.Retime(ctx, (20 * telem.SecondTS).Range(45*telem.SecondTS), shift(10*telem.Second), nil)).To(Succeed())
+				ranges, data := readDomains()
+				Expect(ranges).To(Equal([]telem.TimeRange{
+					(10 * telem.SecondTS).SpanRange(5 * telem.Second),
+					(30 * telem.SecondTS).SpanRange(5 * telem.Second),
+					(50 * telem.SecondTS).SpanRange(5 * telem.Second),
+				}))
+				Expect(data).To(Equal([][]byte{{10, 11, 12, 13, 14}, {20, 21, 22, 23, 24}, {40, 41, 42, 43, 44}}))
+			})
+
+			It("Should rewrite the telemetry of the retimed domains", func() {
+				Expect(db.Retime(
+					ctx,
+					(20 * telem.SecondTS).SpanRange(5*telem.Second),
+					shift(-2*telem.Second),
+					func(tr telem.TimeRange, data []byte) ([]byte, error) {
+						Expect(tr).To(Equal((18 * telem.SecondTS).SpanRange(5 * telem.Second)))
+						return []byte{1, 2, 3, 4, 5}, nil
+					},
+				)).To(Succeed())
+				ranges, data := readDomains()
+				Expect(ranges[1]).To(Equal((18 * telem.SecondTS).SpanRange(5 * telem.Second)))
+				Expect(data[1]).To(Equal([]byte{1, 2, 3, 4, 5}))
+			})
+
+			It("Should persist the retimed domains", func() {
+				Expect(db.Retime(ctx, (10 * telem.SecondTS).SpanRange(5*telem.Second), shift(2*telem.Second), nil)).To(Succeed())
+				Expect(db.Close()).To(Succeed())
+				db = MustSucceed(domain.Open(domain.Config{FS: fs, Instrumentation: PanicLogger()}))
+				ranges, _ := readDomains()
+				Expect(ranges[0]).To(Equal((12 * telem.SecondTS).SpanRange(5 * telem.Second)))
+			})
+
+			It("Should not retime a time range that partially overlaps a domain", func() {
+				Expect(db.Retime(ctx, (12 * telem.SecondTS).Range(30*telem.SecondTS), shift(telem.Second), nil)).
+					To(HaveOccurredAs(validate.Error))
+				ranges, _ := readDomains()
+				Expect(ranges[1]).To(Equal((20 * telem.SecondTS).SpanRange(5 * telem.Second)))
+			})
+
+			It("Should not retime domains to overlap a following domain", func() {
+				Expect(db.Retime(ctx, (20 * telem.SecondTS).SpanRange(5*telem.Second), shift(16*telem.Second), nil)).
+					To(HaveOccurredAs(validate.Error))
+				Expect(db.ValidateRetime((20 * telem.SecondTS).SpanRange(5*telem.Second), shift(16*telem.Second))).
+					To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not retime domains to overlap a preceding domain", func() {
+				Expect(db.Retime(ctx, (20 * telem.SecondTS).SpanRange(5*telem.Second), shift(-6*telem.Second), nil)).
+					To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not retime a domain to be empty", func() {
+				collapse := func(telem.TimeStamp) telem.TimeStamp { return 30 * telem.SecondTS }
+				Expect(db.Retime(ctx, (20 * telem.SecondTS).SpanRange(5*telem.Second), collapse, nil)).
+					To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not replace telemetry with telemetry of a different length", func() {
+				Expect(db.Retime(
+					ctx,
+					(20 * telem.SecondTS).SpanRange(5*telem.Second),
+					shift(telem.Second),
+					func(telem.TimeRange, []byte) ([]byte, error) { return []byte{1}, nil },
+				)).To(MatchError(ContainSubstring("expected 5")))
+				ranges, _ := readDomains()
+				Expect(ranges[1]).To(Equal((20 * telem.SecondTS).SpanRange(5 * telem.Second)))
+			})
+		})
+	}
+})
@@ -0,0 +1,134 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/synnaxlabs/x/errors"
+	xio "github.com/synnaxlabs/x/io"
+	xfs "github.com/synnaxlabs/x/io/fs"
+)
+
+// Snapshot is a point-in-time view of the domains committed to a DB. While a Snapshot
+// is open, the files of the DB are not rewritten by garbage collection or relocated to
+// the cold tier, so the data referenced by the snapshot remains valid. Writes and
+// deletions may proceed while a Snapshot is open, but are not reflected in it.
+type Snapshot struct {
+	db       *DB
+	pointers []pointer
+	release  sync.Once
+}
+
+// OpenSnapshot opens a Snapshot of the domains currently committed to the DB. The
+// Snapshot must be closed after use, as it blocks garbage collection and tiering.
+func (db *DB) OpenSnapshot() (*Snapshot, error) {
+	if db.closed.Load() {
+		return nil, errDBClosed
+	}
+	db.entityCount.Add(1)
+	db.fileLock.RLock()
+	s := &Snapshot{db: db}
+	s.Capture()
+	return s, nil
+}
+
+// Capture updates the snapshot to the domains currently committed to the DB. Capture
+// is used to align snapshots of multiple DBs to the same point in time.
+func (s *Snapshot) Capture() {
+	s.db.idx.mu.RLock()
+	defer s.db.idx.mu.RUnlock()
+	s.pointers = append(make([]pointer, 0, len(s.db.idx.mu.pointers)), s.db.idx.mu.pointers...)
+}
+
+// WriteTo writes the snapshot to dst, which must be empty. The resulting directory is
+// a valid DB that contains only the domains in the snapshot. All files are synced to
+// stable storage before WriteTo returns.
+func (s *Snapshot) WriteTo(ctx context.Context, dst xfs.FS) (err error) {
+	_, span := s.db.cfg.T.Bench(ctx, "snapshot")
+	defer func() { err = span.EndWith(err) }()
+
+	// Only the prefix of each file that contains committed data is copied, as writers
+	// may be appending to the file concurrently.
+	var (
+		ends   = make(map[uint16]int64)
+		maxKey uint16
+	)
+	for _, ptr := range s.pointers {
+		ends[ptr.fileKey] = max(ends[ptr.fileKey], int64(ptr.offset)+int64(ptr.length))
+		maxKey = max(maxKey, ptr.fileKey)
+	}
+	for key, end := range ends {
+		if err = s.copyFile(key, end, dst); err != nil {
+			return err
+		}
+	}
+
+	idx, err := dst.Open(indexFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY)
+	if err != nil {
+		return err
+	}
+	var codec pointerCodec
+	if _, err = idx.Write(codec.encode(0, s.pointers)); err != nil {
+		return errors.Combine(err, idx.Close())
+	}
+	if err = errors.Combine(idx.Sync(), idx.Close()); err != nil {
+		return err
+	}
+
+	counterF, err := dst.Open(counterFile, os.O_CREATE|os.O_EXCL|os.O_RDWR)
+	if err != nil {
+		return err
+	}
+	counter, err := xio.NewInt32Counter(counterF)
+	if err != nil {
+		return errors.Combine(err, counterF.Close())
+	}
+	if _, err = counter.Add(int32(maxKey)); err != nil {
+		return errors.Combine(err, counterF.Close())
+	}
+	if err = errors.Combine(counterF.Sync(), counterF.Close()); err != nil {
+		return err
+	}
+	return xfs.SyncDir(dst, ".")
+}
+
+func (s *Snapshot) copyFile(key uint16, end int64, dst xfs.FS) error {
+	src := s.db.cfg.FS
+	if s.db.fc.isCold(key) {
+		src = s.db.cfg.ColdFS
+	}
+	name := fileKeyToName(key)
+	r, err := src.Open(name, os.O_RDONLY)
+	if err != nil {
+		return err
+	}
+	w, err := dst.Open(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY)
+	if err != nil {
+		return errors.Combine(err, r.Close())
+	}
+	if _, err = io.Copy(w, io.NewSectionReader(r, 0, end)); err == nil {
+		err = w.Sync()
+	}
+	return errors.Combine(errors.Combine(err, w.Close()), r.Close())
+}
+
+// Close releases the snapshot, allowing files to be garbage collected and relocated
+// again. Close is idempotent.
+func (s *Snapshot) Close() error {
+	s.release.Do(func() {
+		s.db.fileLock.RUnlock()
+		s.db.entityCount.Add(-1)
+	})
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/domain"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Snapshot", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db         *domain.DB
+				fs, dstFS  xfs.FS
+				cleanUp    func() error
+				dstCleanUp func() error
+				readAll    = func(db *domain.DB) [][]byte {
+					var (
+						i   = db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+						res [][]byte
+					)
+					for i.SeekFirst(ctx); i.Valid(); i.Next() {
+						r := MustSucceed(i.OpenReader(ctx))
+						buf := make([]byte, r.Len())
+						MustSucceed(r.ReadAt(buf, 0))
+						Expect(r.Close()).To(Succeed())
+						res = append(res, buf)
+					}
+					Expect(i.Close()).To(Succeed())
+					return res
+				}
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				dstFS, dstCleanUp = makeFS()
+				db = MustSucceed(domain.Open(domain.Config{
+					FS:              fs,
+					FileSize:        25 * telem.ByteSize,
+					Instrumentation: PanicLogger(),
+				}))
+				Expect(domain.Write(ctx, db, (10 * telem.SecondTS).SpanRange(5*telem.Second), []byte{1, 2, 3, 4, 5})).To(Succeed())
+				Expect(domain.Write(ctx, db, (20 * telem.SecondTS).SpanRange(5*telem.Second), []byte{6, 7, 8, 9, 10})).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+				Expect(dstCleanUp()).To(Succeed())
+			})
+
+			It("Should write the committed domains to a new DB", func() {
+				s := MustSucceed(db.OpenSnapshot())
+				Expect(s.WriteTo(ctx, dstFS)).To(Succeed())
+				Expect(s.Close()).To(Succeed())
+				snapDB := MustSucceed(domain.Open(domain.Config{FS: dstFS, Instrumentation: PanicLogger()}))
+				Expect(readAll(snapDB)).To(Equal([][]byte{{1, 2, 3, 4, 5}, {6, 7, 8, 9, 10}}))
+				Expect(snapDB.Close()).To(Succeed())
+			})
+
+			It("Should not include domains committed after the snapshot was opened", func() {
+				s := MustSucceed(db.OpenSnapshot())
+				w := MustSucceed(db.OpenWriter(ctx, domain.WriterConfig{Start: 30 * telem.SecondTS}))
+				MustSucceed(w.Write([]byte{11, 12, 13}))
+				Expect(w.Commit(ctx, 35*telem.SecondTS)).To(Succeed())
+				Expect(s.WriteTo(ctx, dstFS)).To(Succeed())
+				Expect(s.Close()).To(Succeed())
+				Expect(w.Close()).To(Succeed())
+
+				snapDB := MustSucceed(domain.Open(domain.Config{FS: dstFS, Instrumentation: PanicLogger()}))
+				Expect(readAll(snapDB)).To(HaveLen(2))
+				By("Allowing new domains to be written to the snapshot")
+				Expect(domain.Write(ctx, snapDB, (40 * telem.SecondTS).SpanRange(5*telem.Second), []byte{14})).To(Succeed())
+				Expect(readAll(snapDB)).To(HaveLen(3))
+				Expect(snapDB.Close()).To(Succeed())
+				Expect(readAll(db)).To(HaveLen(3))
+			})
+
+			It("Should block the DB from being closed until the snapshot is closed", func() {
+				s := MustSucceed(db.OpenSnapshot())
+				Expect(db.Close()).ToNot(Succeed())
+				Expect(s.Close()).To(Succeed())
+				Expect(s.Close()).To(Succeed())
+			})
+		})
+	}
+})
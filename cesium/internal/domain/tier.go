@@ -0,0 +1,260 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/samber/lo"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// fileKeyToCacheName returns the name of the local copy of a file that has been
+// relocated to the cold tier.
+func fileKeyToCacheName(key uint16) string {
+	return strconv.Itoa(int(key)) + ".cache"
+}
+
+// Tier relocates full domain files whose data all ends at or before the given timestamp
+// to the cold tier (Config.ColdFS). A file is only relocated if it is not being
+// written to or read from. Relocated files remain readable: readers fetch them from the
+// cold tier on demand and keep the most recently read files cached locally. Tier
+// returns the number of files relocated, and does nothing if Config.ColdFS is nil.
+func (db *DB) Tier(ctx context.Context, before telem.TimeStamp) (n int, err error) {
+	ctx, span := db.cfg.T.Bench(ctx, "tier")
+	defer func() { err = span.EndWith(err) }()
+
+	if db.cfg.ColdFS == nil {
+		return 0, nil
+	}
+	if db.closed.Load() {
+		return 0, errDBClosed
+	}
+	db.entityCount.Add(1)
+	defer db.entityCount.Add(-1)
+	db.fileLock.Lock()
+	defer db.fileLock.Unlock()
+
+	// Close any writers on full files so that they can be sealed.
+	if _, err = db.fc.gcWriters(); err != nil {
+		return
+	}
+
+	for fileKey := uint16(1); fileKey <= uint16(db.fc.counter.Value()); fileKey++ {
+		if db.fc.isCold(fileKey) || db.fc.hasWriter(fileKey) {
+			continue
+		}
+		name := fileKeyToName(fileKey)
+		exists, err := db.cfg.FS.Exists(name)
+		if err != nil {
+			return n, err
+		}
+		if !exists {
+			continue
+		}
+		s, err := db.cfg.FS.Stat(name)
+		if err != nil {
+			return n, err
+		}
+		if s.Size() < int64(db.cfg.FileSize) {
+			continue
+		}
+		// Files without any data are reclaimed by garbage collection instead.
+		if end, ok := db.idx.fileEnd(fileKey); !ok || end > before {
+			continue
+		}
+		relocated, err := db.fc.relocate(fileKey)
+		if err != nil {
+			return n, err
+		}
+		if relocated {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// fileEnd returns the end of the last domain stored in the file with the given key,
+// and false if no domains are stored in the file.
+func (idx *index) fileEnd(key uint16) (end telem.TimeStamp, ok bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	for _, ptr := range idx.mu.pointers {
+		if ptr.fileKey == key {
+			end, ok = ptr.End, true
+		}
+	}
+	return
+}
+
+// scanColdFiles populates the set of files that have been relocated to the cold tier,
+// along with the set of those files that are cached locally.
+func (fc *fileController) scanColdFiles() error {
+	fc.cold.files = make(map[uint16]struct{})
+	if fc.ColdFS == nil {
+		return nil
+	}
+	for i := 1; i <= int(fc.counter.Value()); i++ {
+		key := uint16(i)
+		local, err := fc.FS.Exists(fileKeyToName(key))
+		if err != nil {
+			return err
+		}
+		cold, err := fc.ColdFS.Exists(fileKeyToName(key))
+		if err != nil {
+			return err
+		}
+		cached, err := fc.FS.Exists(fileKeyToCacheName(key))
+		if err != nil {
+			return err
+		}
+		// If a local file exists, it is the authoritative copy: any cold copy is left
+		// over from an interrupted relocation, and will be replaced if the file is
+		// relocated again.
+		if local || !cold {
+			if cached {
+				if err = fc.FS.Remove(fileKeyToCacheName(key)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		fc.cold.files[key] = struct{}{}
+		if cached {
+			fc.cold.cached = append(fc.cold.cached, key)
+		}
+	}
+	return nil
+}
+
+func (fc *fileController) isCold(key uint16) bool {
+	fc.cold.Lock()
+	defer fc.cold.Unlock()
+	_, ok := fc.cold.files[key]
+	return ok
+}
+
+// relocate moves the file with the given key to the cold tier. The local copy of the
+// file is kept as a cache entry. relocate returns false if the file has open readers.
+func (fc *fileController) relocate(key uint16) (bool, error) {
+	fc.cold.Lock()
+	defer fc.cold.Unlock()
+	fc.readers.Lock()
+	defer fc.readers.Unlock()
+	if !fc.unprotectedCloseReaders(key) {
+		return false, nil
+	}
+	name := fileKeyToName(key)
+	if err := xfs.CopyFile(fc.FS, name, fc.ColdFS, name); err != nil {
+		return false, err
+	}
+	if err := fc.FS.Rename(name, fileKeyToCacheName(key)); err != nil {
+		return false, err
+	}
+	fc.cold.files[key] = struct{}{}
+	return true, fc.unprotectedTouchCache(key)
+}
+
+// removeCold removes the file with the given key from the cold tier along with its
+// local cache entry. removeCold returns false if the file has open readers.
+func (fc *fileController) removeCold(key uint16) (bool, error) {
+	fc.cold.Lock()
+	defer fc.cold.Unlock()
+	fc.readers.Lock()
+	defer fc.readers.Unlock()
+	if !fc.unprotectedCloseReaders(key) {
+		return false, nil
+	}
+	if err := fc.FS.Remove(fileKeyToCacheName(key)); err != nil {
+		return false, err
+	}
+	fc.cold.cached = lo.Without(fc.cold.cached, key)
+	delete(fc.cold.files, key)
+	return true, fc.ColdFS.Remove(fileKeyToName(key))
+}
+
+// unprotectedLocalName returns the name of the local file that readers should open to
+// read the file with the given key, and whether the file is stored in the cold tier.
+// If the file is cold and not cached locally, fetched is false and the file must be
+// fetched before it is opened. The caller must hold fc.cold, and must call
+// unprotectedTouchCache after registering a reader on a cold file.
+func (fc *fileController) unprotectedLocalName(key uint16) (name string, cold, fetched bool, err error) {
+	if _, ok := fc.cold.files[key]; !ok {
+		return fileKeyToName(key), false, true, nil
+	}
+	name = fileKeyToCacheName(key)
+	if lo.Contains(fc.cold.cached, key) {
+		return name, true, true, nil
+	}
+	// The file may have been fetched since the caller last checked, in which case it
+	// is adopted into the cache.
+	if fetched, err = fc.FS.Exists(name); err != nil || !fetched {
+		return name, true, false, err
+	}
+	fc.cold.cached = append(fc.cold.cached, key)
+	return name, true, true, nil
+}
+
+// fetch copies the file with the given key from the cold tier into the local cache.
+// Concurrent fetches of the same file share a single copy. The caller must not hold
+// fc.cold, so that reads of other files are not blocked while the file is copied.
+func (fc *fileController) fetch(key uint16) error {
+	_, err, _ := fc.cold.fetches.Do(strconv.Itoa(int(key)), func() (any, error) {
+		return nil, xfs.CopyFile(fc.ColdFS, fileKeyToName(key), fc.FS, fileKeyToCacheName(key))
+	})
+	return err
+}
+
+// unprotectedTouchCache marks the cache entry for the given key as the most recently
+// used, and evicts the least recently used entries that are not being read from until
+// the cache is within Config.ColdCacheSize. The caller must hold fc.cold and
+// fc.readers.
+func (fc *fileController) unprotectedTouchCache(key uint16) error {
+	fc.cold.cached = append(lo.Without(fc.cold.cached, key), key)
+	for i := 0; len(fc.cold.cached) > fc.ColdCacheSize && i < len(fc.cold.cached)-1; {
+		evict := fc.cold.cached[i]
+		if !fc.unprotectedCloseReaders(evict) {
+			i++
+			continue
+		}
+		if err := fc.FS.Remove(fileKeyToCacheName(evict)); err != nil {
+			return err
+		}
+		fc.cold.cached = append(fc.cold.cached[:i], fc.cold.cached[i+1:]...)
+	}
+	return nil
+}
+
+// unprotectedCloseReaders closes all pooled readers on the file with the given key. If
+// any of the readers are in use, none of them are closed and false is returned. The
+// caller must hold fc.readers.
+func (fc *fileController) unprotectedCloseReaders(key uint16) bool {
+	f, ok := fc.readers.files[key]
+	if !ok {
+		return true
+	}
+	f.Lock()
+	defer f.Unlock()
+	for i, r := range f.open {
+		if !r.tryAcquire() {
+			for _, acquired := range f.open[:i] {
+				_ = acquired.Close()
+			}
+			return false
+		}
+	}
+	for _, r := range f.open {
+		_ = r.HardClose()
+	}
+	delete(fc.readers.files, key)
+	return true
+}
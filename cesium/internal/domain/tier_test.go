@@ -0,0 +1,163 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package domain_test
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/domain"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Tier", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db          *domain.DB
+				fs, coldFS  xfs.FS
+				cleanUp     func() error
+				coldCleanUp func() error
+				openDB      = func() *domain.DB {
+					return MustSucceed(domain.Open(domain.Config{
+						FS:              fs,
+						ColdFS:          coldFS,
+						ColdCacheSize:   1,
+						FileSize:        25 * telem.ByteSize,
+						Instrumentation: PanicLogger(),
+					}))
+				}
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				coldFS, coldCleanUp = makeFS()
+				db = openDB()
+				for i := 1; i <= 3; i++ {
+					data := make([]byte, 20)
+					for j := range data {
+						data[j] = byte(i)
+					}
+					start := telem.TimeStamp(10*i) * telem.SecondTS
+					Expect(domain.Write(ctx, db, start.SpanRange(5*telem.Second), data)).To(Succeed())
+				}
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+				Expect(coldCleanUp()).To(Succeed())
+			})
+
+			readAll := func() [][]byte {
+				var (
+					i   = db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+					res [][]byte
+				)
+				for i.SeekFirst(ctx); i.Valid(); i.Next() {
+					r := MustSucceed(i.OpenReader(ctx))
+					buf := make([]byte, r.Len())
+					MustSucceed(r.ReadAt(buf, 0))
+					Expect(r.Close()).To(Succeed())
+					res = append(res, buf)
+				}
+				Expect(i.Close()).To(Succeed())
+				return res
+			}
+
+			expectData := func() {
+				data := readAll()
+				Expect(data).To(HaveLen(3))
+				for i, d := range data {
+					Expect(d).To(HaveLen(20))
+					Expect(d).To(HaveEach(byte(i + 1)))
+				}
+			}
+
+			It("Should relocate full files to the cold tier and read them back", func() {
+				Expect(MustSucceed(db.Tier(ctx, telem.TimeStampMax))).To(Equal(3))
+				for _, name := range []string{"1.domain", "2.domain", "3.domain"} {
+					Expect(MustSucceed(fs.Exists(name))).To(BeFalse())
+					Expect(MustSucceed(coldFS.Exists(name))).To(BeTrue())
+				}
+
+				By("Only keeping the most recently relocated file in the cache")
+				Expect(MustSucceed(fs.Exists("1.cache"))).To(BeFalse())
+				Expect(MustSucceed(fs.Exists("2.cache"))).To(BeFalse())
+				Expect(MustSucceed(fs.Exists("3.cache"))).To(BeTrue())
+
+				By("Fetching relocated files on demand")
+				expectData()
+				Expect(MustSucceed(fs.Exists("3.cache"))).To(BeTrue())
+				Expect(MustSucceed(fs.Exists("1.cache"))).To(BeFalse())
+			})
+
+			It("Should serve concurrent reads of relocated files", func() {
+				Expect(MustSucceed(db.Tier(ctx, telem.TimeStampMax))).To(Equal(3))
+				var wg sync.WaitGroup
+				for range 10 {
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+						expectData()
+					}()
+				}
+				wg.Wait()
+				Expect(MustSucceed(fs.Exists("3.cache"))).To(BeTrue())
+				Expect(MustSucceed(fs.Exists("3.cache.tmp"))).To(BeFalse())
+			})
+
+			It("Should not relocate files with data after the given timestamp", func() {
+				Expect(MustSucceed(db.Tier(ctx, 24*telem.SecondTS))).To(Equal(1))
+				Expect(MustSucceed(coldFS.Exists("1.domain"))).To(BeTrue())
+				Expect(MustSucceed(coldFS.Exists("2.domain"))).To(BeFalse())
+				Expect(MustSucceed(fs.Exists("2.domain"))).To(BeTrue())
+				expectData()
+			})
+
+			It("Should not relocate files that are being read", func() {
+				i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+				Expect(i.SeekFirst(ctx)).To(BeTrue())
+				r := MustSucceed(i.OpenReader(ctx))
+				Expect(MustSucceed(db.Tier(ctx, telem.TimeStampMax))).To(Equal(2))
+				Expect(MustSucceed(fs.Exists("1.domain"))).To(BeTrue())
+				Expect(r.Close()).To(Succeed())
+				Expect(i.Close()).To(Succeed())
+				Expect(MustSucceed(db.Tier(ctx, telem.TimeStampMax))).To(Equal(1))
+				Expect(MustSucceed(fs.Exists("1.domain"))).To(BeFalse())
+			})
+
+			It("Should read relocated files after the database is reopened", func() {
+				Expect(MustSucceed(db.Tier(ctx, telem.TimeStampMax))).To(Equal(3))
+				Expect(db.Close()).To(Succeed())
+				db = openDB()
+				expectData()
+				Expect(MustSucceed(db.Tier(ctx, telem.TimeStampMax))).To(BeZero())
+			})
+
+			It("Should remove cold files once all of their data has been deleted", func() {
+				Expect(MustSucceed(db.Tier(ctx, telem.TimeStampMax))).To(Equal(3))
+				Expect(db.Delete(
+					ctx,
+					createCalcOffset(0),
+					createCalcOffset(20),
+					(10 * telem.SecondTS).SpanRange(5*telem.Second),
+					telem.Density(1),
+				)).To(Succeed())
+				Expect(db.GarbageCollect(ctx)).To(Succeed())
+				Expect(MustSucceed(coldFS.Exists("1.domain"))).To(BeFalse())
+				Expect(MustSucceed(coldFS.Exists("2.domain"))).To(BeTrue())
+				Expect(readAll()).To(HaveLen(2))
+			})
+		})
+	}
+})
@@ -47,11 +47,21 @@ type WriterConfig struct {
 	// Setting an AutoIndexPersistInterval is invalid if EnableAutoCommit is off.
 	// [OPTIONAL] Defaults to 1s
 	AutoIndexPersistInterval telem.TimeSpan
+
+	// AllowOverlap allows the writer to be opened with a Start that overlaps with an
+	// existing domain. The writer must Split past any existing domains before it
+	// commits, or Commit will return an ErrWriteConflict.
+	// [OPTIONAL] - Defaults to false.
+	AllowOverlap *bool
 }
 
 var (
 	errWriterClosed     = core.EntityClosed("domain.writer")
-	DefaultWriterConfig = WriterConfig{EnableAutoCommit: config.False(), AutoIndexPersistInterval: 1 * telem.Second}
+	DefaultWriterConfig = WriterConfig{
+		EnableAutoCommit:         config.False(),
+		AutoIndexPersistInterval: 1 * telem.Second,
+		AllowOverlap:             config.False(),
+	}
 )
 
 const AlwaysIndexPersistOnAutoCommit telem.TimeSpan = -1
@@ -76,6 +86,7 @@ func (w WriterConfig) Override(other WriterConfig) WriterConfig {
 	w.End = override.Zero(w.End, other.End)
 	w.EnableAutoCommit = override.Nil(w.EnableAutoCommit, other.EnableAutoCommit)
 	w.AutoIndexPersistInterval = override.Zero(w.AutoIndexPersistInterval, other.AutoIndexPersistInterval)
+	w.AllowOverlap = override.Nil(w.AllowOverlap, other.AllowOverlap)
 	return w
 }
 
@@ -129,6 +140,13 @@ type Writer struct {
 	len int64
 	// internal is a TrackedWriteCloser used to write telemetry to FS.
 	internal xio.TrackedWriteCloser
+	// uncompressed holds telemetry written since the last flush when the DB is
+	// compressed. It is compressed and flushed to internal as a block each time it
+	// reaches maxBlockSize, and on Commit.
+	uncompressed []byte
+	// rawLen is the number of decompressed bytes flushed to internal since it was
+	// acquired. Only used when the DB is compressed.
+	rawLen uint32
 	// presetEnd denotes whether the writer has a preset end as part of its WriterConfig.
 	// If it does, then commits to the writer will use that end as the end of the domain.
 	presetEnd bool
@@ -140,6 +158,21 @@ type Writer struct {
 	closed bool
 	// onClose is called when the writer is closed.
 	onClose func()
+	// segments are the domains ended by calls to Split that have not yet been committed.
+	segments []segment
+	// segmentOffset is the offset of the current domain's telemetry within the
+	// decompressed telemetry written to internal.
+	segmentOffset uint32
+}
+
+// segment is a domain that was ended by a call to Writer.Split.
+type segment struct {
+	telem.TimeRange
+	// start and end are the byte range of the domain's telemetry within the
+	// decompressed telemetry written to the writer's internal file writer.
+	start, end uint32
+	// committed is true if a previous version of the domain is already in the index.
+	committed bool
 }
 
 // OpenWriter opens a new Writer using the given configuration.
@@ -153,7 +186,7 @@ func (db *DB) OpenWriter(ctx context.Context, cfg WriterConfig) (*Writer, error)
 	if err != nil {
 		return nil, err
 	}
-	if db.idx.overlap(cfg.Domain()) {
+	if !*cfg.AllowOverlap && db.idx.overlap(cfg.Domain()) {
 		return nil, errors.Wrap(
 			NewErrWriteConflict(cfg.Domain(), db.idx.timeRange()),
 			"cannot open writer because there is already data in the writer's time range",
@@ -202,12 +235,83 @@ func (w *Writer) Write(p []byte) (int, error) {
 	if w.closed {
 		return 0, errWriterClosed
 	}
+	if w.fc.Compression != nil {
+		w.uncompressed = append(w.uncompressed, p...)
+		w.len += int64(len(p))
+		for len(w.uncompressed) >= maxBlockSize {
+			if err := w.flush(maxBlockSize); err != nil {
+				return len(p), err
+			}
+		}
+		return len(p), nil
+	}
 	n, err := w.internal.Write(p)
 	w.fileSize += telem.Size(n)
 	w.len += int64(n)
 	return n, err
 }
 
+// Split ends the domain being written at end, and starts a new domain at start for all
+// telemetry written after Split returns. Both domains are added to the DB on the next
+// call to Commit. Split allows a single writer to fill the gaps between existing
+// domains. If no telemetry has been written to the current domain, Split only moves
+// its starting bound to start.
+func (w *Writer) Split(end, start telem.TimeStamp) error {
+	if w.closed {
+		return errWriterClosed
+	}
+	if w.presetEnd {
+		return errors.Wrap(validate.Error, "cannot split a writer with a preset end")
+	}
+	if pos := w.rawPosition(); pos > w.segmentOffset {
+		if !w.Start.Before(end) {
+			return errors.Wrapf(validate.Error, "split timestamp %s must be strictly greater than the starting timestamp %s", end, w.Start)
+		}
+		if start.Before(end) {
+			return errors.Wrapf(validate.Error, "start of new domain %s must not be before the end of the previous domain %s", start, end)
+		}
+		w.segments = append(w.segments, segment{
+			TimeRange: w.Start.Range(end),
+			start:     w.segmentOffset,
+			end:       pos,
+			committed: !w.prevCommit.IsZero(),
+		})
+		w.segmentOffset = pos
+	}
+	w.Start = start
+	w.prevCommit = 0
+	return nil
+}
+
+// rawPosition returns the number of decompressed bytes written to internal, including
+// any telemetry that has not yet been flushed.
+func (w *Writer) rawPosition() uint32 {
+	if w.fc.Compression != nil {
+		return w.rawLen + uint32(len(w.uncompressed))
+	}
+	return uint32(w.internal.Len())
+}
+
+// flush compresses the first n bytes of telemetry written since the last flush into a
+// block, and writes it to the underlying file.
+func (w *Writer) flush(n int) error {
+	if n == 0 {
+		return nil
+	}
+	block, err := encodeBlock(w.fc.Compression, w.uncompressed[:n])
+	if err != nil {
+		return err
+	}
+	written, err := w.internal.Write(block)
+	w.fileSize += telem.Size(written)
+	if err != nil {
+		return err
+	}
+	w.rawLen += uint32(n)
+	w.uncompressed = append(w.uncompressed[:0], w.uncompressed[n:]...)
+	return nil
+}
+
 // Commit commits the domain to the DB, making it available for reading by other processes.
 // If the WriterConfig.End parameter was set, Commit will ignore the provided timestamp
 // and use the WriterConfig.End parameter instead. If the WriterConfig.End parameter was
@@ -243,32 +347,40 @@ func (w *Writer) commit(ctx context.Context, end telem.TimeStamp, persist bool)
 		return span.Error(errors.Newf("commit timestamp %v cannot be greater than preset end timestamp %v: exceeded by a time span of %v", end, w.End, w.End.Span(end)))
 	}
 
-	length := w.internal.Len()
-	if length == 0 {
+	if w.fc.Compression != nil {
+		if err := w.flush(len(w.uncompressed)); err != nil {
+			return span.Error(err)
+		}
+	}
+
+	if w.internal.Len() == 0 {
 		return nil
 	}
 
 	commitEnd, switchingFile := w.resolveCommitEnd(end)
-	if err := w.validateCommitRange(commitEnd, switchingFile); err != nil {
-		return span.Error(err)
+	pos := w.rawPosition()
+	if pos > w.segmentOffset {
+		if err := w.validateCommitRange(commitEnd, switchingFile); err != nil {
+			return span.Error(err)
+		}
 	}
 
-	ptr := pointer{
-		TimeRange: telem.TimeRange{Start: w.Start, End: commitEnd},
-		offset:    uint32(w.internal.Offset()),
-		length:    uint32(length),
-		fileKey:   w.fileKey,
+	for len(w.segments) > 0 {
+		s := w.segments[0]
+		if err := w.put(ctx, w.pointer(s.TimeRange, s.start, s.end), s.committed, persist); err != nil {
+			return span.Error(err)
+		}
+		w.segments = w.segments[1:]
 	}
-	f := lo.Ternary(w.prevCommit.IsZero(), w.idx.insert, w.idx.update)
-
-	err := span.Error(f(ctx, ptr, persist))
-	if err != nil {
-		return span.Error(err)
+	if pos > w.segmentOffset {
+		ptr := w.pointer(w.Start.Range(commitEnd), w.segmentOffset, pos)
+		if err := w.put(ctx, ptr, !w.prevCommit.IsZero(), persist); err != nil {
+			return span.Error(err)
+		}
 	}
 
 	if switchingFile {
-		err = w.internal.Close()
-		if err != nil {
+		if err := w.internal.Close(); err != nil {
 			return span.Error(err)
 		}
 
@@ -280,15 +392,42 @@ func (w *Writer) commit(ctx context.Context, end telem.TimeStamp, persist bool)
 		w.fileKey = newFileKey
 		w.internal = newInternalWriter
 		w.fileSize = telem.Size(newFileSize)
-		w.Start = commitEnd
+		w.rawLen = 0
+		if pos > w.segmentOffset {
+			w.Start = commitEnd
+		}
+		w.segmentOffset = 0
 		w.prevCommit = 0
-	} else {
+	} else if pos > w.segmentOffset {
 		w.prevCommit = commitEnd
 	}
 
 	return nil
 }
 
+// pointer returns a pointer to the domain occupying the time range tr, whose
+// telemetry is in the byte range [start, end) of the decompressed telemetry written
+// to internal.
+func (w *Writer) pointer(tr telem.TimeRange, start, end uint32) pointer {
+	ptr := pointer{
+		TimeRange: tr,
+		offset:    uint32(w.internal.Offset()),
+		length:    uint32(w.internal.Len()),
+		rawLength: end - start,
+		fileKey:   w.fileKey,
+	}
+	return ptr.slice(tr, start, end, w.fc.Compression != nil)
+}
+
+// put inserts the pointer into the index, or updates it if a previous version of the
+// pointer was already inserted.
+func (w *Writer) put(ctx context.Context, ptr pointer, update bool, persist bool) error {
+	if update {
+		return w.idx.update(ctx, ptr, persist)
+	}
+	return w.idx.insert(ctx, ptr, persist)
+}
+
 // resolveCommitEnd returns whether a file change is needed, the resolved commit end, and any errors.
 func (w *Writer) resolveCommitEnd(end telem.TimeStamp) (telem.TimeStamp, bool) {
 	// fc.Config.Filesize is the nominal file size to not exceed, in reality, this value
@@ -557,6 +557,98 @@ var _ = Describe("Writer Behavior", Ordered, func() {
 					Expect(w.Close()).To(Succeed())
 				})
 			})
+			Describe("Split", func() {
+				readAll := func(i *domain.Iterator) []byte {
+					r := MustSucceed(i.OpenReader(ctx))
+					buf := make([]byte, r.Len())
+					MustSucceed(r.ReadAt(buf, 0))
+					Expect(r.Close()).To(Succeed())
+					return buf
+				}
+				It("Should commit the telemetry on each side of a split as separate domains", func() {
+					Expect(domain.Write(ctx, db, (20 * telem.SecondTS).Range(30*telem.SecondTS), []byte{20, 21})).To(Succeed())
+					w := MustSucceed(db.OpenWriter(ctx, domain.WriterConfig{Start: 10 * telem.SecondTS}))
+					MustSucceed(w.Write([]byte{10, 11, 12}))
+					Expect(w.Split(12*telem.SecondTS+1, 30*telem.SecondTS)).To(Succeed())
+					MustSucceed(w.Write([]byte{30, 31}))
+					Expect(w.Commit(ctx, 31*telem.SecondTS+1)).To(Succeed())
+					Expect(w.Close()).To(Succeed())
+
+					i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+					var (
+						ranges []telem.TimeRange
+						data   []byte
+					)
+					for i.SeekFirst(ctx); i.Valid(); i.Next() {
+						ranges = append(ranges, i.TimeRange())
+						data = append(data, readAll(i)...)
+					}
+					Expect(i.Close()).To(Succeed())
+					Expect(ranges).To(Equal([]telem.TimeRange{
+						(10 * telem.SecondTS).Range(12*telem.SecondTS + 1),
+						(20 * telem.SecondTS).Range(30 * telem.SecondTS),
+						(30 * telem.SecondTS).Range(31*telem.SecondTS + 1),
+					}))
+					Expect(data).To(Equal([]byte{10, 11, 12, 20, 21, 30, 31}))
+				})
+				It("Should split a domain that was already committed", func() {
+					Expect(domain.Write(ctx, db, (20 * telem.SecondTS).Range(30*telem.SecondTS), []byte{20, 21})).To(Succeed())
+					w := MustSucceed(db.OpenWriter(ctx, domain.WriterConfig{Start: 10 * telem.SecondTS}))
+					MustSucceed(w.Write([]byte{10, 11}))
+					Expect(w.Commit(ctx, 11*telem.SecondTS+1)).To(Succeed())
+					MustSucceed(w.Write([]byte{12}))
+					Expect(w.Split(12*telem.SecondTS+1, 30*telem.SecondTS)).To(Succeed())
+					MustSucceed(w.Write([]byte{30}))
+					Expect(w.Commit(ctx, 30*telem.SecondTS+1)).To(Succeed())
+					Expect(w.Close()).To(Succeed())
+					i := db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+					Expect(i.SeekFirst(ctx)).To(BeTrue())
+					Expect(i.TimeRange()).To(Equal((10 * telem.SecondTS).Range(12*telem.SecondTS + 1)))
+					Expect(readAll(i)).To(Equal([]byte{10, 11, 12}))
+					Expect(i.SeekLast(ctx)).To(BeTrue())
+					Expect(i.TimeRange()).To(Equal((30 * telem.SecondTS).Range(30*telem.SecondTS + 1)))
+					Expect(readAll(i)).To(Equal([]byte{30}))
+					Expect(i.Close()).To(Succeed())
+				})
+				It("Should only move the start of an empty domain", func() {
+					Expect(domain.Write(ctx, db, (10 * telem.SecondTS).Range(20*telem.SecondTS), []byte{10})).To(Succeed())
+					w := MustSucceed(db.OpenWriter(ctx, domain.WriterConfig{
+						Start:        15 * telem.SecondTS,
+						AllowOverlap: config.True(),
+					}))
+					Expect(w.Split(15*telem.SecondTS, 20*telem.SecondTS)).To(Succeed())
+					MustSucceed(w.Write([]byte{20}))
+					Expect(w.Commit(ctx, 20*telem.SecondTS+1)).To(Succeed())
+					Expect(w.Close()).To(Succeed())
+					Expect(db.Domains(telem.TimeRangeMax)).To(Equal([]telem.TimeRange{
+						(10 * telem.SecondTS).Range(20 * telem.SecondTS),
+						(20 * telem.SecondTS).Range(20*telem.SecondTS + 1),
+					}))
+				})
+				It("Should fail to commit a domain that overlaps with existing data", func() {
+					Expect(domain.Write(ctx, db, (10 * telem.SecondTS).Range(20*telem.SecondTS), []byte{10})).To(Succeed())
+					w := MustSucceed(db.OpenWriter(ctx, domain.WriterConfig{
+						Start:        15 * telem.SecondTS,
+						AllowOverlap: config.True(),
+					}))
+					MustSucceed(w.Write([]byte{15}))
+					Expect(w.Commit(ctx, 15*telem.SecondTS+1)).To(HaveOccurredAs(domain.ErrWriteConflict))
+					Expect(w.Close()).To(Succeed())
+				})
+				It("Should not split a writer with a preset end", func() {
+					w := MustSucceed(db.OpenWriter(ctx, domain.WriterConfig{Start: 10 * telem.SecondTS, End: 20 * telem.SecondTS}))
+					MustSucceed(w.Write([]byte{10}))
+					Expect(w.Split(11*telem.SecondTS, 12*telem.SecondTS)).To(HaveOccurredAs(validate.Error))
+					Expect(w.Close()).To(Succeed())
+				})
+				It("Should not start a new domain before the end of the previous one", func() {
+					w := MustSucceed(db.OpenWriter(ctx, domain.WriterConfig{Start: 10 * telem.SecondTS}))
+					MustSucceed(w.Write([]byte{10}))
+					Expect(w.Split(12*telem.SecondTS, 11*telem.SecondTS)).To(HaveOccurredAs(validate.Error))
+					Expect(w.Split(10*telem.SecondTS, 11*telem.SecondTS)).To(HaveOccurredAs(validate.Error))
+					Expect(w.Close()).To(Succeed())
+				})
+			})
 			Describe("Close", func() {
 				It("Should not allow operations on a closed writer", func() {
 					var (
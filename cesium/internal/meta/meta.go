@@ -42,6 +42,10 @@ func ReadOrCreate(fs xfs.FS, ch core.Channel, codec binary.Codec) (core.Channel,
 	return ch, Create(fs, codec, ch)
 }
 
+// Exists returns true if the metadata file for a database whose data is kept in fs
+// exists.
+func Exists(fs xfs.FS) (bool, error) { return fs.Exists(metaFile) }
+
 // Read reads the metadata file for a database whose data is kept in fs and is encoded
 // by the provided encoder.
 func Read(fs xfs.FS, codec binary.Codec) (ch core.Channel, err error) {
@@ -83,6 +87,9 @@ func Create(fs xfs.FS, codec binary.Codec, ch core.Channel) error {
 	if _, err = metaF.Write(b); err != nil {
 		return err
 	}
+	if err = metaF.Sync(); err != nil {
+		return err
+	}
 	return metaF.Close()
 }
 
@@ -92,11 +99,13 @@ func validateMeta(ch core.Channel) error {
 	v := validate.New("meta")
 	validate.Positive(v, "key", ch.Key)
 	validate.NotEmptyString(v, "dataType", ch.DataType)
+	v.Exec(ch.ValidateCompression)
+	v.Exec(ch.ValidateRetention)
+	v.Exec(ch.ValidateRollups)
 	if ch.Virtual {
 		v.Ternaryf("index", ch.Index != 0, "virtual channel cannot be indexed")
 		v.Ternaryf("rate", ch.Rate != 0, "virtual channel cannot have a rate")
 	} else {
-		v.Ternary("data_type", ch.DataType == telem.StringT, "persisted channels cannot have string data types")
 		if ch.IsIndex {
 			v.Ternary("data_type", ch.DataType != telem.TimeStampT, "index channel must be of type timestamp")
 			v.Ternaryf("index", ch.Index != 0 && ch.Index != ch.Key, "index channel cannot be indexed by another channel")
@@ -0,0 +1,223 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary
+
+import (
+	"context"
+	"slices"
+
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// bucket is the aggregate value of the samples in a bucket of time.
+type bucket struct {
+	telem.TimeRange
+	value []byte
+}
+
+// aggregateNext moves the iterator forward by span in aggregation mode. The iterator
+// walks the view one bucket at a time, summarizing the samples in each bucket and
+// reducing them to a single value, so that only the aggregate values are placed in the
+// iterator's frame. Buckets are read from the coarsest rollup tier that can serve them,
+// falling back to the raw samples for buckets not covered by the tier.
+//
+// Each series in the frame holds the values of a contiguous run of buckets containing
+// data: the i-th value of a series is the aggregate of the i-th bucket intersecting the
+// series' time range. Buckets without any data are omitted, starting a new series.
+func (i *Iterator) aggregateNext(ctx context.Context, span telem.TimeSpan) {
+	if span == AutoSpan {
+		span = i.autoAggregateSpan()
+	}
+	view := i.view.End.SpanRange(span).BoundBy(i.bounds)
+	var buckets []bucket
+	for cursor := view.Start; cursor < view.End; {
+		bucketEnd := min(i.bucketStart(cursor).Add(i.Aggregation.Span), view.End)
+		b, ok := i.readBucket(ctx, cursor.Range(bucketEnd), true)
+		if i.err != nil {
+			return
+		}
+		if ok {
+			buckets = append(buckets, b)
+			cursor = bucketEnd
+			continue
+		}
+		next, ok := i.nextDataBucket(bucketEnd)
+		if !ok {
+			break
+		}
+		cursor = next
+	}
+	i.view = view
+	i.frame = i.aggregateFrame(buckets)
+}
+
+// aggregatePrev moves the iterator backward by span in aggregation mode. See
+// aggregateNext for more details.
+func (i *Iterator) aggregatePrev(ctx context.Context, span telem.TimeSpan) {
+	if span == AutoSpan {
+		span = i.autoAggregateSpan()
+	}
+	view := i.view.Start.SpanRange(-1 * span).BoundBy(i.bounds)
+	var buckets []bucket
+	for cursor := view.End; cursor > view.Start; {
+		bucketStart := max(i.bucketStart(cursor-1), view.Start)
+		b, ok := i.readBucket(ctx, bucketStart.Range(cursor), false)
+		if i.err != nil {
+			return
+		}
+		if ok {
+			buckets = append(buckets, b)
+			cursor = bucketStart
+			continue
+		}
+		prev, ok := i.prevDataBucket(bucketStart)
+		if !ok {
+			break
+		}
+		cursor = prev
+	}
+	slices.Reverse(buckets)
+	i.view = view
+	i.frame = i.aggregateFrame(buckets)
+}
+
+// readBucket computes the aggregate value of the bucket occupying the given time
+// range, returning false if the bucket contains no data. The summary of the bucket is
+// read from the iterator's rollup tier if possible, and is otherwise computed from the
+// raw samples in the bucket, which are read by moving the iterator across the bucket
+// in the given direction.
+func (i *Iterator) readBucket(ctx context.Context, tr telem.TimeRange, forward bool) (bucket, bool) {
+	summary, ok := i.readRollup(ctx, tr)
+	if i.err != nil {
+		return bucket{}, false
+	}
+	if !ok {
+		if forward {
+			i.view = tr.Start.SpanRange(0)
+			i.next(ctx, tr.Span())
+		} else {
+			i.view = tr.End.SpanRange(0)
+			i.prev(ctx, tr.Span())
+		}
+		if i.err != nil || len(i.frame.Series) == 0 {
+			return bucket{}, false
+		}
+		summary = i.summarizer.summarize(i.frame.Series)
+	}
+	if summaryCount(summary) == 0 {
+		return bucket{}, false
+	}
+	return bucket{TimeRange: tr, value: i.summarizer.value(i.Aggregation, summary)}, true
+}
+
+// readRollup reads the summary of the bucket occupying the given time range from the
+// iterator's rollup tier, returning false if the tier does not cover the bucket.
+func (i *Iterator) readRollup(ctx context.Context, tr telem.TimeRange) ([]byte, bool) {
+	if i.rollup == nil || !i.rollup.aligned(tr) {
+		return nil, false
+	}
+	summaries, ok, err := i.rollup.read(ctx, i.rollupIter, tr)
+	if err != nil || !ok {
+		i.err = err
+		return nil, false
+	}
+	return i.summarizer.merge(summaries), true
+}
+
+// autoAggregateSpan returns the span covered by AutoChunkSize buckets.
+func (i *Iterator) autoAggregateSpan() telem.TimeSpan {
+	if i.AutoChunkSize > int64(telem.TimeSpanMax/i.Aggregation.Span) {
+		return telem.TimeSpanMax
+	}
+	return telem.TimeSpan(i.AutoChunkSize) * i.Aggregation.Span
+}
+
+// bucketStart returns the start of the bucket containing the given timestamp. Buckets
+// are aligned to the start of the iterator's bounds.
+func (i *Iterator) bucketStart(ts telem.TimeStamp) telem.TimeStamp {
+	return ts.Sub(i.bounds.Start.Span(ts) % i.Aggregation.Span)
+}
+
+// nextDataBucket returns the start of the first bucket at or after the given timestamp
+// that may contain data, moving the underlying domain iterator forward past any
+// domains that end before the timestamp. nextDataBucket returns false if there is no
+// more data.
+func (i *Iterator) nextDataBucket(after telem.TimeStamp) (telem.TimeStamp, bool) {
+	if !i.internal.Valid() {
+		return 0, false
+	}
+	for i.internal.TimeRange().End.BeforeEq(after) {
+		if !i.internal.Next() {
+			return 0, false
+		}
+	}
+	if start := i.internal.TimeRange().Start; start.After(after) {
+		return i.bucketStart(start), true
+	}
+	return after, true
+}
+
+// prevDataBucket returns the end of the last bucket at or before the given timestamp
+// that may contain data, moving the underlying domain iterator backward past any
+// domains that start after the timestamp. prevDataBucket returns false if there is no
+// more data.
+func (i *Iterator) prevDataBucket(before telem.TimeStamp) (telem.TimeStamp, bool) {
+	if !i.internal.Valid() {
+		return 0, false
+	}
+	for i.internal.TimeRange().Start.AfterEq(before) {
+		if !i.internal.Prev() {
+			return 0, false
+		}
+	}
+	if end := i.internal.TimeRange().End; end.Before(before) {
+		return i.bucketStart(end - 1).Add(i.Aggregation.Span), true
+	}
+	return before, true
+}
+
+// aggregateFrame builds a frame from the given buckets, placing each contiguous run of
+// buckets into its own series.
+func (i *Iterator) aggregateFrame(buckets []bucket) core.Frame {
+	var (
+		frame core.Frame
+		run   telem.Series
+		dt    = i.Aggregation.DataType(i.Channel.DataType)
+	)
+	for _, b := range buckets {
+		if len(run.Data) > 0 && run.TimeRange.End != b.Start {
+			frame = frame.Append(i.Channel.Key, run)
+			run = telem.Series{}
+		}
+		if len(run.Data) == 0 {
+			run = telem.Series{DataType: dt, TimeRange: b.TimeRange}
+		}
+		run.Data = append(run.Data, b.value...)
+		run.TimeRange.End = b.End
+	}
+	if len(run.Data) > 0 {
+		frame = frame.Append(i.Channel.Key, run)
+	}
+	return frame
+}
+
+// rollupFor returns the coarsest of the given rollup tiers whose buckets evenly divide
+// the buckets of the aggregation, or nil if there is no such tier. Buckets that are
+// not aligned to the tier's buckets, such as those clipped by the iterator's bounds,
+// are still read from raw data.
+func rollupFor(rollups []rollup, agg core.Aggregation) *rollup {
+	for j := len(rollups) - 1; j >= 0; j-- {
+		if agg.Span%rollups[j].span == 0 {
+			return &rollups[j]
+		}
+	}
+	return nil
+}
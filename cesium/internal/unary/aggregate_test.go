@@ -0,0 +1,142 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary_test
+
+import (
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/cesium/internal/unary"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Aggregation", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *unary.DB
+				indexDB *unary.DB
+				index   core.ChannelKey = 1
+				data    core.ChannelKey = 2
+				fs      xfs.FS
+				cleanUp func() error
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				indexDB = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("index")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      index,
+						DataType: telem.TimeStampT,
+						IsIndex:  true,
+						Index:    index,
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				db = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("data")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      data,
+						DataType: telem.Int64T,
+						Index:    index,
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				db.SetIndex(indexDB.Index())
+				Expect(unary.Write(ctx, indexDB, 10*telem.SecondTS, telem.NewSecondsTSV(10, 11, 12, 13, 14, 15))).To(Succeed())
+				Expect(unary.Write(ctx, db, 10*telem.SecondTS, telem.NewSeriesV[int64](1, 2, 3, 4, 5, 6))).To(Succeed())
+				Expect(unary.Write(ctx, indexDB, 30*telem.SecondTS, telem.NewSecondsTSV(30, 31, 32, 33))).To(Succeed())
+				Expect(unary.Write(ctx, db, 30*telem.SecondTS, telem.NewSeriesV[int64](-1, -2, -3, -4))).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(indexDB.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			openIter := func(fn core.AggregateFunc) *unary.Iterator {
+				return db.OpenIterator(unary.IteratorConfig{
+					Bounds:      telem.TimeRangeMax,
+					Aggregation: core.Aggregation{Span: 5 * telem.Second, Func: fn},
+				})
+			}
+
+			DescribeTable("Next", func(fn core.AggregateFunc, expected ...telem.Series) {
+				iter := openIter(fn)
+				Expect(iter.SeekFirst(ctx)).To(BeTrue())
+				Expect(iter.Next(ctx, 30*telem.Second)).To(BeTrue())
+				Expect(iter.View()).To(Equal((10 * telem.SecondTS).SpanRange(30 * telem.Second)))
+				f := iter.Value()
+				Expect(f.Series).To(HaveLen(2))
+				Expect(f.Series[0].TimeRange).To(Equal((10 * telem.SecondTS).Range(20 * telem.SecondTS)))
+				Expect(f.Series[1].TimeRange).To(Equal((30 * telem.SecondTS).Range(35 * telem.SecondTS)))
+				for i, s := range expected {
+					Expect(f.Series[i].DataType).To(Equal(s.DataType))
+					Expect(f.Series[i].Data).To(Equal(s.Data))
+				}
+				Expect(iter.Next(ctx, 30*telem.Second)).To(BeFalse())
+				Expect(iter.Close()).To(Succeed())
+			},
+				Entry("Min", core.AggregateMin, telem.NewSeriesV[int64](1, 6), telem.NewSeriesV[int64](-4)),
+				Entry("Max", core.AggregateMax, telem.NewSeriesV[int64](5, 6), telem.NewSeriesV[int64](-1)),
+				Entry("Mean", core.AggregateMean, telem.NewSeriesV[float64](3, 6), telem.NewSeriesV[float64](-2.5)),
+				Entry("First", core.AggregateFirst, telem.NewSeriesV[int64](1, 6), telem.NewSeriesV[int64](-1)),
+				Entry("Last", core.AggregateLast, telem.NewSeriesV[int64](5, 6), telem.NewSeriesV[int64](-4)),
+				Entry("Count", core.AggregateCount, telem.NewSeriesV[int64](5, 1), telem.NewSeriesV[int64](4)),
+			)
+
+			It("Should aggregate buckets when moving backward", func() {
+				iter := openIter(core.AggregateCount)
+				Expect(iter.SeekLast(ctx)).To(BeTrue())
+				Expect(iter.Prev(ctx, 30*telem.Second)).To(BeTrue())
+				f := iter.Value()
+				Expect(f.Series).To(HaveLen(2))
+				Expect(f.Series[0].TimeRange).To(Equal((10 * telem.SecondTS).Range(20 * telem.SecondTS)))
+				Expect(f.Series[0].Data).To(Equal(telem.NewSeriesV[int64](5, 1).Data))
+				Expect(f.Series[1].TimeRange.Start).To(Equal(30 * telem.SecondTS))
+				Expect(f.Series[1].Data).To(Equal(telem.NewSeriesV[int64](4).Data))
+				Expect(iter.Prev(ctx, 30*telem.Second)).To(BeFalse())
+				Expect(iter.Close()).To(Succeed())
+			})
+
+			It("Should move by AutoChunkSize buckets when using AutoSpan", func() {
+				iter := db.OpenIterator(unary.IteratorConfig{
+					Bounds:        telem.TimeRangeMax,
+					AutoChunkSize: 2,
+					Aggregation:   core.Aggregation{Span: 5 * telem.Second, Func: core.AggregateMax},
+				})
+				Expect(iter.SeekFirst(ctx)).To(BeTrue())
+				Expect(iter.Next(ctx, unary.AutoSpan)).To(BeTrue())
+				Expect(iter.Value().Series).To(HaveLen(1))
+				Expect(iter.Value().Series[0].Data).To(Equal(telem.NewSeriesV[int64](5, 6).Data))
+				Expect(iter.Next(ctx, unary.AutoSpan)).To(BeFalse())
+				Expect(iter.Next(ctx, unary.AutoSpan)).To(BeTrue())
+				Expect(iter.Value().Series[0].Data).To(Equal(telem.NewSeriesV[int64](-1).Data))
+				Expect(iter.Close()).To(Succeed())
+			})
+
+			It("Should not hang when moving across an unbounded span without data", func() {
+				iter := openIter(core.AggregateMean)
+				Expect(iter.SeekFirst(ctx)).To(BeTrue())
+				Expect(iter.Next(ctx, telem.TimeSpanMax)).To(BeTrue())
+				Expect(iter.Value().Series).To(HaveLen(2))
+				Expect(math.IsNaN(telem.ValueAt[float64](iter.Value().Series[0], 0))).To(BeFalse())
+				Expect(iter.Next(ctx, telem.TimeSpanMax)).To(BeFalse())
+				Expect(iter.Close()).To(Succeed())
+			})
+		})
+	}
+})
@@ -0,0 +1,151 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/x/control"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// convertChunkSize is the maximum number of samples converted at a time.
+const convertChunkSize = 1e5
+
+// Convertible returns true if the samples of a channel can be converted from and to the
+// given data type.
+func Convertible(dt telem.DataType) bool {
+	return convertsAsInteger(dt) || dt == telem.Float32T || dt == telem.Float64T
+}
+
+func convertsAsInteger(dt telem.DataType) bool {
+	switch dt {
+	case telem.Int8T, telem.Int16T, telem.Int32T, telem.Int64T,
+		telem.Uint8T, telem.Uint16T, telem.Uint32T, telem.Uint64T,
+		telem.TimeStampT:
+		return true
+	}
+	return false
+}
+
+// convertSeries converts the samples in series to the given data type using Go's
+// numeric conversion rules. Samples are converted through int64 when both data types
+// are integers so that no precision is lost, and through float64 otherwise.
+func convertSeries(series telem.Series, dt telem.DataType) telem.Series {
+	out := telem.AllocSeries(dt, series.Len())
+	out.TimeRange = series.TimeRange
+	out.Alignment = series.Alignment
+	var (
+		inDensity  = int64(series.DataType.Density())
+		outDensity = int64(dt.Density())
+	)
+	if convertsAsInteger(series.DataType) && convertsAsInteger(dt) {
+		marshal := telem.MarshalF[int64](dt)
+		for i := range series.Len() {
+			marshal(out.Data[i*outDensity:], unmarshalInteger(series.DataType, series.Data[i*inDensity:]))
+		}
+		return out
+	}
+	marshal := telem.MarshalF[float64](dt)
+	for i := range series.Len() {
+		marshal(out.Data[i*outDensity:], unmarshalFloat(series.DataType, series.Data[i*inDensity:]))
+	}
+	return out
+}
+
+// unmarshalInteger unmarshals a single sample of an integer data type, sign extending
+// signed samples.
+func unmarshalInteger(dt telem.DataType, b []byte) int64 {
+	switch dt {
+	case telem.Int8T:
+		return int64(int8(b[0]))
+	case telem.Int16T:
+		return int64(int16(telem.ByteOrder.Uint16(b)))
+	case telem.Int32T:
+		return int64(int32(telem.ByteOrder.Uint32(b)))
+	}
+	return telem.UnmarshalF[int64](dt)(b)
+}
+
+// unmarshalFloat unmarshals a single sample of any convertible data type as a float64.
+func unmarshalFloat(dt telem.DataType, b []byte) float64 {
+	switch dt {
+	case telem.Float32T, telem.Float64T:
+		return telem.UnmarshalF[float64](dt)(b)
+	case telem.Uint64T:
+		return float64(telem.ByteOrder.Uint64(b))
+	}
+	return float64(unmarshalInteger(dt, b))
+}
+
+// ConvertTo writes the data in the DB into dst, converting each sample to the data type
+// of dst's channel. dst must be empty, and its channel must have a data type that the
+// DB's data type can be converted to (see Convertible). Every domain in the DB is
+// written to a domain occupying the same time range in dst, so the converted data
+// remains aligned with the channel's index.
+//
+// ConvertTo does not acquire control of the DB, so the caller must ensure that nothing
+// is writing to it.
+func (db *DB) ConvertTo(ctx context.Context, dst *DB) error {
+	if db.closed.Load() || dst.closed.Load() {
+		return db.wrapError(ErrDBClosed)
+	}
+	from, to := db.cfg.Channel.DataType, dst.cfg.Channel.DataType
+	if !Convertible(from) || !Convertible(to) {
+		return db.wrapError(errors.Wrapf(
+			validate.Error,
+			"cannot convert data type %s to %s",
+			from,
+			to,
+		))
+	}
+	for _, dr := range db.Domains(telem.TimeRangeMax) {
+		if err := db.convertDomain(ctx, dr, dst); err != nil {
+			return db.wrapError(err)
+		}
+	}
+	return nil
+}
+
+// convertDomain converts the domain occupying dr into a domain in dst occupying the
+// same time range.
+func (db *DB) convertDomain(ctx context.Context, dr telem.TimeRange, dst *DB) (err error) {
+	w, _, err := dst.OpenWriter(ctx, WriterConfig{
+		Start:     dr.Start,
+		End:       dr.End,
+		Authority: control.Absolute,
+		Subject:   control.Subject{Key: uuid.NewString(), Name: "convert_writer"},
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, closeErr := w.Close()
+		err = errors.Combine(err, closeErr)
+	}()
+	i := db.OpenIterator(IteratorConfig{Bounds: dr, AutoChunkSize: convertChunkSize})
+	defer func() { err = errors.Combine(err, i.Close()) }()
+	if i.SeekFirst(ctx) {
+		for i.Next(ctx, AutoSpan) {
+			for _, s := range i.Value().Series {
+				if _, err = w.Write(convertSeries(s, dst.cfg.Channel.DataType)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err = i.Error(); err != nil {
+		return err
+	}
+	return w.CommitWithEnd(ctx, dr.End)
+}
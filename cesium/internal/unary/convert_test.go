@@ -0,0 +1,110 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/cesium/internal/unary"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Convert", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS:"+fsName, func() {
+			var (
+				db      *unary.DB
+				indexDB *unary.DB
+				index   uint32 = 1
+				data    uint32 = 2
+				fs      xfs.FS
+				cleanUp func() error
+			)
+			openDst := func(dt telem.DataType) *unary.DB {
+				dst := MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("converted")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      data,
+						DataType: dt,
+						Index:    index,
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				dst.SetIndex(indexDB.Index())
+				return dst
+			}
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				indexDB = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("index")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      index,
+						DataType: telem.TimeStampT,
+						IsIndex:  true,
+						Index:    index,
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				db = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("data")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      data,
+						DataType: telem.Int16T,
+						Index:    index,
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				db.SetIndex(indexDB.Index())
+				Expect(unary.Write(ctx, indexDB, 10*telem.SecondTS, telem.NewSecondsTSV(10, 11, 12))).To(Succeed())
+				Expect(unary.Write(ctx, db, 10*telem.SecondTS, telem.NewSeriesV[int16](-300, 0, 300))).To(Succeed())
+				Expect(unary.Write(ctx, indexDB, 20*telem.SecondTS, telem.NewSecondsTSV(20, 21))).To(Succeed())
+				Expect(unary.Write(ctx, db, 20*telem.SecondTS, telem.NewSeriesV[int16](1, 2))).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(indexDB.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			It("Should convert each domain into a domain occupying the same time range", func() {
+				dst := openDst(telem.Float32T)
+				Expect(db.ConvertTo(ctx, dst)).To(Succeed())
+				Expect(dst.Domains(telem.TimeRangeMax)).To(Equal(db.Domains(telem.TimeRangeMax)))
+				var values []float32
+				for _, s := range MustSucceed(dst.Read(ctx, telem.TimeRangeMax)).Series {
+					values = append(values, telem.UnmarshalSlice[float32](s.Data, s.DataType)...)
+				}
+				Expect(values).To(Equal([]float32{-300, 0, 300, 1, 2}))
+				Expect(dst.Close()).To(Succeed())
+			})
+
+			It("Should wrap integers converted to a narrower data type", func() {
+				dst := openDst(telem.Uint8T)
+				Expect(db.ConvertTo(ctx, dst)).To(Succeed())
+				frame := MustSucceed(dst.Read(ctx, (10 * telem.SecondTS).Range(13*telem.SecondTS)))
+				Expect(frame.Series[0].Data).To(Equal([]byte{212, 0, 44}))
+				Expect(dst.Close()).To(Succeed())
+			})
+
+			It("Should not convert to a data type that is not numeric", func() {
+				dst := openDst(telem.UUIDT)
+				Expect(db.ConvertTo(ctx, dst)).To(HaveOccurredAs(validate.Error))
+				Expect(dst.Close()).To(Succeed())
+			})
+		})
+	}
+})
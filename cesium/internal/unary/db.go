@@ -31,7 +31,12 @@ type DB struct {
 	// Config contains validated configuration parameters for the DB.
 	cfg Config
 	// domain is the underlying domain database on which writes will be executed.
-	domain     *domain.DB
+	domain *domain.DB
+	// rollups are the channel's rollup tiers, sorted by span in ascending order.
+	rollups []rollup
+	// offsets holds the offset table of each domain if the channel has a variable
+	// density data type, and is nil otherwise. See variable.go for more details.
+	offsets    *domain.DB
 	controller *controller.Controller[*controlledWriter]
 	// _idx is the index used for resolving timestamp positions on this channel.
 	_idx             index.Index
@@ -71,6 +76,14 @@ func (db *DB) LeadingControlState() *controller.State {
 	return db.controller.LeadingState()
 }
 
+// TimeRange returns the time range spanned by all committed data in the unary DB, or
+// telem.TimeRangeZero if the DB is empty.
+func (db *DB) TimeRange() telem.TimeRange { return db.domain.TimeRange() }
+
+// Domains returns the time ranges of all domains of committed data that overlap with
+// the given time range, in ascending order.
+func (db *DB) Domains(tr telem.TimeRange) []telem.TimeRange { return db.domain.Domains(tr) }
+
 // HasDataFor check whether there is a time range in the unary DB's underlying domain that
 // overlaps with the given time range. Note that this function will return false if there
 // is an open writer that could write into the requested time range
@@ -142,8 +155,10 @@ func (db *DB) Close() error {
 		}
 		return db.wrapError(err)
 	}
-
-	return nil
+	if db.offsets != nil {
+		err = db.offsets.Close()
+	}
+	return db.wrapError(errors.Combine(err, closeRollups(db.rollups)))
 }
 
 // RenameChannelInMeta renames the channel to the given name, and persists the change to the
@@ -159,6 +174,21 @@ func (db *DB) RenameChannelInMeta(newName string) error {
 	return meta.Create(db.cfg.FS, db.cfg.MetaCodec, db.cfg.Channel)
 }
 
+// SetRetentionInMeta sets the channel's retention policy, and persists the change to
+// the underlying file system.
+func (db *DB) SetRetentionInMeta(policy core.RetentionPolicy) error {
+	if db.closed.Load() {
+		return ErrDBClosed
+	}
+	ch := db.cfg.Channel
+	ch.Retention = policy
+	if err := ch.ValidateRetention(); err != nil {
+		return err
+	}
+	db.cfg.Channel = ch
+	return meta.Create(db.cfg.FS, db.cfg.MetaCodec, db.cfg.Channel)
+}
+
 // SetIndexKeyInMeta changes the channel's index to the channel with the given key,
 // and persists the change to the underlying file system.
 func (db *DB) SetIndexKeyInMeta(key core.ChannelKey) error {
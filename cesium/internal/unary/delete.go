@@ -11,10 +11,11 @@ package unary
 
 import (
 	"context"
-	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
+	"github.com/synnaxlabs/cesium/internal/domain"
 	"github.com/synnaxlabs/cesium/internal/index"
 	"github.com/synnaxlabs/x/control"
+	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/telem"
 )
 
@@ -33,7 +34,84 @@ func (db *DB) GarbageCollect(ctx context.Context) error {
 	if db.closed.Load() {
 		return ErrDBClosed
 	}
-	return db.wrapError(db.domain.GarbageCollect(ctx))
+	c := errors.NewCatcher(errors.WithAggregation())
+	c.Exec(func() error { return db.domain.GarbageCollect(ctx) })
+	if db.offsets != nil {
+		c.Exec(func() error { return db.offsets.GarbageCollect(ctx) })
+	}
+	for _, r := range db.rollups {
+		c.Exec(func() error { return r.db.GarbageCollect(ctx) })
+	}
+	return db.wrapError(c.Error())
+}
+
+// Tier relocates full files whose data all ends at or before the given timestamp to
+// the cold tier, returning the number of files relocated. Tier does nothing if the DB
+// was not opened with a cold file system.
+func (db *DB) Tier(ctx context.Context, before telem.TimeStamp) (int, error) {
+	if db.closed.Load() {
+		return 0, ErrDBClosed
+	}
+	n, err := db.domain.Tier(ctx, before)
+	return n, db.wrapError(err)
+}
+
+// ExpireResult describes the data removed from a unary database by Expire.
+type ExpireResult struct {
+	domain.ExpireResult
+	// Samples is the number of samples that were removed.
+	Samples int64
+}
+
+// Expire removes all domains that violate the channel's retention policy as of now. The
+// space occupied by the removed domains is reclaimed by a subsequent GarbageCollect.
+// No domain ending after limit is removed. Expire does nothing if the range to expire
+// is currently controlled by a writer.
+func (db *DB) Expire(ctx context.Context, now, limit telem.TimeStamp) (res ExpireResult, err error) {
+	if db.closed.Load() {
+		return res, ErrDBClosed
+	}
+	policy := db.cfg.Channel.Retention
+	if policy.IsZero() {
+		return res, nil
+	}
+	end, ok := db.domain.ExpiryBoundary(policy.Cutoff(now), policy.MaxSize)
+	if !ok {
+		return res, nil
+	}
+	if end > limit {
+		end = limit
+	}
+	g, _, err := db.controller.OpenAbsoluteGateIfUncontrolled(
+		telem.TimeRange{Start: telem.TimeStampMin, End: end},
+		control.Subject{Key: uuid.NewString(), Name: "expire_writer"},
+		func() (*controlledWriter, error) {
+			return &controlledWriter{Writer: nil, channelKey: db.cfg.Channel.Key}, nil
+		})
+	if err != nil {
+		if errors.Is(err, control.Unauthorized) {
+			return res, nil
+		}
+		return res, db.wrapError(err)
+	}
+	if _, ok = g.Authorized(); !ok {
+		return res, nil
+	}
+	defer g.Release()
+	res.ExpireResult, err = db.domain.Expire(ctx, end)
+	if db.offsets == nil {
+		res.Samples = db.cfg.Channel.DataType.Density().SampleCount(res.Size)
+	} else if err == nil {
+		// Variable length samples have no fixed density, so they are counted from
+		// the entries removed from the offset table.
+		var offsets domain.ExpireResult
+		offsets, err = db.offsets.Expire(ctx, end)
+		res.Samples = offsetDensity.SampleCount(offsets.Size)
+	}
+	if err == nil {
+		err = db.deleteRollups(ctx, telem.TimeStampMin.Range(end))
+	}
+	return res, db.wrapError(err)
 }
 
 func (db *DB) delete(ctx context.Context, tr telem.TimeRange) error {
@@ -57,16 +135,67 @@ func (db *DB) delete(ctx context.Context, tr telem.TimeRange) error {
 		return err
 	}
 	defer g.Release()
+	return db.deleteData(ctx, tr)
+}
 
-	return db.domain.Delete(
+// deleteData deletes the samples in the given time range, along with the summaries of
+// any rollup buckets that overlap with it. The caller must hold control over the time
+// range.
+func (db *DB) deleteData(ctx context.Context, tr telem.TimeRange) error {
+	if db.offsets != nil {
+		if err := db.deleteVariable(ctx, tr); err != nil {
+			return err
+		}
+		return db.deleteRollups(ctx, tr)
+	}
+	if err := db.domain.Delete(
 		ctx,
 		db.calculateStartOffset,
 		db.calculateEndOffset,
 		tr,
 		db.cfg.Channel.DataType.Density(),
+	); err != nil {
+		return err
+	}
+	return db.deleteRollups(ctx, tr)
+}
+
+// deleteVariable deletes the samples in the given time range from a channel with a
+// variable density data type, along with their offset table entries. The data domains
+// must be deleted first, as resolving the byte offsets to delete requires their offset
+// tables.
+func (db *DB) deleteVariable(ctx context.Context, tr telem.TimeRange) (err error) {
+	t := db.openOffsetTable()
+	defer func() { err = errors.Combine(err, t.Close()) }()
+	if err = db.domain.Delete(
+		ctx,
+		t.toByteOffset(db.calculateStartOffset),
+		t.toByteOffset(db.calculateEndOffset),
+		tr,
+		telem.Bit8,
+	); err != nil {
+		return err
+	}
+	return db.offsets.Delete(
+		ctx,
+		db.calculateStartOffset,
+		db.calculateEndOffset,
+		tr,
+		offsetDensity,
 	)
 }
 
+// deleteRollups removes the summaries of all buckets overlapping with the given time
+// range from the channel's rollup tiers.
+func (db *DB) deleteRollups(ctx context.Context, tr telem.TimeRange) error {
+	for _, r := range db.rollups {
+		if err := r.delete(ctx, tr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // calculateStartOffset calculates the distance from a domain's start to the given time stamp.
 // Additionally, it "snaps" the time stamp to the nearest previous sample + 1.
 // calculateOffset returns the calculated offset, the "snapped" time stamp, and any errors.
@@ -25,8 +25,13 @@ import (
 type IteratorConfig struct {
 	Bounds telem.TimeRange
 	// AutoChunkSize sets the maximum size of a chunk that will be returned by the
-	// iterator when using AutoSpan in calls ot Next or Prev.
+	// iterator when using AutoSpan in calls ot Next or Prev. When Aggregation is set,
+	// AutoChunkSize is the number of buckets to move by instead.
 	AutoChunkSize int64
+	// Aggregation, if set, makes the iterator return a single aggregate value for each
+	// bucket of time instead of the raw samples in the bucket. See aggregateNext for
+	// the layout of the returned frames.
+	Aggregation core.Aggregation
 }
 
 func (i IteratorConfig) domainIteratorConfig() domain.IteratorConfig {
@@ -37,6 +42,7 @@ func (i IteratorConfig) domainIteratorConfig() domain.IteratorConfig {
 func (i IteratorConfig) Override(other IteratorConfig) IteratorConfig {
 	i.Bounds = override.Zero(i.Bounds, other.Bounds)
 	i.AutoChunkSize = override.Numeric(i.AutoChunkSize, other.AutoChunkSize)
+	i.Aggregation = override.Zero(i.Aggregation, other.Aggregation)
 	return i
 }
 
@@ -67,6 +73,16 @@ type Iterator struct {
 	bounds   telem.TimeRange
 	err      error
 	closed   bool
+	// summarizer summarizes the samples in each bucket when Aggregation is set.
+	summarizer summarizer
+	// rollup is the rollup tier used to read bucket summaries when Aggregation is set,
+	// and rollupIter iterates over its domains. rollup is nil if the channel has no
+	// tier that can serve the aggregation.
+	rollup     *rollup
+	rollupIter *domain.Iterator
+	// offsets resolves the byte offsets of samples if the channel has a variable
+	// density data type, and is nil otherwise.
+	offsets *offsetTable
 }
 
 func (db *DB) OpenIterator(cfgs ...IteratorConfig) *Iterator {
@@ -79,6 +95,15 @@ func (db *DB) OpenIterator(cfgs ...IteratorConfig) *Iterator {
 		internal:       iter,
 		IteratorConfig: cfg,
 	}
+	if db.offsets != nil {
+		i.offsets = db.openOffsetTable()
+	}
+	if !cfg.Aggregation.IsZero() {
+		i.summarizer = newSummarizer(db.cfg.Channel.DataType)
+		if i.rollup = rollupFor(db.rollups, cfg.Aggregation); i.rollup != nil {
+			i.rollupIter = i.rollup.db.OpenIterator(domain.IterRange(telem.TimeRangeMax))
+		}
+	}
 	i.SetBounds(cfg.Bounds)
 	return i
 }
@@ -172,13 +197,23 @@ func (i *Iterator) Next(ctx context.Context, span telem.TimeSpan) (ok bool) {
 		ok = i.Valid()
 		span_.End()
 	}()
+	if !i.Aggregation.IsZero() {
+		i.aggregateNext(ctx, span)
+		return
+	}
+	i.next(ctx, span)
+	return
+}
+
+func (i *Iterator) next(ctx context.Context, span telem.TimeSpan) {
 	if i.atEnd() {
 		i.reset(i.bounds.End.SpanRange(0))
 		return
 	}
 
 	if span == AutoSpan {
-		return i.autoNext(ctx)
+		i.autoNext(ctx)
+		return
 	}
 
 	i.reset(i.view.End.SpanRange(span).BoundBy(i.bounds))
@@ -196,12 +231,17 @@ func (i *Iterator) Next(ctx context.Context, span telem.TimeSpan) (ok bool) {
 		i.accumulate(ctx) &&
 		!i.satisfied() {
 	}
-	return
 }
 
 func (i *Iterator) autoNext(ctx context.Context) bool {
 	i.view.Start = i.view.End
 	endApprox, err := i.idx.Stamp(ctx, i.view.Start, i.IteratorConfig.AutoChunkSize, false)
+	if errors.Is(err, domain.ErrRangeNotFound) {
+		// The index has no samples at or after the start of the view, so the iterator
+		// is exhausted.
+		i.reset(i.bounds.End.SpanRange(0))
+		return false
+	}
 	if err != nil {
 		i.err = err
 		return false
@@ -225,16 +265,21 @@ func (i *Iterator) autoNext(ctx context.Context) bool {
 			i.err = err
 			return false
 		}
-		startOffset := i.Channel.DataType.Density().Size(startApprox.Upper)
+		start := startApprox.Upper
 		if !startApprox.Exact() && !startApprox.StartExact {
 			// If we are starting from a cutoff dmn, use the lower offset.
-			startOffset = i.Channel.DataType.Density().Size(startApprox.Lower)
+			start = startApprox.Lower
+		}
+		offsets, err := i.byteOffsets(ctx, start, start+nRemaining)
+		if err != nil {
+			i.err = err
+			return false
 		}
 		series, err := i.read(
 			ctx,
 			dmn,
-			startOffset,
-			i.Channel.DataType.Density().Size(nRemaining),
+			telem.Offset(offsets[0]),
+			telem.Size(offsets[1]-offsets[0]),
 		)
 		if err != nil && !errors.Is(err, io.EOF) {
 			i.err = err
@@ -264,7 +309,15 @@ func (i *Iterator) Prev(ctx context.Context, span telem.TimeSpan) (ok bool) {
 		ok = i.Valid()
 		span_.End()
 	}()
+	if !i.Aggregation.IsZero() {
+		i.aggregatePrev(ctx, span)
+		return
+	}
+	i.prev(ctx, span)
+	return
+}
 
+func (i *Iterator) prev(ctx context.Context, span telem.TimeSpan) {
 	if i.atStart() {
 		i.reset(i.bounds.Start.SpanRange(0))
 		return
@@ -285,7 +338,6 @@ func (i *Iterator) Prev(ctx context.Context, span telem.TimeSpan) (ok bool) {
 		i.accumulate(ctx) &&
 		!i.satisfied() {
 	}
-	return
 }
 
 // Len returns the number of samples in the iterator's frame.
@@ -318,7 +370,14 @@ func (i *Iterator) Close() (err error) {
 	}
 	i.closed = true
 	wrap := core.NewErrorWrapper(i.Channel)
-	return wrap(i.internal.Close())
+	err = i.internal.Close()
+	if i.rollupIter != nil {
+		err = errors.Combine(err, i.rollupIter.Close())
+	}
+	if i.offsets != nil {
+		err = errors.Combine(err, i.offsets.Close())
+	}
+	return wrap(err)
 }
 
 // accumulate reads the underlying data contained in the view from OS and
@@ -391,39 +450,59 @@ func (i *Iterator) sliceDomain(ctx context.Context) (
 	if err != nil {
 		return 0, align, 0, err
 	}
-	startOffset := i.Channel.DataType.Density().Size(startApprox.Upper)
+	start := startApprox.Upper
 	// Split into cases to determine which offsets to use. See unary/delete.go's
 	// calculateStartOffset function for more detail.
 	if !startApprox.Exact() && !startApprox.StartExact {
 		if startApprox.EndExact {
 			// If the start of the domain is inexact due to cutoff, but the end
 			// approximation is exact, we want to use the lower approximation.
-			startOffset = i.Channel.DataType.Density().Size(startApprox.Lower)
+			start = startApprox.Lower
 		} else {
-			off := (startApprox.Lower + startApprox.Upper) / 2
-			startOffset = i.Channel.DataType.Density().Size(off)
+			start = (startApprox.Lower + startApprox.Upper) / 2
 		}
 	}
 	endApprox, err := i.approximateEnd(ctx)
 	if err != nil {
 		return 0, align, 0, err
 	}
-	endOffset := i.Channel.DataType.Density().Size(endApprox.Upper)
+	end := endApprox.Upper
 	// Split into cases to determine which offsets to use. See unary/delete.go's
 	// calculateEndOffset function for more detail.
 	if !endApprox.Exact() && !endApprox.StartExact {
 		if endApprox.EndExact {
 			// If the start of the domain is inexact due to cutoff, but the end
 			// approximation is exact, we want to use the lower approximation.
-			endOffset = i.Channel.DataType.Density().Size(endApprox.Lower)
+			end = endApprox.Lower
 		} else {
-			off := (endApprox.Lower + endApprox.Upper) / 2
-			endOffset = i.Channel.DataType.Density().Size(off)
+			end = (endApprox.Lower + endApprox.Upper) / 2
 		}
 	}
+	offsets, err := i.byteOffsets(ctx, start, end)
+	if err != nil {
+		return 0, align, 0, err
+	}
+	return telem.Offset(offsets[0]), align, telem.Size(offsets[1] - offsets[0]), nil
+}
 
-	size := endOffset - startOffset
-	return startOffset, align, size, nil
+// byteOffsets returns the byte offsets of the given samples within the current domain.
+func (i *Iterator) byteOffsets(ctx context.Context, samples ...int64) ([]int64, error) {
+	if i.offsets != nil {
+		return i.offsets.byteOffsets(ctx, i.internal.TimeRange(), i.internal.Len(), samples...)
+	}
+	offsets := make([]int64, len(samples))
+	for j, s := range samples {
+		offsets[j] = int64(i.Channel.DataType.Density().Size(s))
+	}
+	return offsets, nil
+}
+
+// sampleCount returns the number of samples in the current domain.
+func (i *Iterator) sampleCount(ctx context.Context) (int64, error) {
+	if i.offsets != nil {
+		return i.offsets.sampleCount(ctx, i.internal.TimeRange(), i.internal.Len())
+	}
+	return i.Channel.DataType.Density().SampleCount(telem.Size(i.internal.Len())), nil
 }
 
 // approximateStart approximates the number of samples between the start of the current
@@ -447,7 +526,11 @@ func (i *Iterator) approximateStart(ctx context.Context) (
 // after the end of the range, the returned value will be the number of samples in the
 // range.
 func (i *Iterator) approximateEnd(ctx context.Context) (endApprox index.DistanceApproximation, err error) {
-	endApprox.Approximation = index.Exactly(i.Channel.DataType.Density().SampleCount(telem.Size(i.internal.Len())))
+	count, err := i.sampleCount(ctx)
+	if err != nil {
+		return
+	}
+	endApprox.Approximation = index.Exactly(count)
 	if i.internal.TimeRange().End.After(i.view.End) {
 		target := i.internal.TimeRange().Start.Range(i.view.End)
 		endApprox, _, err = i.idx.Distance(ctx, target, true)
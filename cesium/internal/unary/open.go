@@ -57,6 +57,14 @@ type Config struct {
 	// instead, set it to a very small number greater than 0.
 	// [OPTIONAL] Default: 0.2
 	GCThreshold float32
+	// ColdFS is a secondary file system that full domain files are relocated to by
+	// Tier. If nil, files are never relocated.
+	// [OPTIONAL] Default: nil
+	ColdFS xfs.FS
+	// ColdCacheSize is the maximum number of files relocated to ColdFS that are cached
+	// in FS after being read.
+	// [OPTIONAL] Default: 5
+	ColdCacheSize int
 }
 
 var (
@@ -83,6 +91,8 @@ func (cfg Config) Override(other Config) Config {
 	cfg.FileSize = override.Numeric(cfg.FileSize, other.FileSize)
 	cfg.GCThreshold = override.Numeric(cfg.GCThreshold, other.GCThreshold)
 	cfg.MetaCodec = override.Nil(cfg.MetaCodec, other.MetaCodec)
+	cfg.ColdFS = override.Nil(cfg.ColdFS, other.ColdFS)
+	cfg.ColdCacheSize = override.Numeric(cfg.ColdCacheSize, other.ColdCacheSize)
 	return cfg
 }
 
@@ -99,12 +109,31 @@ func Open(configs ...Config) (*DB, error) {
 	if cfg.Channel.Virtual {
 		return nil, wrapError(ErrVirtual)
 	}
+	// Migrations may rewrite the files of the domain database, so they must run
+	// before it is opened.
+	if err = migrate(&cfg); err != nil {
+		return nil, err
+	}
 	domainDB, err := domain.Open(domain.Config{
 		FS:              cfg.FS,
 		Instrumentation: cfg.Instrumentation,
 		FileSize:        cfg.FileSize,
 		GCThreshold:     cfg.GCThreshold,
+		Compression:     cfg.Channel.Compression.Codec(cfg.Channel.DataType),
+		ColdFS:          cfg.ColdFS,
+		ColdCacheSize:   cfg.ColdCacheSize,
 	})
+	if err != nil {
+		return nil, err
+	}
+	rollups, err := openRollups(cfg)
+	if err != nil {
+		return nil, errors.Combine(err, domainDB.Close())
+	}
+	offsets, err := openOffsets(cfg)
+	if err != nil {
+		return nil, errors.Combine(err, errors.Combine(domainDB.Close(), closeRollups(rollups)))
+	}
 	c, err := controller.New[*controlledWriter](controller.Config{
 		Concurrency:     cfg.Channel.Concurrency,
 		Instrumentation: cfg.Instrumentation,
@@ -115,6 +144,8 @@ func Open(configs ...Config) (*DB, error) {
 	db := &DB{
 		cfg:              cfg,
 		domain:           domainDB,
+		rollups:          rollups,
+		offsets:          offsets,
 		controller:       c,
 		wrapError:        wrapError,
 		closed:           &atomic.Bool{},
@@ -126,20 +157,25 @@ func Open(configs ...Config) (*DB, error) {
 	} else if cfg.Channel.Index == 0 {
 		db._idx = index.Rate{Rate: cfg.Channel.Rate, Channel: cfg.Channel}
 	}
-	return db, db.checkMigration()
+	return db, nil
 }
 
-// checkMigration compares the version stored in channel to the current version of the
+// migrate compares the version stored in channel to the current version of the
 // data engine format. If there is a migration to be performed, data is migrated and
 // persisted to the new version.
-func (db *DB) checkMigration() error {
-	if db.cfg.Channel.Version == version.Current {
-		return nil
+func migrate(cfg *Config) error {
+	if cfg.Channel.Version == version.Current {
+		// A migration interrupted after the new version was persisted leaves behind
+		// the files kept to recover from it.
+		return version.Finalize(cfg.FS)
 	}
-	err := version.Migrate(db.cfg.FS, db.cfg.Channel.Version, version.Current)
+	err := version.Migrate(cfg.FS, cfg.Channel.Version, version.Current)
 	if err != nil {
 		return err
 	}
-	db.cfg.Channel.Version = version.Current
-	return meta.Create(db.cfg.FS, db.cfg.MetaCodec, db.cfg.Channel)
+	cfg.Channel.Version = version.Current
+	if err = meta.Create(cfg.FS, cfg.MetaCodec, cfg.Channel); err != nil {
+		return err
+	}
+	return version.Finalize(cfg.FS)
 }
@@ -0,0 +1,114 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/x/control"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Overwrite replaces the samples in the specified time range with the samples in
+// series, which must contain exactly as many samples as the time range. The time range
+// must overlap with exactly one domain of the database. Note that the start of the time
+// range is inclusive whereas the end is not.
+//
+// If the database is an index, the timestamps in series must be strictly increasing and
+// fall within both the time range and the domain being overwritten. Channels with a
+// variable density data type cannot be overwritten.
+func (db *DB) Overwrite(ctx context.Context, tr telem.TimeRange, series telem.Series) error {
+	if db.closed.Load() {
+		return ErrDBClosed
+	}
+	return db.wrapError(db.overwrite(ctx, tr, series))
+}
+
+func (db *DB) overwrite(ctx context.Context, tr telem.TimeRange, series telem.Series) error {
+	if !tr.Valid() {
+		return errors.Newf("overwrite start %d cannot be after overwrite end %d", tr.Start, tr.End)
+	}
+	if err := db.cfg.Channel.ValidateSeries(series); err != nil {
+		return err
+	}
+	if db.offsets != nil {
+		return errors.Wrapf(
+			validate.Error,
+			"cannot overwrite channel %v with variable density data type %s",
+			db.cfg.Channel,
+			db.cfg.Channel.DataType,
+		)
+	}
+	if db.cfg.Channel.IsIndex {
+		if err := db.validateIndexOverwrite(tr, series); err != nil {
+			return err
+		}
+	}
+
+	// Open an absolute gate to avoid overwriting a time range in write.
+	g, _, err := db.controller.OpenAbsoluteGateIfUncontrolled(
+		tr,
+		control.Subject{Key: uuid.NewString(), Name: "overwrite_writer"},
+		func() (*controlledWriter, error) {
+			return &controlledWriter{Writer: nil, channelKey: db.cfg.Channel.Key}, nil
+		})
+	if err != nil {
+		return err
+	}
+	if _, err = g.Authorize(); err != nil {
+		return err
+	}
+	defer g.Release()
+	if err = db.domain.Overwrite(
+		ctx,
+		db.calculateStartOffset,
+		db.calculateEndOffset,
+		tr,
+		db.cfg.Channel.DataType.Density(),
+		series.Data,
+	); err != nil {
+		return err
+	}
+	return db.deleteRollups(ctx, tr)
+}
+
+// validateIndexOverwrite checks that the timestamps in series can replace the index
+// data in the given time range without breaking the ordering of the index.
+func (db *DB) validateIndexOverwrite(tr telem.TimeRange, series telem.Series) error {
+	bounds := tr
+	if domains := db.domain.Domains(tr); len(domains) == 1 {
+		bounds = tr.Intersection(domains[0])
+	}
+	stamps := telem.UnmarshalSlice[telem.TimeStamp](series.Data, series.DataType)
+	for i, ts := range stamps {
+		if !bounds.ContainsStamp(ts) {
+			return errors.Wrapf(
+				validate.Error,
+				"overwritten timestamp %s for index channel %v is outside of the time range %s",
+				ts,
+				db.cfg.Channel,
+				bounds,
+			)
+		}
+		if i > 0 && ts <= stamps[i-1] {
+			return errors.Wrapf(
+				validate.Error,
+				"overwritten timestamps for index channel %v must be strictly increasing, but %s is not after %s",
+				db.cfg.Channel,
+				ts,
+				stamps[i-1],
+			)
+		}
+	}
+	return nil
+}
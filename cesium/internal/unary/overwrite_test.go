@@ -0,0 +1,124 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/cesium/internal/unary"
+	"github.com/synnaxlabs/x/control"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Overwrite", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS:"+fsName, func() {
+			var (
+				db      *unary.DB
+				indexDB *unary.DB
+				index   uint32 = 1
+				data    uint32 = 2
+				fs      xfs.FS
+				cleanUp func() error
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				indexDB = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("index")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      index,
+						DataType: telem.TimeStampT,
+						IsIndex:  true,
+						Index:    index,
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				db = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("data")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      data,
+						DataType: telem.Int64T,
+						Index:    index,
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				db.SetIndex(indexDB.Index())
+				Expect(unary.Write(ctx, indexDB, 10*telem.SecondTS, telem.NewSecondsTSV(10, 12, 14, 16, 18))).To(Succeed())
+				Expect(unary.Write(ctx, db, 10*telem.SecondTS, telem.NewSeriesV[int64](10, 12, 14, 16, 18))).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(indexDB.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			readAll := func(db *unary.DB) []int64 {
+				var values []int64
+				for _, s := range MustSucceed(db.Read(ctx, telem.TimeRangeMax)).Series {
+					values = append(values, telem.UnmarshalSlice[int64](s.Data, s.DataType)...)
+				}
+				return values
+			}
+
+			It("Should replace the samples in a time range", func() {
+				Expect(db.Overwrite(ctx, (12 * telem.SecondTS).Range(16*telem.SecondTS), telem.NewSeriesV[int64](1, 2))).To(Succeed())
+				Expect(readAll(db)).To(Equal([]int64{10, 1, 2, 16, 18}))
+			})
+
+			It("Should snap an inexact time range to the samples it contains", func() {
+				Expect(db.Overwrite(ctx, (11 * telem.SecondTS).Range(15*telem.SecondTS), telem.NewSeriesV[int64](1, 2))).To(Succeed())
+				Expect(readAll(db)).To(Equal([]int64{10, 1, 2, 16, 18}))
+			})
+
+			It("Should replace the timestamps of an index", func() {
+				Expect(indexDB.Overwrite(ctx, (12 * telem.SecondTS).Range(16*telem.SecondTS), telem.NewSecondsTSV(13, 15))).To(Succeed())
+				Expect(readAll(indexDB)).To(Equal([]int64{
+					int64(10 * telem.SecondTS),
+					int64(13 * telem.SecondTS),
+					int64(15 * telem.SecondTS),
+					int64(16 * telem.SecondTS),
+					int64(18 * telem.SecondTS),
+				}))
+			})
+
+			It("Should not replace index timestamps with ones outside of the time range", func() {
+				Expect(indexDB.Overwrite(ctx, (12 * telem.SecondTS).Range(16*telem.SecondTS), telem.NewSecondsTSV(13, 17))).
+					To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not replace index timestamps with ones that are not increasing", func() {
+				Expect(indexDB.Overwrite(ctx, (12 * telem.SecondTS).Range(16*telem.SecondTS), telem.NewSecondsTSV(14, 13))).
+					To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not overwrite with a series of the wrong data type", func() {
+				Expect(db.Overwrite(ctx, (12 * telem.SecondTS).Range(16*telem.SecondTS), telem.NewSeriesV[float32](1, 2))).
+					To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not overwrite a time range controlled by a writer", func() {
+				w, _ := MustSucceed2(db.OpenWriter(ctx, unary.WriterConfig{
+					Start:   20 * telem.SecondTS,
+					Subject: control.Subject{Key: "foo"},
+				}))
+				Expect(db.Overwrite(ctx, (12 * telem.SecondTS).Range(30*telem.SecondTS), telem.NewSeriesV[int64](1, 2, 3, 4))).
+					To(HaveOccurredAs(control.Unauthorized))
+				MustSucceed(w.Close())
+				Expect(readAll(db)).To(Equal([]int64{10, 12, 14, 16, 18}))
+			})
+		})
+	}
+})
@@ -0,0 +1,237 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/cesium/internal/controller"
+	"github.com/synnaxlabs/x/control"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Retime moves the domains in the time range tr to the time range obtained by applying
+// retime to their start and end. retime must be non-decreasing. Every domain
+// overlapping tr must be entirely contained in it, and retimed domains must not overlap
+// any other domain in the DB.
+//
+// If the DB's channel is an index, the timestamps in each domain are also retimed, and
+// must remain strictly increasing. Summaries in the channel's rollup tiers that overlap
+// either the original or the retimed time range are removed.
+func (db *DB) Retime(
+	ctx context.Context,
+	tr telem.TimeRange,
+	retime func(telem.TimeStamp) telem.TimeStamp,
+) (err error) {
+	r, err := db.OpenRetimer(tr, retime)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return r.Retime(ctx)
+}
+
+// ValidateRetime checks that Retime can be called with the given time range and
+// retiming function without modifying the DB. ValidateRetime does not check the
+// timestamps of an index channel, which are only verified by Retime.
+func (db *DB) ValidateRetime(tr telem.TimeRange, retime func(telem.TimeStamp) telem.TimeStamp) error {
+	if db.closed.Load() {
+		return ErrDBClosed
+	}
+	return db.wrapError(db.domain.ValidateRetime(tr, retime))
+}
+
+// Retimer retimes the domains of a DB in a time range while holding control over both
+// the original and retimed time ranges, so that several DBs can be retimed together
+// without any of them being written to in between. A Retimer must be closed after use
+// to release control.
+type Retimer struct {
+	db      *DB
+	tr      telem.TimeRange
+	retime  func(telem.TimeStamp) telem.TimeStamp
+	gate    *controller.Gate[*controlledWriter]
+	bounds  telem.TimeRange
+	retimed telem.TimeRange
+	// original maps the retimed start and end of each domain to its original start
+	// and end, so that the domains can be moved back by Revert.
+	original map[telem.TimeStamp]telem.TimeStamp
+	// data holds the original telemetry of each domain of an index channel, keyed by
+	// the retimed start of the domain.
+	data map[telem.TimeStamp][]byte
+	// domainRetimed and offsetsRetimed are true once the domains of the DB and of its
+	// offset table have been retimed, respectively.
+	domainRetimed, offsetsRetimed bool
+}
+
+// OpenRetimer validates that the domains in the time range tr can be moved by retime,
+// and acquires control over the time ranges they are moved from and to. OpenRetimer
+// returns an error if any part of those time ranges is controlled by a writer.
+func (db *DB) OpenRetimer(
+	tr telem.TimeRange,
+	retime func(telem.TimeStamp) telem.TimeStamp,
+) (*Retimer, error) {
+	if db.closed.Load() {
+		return nil, ErrDBClosed
+	}
+	if !tr.Valid() {
+		return nil, errors.Newf("retime start %d cannot be after retime end %d", tr.Start, tr.End)
+	}
+	if err := db.ValidateRetime(tr, retime); err != nil {
+		return nil, err
+	}
+	r := &Retimer{
+		db:       db,
+		tr:       tr,
+		retime:   retime,
+		original: make(map[telem.TimeStamp]telem.TimeStamp),
+		data:     make(map[telem.TimeStamp][]byte),
+	}
+	domains := db.domain.Domains(tr)
+	if len(domains) == 0 {
+		return r, nil
+	}
+	for _, d := range domains {
+		r.original[retime(d.Start)] = d.Start
+		r.original[retime(d.End)] = d.End
+	}
+	r.bounds = telem.TimeRange{Start: domains[0].Start, End: domains[len(domains)-1].End}
+	r.retimed = telem.TimeRange{Start: retime(r.bounds.Start), End: retime(r.bounds.End)}
+
+	// Open an absolute gate over both the original and retimed time ranges to avoid
+	// moving data into or out of a time range in write.
+	g, _, err := db.controller.OpenAbsoluteGateIfUncontrolled(
+		r.bounds.Union(r.retimed),
+		control.Subject{Key: uuid.NewString(), Name: "retime_writer"},
+		func() (*controlledWriter, error) {
+			return &controlledWriter{Writer: nil, channelKey: db.cfg.Channel.Key}, nil
+		})
+	if err != nil {
+		return nil, db.wrapError(err)
+	}
+	if _, err = g.Authorize(); err != nil {
+		g.Release()
+		return nil, db.wrapError(err)
+	}
+	r.gate = g
+	return r, nil
+}
+
+// Retime moves the domains of the DB as described in DB.Retime.
+func (r *Retimer) Retime(ctx context.Context) error {
+	if r.db.closed.Load() {
+		return ErrDBClosed
+	}
+	return r.db.wrapError(r.apply(ctx))
+}
+
+func (r *Retimer) apply(ctx context.Context) error {
+	if r.gate == nil {
+		return nil
+	}
+	var rewrite func(telem.TimeRange, []byte) ([]byte, error)
+	if r.db.cfg.Channel.IsIndex {
+		rewrite = func(dr telem.TimeRange, data []byte) ([]byte, error) {
+			r.data[dr.Start] = slices.Clone(data)
+			return r.db.retimeIndexData(dr, data, r.retime)
+		}
+	}
+	if err := r.db.domain.Retime(ctx, r.tr, r.retime, rewrite); err != nil {
+		return err
+	}
+	r.domainRetimed = true
+	if r.db.offsets != nil {
+		if err := r.db.offsets.Retime(ctx, r.tr, r.retime, nil); err != nil {
+			return err
+		}
+		r.offsetsRetimed = true
+	}
+	if err := r.db.deleteRollups(ctx, r.bounds); err != nil {
+		return err
+	}
+	return r.db.deleteRollups(ctx, r.retimed)
+}
+
+// Revert moves any domains moved by Retime back to their original time ranges,
+// restoring the original timestamps of an index channel. Revert is used to roll back a
+// retime of several DBs that failed part way through.
+func (r *Retimer) Revert(ctx context.Context) error {
+	if r.db.closed.Load() {
+		return ErrDBClosed
+	}
+	return r.db.wrapError(r.revert(ctx))
+}
+
+func (r *Retimer) revert(ctx context.Context) error {
+	restore := func(ts telem.TimeStamp) telem.TimeStamp { return r.original[ts] }
+	if r.offsetsRetimed {
+		if err := r.db.offsets.Retime(ctx, r.retimed, restore, nil); err != nil {
+			return err
+		}
+		r.offsetsRetimed = false
+	}
+	if !r.domainRetimed {
+		return nil
+	}
+	var rewrite func(telem.TimeRange, []byte) ([]byte, error)
+	if r.db.cfg.Channel.IsIndex {
+		rewrite = func(dr telem.TimeRange, _ []byte) ([]byte, error) {
+			return r.data[r.retime(dr.Start)], nil
+		}
+	}
+	if err := r.db.domain.Retime(ctx, r.retimed, restore, rewrite); err != nil {
+		return err
+	}
+	r.domainRetimed = false
+	return r.db.deleteRollups(ctx, r.retimed)
+}
+
+// Close releases control over the time ranges held by the Retimer.
+func (r *Retimer) Close() {
+	if r.gate != nil {
+		r.gate.Release()
+		r.gate = nil
+	}
+}
+
+// retimeIndexData applies retime to the timestamps in data, checking that they remain
+// strictly increasing and within the retimed domain dr.
+func (db *DB) retimeIndexData(
+	dr telem.TimeRange,
+	data []byte,
+	retime func(telem.TimeStamp) telem.TimeStamp,
+) ([]byte, error) {
+	stamps := telem.UnmarshalSlice[telem.TimeStamp](data, telem.TimeStampT)
+	for i, ts := range stamps {
+		stamps[i] = retime(ts)
+		if !dr.ContainsStamp(stamps[i]) {
+			return nil, errors.Wrapf(
+				validate.Error,
+				"retimed timestamp %s for index channel %v is outside of the time range %s",
+				stamps[i],
+				db.cfg.Channel,
+				dr,
+			)
+		}
+		if i > 0 && stamps[i] <= stamps[i-1] {
+			return nil, errors.Wrapf(
+				validate.Error,
+				"retimed timestamps for index channel %v must be strictly increasing, but %s is not after %s",
+				db.cfg.Channel,
+				stamps[i],
+				stamps[i-1],
+			)
+		}
+	}
+	return telem.NewSeries(stamps).Data, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/cesium/internal/unary"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Retime", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS:"+fsName, func() {
+			var (
+				db      *unary.DB
+				indexDB *unary.DB
+				index   uint32 = 1
+				data    uint32 = 2
+				fs      xfs.FS
+				cleanUp func() error
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				indexDB = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("index")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      index,
+						DataType: telem.TimeStampT,
+						IsIndex:  true,
+						Index:    index,
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				db = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("data")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      data,
+						DataType: telem.StringT,
+						Index:    index,
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				db.SetIndex(indexDB.Index())
+				Expect(unary.Write(ctx, indexDB, 10*telem.SecondTS, telem.NewSecondsTSV(10, 11, 12))).To(Succeed())
+				Expect(unary.Write(ctx, db, 10*telem.SecondTS, telem.NewStringsV("a", "bb", "ccc"))).To(Succeed())
+				Expect(unary.Write(ctx, indexDB, 20*telem.SecondTS, telem.NewSecondsTSV(20, 21))).To(Succeed())
+				Expect(unary.Write(ctx, db, 20*telem.SecondTS, telem.NewStringsV("d", "ee"))).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(indexDB.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			shift := func(ts telem.TimeStamp) telem.TimeStamp { return ts.Add(5 * telem.Second) }
+
+			It("Should retime the timestamps of an index channel", func() {
+				tr := (10 * telem.SecondTS).Range(13*telem.SecondTS + 1)
+				Expect(indexDB.Retime(ctx, tr, shift)).To(Succeed())
+				frame := MustSucceed(indexDB.Read(ctx, telem.TimeRangeMax))
+				var stamps []telem.TimeStamp
+				for _, s := range frame.Series {
+					stamps = append(stamps, telem.UnmarshalSlice[telem.TimeStamp](s.Data, s.DataType)...)
+				}
+				Expect(stamps).To(Equal([]telem.TimeStamp{
+					15 * telem.SecondTS,
+					16 * telem.SecondTS,
+					17 * telem.SecondTS,
+					20 * telem.SecondTS,
+					21 * telem.SecondTS,
+				}))
+			})
+
+			It("Should move the domains of a channel with a variable density data type", func() {
+				tr := (10 * telem.SecondTS).Range(13*telem.SecondTS + 1)
+				Expect(indexDB.Retime(ctx, tr, shift)).To(Succeed())
+				Expect(db.Retime(ctx, tr, shift)).To(Succeed())
+				frame := MustSucceed(db.Read(ctx, (15 * telem.SecondTS).Range(18*telem.SecondTS)))
+				Expect(frame.Series).To(HaveLen(1))
+				Expect(telem.UnmarshalStrings(frame.Series[0].Data)).To(Equal([]string{"a", "bb", "ccc"}))
+			})
+
+			It("Should revert a retime to the original timestamps", func() {
+				tr := (10 * telem.SecondTS).Range(13*telem.SecondTS + 1)
+				stretch := func(ts telem.TimeStamp) telem.TimeStamp {
+					return tr.Start + telem.TimeStamp(float64(ts-tr.Start)*1.37)
+				}
+				domains := indexDB.Domains(telem.TimeRangeMax)
+				r := MustSucceed(indexDB.OpenRetimer(tr, stretch))
+				Expect(r.Retime(ctx)).To(Succeed())
+				Expect(indexDB.Domains(telem.TimeRangeMax)).ToNot(Equal(domains))
+				Expect(r.Revert(ctx)).To(Succeed())
+				r.Close()
+				Expect(indexDB.Domains(telem.TimeRangeMax)).To(Equal(domains))
+				frame := MustSucceed(indexDB.Read(ctx, telem.TimeRangeMax))
+				Expect(frame.Series[0].Data).To(Equal(telem.NewSecondsTSV(10, 11, 12).Data))
+			})
+
+			It("Should not retime a time range that another retimer controls", func() {
+				tr := (10 * telem.SecondTS).Range(13*telem.SecondTS + 1)
+				r := MustSucceed(indexDB.OpenRetimer(tr, shift))
+				Expect(indexDB.Retime(ctx, tr, shift)).To(HaveOccurred())
+				Expect(r.Retime(ctx)).To(Succeed())
+				r.Close()
+				Expect(indexDB.Domains(telem.TimeRangeMax)[0].Start).To(Equal(15 * telem.SecondTS))
+			})
+
+			It("Should not retime index timestamps that are no longer strictly increasing", func() {
+				tr := (10 * telem.SecondTS).Range(13*telem.SecondTS + 1)
+				collapse := func(ts telem.TimeStamp) telem.TimeStamp {
+					if ts == tr.Start || ts == tr.End {
+						return ts
+					}
+					return 11 * telem.SecondTS
+				}
+				Expect(indexDB.Retime(ctx, tr, collapse)).To(HaveOccurredAs(validate.Error))
+				Expect(indexDB.Domains(telem.TimeRangeMax)[0]).To(Equal(db.Domains(telem.TimeRangeMax)[0]))
+			})
+		})
+	}
+})
@@ -0,0 +1,282 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/cesium/internal/domain"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"go.uber.org/zap"
+)
+
+// rollup is a tier of pre-computed summaries of a channel's samples, holding one
+// summary for each bucket of span. Bucket boundaries are multiples of span. Summaries
+// are stored in a separate domain database, and each domain holds the summaries of a
+// run of consecutive buckets, including empty ones.
+//
+// A rollup only holds the summaries of buckets that were complete when they were
+// written, so buckets without a summary must be read from the channel's raw data.
+type rollup struct {
+	span telem.TimeSpan
+	db   *domain.DB
+	// density is the size of a single summary.
+	density telem.Density
+}
+
+// rollupDirname returns the name of the directory, relative to the channel's
+// directory, that the rollup tier with the given span is stored in.
+func rollupDirname(span telem.TimeSpan) string {
+	return "rollup-" + strconv.FormatInt(int64(span), 10)
+}
+
+// openRollups opens the rollup tiers of the channel, sorted by span in ascending order.
+func openRollups(cfg Config) (rollups []rollup, err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Combine(err, closeRollups(rollups))
+		}
+	}()
+	spans := slices.Clone(cfg.Channel.Rollups)
+	slices.Sort(spans)
+	for _, span := range spans {
+		fs, err := cfg.FS.Sub(rollupDirname(span))
+		if err != nil {
+			return rollups, err
+		}
+		db, err := domain.Open(domain.Config{
+			FS:              fs,
+			Instrumentation: cfg.Instrumentation.Child("rollup"),
+			FileSize:        cfg.FileSize,
+			GCThreshold:     cfg.GCThreshold,
+		})
+		if err != nil {
+			return rollups, err
+		}
+		rollups = append(rollups, rollup{
+			span:    span,
+			db:      db,
+			density: summaryDensity(cfg.Channel.DataType),
+		})
+	}
+	return rollups, nil
+}
+
+func closeRollups(rollups []rollup) error {
+	c := errors.NewCatcher(errors.WithAggregation())
+	for _, r := range rollups {
+		c.Exec(r.db.Close)
+	}
+	return c.Error()
+}
+
+// bucketStart returns the start of the bucket containing the given timestamp.
+func (r rollup) bucketStart(ts telem.TimeStamp) telem.TimeStamp {
+	return ts - ts%telem.TimeStamp(r.span)
+}
+
+// bucketEnd returns the end of the bucket containing the timestamp immediately before
+// the given one.
+func (r rollup) bucketEnd(ts telem.TimeStamp) telem.TimeStamp {
+	if ts%telem.TimeStamp(r.span) == 0 {
+		return ts
+	}
+	return r.bucketStart(ts).Add(r.span)
+}
+
+// aligned returns true if the given time range starts and ends on bucket boundaries.
+func (r rollup) aligned(tr telem.TimeRange) bool {
+	return tr.Start%telem.TimeStamp(r.span) == 0 && tr.End%telem.TimeStamp(r.span) == 0
+}
+
+// read returns the concatenated summaries of the buckets in the given aligned time
+// range. read returns false if the rollup does not hold a summary for every bucket in
+// the time range.
+func (r rollup) read(ctx context.Context, i *domain.Iterator, tr telem.TimeRange) ([]byte, bool, error) {
+	i.SetBounds(tr)
+	if !i.SeekFirst(ctx) {
+		return nil, false, nil
+	}
+	var (
+		summaries = make([]byte, 0, r.density.Size(int64(tr.Span()/r.span)))
+		covered   = tr.Start
+	)
+	for {
+		dr := i.TimeRange()
+		if dr.Start > covered {
+			return nil, false, nil
+		}
+		end := min(dr.End, tr.End)
+		b := make([]byte, r.density.Size(int64(covered.Span(end)/r.span)))
+		reader, err := i.OpenReader(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		offset := r.density.Size(int64(dr.Start.Span(covered) / r.span))
+		_, err = reader.ReadAt(b, int64(offset))
+		if err = errors.Combine(err, reader.Close()); err != nil && !errors.Is(err, io.EOF) {
+			return nil, false, err
+		}
+		summaries = append(summaries, b...)
+		if covered = end; covered >= tr.End {
+			return summaries, true, nil
+		}
+		if !i.Next() {
+			return nil, false, nil
+		}
+	}
+}
+
+// delete removes the summaries of all buckets that overlap with the given time range.
+func (r rollup) delete(ctx context.Context, tr telem.TimeRange) error {
+	tr = r.bucketStart(tr.Start).Range(r.bucketEnd(tr.End))
+	return r.db.Delete(ctx, r.offset, r.offset, tr, r.density)
+}
+
+// offset returns the number of summaries between the start of a domain and the given
+// timestamp, snapping the timestamp to the start of its bucket. offset is passed as a
+// closure to domain.Delete.
+func (r rollup) offset(
+	_ context.Context,
+	domainStart telem.TimeStamp,
+	ts telem.TimeStamp,
+) (int64, telem.TimeStamp, error) {
+	n := int64(domainStart.Span(ts) / r.span)
+	return n, domainStart.Add(telem.TimeSpan(n) * r.span), nil
+}
+
+// rollupWriter updates the rollup tiers of a channel as a unary writer commits data.
+// Each time a commit completes one or more buckets of a tier, the raw samples in those
+// buckets are read back from the database, summarized, and written to the tier.
+// Summaries that already exist for the buckets are replaced.
+type rollupWriter struct {
+	db    *DB
+	tiers []*tierWriter
+}
+
+type tierWriter struct {
+	rollup
+	// next is the start of the first bucket that has not yet been summarized.
+	next telem.TimeStamp
+	// w writes consecutive runs of summaries into a single domain. w is nil if no
+	// summaries have been written, or if the last write failed.
+	w *domain.Writer
+}
+
+func (db *DB) openRollupWriter(start telem.TimeStamp) *rollupWriter {
+	if len(db.rollups) == 0 {
+		return nil
+	}
+	w := &rollupWriter{db: db}
+	for _, r := range db.rollups {
+		w.tiers = append(w.tiers, &tierWriter{rollup: r, next: r.bucketStart(start)})
+	}
+	return w
+}
+
+// seek moves the start of the first bucket to summarize to the bucket containing the
+// given timestamp. seek must only be called before any data is committed.
+func (w *rollupWriter) seek(start telem.TimeStamp) {
+	for _, t := range w.tiers {
+		t.next = t.bucketStart(start)
+	}
+}
+
+// commit summarizes and writes all buckets that were completed by a commit ending at
+// the given timestamp. Rollups are derived data, so failures are logged instead of
+// failing the commit, and the affected buckets are served from raw data instead.
+func (w *rollupWriter) commit(ctx context.Context, end telem.TimeStamp) {
+	for _, t := range w.tiers {
+		complete := t.bucketStart(end)
+		if complete <= t.next {
+			continue
+		}
+		tr := t.next.Range(complete)
+		if err := t.write(ctx, tr, w.db); err != nil {
+			err = errors.Combine(err, t.close())
+			w.db.cfg.L.Warn(
+				"failed to update rollup",
+				zap.Stringer("channel", w.db.cfg.Channel),
+				zap.Stringer("span", t.span),
+				zap.Stringer("time_range", tr),
+				zap.Error(err),
+			)
+		}
+		t.next = complete
+	}
+}
+
+func (t *tierWriter) write(ctx context.Context, tr telem.TimeRange, db *DB) error {
+	summaries, err := db.summarize(ctx, tr, t.span)
+	if err != nil {
+		return err
+	}
+	stale, err := t.db.HasDataFor(ctx, tr)
+	if err != nil {
+		return err
+	}
+	if stale {
+		if err = t.delete(ctx, tr); err != nil {
+			return err
+		}
+	}
+	if t.w == nil {
+		if t.w, err = t.db.OpenWriter(ctx, domain.WriterConfig{Start: tr.Start}); err != nil {
+			return err
+		}
+	}
+	if _, err = t.w.Write(summaries); err != nil {
+		return err
+	}
+	return t.w.Commit(ctx, tr.End)
+}
+
+func (t *tierWriter) close() error {
+	if t.w == nil {
+		return nil
+	}
+	err := t.w.Close()
+	t.w = nil
+	return err
+}
+
+func (w *rollupWriter) close() error {
+	c := errors.NewCatcher(errors.WithAggregation())
+	for _, t := range w.tiers {
+		c.Exec(t.close)
+	}
+	return c.Error()
+}
+
+// summarize reads the raw samples in the given time range, and returns the
+// concatenated summaries of each bucket of span in the time range.
+func (db *DB) summarize(ctx context.Context, tr telem.TimeRange, span telem.TimeSpan) ([]byte, error) {
+	var (
+		s         = newSummarizer(db.cfg.Channel.DataType)
+		n         = int64(tr.Span() / span)
+		summaries = make([]byte, 0, summaryDensity(db.cfg.Channel.DataType).Size(n))
+		i         = db.OpenIterator(IterRange(tr))
+	)
+	ok := i.SeekFirst(ctx)
+	for cursor := tr.Start; cursor < tr.End; cursor = cursor.Add(span) {
+		i.frame = core.Frame{}
+		if ok {
+			i.view = cursor.SpanRange(0)
+			i.next(ctx, span)
+		}
+		summaries = append(summaries, s.summarize(i.frame.Series)...)
+	}
+	return summaries, errors.Combine(i.err, i.Close())
+}
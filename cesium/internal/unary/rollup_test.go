@@ -0,0 +1,132 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/cesium/internal/unary"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Rollup", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *unary.DB
+				indexDB *unary.DB
+				index   core.ChannelKey = 1
+				data    core.ChannelKey = 2
+				fs      xfs.FS
+				cleanUp func() error
+			)
+			openDBs := func() {
+				indexDB = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("index")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      index,
+						DataType: telem.TimeStampT,
+						IsIndex:  true,
+						Index:    index,
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				db = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("data")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      data,
+						DataType: telem.Int64T,
+						Index:    index,
+						Rollups:  []telem.TimeSpan{4 * telem.Second, 2 * telem.Second},
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				db.SetIndex(indexDB.Index())
+			}
+			closeDBs := func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(indexDB.Close()).To(Succeed())
+			}
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				openDBs()
+				Expect(unary.Write(ctx, indexDB, 0, telem.NewSecondsTSV(0, 1, 2, 3, 4, 5, 6, 7, 8, 9))).To(Succeed())
+				Expect(unary.Write(ctx, db, 0, telem.NewSeriesV[int64](9, 8, 7, 6, 5, 4, 3, 2, 1, 0))).To(Succeed())
+			})
+			AfterEach(func() {
+				closeDBs()
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			aggregate := func(agg core.Aggregation) []telem.Series {
+				iter := db.OpenIterator(unary.IteratorConfig{
+					Bounds:      telem.TimeRangeMax,
+					Aggregation: agg,
+				})
+				Expect(iter.SeekFirst(ctx)).To(BeTrue())
+				Expect(iter.Next(ctx, telem.TimeSpanMax)).To(BeTrue())
+				series := iter.Value().Series
+				Expect(iter.Close()).To(Succeed())
+				return series
+			}
+
+			It("Should write the summaries of completed buckets to each tier", func() {
+				dataFS := MustSucceed(fs.Sub("data"))
+				for span, n := range map[string]int64{"rollup-2000000000": 4, "rollup-4000000000": 2} {
+					tierFS := MustSucceed(dataFS.Sub(span))
+					f := MustSucceed(tierFS.Open("1.domain", 0))
+					Expect(MustSucceed(f.Stat()).Size()).To(Equal(n * 48))
+					Expect(f.Close()).To(Succeed())
+				}
+			})
+
+			DescribeTable("Aggregating with rollups", func(agg core.Aggregation, expected telem.Series) {
+				series := aggregate(agg)
+				Expect(series).To(HaveLen(1))
+				Expect(series[0].TimeRange).To(Equal(telem.TimeStamp(0).SpanRange(telem.TimeSpan(expected.Len()) * agg.Span)))
+				Expect(series[0].Data).To(Equal(expected.Data))
+			},
+				Entry("Min", core.Aggregation{Span: 4 * telem.Second, Func: core.AggregateMin}, telem.NewSeriesV[int64](6, 2, 0)),
+				Entry("Max", core.Aggregation{Span: 4 * telem.Second, Func: core.AggregateMax}, telem.NewSeriesV[int64](9, 5, 1)),
+				Entry("Mean", core.Aggregation{Span: 8 * telem.Second, Func: core.AggregateMean}, telem.NewSeriesV[float64](5.5, 0.5)),
+				Entry("First", core.Aggregation{Span: 6 * telem.Second, Func: core.AggregateFirst}, telem.NewSeriesV[int64](9, 3)),
+				Entry("Last", core.Aggregation{Span: 6 * telem.Second, Func: core.AggregateLast}, telem.NewSeriesV[int64](4, 0)),
+				Entry("Count", core.Aggregation{Span: 2 * telem.Second, Func: core.AggregateCount}, telem.NewSeriesV[int64](2, 2, 2, 2, 2)),
+			)
+
+			It("Should complete buckets left open by a previous writer", func() {
+				Expect(unary.Write(ctx, indexDB, 10*telem.SecondTS, telem.NewSecondsTSV(10, 11, 12))).To(Succeed())
+				Expect(unary.Write(ctx, db, 10*telem.SecondTS, telem.NewSeriesV[int64](-1, -2, -3))).To(Succeed())
+				series := aggregate(core.Aggregation{Span: 4 * telem.Second, Func: core.AggregateCount})
+				Expect(series).To(HaveLen(1))
+				Expect(series[0].Data).To(Equal(telem.NewSeriesV[int64](4, 4, 4, 1).Data))
+			})
+
+			It("Should remove the summaries of deleted data", func() {
+				Expect(db.Delete(ctx, (1 * telem.SecondTS).Range(3*telem.SecondTS))).To(Succeed())
+				series := aggregate(core.Aggregation{Span: 4 * telem.Second, Func: core.AggregateCount})
+				Expect(series).To(HaveLen(1))
+				Expect(series[0].Data).To(Equal(telem.NewSeriesV[int64](2, 4, 2).Data))
+			})
+
+			It("Should persist rollups across reopening the database", func() {
+				closeDBs()
+				openDBs()
+				series := aggregate(core.Aggregation{Span: 4 * telem.Second, Func: core.AggregateMax})
+				Expect(series[0].Data).To(Equal(telem.NewSeriesV[int64](9, 5, 1).Data))
+			})
+		})
+	}
+})
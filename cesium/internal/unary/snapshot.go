@@ -0,0 +1,119 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary
+
+import (
+	"context"
+
+	"github.com/synnaxlabs/cesium/internal/domain"
+	"github.com/synnaxlabs/cesium/internal/meta"
+	"github.com/synnaxlabs/x/errors"
+	xfs "github.com/synnaxlabs/x/io/fs"
+)
+
+// Snapshot is a point-in-time view of the data committed to a unary DB. See
+// domain.Snapshot for details on the guarantees provided while a Snapshot is open.
+type Snapshot struct {
+	*domain.Snapshot
+	// offsets is a snapshot of the channel's offset tables if the channel has a
+	// variable density data type, and is nil otherwise.
+	offsets *domain.Snapshot
+	// rollups are snapshots of the channel's rollup tiers, in the same order as
+	// DB.rollups.
+	rollups []*domain.Snapshot
+	db      *DB
+}
+
+// OpenSnapshot opens a Snapshot of the data currently committed to the DB. The Snapshot
+// must be closed after use.
+func (db *DB) OpenSnapshot() (*Snapshot, error) {
+	if db.closed.Load() {
+		return nil, db.wrapError(ErrDBClosed)
+	}
+	s, err := db.domain.OpenSnapshot()
+	if err != nil {
+		return nil, db.wrapError(err)
+	}
+	snap := &Snapshot{Snapshot: s, db: db}
+	if db.offsets != nil {
+		if snap.offsets, err = db.offsets.OpenSnapshot(); err != nil {
+			return nil, db.wrapError(errors.Combine(err, snap.Close()))
+		}
+	}
+	for _, r := range db.rollups {
+		rs, err := r.db.OpenSnapshot()
+		if err != nil {
+			return nil, db.wrapError(errors.Combine(err, snap.Close()))
+		}
+		snap.rollups = append(snap.rollups, rs)
+	}
+	return snap, nil
+}
+
+// Capture updates the snapshot to the data currently committed to the DB. See
+// domain.Snapshot.Capture for more details.
+func (s *Snapshot) Capture() {
+	// Rollups are committed after the data they summarize, so capturing them before
+	// the data guarantees that every captured summary covers captured samples.
+	for _, r := range s.rollups {
+		r.Capture()
+	}
+	s.Snapshot.Capture()
+	// Offset tables are committed before their data, so capturing them after the
+	// data guarantees that every captured sample has an entry.
+	if s.offsets != nil {
+		s.offsets.Capture()
+	}
+}
+
+// WriteTo writes the channel's metadata, the snapshot's data, and its rollup tiers to
+// dst, which must be empty. The resulting directory can be opened as a unary DB.
+func (s *Snapshot) WriteTo(ctx context.Context, dst xfs.FS) error {
+	if err := meta.Create(dst, s.db.cfg.MetaCodec, s.db.cfg.Channel); err != nil {
+		return s.db.wrapError(err)
+	}
+	if err := s.Snapshot.WriteTo(ctx, dst); err != nil {
+		return s.db.wrapError(err)
+	}
+	if s.offsets != nil {
+		if err := writeSubSnapshot(ctx, s.offsets, dst, OffsetsDirname); err != nil {
+			return s.db.wrapError(err)
+		}
+	}
+	for i, r := range s.rollups {
+		if err := writeSubSnapshot(ctx, r, dst, rollupDirname(s.db.rollups[i].span)); err != nil {
+			return s.db.wrapError(err)
+		}
+	}
+	// Sync the directory again, as writing the offsets and rollups created
+	// subdirectories in it.
+	return s.db.wrapError(xfs.SyncDir(dst, "."))
+}
+
+func writeSubSnapshot(ctx context.Context, s *domain.Snapshot, dst xfs.FS, dirname string) error {
+	sub, err := dst.Sub(dirname)
+	if err != nil {
+		return err
+	}
+	return s.WriteTo(ctx, sub)
+}
+
+// Close releases the snapshot. Close is idempotent.
+func (s *Snapshot) Close() error {
+	c := errors.NewCatcher(errors.WithAggregation())
+	c.Exec(s.Snapshot.Close)
+	if s.offsets != nil {
+		c.Exec(s.offsets.Close)
+	}
+	for _, r := range s.rollups {
+		c.Exec(r.Close)
+	}
+	return c.Error()
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary
+
+import (
+	"math"
+
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/types"
+)
+
+// A summary is the encoded summary of the samples in a bucket of time. Summaries are
+// the records stored for each bucket in a rollup tier, and have the layout:
+//
+//	| count (int64) | sum (float64) | min | max | first | last |
+//
+// where min, max, first, and last are encoded in the data type of the channel.
+const summaryHeaderSize = 16
+
+// summaryDensity returns the size of a summary of samples of the given data type.
+func summaryDensity(dt telem.DataType) telem.Density {
+	return summaryHeaderSize + 4*dt.Density()
+}
+
+// summaryCount returns the number of samples summarized by the given summary.
+func summaryCount(s []byte) int64 { return int64(telem.ByteOrder.Uint64(s)) }
+
+// summarizer computes, merges, and reduces summaries of samples of a particular data
+// type.
+type summarizer interface {
+	// summarize returns the summary of the samples in the given series.
+	summarize(series []telem.Series) []byte
+	// merge returns the summary of a run of consecutive buckets given their
+	// concatenated summaries.
+	merge(summaries []byte) []byte
+	// value returns the aggregate value of the summarized samples.
+	value(agg core.Aggregation, s []byte) []byte
+}
+
+// newSummarizer returns a summarizer for samples of the given data type, or nil if
+// samples of the data type cannot be aggregated.
+func newSummarizer(dt telem.DataType) summarizer {
+	switch dt {
+	case telem.Float64T:
+		return numericSummarizer[float64]{dt: dt}
+	case telem.Float32T:
+		return numericSummarizer[float32]{dt: dt}
+	case telem.Int64T, telem.TimeStampT:
+		return numericSummarizer[int64]{dt: dt}
+	case telem.Int32T:
+		return numericSummarizer[int32]{dt: dt}
+	case telem.Int16T:
+		return numericSummarizer[int16]{dt: dt}
+	case telem.Int8T:
+		return numericSummarizer[int8]{dt: dt}
+	case telem.Uint64T:
+		return numericSummarizer[uint64]{dt: dt}
+	case telem.Uint32T:
+		return numericSummarizer[uint32]{dt: dt}
+	case telem.Uint16T:
+		return numericSummarizer[uint16]{dt: dt}
+	case telem.Uint8T:
+		return numericSummarizer[uint8]{dt: dt}
+	default:
+		return nil
+	}
+}
+
+type summary[T types.Numeric] struct {
+	count                 int64
+	sum                   float64
+	min, max, first, last T
+}
+
+// add adds a sample to the summary. Samples must be added in time order.
+func (s *summary[T]) add(v T) {
+	if s.count == 0 {
+		s.min, s.max, s.first = v, v, v
+	}
+	s.min, s.max, s.last = min(s.min, v), max(s.max, v), v
+	s.sum += float64(v)
+	s.count++
+}
+
+// merge merges the summary of a later bucket into the summary.
+func (s *summary[T]) merge(o summary[T]) {
+	if o.count == 0 {
+		return
+	}
+	if s.count == 0 {
+		*s = o
+		return
+	}
+	s.min, s.max, s.last = min(s.min, o.min), max(s.max, o.max), o.last
+	s.sum += o.sum
+	s.count += o.count
+}
+
+type numericSummarizer[T types.Numeric] struct{ dt telem.DataType }
+
+var _ summarizer = numericSummarizer[float64]{}
+
+func (n numericSummarizer[T]) summarize(series []telem.Series) []byte {
+	var (
+		s       summary[T]
+		um      = telem.UnmarshalF[T](n.dt)
+		density = int(n.dt.Density())
+	)
+	for _, ser := range series {
+		for j := 0; j+density <= len(ser.Data); j += density {
+			s.add(um(ser.Data[j : j+density]))
+		}
+	}
+	return n.encode(s)
+}
+
+func (n numericSummarizer[T]) merge(summaries []byte) []byte {
+	var (
+		s       summary[T]
+		density = int(summaryDensity(n.dt))
+	)
+	for j := 0; j+density <= len(summaries); j += density {
+		s.merge(n.decode(summaries[j : j+density]))
+	}
+	return n.encode(s)
+}
+
+func (n numericSummarizer[T]) value(agg core.Aggregation, b []byte) []byte {
+	var (
+		s  = n.decode(b)
+		dt = agg.DataType(n.dt)
+		v  = make([]byte, dt.Density())
+	)
+	switch agg.Func {
+	case core.AggregateMin:
+		telem.MarshalF[T](dt)(v, s.min)
+	case core.AggregateMax:
+		telem.MarshalF[T](dt)(v, s.max)
+	case core.AggregateFirst:
+		telem.MarshalF[T](dt)(v, s.first)
+	case core.AggregateLast:
+		telem.MarshalF[T](dt)(v, s.last)
+	case core.AggregateMean:
+		mean := math.NaN()
+		if s.count > 0 {
+			mean = s.sum / float64(s.count)
+		}
+		telem.MarshalF[float64](dt)(v, mean)
+	case core.AggregateCount:
+		telem.MarshalF[int64](dt)(v, s.count)
+	}
+	return v
+}
+
+func (n numericSummarizer[T]) encode(s summary[T]) []byte {
+	var (
+		density = int(n.dt.Density())
+		b       = make([]byte, summaryDensity(n.dt))
+		m       = telem.MarshalF[T](n.dt)
+	)
+	telem.ByteOrder.PutUint64(b, uint64(s.count))
+	telem.ByteOrder.PutUint64(b[8:], math.Float64bits(s.sum))
+	for j, v := range [4]T{s.min, s.max, s.first, s.last} {
+		m(b[summaryHeaderSize+j*density:], v)
+	}
+	return b
+}
+
+func (n numericSummarizer[T]) decode(b []byte) (s summary[T]) {
+	var (
+		density = int(n.dt.Density())
+		um      = telem.UnmarshalF[T](n.dt)
+		values  [4]T
+	)
+	s.count = summaryCount(b)
+	s.sum = math.Float64frombits(telem.ByteOrder.Uint64(b[8:]))
+	for j := range values {
+		offset := summaryHeaderSize + j*density
+		values[j] = um(b[offset : offset+density])
+	}
+	s.min, s.max, s.first, s.last = values[0], values[1], values[2], values[3]
+	return s
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/synnaxlabs/cesium/internal/domain"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Channels with a variable density data type store each sample as its encoded value
+// followed by a newline. Since the position of a sample within a domain cannot be
+// calculated from its index, each domain of such a channel has an offset table holding
+// one entry per sample. The offset tables are stored in a separate domain database, and
+// the domain holding the offset table of a data domain occupies the same time range as
+// it.
+//
+// Each entry is the offset of the start of its sample relative to the first sample
+// written by the domain writer that wrote it. The offset of a sample within its domain is
+// therefore the difference between its entry and the entry of the first sample in the
+// domain, which keeps the offset table valid when a domain is sliced by a deletion.
+
+// OffsetsDirname is the name of the directory, relative to the channel's directory,
+// that the offset tables of a variable density channel are stored in.
+const OffsetsDirname = "offsets"
+
+// offsetDensity is the size of a single entry in an offset table.
+const offsetDensity = telem.Bit64
+
+// sampleDelimiter terminates each sample of a variable density series.
+const sampleDelimiter = '\n'
+
+// openOffsets opens the database holding the offset tables of the channel, returning
+// nil if the channel does not have a variable density data type.
+func openOffsets(cfg Config) (*domain.DB, error) {
+	if !cfg.Channel.DataType.IsVariable() {
+		return nil, nil
+	}
+	fs, err := cfg.FS.Sub(OffsetsDirname)
+	if err != nil {
+		return nil, err
+	}
+	return domain.Open(domain.Config{
+		FS:              fs,
+		Instrumentation: cfg.Instrumentation.Child("offsets"),
+		FileSize:        cfg.FileSize,
+		GCThreshold:     cfg.GCThreshold,
+	})
+}
+
+// validateVariableSeries checks that every sample in a variable density series is
+// terminated by a delimiter.
+func validateVariableSeries(series telem.Series) error {
+	if len(series.Data) > 0 && series.Data[len(series.Data)-1] != sampleDelimiter {
+		return errors.Wrapf(
+			validate.Error,
+			"the last sample of a %s series must be terminated by a newline",
+			series.DataType,
+		)
+	}
+	return nil
+}
+
+// offsetEntries returns the offset table entries for the samples in data, given the
+// offset of the first sample.
+func offsetEntries(base int64, data []byte) []byte {
+	var (
+		entries = make([]byte, 0, offsetDensity.Size(int64(bytes.Count(data, []byte{sampleDelimiter}))))
+		start   = base
+	)
+	for i, b := range data {
+		if b == sampleDelimiter {
+			entries = telem.ByteOrder.AppendUint64(entries, uint64(start))
+			start = base + int64(i) + 1
+		}
+	}
+	return entries
+}
+
+// offsetTable resolves the positions of samples within the domains of a variable
+// density channel to byte offsets.
+type offsetTable struct {
+	// offsets iterates over the domains of the offset tables.
+	offsets *domain.Iterator
+	// data iterates over the data domains. data is only used to find the length of a
+	// domain when it is not known by the caller.
+	data *domain.Iterator
+}
+
+func (db *DB) openOffsetTable() *offsetTable {
+	return &offsetTable{
+		offsets: db.offsets.OpenIterator(domain.IterRange(telem.TimeRangeMax)),
+		data:    db.domain.OpenIterator(domain.IterRange(telem.TimeRangeMax)),
+	}
+}
+
+// open opens a reader on the offset table of the data domain occupying dr.
+func (t *offsetTable) open(ctx context.Context, dr telem.TimeRange) (*domain.Reader, error) {
+	if !t.offsets.SeekGE(ctx, dr.Start) || t.offsets.TimeRange().Start != dr.Start {
+		return nil, errors.Newf("offset table for domain %s not found", dr)
+	}
+	return t.offsets.OpenReader(ctx)
+}
+
+func readEntry(r *domain.Reader, i int64) (int64, error) {
+	b := make([]byte, offsetDensity)
+	if _, err := r.ReadAt(b, int64(offsetDensity.Size(i))); err != nil {
+		return 0, err
+	}
+	return int64(telem.ByteOrder.Uint64(b)), nil
+}
+
+// byteOffsets returns the byte offsets of the given samples within the data domain
+// occupying dr, whose length is length. Samples at or beyond the end of the domain
+// resolve to its length.
+func (t *offsetTable) byteOffsets(
+	ctx context.Context,
+	dr telem.TimeRange,
+	length int64,
+	samples ...int64,
+) (offsets []int64, err error) {
+	r, err := t.open(ctx, dr)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Combine(err, r.Close()) }()
+	var (
+		count = offsetDensity.SampleCount(telem.Size(r.Len()))
+		base  int64
+	)
+	if count > 0 {
+		if base, err = readEntry(r, 0); err != nil {
+			return nil, err
+		}
+	}
+	offsets = make([]int64, len(samples))
+	for j, s := range samples {
+		switch {
+		case s <= 0:
+			offsets[j] = 0
+		case s >= count:
+			offsets[j] = length
+		default:
+			e, err := readEntry(r, s)
+			if err != nil {
+				return nil, err
+			}
+			offsets[j] = min(e-base, length)
+		}
+	}
+	return offsets, nil
+}
+
+// sampleCount returns the number of samples in the data domain occupying dr, whose
+// length is length.
+func (t *offsetTable) sampleCount(
+	ctx context.Context,
+	dr telem.TimeRange,
+	length int64,
+) (count int64, err error) {
+	r, err := t.open(ctx, dr)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errors.Combine(err, r.Close()) }()
+	count = offsetDensity.SampleCount(telem.Size(r.Len()))
+	if count == 0 {
+		return 0, nil
+	}
+	base, err := readEntry(r, 0)
+	if err != nil {
+		return 0, err
+	}
+	last, err := readEntry(r, count-1)
+	if err != nil || last-base < length {
+		return count, err
+	}
+	// The offset table is committed before its data domain, so it may hold entries
+	// for samples that are not yet part of the domain.
+	return int64(sort.Search(int(count), func(i int) bool {
+		if err != nil {
+			return true
+		}
+		var e int64
+		e, err = readEntry(r, int64(i))
+		return e-base >= length
+	})), err
+}
+
+// domainLen returns the length of the data domain starting at start.
+func (t *offsetTable) domainLen(ctx context.Context, start telem.TimeStamp) (telem.TimeRange, int64, error) {
+	if !t.data.SeekGE(ctx, start) || t.data.TimeRange().Start != start {
+		return telem.TimeRange{}, 0, errors.Newf("domain starting at %s not found", start)
+	}
+	return t.data.TimeRange(), t.data.Len(), nil
+}
+
+// toByteOffset wraps a function that calculates the number of samples between the start
+// of a domain and a timestamp, returning one that calculates the number of bytes
+// instead. It is used to delete the data domains of a variable density channel.
+func (t *offsetTable) toByteOffset(
+	calculate func(
+		ctx context.Context,
+		domainStart telem.TimeStamp,
+		ts telem.TimeStamp,
+	) (int64, telem.TimeStamp, error),
+) func(context.Context, telem.TimeStamp, telem.TimeStamp) (int64, telem.TimeStamp, error) {
+	return func(ctx context.Context, domainStart, ts telem.TimeStamp) (int64, telem.TimeStamp, error) {
+		n, ts, err := calculate(ctx, domainStart, ts)
+		if err != nil {
+			return 0, ts, err
+		}
+		dr, length, err := t.domainLen(ctx, domainStart)
+		if err != nil {
+			return 0, ts, err
+		}
+		offsets, err := t.byteOffsets(ctx, dr, length, n)
+		if err != nil {
+			return 0, ts, err
+		}
+		return offsets[0], ts, nil
+	}
+}
+
+func (t *offsetTable) Close() error {
+	return errors.Combine(t.offsets.Close(), t.data.Close())
+}
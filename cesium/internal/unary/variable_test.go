@@ -0,0 +1,179 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package unary_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/cesium/internal/unary"
+	"github.com/synnaxlabs/x/control"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Variable Density", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS:"+fsName, func() {
+			var (
+				db      *unary.DB
+				indexDB *unary.DB
+				index   uint32 = 1
+				data    uint32 = 2
+				fs      xfs.FS
+				cleanUp func() error
+			)
+			openDB := func(fileSize telem.Size) {
+				indexDB = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("index")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      index,
+						DataType: telem.TimeStampT,
+						IsIndex:  true,
+						Index:    index,
+					},
+					Instrumentation: PanicLogger(),
+				}))
+				db = MustSucceed(unary.Open(unary.Config{
+					FS:        MustSucceed(fs.Sub("data")),
+					MetaCodec: codec,
+					Channel: core.Channel{
+						Key:      data,
+						DataType: telem.StringT,
+						Index:    index,
+					},
+					FileSize:        fileSize,
+					Instrumentation: PanicLogger(),
+				}))
+				db.SetIndex(indexDB.Index())
+			}
+			closeDB := func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(indexDB.Close()).To(Succeed())
+			}
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				openDB(0)
+			})
+			AfterEach(func() {
+				closeDB()
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			write := func(start telem.TimeStamp, stamps []telem.TimeStamp, values ...string) {
+				Expect(unary.Write(ctx, indexDB, start, telem.NewSeriesV(stamps...))).To(Succeed())
+				Expect(unary.Write(ctx, db, start, telem.NewStringsV(values...))).To(Succeed())
+			}
+
+			read := func(tr telem.TimeRange) []string {
+				var values []string
+				for _, s := range MustSucceed(db.Read(ctx, tr)).Series {
+					values = append(values, telem.UnmarshalStrings(s.Data)...)
+				}
+				return values
+			}
+
+			It("Should read back variable length samples", func() {
+				write(10*telem.SecondTS, []telem.TimeStamp{10 * telem.SecondTS, 11 * telem.SecondTS, 12 * telem.SecondTS}, "a", "bbb", "cc")
+				Expect(read(telem.TimeRangeMax)).To(Equal([]string{"a", "bbb", "cc"}))
+			})
+
+			It("Should seek to samples in the middle of a domain", func() {
+				write(
+					10*telem.SecondTS,
+					[]telem.TimeStamp{10 * telem.SecondTS, 11 * telem.SecondTS, 12 * telem.SecondTS, 13 * telem.SecondTS},
+					"cat", "", "horse", "ox",
+				)
+				Expect(read((11 * telem.SecondTS).Range(13 * telem.SecondTS))).To(Equal([]string{"", "horse"}))
+				Expect(read((12*telem.SecondTS + 1).Range(20 * telem.SecondTS))).To(Equal([]string{"ox"}))
+			})
+
+			It("Should iterate over samples in automatically sized chunks", func() {
+				write(
+					10*telem.SecondTS,
+					[]telem.TimeStamp{10 * telem.SecondTS, 11 * telem.SecondTS, 12 * telem.SecondTS, 13 * telem.SecondTS, 14 * telem.SecondTS},
+					"a", "bb", "ccc", "dddd", "eeeee",
+				)
+				i := db.OpenIterator(unary.IteratorConfig{Bounds: telem.TimeRangeMax, AutoChunkSize: 2})
+				Expect(i.SeekFirst(ctx)).To(BeTrue())
+				var chunks [][]string
+				for i.Next(ctx, unary.AutoSpan) {
+					chunks = append(chunks, telem.UnmarshalStrings(i.Value().Series[0].Data))
+				}
+				Expect(i.Close()).To(Succeed())
+				Expect(chunks).To(Equal([][]string{{"a", "bb"}, {"ccc", "dddd"}, {"eeeee"}}))
+			})
+
+			It("Should keep samples aligned with the offset tables across file switches", func() {
+				closeDB()
+				openDB(8 * telem.ByteSize)
+				w, _ := MustSucceed2(db.OpenWriter(ctx, unary.WriterConfig{
+					Start:   10 * telem.SecondTS,
+					Subject: control.Subject{Key: "foo"},
+				}))
+				iw, _ := MustSucceed2(indexDB.OpenWriter(ctx, unary.WriterConfig{
+					Start:   10 * telem.SecondTS,
+					Subject: control.Subject{Key: "foo"},
+				}))
+				var expected []string
+				for s := 10; s < 20; s++ {
+					v := string(make([]byte, s-9))
+					expected = append(expected, v)
+					MustSucceed(iw.Write(telem.NewSecondsTSV(telem.TimeStamp(s))))
+					MustSucceed(iw.Commit(ctx))
+					MustSucceed(w.Write(telem.NewStringsV(v)))
+					MustSucceed(w.Commit(ctx))
+				}
+				MustSucceed(w.Close())
+				MustSucceed(iw.Close())
+				Expect(len(db.Domains(telem.TimeRangeMax))).To(BeNumerically(">", 1))
+				Expect(read(telem.TimeRangeMax)).To(Equal(expected))
+				Expect(read((13 * telem.SecondTS).Range(16 * telem.SecondTS))).To(Equal(expected[3:6]))
+			})
+
+			It("Should delete samples in a time range", func() {
+				write(
+					10*telem.SecondTS,
+					[]telem.TimeStamp{10 * telem.SecondTS, 11 * telem.SecondTS, 12 * telem.SecondTS, 13 * telem.SecondTS},
+					"cat", "dog", "horse", "ox",
+				)
+				Expect(db.Delete(ctx, (11 * telem.SecondTS).Range(13*telem.SecondTS))).To(Succeed())
+				Expect(read(telem.TimeRangeMax)).To(Equal([]string{"cat", "ox"}))
+				Expect(read((13 * telem.SecondTS).Range(14 * telem.SecondTS))).To(Equal([]string{"ox"}))
+			})
+
+			It("Should persist offset tables across reopening the database", func() {
+				write(10*telem.SecondTS, []telem.TimeStamp{10 * telem.SecondTS, 11 * telem.SecondTS}, "hello", "world")
+				closeDB()
+				openDB(0)
+				Expect(read((11 * telem.SecondTS).Range(12 * telem.SecondTS))).To(Equal([]string{"world"}))
+			})
+
+			It("Should not write a sample that is not terminated by a newline", func() {
+				w, _ := MustSucceed2(db.OpenWriter(ctx, unary.WriterConfig{
+					Start:   10 * telem.SecondTS,
+					Subject: control.Subject{Key: "foo"},
+				}))
+				_, err := w.Write(telem.Series{DataType: telem.StringT, Data: []byte("a\nb")})
+				Expect(err).To(HaveOccurredAs(validate.Error))
+				MustSucceed(w.Close())
+			})
+
+			It("Should not overwrite samples", func() {
+				write(10*telem.SecondTS, []telem.TimeStamp{10 * telem.SecondTS}, "a")
+				Expect(db.Overwrite(ctx, (10 * telem.SecondTS).Range(11*telem.SecondTS), telem.NewStringsV("b"))).
+					To(HaveOccurredAs(validate.Error))
+			})
+		})
+	}
+})
@@ -59,6 +59,10 @@ type WriterConfig struct {
 	// [OPTIONAL] - Defaults to false
 	ErrOnUnauthorized    *bool
 	AlignmentDomainIndex uint32
+	// AllowOverlap allows the writer to be opened with a Start that overlaps with
+	// existing data. See domain.WriterConfig.AllowOverlap for more details.
+	// [OPTIONAL] - Defaults to false
+	AllowOverlap *bool
 }
 
 var (
@@ -68,6 +72,7 @@ var (
 		EnableAutoCommit:         config.False(),
 		AutoIndexPersistInterval: 1 * telem.Second,
 		ErrOnUnauthorized:        config.False(),
+		AllowOverlap:             config.False(),
 	}
 	errWriterClosed = core.EntityClosed("unary.writer")
 )
@@ -94,11 +99,18 @@ func (c WriterConfig) Override(other WriterConfig) WriterConfig {
 	c.AutoIndexPersistInterval = override.Zero(c.AutoIndexPersistInterval, other.AutoIndexPersistInterval)
 	c.ErrOnUnauthorized = override.Nil(c.ErrOnUnauthorized, other.ErrOnUnauthorized)
 	c.AlignmentDomainIndex = override.Numeric(c.AlignmentDomainIndex, other.AlignmentDomainIndex)
+	c.AllowOverlap = override.Nil(c.AllowOverlap, other.AllowOverlap)
 	return c
 }
 
 func (c WriterConfig) domain() domain.WriterConfig {
-	return domain.WriterConfig{Start: c.Start, End: c.End, EnableAutoCommit: c.EnableAutoCommit, AutoIndexPersistInterval: c.AutoIndexPersistInterval}
+	return domain.WriterConfig{
+		Start:                    c.Start,
+		End:                      c.End,
+		EnableAutoCommit:         c.EnableAutoCommit,
+		AutoIndexPersistInterval: c.AutoIndexPersistInterval,
+		AllowOverlap:             c.AllowOverlap,
+	}
 }
 
 func (c WriterConfig) controlTimeRange() telem.TimeRange {
@@ -113,6 +125,9 @@ func (c WriterConfig) controlTimeRange() telem.TimeRange {
 // are consistent.
 type controlledWriter struct {
 	*domain.Writer
+	// offsets writes the offset tables of the domains written by Writer if the channel
+	// has a variable density data type, and is nil otherwise.
+	offsets    *domain.Writer
 	channelKey core.ChannelKey
 	alignment  telem.AlignmentPair
 }
@@ -122,8 +137,65 @@ var _ controller.Entity = controlledWriter{}
 // ChannelKey implements controller.Entity.
 func (w controlledWriter) ChannelKey() core.ChannelKey { return w.channelKey }
 
+// write writes the data of the given series, along with its offset table entries if
+// the channel has a variable density data type.
+func (w *controlledWriter) write(series telem.Series) error {
+	if w.offsets != nil {
+		if _, err := w.offsets.Write(offsetEntries(w.Len(), series.Data)); err != nil {
+			return err
+		}
+	}
+	_, err := w.Writer.Write(series.Data)
+	return err
+}
+
+// split splits both the data domain and its offset table. See domain.Writer.Split.
+func (w *controlledWriter) split(end, start telem.TimeStamp) error {
+	if w.offsets != nil {
+		if err := w.offsets.Split(end, start); err != nil {
+			return err
+		}
+	}
+	return w.Split(end, start)
+}
+
+// commit commits both the data domain and its offset table. The offset table is
+// committed first so that every committed sample has an entry. When only one of the
+// underlying writers switches files during a commit, the other is split so that each
+// data domain and its offset table continue to occupy the same time range.
+func (w *controlledWriter) commit(ctx context.Context, end telem.TimeStamp) error {
+	if w.offsets == nil {
+		return w.Commit(ctx, end)
+	}
+	dataStart, offsetsStart := w.Start, w.offsets.Start
+	if err := w.offsets.Commit(ctx, end); err != nil {
+		return err
+	}
+	if err := w.Commit(ctx, end); err != nil {
+		return err
+	}
+	dataSwitched, offsetsSwitched := w.Start != dataStart, w.offsets.Start != offsetsStart
+	if dataSwitched && !offsetsSwitched {
+		return w.offsets.Split(end, end)
+	}
+	if offsetsSwitched && !dataSwitched {
+		return w.Split(end, end)
+	}
+	return nil
+}
+
+// Close closes the underlying writers.
+func (w *controlledWriter) Close() error {
+	if w.offsets == nil {
+		return w.Writer.Close()
+	}
+	return errors.Combine(w.Writer.Close(), w.offsets.Close())
+}
+
 type Writer struct {
 	cfg WriterConfig
+	// db is the database the writer writes to.
+	db *DB
 	// Channel stores information about the channel this writer is writing to, including
 	// but not limited to density and index.
 	Channel core.Channel
@@ -144,6 +216,9 @@ type Writer struct {
 	// closed stores whether the writer is closed. Operations like Write and Commit do not
 	// succeed on closed writers.
 	closed bool
+	// rollups updates the channel's rollup tiers as data is committed. rollups is nil
+	// if the channel has no rollup tiers or the writer does not persist data.
+	rollups *rollupWriter
 }
 
 func (db *DB) OpenWriter(ctx context.Context, cfgs ...WriterConfig) (
@@ -158,12 +233,22 @@ func (db *DB) OpenWriter(ctx context.Context, cfgs ...WriterConfig) (
 	if err != nil {
 		return nil, transfer, err
 	}
+	if db.offsets != nil && !cfg.End.IsZero() {
+		return nil, transfer, db.wrapError(errors.Wrap(
+			validate.Error,
+			"writers for channels with variable density data types cannot have a preset end",
+		))
+	}
 	w = &Writer{
 		cfg:       cfg,
+		db:        db,
 		Channel:   db.cfg.Channel,
 		idx:       db.index(),
 		wrapError: db.wrapError,
 	}
+	if *cfg.Persist {
+		w.rollups = db.openRollupWriter(cfg.Start)
+	}
 	gateCfg := controller.GateConfig{
 		TimeRange: cfg.controlTimeRange(),
 		Authority: cfg.Authority,
@@ -177,6 +262,11 @@ func (db *DB) OpenWriter(ctx context.Context, cfgs ...WriterConfig) (
 			channelKey: db.cfg.Channel.Key,
 			alignment:  telem.NewAlignmentPair(cfg.AlignmentDomainIndex, 0),
 		}
+		if err == nil && db.offsets != nil {
+			if cw.offsets, err = db.offsets.OpenWriter(ctx, cfg.domain()); err != nil {
+				err = errors.Combine(err, dw.Close())
+			}
+		}
 		if cfg.AlignmentDomainIndex == 0 {
 			cw.alignment = telem.NewAlignmentPair(db.leadingAlignment.Add(1), 0)
 		}
@@ -220,7 +310,10 @@ func Write(
 	return err
 }
 
-func (w *Writer) len(dw *domain.Writer) int64 {
+func (w *Writer) len(dw *controlledWriter) int64 {
+	if dw.offsets != nil {
+		return offsetDensity.SampleCount(telem.Size(dw.offsets.Len()))
+	}
 	return w.Channel.DataType.Density().SampleCount(telem.Size(dw.Len()))
 }
 
@@ -232,6 +325,11 @@ func (w *Writer) Write(series telem.Series) (telem.AlignmentPair, error) {
 	if err := w.Channel.ValidateSeries(series); err != nil {
 		return 0, w.wrapError(err)
 	}
+	if series.DataType.IsVariable() {
+		if err := validateVariableSeries(series); err != nil {
+			return 0, w.wrapError(err)
+		}
+	}
 	dw, err := w.control.Authorize()
 	if err != nil {
 		return 0, w.wrapError(err)
@@ -240,8 +338,8 @@ func (w *Writer) Write(series telem.Series) (telem.AlignmentPair, error) {
 		w.updateHwm(series)
 	}
 	if *w.cfg.Persist {
-		dw.alignment = telem.NewAlignmentPair(dw.alignment.DomainIndex(), uint32(w.len(dw.Writer)))
-		_, err = dw.Write(series.Data)
+		dw.alignment = telem.NewAlignmentPair(dw.alignment.DomainIndex(), uint32(w.len(dw)))
+		err = dw.write(series)
 	} else {
 		dw.alignment = dw.alignment.AddSamples(uint32(series.Len()))
 	}
@@ -263,6 +361,40 @@ func (w *Writer) updateHwm(series telem.Series) {
 	w.hwm = telem.ValueAt[telem.TimeStamp](series, series.Len()-1)
 }
 
+// Split ends the domain being written at end, and starts a new domain at start for all
+// series written after Split returns. See domain.Writer.Split for more details.
+func (w *Writer) Split(end, start telem.TimeStamp) error {
+	if w.closed {
+		return w.wrapError(errWriterClosed)
+	}
+	dw, err := w.control.Authorize()
+	if err != nil {
+		return w.wrapError(err)
+	}
+	if !*w.cfg.Persist {
+		return nil
+	}
+	if dw.Len() == 0 && w.rollups != nil {
+		w.rollups.seek(start)
+	}
+	return w.wrapError(dw.split(end, start))
+}
+
+// Overwrite deletes all data in the given time range so that the writer can commit
+// over it. The time range must not contain any data committed by the writer.
+func (w *Writer) Overwrite(ctx context.Context, tr telem.TimeRange) error {
+	if w.closed {
+		return w.wrapError(errWriterClosed)
+	}
+	if _, err := w.control.Authorize(); err != nil {
+		return w.wrapError(err)
+	}
+	if !*w.cfg.Persist {
+		return nil
+	}
+	return w.wrapError(w.db.deleteData(ctx, tr))
+}
+
 // Commit commits the written series to the database.
 func (w *Writer) Commit(ctx context.Context) (telem.TimeStamp, error) {
 	if w.closed {
@@ -294,7 +426,7 @@ func (w *Writer) commitWithEnd(ctx context.Context, end telem.TimeStamp) (telem.
 	if end.IsZero() {
 		// We're using w.len - 1 here because we want the timestamp of the last
 		// written frame.
-		approx, err := w.idx.Stamp(ctx, w.cfg.Start, w.len(dw.Writer)-1, true)
+		approx, err := w.idx.Stamp(ctx, w.cfg.Start, w.len(dw)-1, true)
 		if err != nil {
 			return 0, err
 		}
@@ -310,7 +442,13 @@ func (w *Writer) commitWithEnd(ctx context.Context, end telem.TimeStamp) (telem.
 		end = approx.Lower + 1
 	}
 
-	return end, dw.Commit(ctx, end)
+	if err = dw.commit(ctx, end); err != nil {
+		return end, err
+	}
+	if w.rollups != nil {
+		w.rollups.commit(ctx, end)
+	}
+	return end, nil
 }
 
 func (w *Writer) Close() (controller.Transfer, error) {
@@ -318,6 +456,12 @@ func (w *Writer) Close() (controller.Transfer, error) {
 		return controller.Transfer{}, nil
 	}
 	w.closed = true
+	if w.rollups != nil {
+		if err := w.rollups.close(); err != nil {
+			w.control.Release()
+			return controller.Transfer{}, w.wrapError(err)
+		}
+	}
 	dw, t := w.control.Release()
 	if t.IsRelease() {
 		return t, w.wrapError(dw.Close())
@@ -82,6 +82,48 @@ var _ = Describe("Writer Behavior", Ordered, func() {
 						Expect(err).To(MatchError(ContainSubstring("Subject.Key:field must be set")))
 					})
 				})
+				Describe("Overlap", func() {
+					BeforeEach(func() {
+						Expect(unary.Write(ctx, db, 10*telem.SecondTS, telem.NewSecondsTSV(10, 11, 12))).To(Succeed())
+					})
+					It("Should write into the gaps around existing data", func() {
+						w, _ := MustSucceed2(db.OpenWriter(ctx, unary.WriterConfig{
+							Start:        11 * telem.SecondTS,
+							Subject:      control.Subject{Key: "foo"},
+							AllowOverlap: config.True(),
+						}))
+						Expect(w.Split(0, 13*telem.SecondTS)).To(Succeed())
+						MustSucceed(w.Write(telem.NewSecondsTSV(13, 14)))
+						Expect(MustSucceed(w.Commit(ctx))).To(Equal(14*telem.SecondTS + 1))
+						MustSucceed(w.Close())
+						Expect(db.Domains(telem.TimeRangeMax)).To(Equal([]telem.TimeRange{
+							(10 * telem.SecondTS).Range(12*telem.SecondTS + 1),
+							(13 * telem.SecondTS).Range(14*telem.SecondTS + 1),
+						}))
+					})
+					It("Should overwrite existing data", func() {
+						w, _ := MustSucceed2(db.OpenWriter(ctx, unary.WriterConfig{
+							Start:        11 * telem.SecondTS,
+							Subject:      control.Subject{Key: "foo"},
+							AllowOverlap: config.True(),
+						}))
+						MustSucceed(w.Write(telem.NewSecondsTSV(11, 13)))
+						Expect(w.Overwrite(ctx, (11 * telem.SecondTS).Range(13*telem.SecondTS+1))).To(Succeed())
+						Expect(MustSucceed(w.Commit(ctx))).To(Equal(13*telem.SecondTS + 1))
+						MustSucceed(w.Close())
+						Expect(db.Domains(telem.TimeRangeMax)).To(Equal([]telem.TimeRange{
+							(10 * telem.SecondTS).Range(11 * telem.SecondTS),
+							(11 * telem.SecondTS).Range(13*telem.SecondTS + 1),
+						}))
+						i := db.OpenIterator(unary.IterRange(telem.TimeRangeMax))
+						Expect(i.SeekFirst(ctx)).To(BeTrue())
+						Expect(i.Next(ctx, telem.TimeSpanMax)).To(BeTrue())
+						Expect(i.Value().Series).To(HaveLen(2))
+						Expect(i.Value().Series[0].Data).To(Equal(telem.NewSecondsTSV(10).Data))
+						Expect(i.Value().Series[1].Data).To(Equal(telem.NewSecondsTSV(11, 13).Data))
+						Expect(i.Close()).To(Succeed())
+					})
+				})
 			})
 			Describe("Channel Indexed", func() {
 				var (
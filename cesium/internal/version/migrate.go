@@ -10,25 +10,30 @@
 package version
 
 import (
+	"os"
+	"strconv"
+
 	"github.com/synnaxlabs/x/errors"
 	xfs "github.com/synnaxlabs/x/io/fs"
-	"strconv"
 )
 
 var migrations = map[string]func(fs xfs.FS) error{
 	"01": migrate01,
+	"12": migrate12,
 }
 
+// Migrate migrates the channel files in fs from oldVersion to newVersion, applying
+// each intermediate migration in order.
 func Migrate(fs xfs.FS, oldVersion Version, newVersion Version) error {
-	migrate, ok := migrations[strconv.Itoa(int(oldVersion))+strconv.Itoa(int(newVersion))]
-	if !ok {
-		return errors.Newf("migration from version %d to version %d not found", oldVersion, newVersion)
-	}
-	err := migrate(fs)
-	if err != nil {
-		return errors.Wrap(err, "version migration error")
+	for v := oldVersion; v < newVersion; v++ {
+		migrate, ok := migrations[strconv.Itoa(int(v))+strconv.Itoa(int(v+1))]
+		if !ok {
+			return errors.Newf("migration from version %d to version %d not found", v, v+1)
+		}
+		if err := migrate(fs); err != nil {
+			return errors.Wrap(err, "version migration error")
+		}
 	}
-
 	return nil
 }
 
@@ -36,3 +41,105 @@ func Migrate(fs xfs.FS, oldVersion Version, newVersion Version) error {
 func migrate01(_ xfs.FS) error {
 	return nil
 }
+
+const (
+	indexFile = "index.domain"
+	// migratingIndexFile holds the migrated contents of the index file until they are
+	// fully written and synced, at which point it replaces the index file.
+	migratingIndexFile = "index.domain.migrating"
+	// backupIndexFile holds the index file as it was before migration. Its presence
+	// indicates that the migrated index file has replaced, or is about to replace, the
+	// original, so the index file must not be migrated again. It is removed by
+	// Finalize once the new version has been persisted.
+	backupIndexFile   = "index.domain.v1"
+	v1PointerByteSize = 26
+	v2PointerByteSize = 34
+)
+
+// Finalize removes the files kept by a migration to recover from a crash before the
+// new version of the channel is persisted. Finalize must only be called after the new
+// version has been persisted.
+func Finalize(fs xfs.FS) error {
+	return errors.Combine(fs.Remove(backupIndexFile), fs.Remove(migratingIndexFile))
+}
+
+// migrate12 extends each pointer in the domain index file with the offset and length
+// of its samples within the decompressed contents of the pointer. Version 1 files are
+// never compressed, so the offset is zero and the length is the stored length of the
+// pointer.
+//
+// The migrated index is written to a separate file and synced before it replaces the
+// original, so that a crash at any point leaves either the original index file or a
+// complete migrated one.
+func migrate12(fs xfs.FS) error {
+	migrated, err := fs.Exists(backupIndexFile)
+	if err != nil {
+		return err
+	}
+	if migrated {
+		// A previous migration was interrupted after the migrated index file was
+		// synced, so it only needs to be moved into place.
+		exists, err := fs.Exists(indexFile)
+		if err != nil || exists {
+			return err
+		}
+		return fs.Rename(migratingIndexFile, indexFile)
+	}
+	exists, err := fs.Exists(indexFile)
+	if err != nil || !exists {
+		return err
+	}
+	b, err := migrateIndex12(fs)
+	if err != nil {
+		return err
+	}
+	if err = writeSynced(fs, migratingIndexFile, b); err != nil {
+		return err
+	}
+	if err = fs.Rename(indexFile, backupIndexFile); err != nil {
+		return err
+	}
+	return fs.Rename(migratingIndexFile, indexFile)
+}
+
+// migrateIndex12 returns the contents of the version 1 index file in fs migrated to
+// version 2.
+func migrateIndex12(fs xfs.FS) (b []byte, err error) {
+	f, err := fs.Open(indexFile, os.O_RDONLY)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Combine(err, f.Close()) }()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	count := int(info.Size()) / v1PointerByteSize
+	old := make([]byte, count*v1PointerByteSize)
+	b = make([]byte, count*v2PointerByteSize)
+	if _, err = f.ReadAt(old, 0); err != nil {
+		return nil, err
+	}
+	for i := 0; i < count; i++ {
+		src := old[i*v1PointerByteSize : (i+1)*v1PointerByteSize]
+		dst := b[i*v2PointerByteSize : (i+1)*v2PointerByteSize]
+		copy(dst, src)
+		// The raw offset is zero, and the raw length is equal to the stored length.
+		copy(dst[v1PointerByteSize+4:], src[v1PointerByteSize-4:])
+	}
+	return b, nil
+}
+
+// writeSynced writes b to a new file with the given name, syncing it to disk before
+// returning.
+func writeSynced(fs xfs.FS, name string, b []byte) (err error) {
+	f, err := fs.Open(name, os.O_CREATE|os.O_TRUNC|os.O_WRONLY)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Combine(err, f.Close()) }()
+	if _, err = f.Write(b); err != nil {
+		return err
+	}
+	return f.Sync()
+}
@@ -15,8 +15,10 @@ import (
 	"github.com/synnaxlabs/cesium"
 	"github.com/synnaxlabs/cesium/internal/testdata"
 	"github.com/synnaxlabs/cesium/internal/testutil"
+	"github.com/synnaxlabs/cesium/internal/version"
 	"github.com/synnaxlabs/x/binary"
 	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
 	. "github.com/synnaxlabs/x/testutil"
 	"os"
 	"strconv"
@@ -45,7 +47,7 @@ var _ = Describe("Migration Test", func() {
 				By("Asserting that the version got migrated, the meta file got changed, and the format is correct")
 				for _, ch := range testdata.Channels {
 					chInDB := MustSucceed(db.RetrieveChannel(ctx, ch.Key))
-					Expect(chInDB.Version).To(Equal(uint8(1)))
+					Expect(chInDB.Version).To(Equal(version.Current))
 
 					var (
 						channelFS = MustSucceed(fs.Sub(strconv.Itoa(int(ch.Key))))
@@ -65,8 +67,69 @@ var _ = Describe("Migration Test", func() {
 
 				}
 
+				By("Asserting that the migrated data can still be read")
+				f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, testdata.Channels[0].Key, testdata.Channels[1].Key))
+				var stamps, values []byte
+				for _, s := range f.Get(testdata.Channels[0].Key) {
+					stamps = append(stamps, s.Data...)
+				}
+				for _, s := range f.Get(testdata.Channels[1].Key) {
+					values = append(values, s.Data...)
+				}
+				Expect(stamps).To(Equal(telem.NewSecondsTSV(0, 1, 2, 3, 5, 6, 7, 9, 10, 13, 17, 18, 19).Data))
+				Expect(values).To(Equal(telem.NewSeriesV[uint8](10, 11, 12, 13, 15, 16, 17, 19, 100, 103, 107, 108, 109).Data))
+
+				Expect(db.Close()).To(Succeed())
+			})
+
+			It("Should remove the original index file left by a migration interrupted after the new version was persisted", func() {
+				sourceFS := MustSucceed(xfs.Default.Sub("../testdata/v1/db-data"))
+				Expect(testutil.CopyFS(sourceFS, fs)).To(Succeed())
+				db = MustSucceed(cesium.Open("", cesium.WithFS(fs), cesium.WithInstrumentation(PanicLogger())))
+				Expect(db.Close()).To(Succeed())
+				channelFS := MustSucceed(fs.Sub(strconv.Itoa(int(testdata.Channels[0].Key))))
+				f := MustSucceed(channelFS.Open("index.domain.v1", os.O_CREATE|os.O_WRONLY))
+				MustSucceed(f.Write([]byte{1, 2, 3}))
+				Expect(f.Close()).To(Succeed())
+
+				By("Reopening the database")
+				db = MustSucceed(cesium.Open("", cesium.WithFS(fs), cesium.WithInstrumentation(PanicLogger())))
+				Expect(channelFS.Exists("index.domain.v1")).To(BeFalse())
+				fr := MustSucceed(db.Read(ctx, telem.TimeRangeMax, testdata.Channels[0].Key))
+				Expect(fr.Get(testdata.Channels[0].Key)).ToNot(BeEmpty())
 				Expect(db.Close()).To(Succeed())
 			})
+
+			DescribeTable("Interrupted v1 to v2 migration", func(interrupt func(channelFS xfs.FS)) {
+				sourceFS := MustSucceed(xfs.Default.Sub("../testdata/v1/db-data"))
+				Expect(testutil.CopyFS(sourceFS, fs)).To(Succeed())
+				By("Migrating the index file without persisting the new version")
+				channelFS := MustSucceed(fs.Sub(strconv.Itoa(int(testdata.Channels[0].Key))))
+				Expect(version.Migrate(channelFS, 1, 2)).To(Succeed())
+				interrupt(channelFS)
+
+				By("Opening the database and migrating again")
+				db = MustSucceed(cesium.Open("", cesium.WithFS(fs), cesium.WithInstrumentation(PanicLogger())))
+				f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, testdata.Channels[0].Key))
+				var stamps []byte
+				for _, s := range f.Get(testdata.Channels[0].Key) {
+					stamps = append(stamps, s.Data...)
+				}
+				Expect(stamps).To(Equal(telem.NewSecondsTSV(0, 1, 2, 3, 5, 6, 7, 9, 10, 13, 17, 18, 19).Data))
+				Expect(channelFS.Exists("index.domain.v1")).To(BeFalse())
+				Expect(db.Close()).To(Succeed())
+			},
+				Entry("after the migrated index file replaced the original", func(xfs.FS) {}),
+				Entry("before the migrated index file replaced the original", func(channelFS xfs.FS) {
+					Expect(channelFS.Rename("index.domain", "index.domain.migrating")).To(Succeed())
+				}),
+				Entry("while writing the migrated index file", func(channelFS xfs.FS) {
+					Expect(channelFS.Rename("index.domain.v1", "index.domain")).To(Succeed())
+					f := MustSucceed(channelFS.Open("index.domain.migrating", os.O_CREATE|os.O_WRONLY))
+					MustSucceed(f.Write([]byte{1, 2, 3}))
+					Expect(f.Close()).To(Succeed())
+				}),
+			)
 		})
 	}
 })
@@ -11,4 +11,4 @@ package version
 
 type Version = uint8
 
-const Current Version = 1
+const Current Version = 2
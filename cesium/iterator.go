@@ -22,6 +22,20 @@ import (
 
 const AutoSpan = unary.AutoSpan
 
+type (
+	Aggregation   = core.Aggregation
+	AggregateFunc = core.AggregateFunc
+)
+
+const (
+	AggregateMin   = core.AggregateMin
+	AggregateMax   = core.AggregateMax
+	AggregateMean  = core.AggregateMean
+	AggregateFirst = core.AggregateFirst
+	AggregateLast  = core.AggregateLast
+	AggregateCount = core.AggregateCount
+)
+
 var errIteratorClosed = core.EntityClosed("cesium.iterator")
 
 type Iterator struct {
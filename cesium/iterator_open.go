@@ -52,7 +52,14 @@ func (db *DB) newStreamIterator(cfg IteratorConfig) (*streamIterator, error) {
 			}
 			return nil, core.NewErrChannelNotFound(key)
 		}
-		internal[i] = uDB.OpenIterator(unary.IteratorConfig{Bounds: cfg.Bounds, AutoChunkSize: cfg.AutoChunkSize})
+		if err := cfg.Aggregation.Validate(uDB.Channel()); err != nil {
+			return nil, err
+		}
+		internal[i] = uDB.OpenIterator(unary.IteratorConfig{
+			Bounds:        cfg.Bounds,
+			AutoChunkSize: cfg.AutoChunkSize,
+			Aggregation:   cfg.Aggregation,
+		})
 	}
 
 	return &streamIterator{internal: internal, openSignal: cfg.OpenSignal}, nil
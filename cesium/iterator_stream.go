@@ -134,6 +134,13 @@ type IteratorConfig struct {
 	// OpenSignal is a channel that will be closed once the iterator is successfully
 	// opened.
 	OpenSignal chan struct{}
+	// Aggregation, if set, makes the iterator return a single aggregate value for each
+	// bucket of Aggregation.Span instead of the raw samples in the bucket, so that
+	// large time ranges can be read at reduced resolution. Each series in a returned
+	// frame holds the values of a contiguous run of buckets containing data: the i-th
+	// value of a series is the aggregate of the i-th bucket intersecting the series'
+	// time range. When using AutoSpan, the iterator moves by AutoChunkSize buckets.
+	Aggregation Aggregation
 }
 
 // Flow implements the confluence.Segment interface.
@@ -251,6 +251,9 @@ var _ = Describe("Iterator Behavior", func() {
 					f = i.Value()
 					Expect(f.Series).To(HaveLen(1))
 					Expect(f.Get(data1Key)[0].Data).To(EqualUnmarshal([]uint16{25}))
+
+					Expect(i.Next(cesium.AutoSpan)).To(BeFalse())
+					Expect(i.Error()).ToNot(HaveOccurred())
 					Expect(i.Close()).To(Succeed())
 				})
 			})
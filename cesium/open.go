@@ -29,6 +29,9 @@ import (
 // execution.
 func Open(dirname string, opts ...Option) (*DB, error) {
 	o := newOptions(dirname, opts...)
+	if o.coldCfg != nil && o.coldCfg.FS == nil {
+		return nil, errors.Wrap(validate.Error, "cold storage file system must be set")
+	}
 	if err := openFS(o); err != nil {
 		return nil, err
 	}
@@ -40,6 +43,12 @@ func Open(dirname string, opts ...Option) (*DB, error) {
 		return nil, err
 	}
 	db := &DB{options: o, closed: &atomic.Bool{}}
+	if err = db.recoverConversions(info); err != nil {
+		return nil, err
+	}
+	if info, err = o.fs.List(""); err != nil {
+		return nil, err
+	}
 	db.mu.unaryDBs = make(map[core.ChannelKey]unary.DB, len(info))
 	db.mu.virtualDBs = make(map[core.ChannelKey]virtual.DB, len(info))
 	for _, i := range info {
@@ -95,14 +104,11 @@ func (db *DB) openUnary(ch Channel, fs xfs.FS) error {
 	if isOpen {
 		return nil
 	}
-	u, err := unary.Open(unary.Config{
-		FS:              fs,
-		MetaCodec:       db.metaCodec,
-		Channel:         ch,
-		Instrumentation: db.options.Instrumentation,
-		FileSize:        db.options.fileSize,
-		GCThreshold:     db.options.gcCfg.GCThreshold,
-	})
+	cfg, err := db.unaryConfig(ch, fs)
+	if err != nil {
+		return err
+	}
+	u, err := unary.Open(cfg)
 	if err != nil {
 		return err
 	}
@@ -127,6 +133,28 @@ func (db *DB) openUnary(ch Channel, fs xfs.FS) error {
 	return nil
 }
 
+// unaryConfig returns the configuration for opening a unary database for the given
+// channel on the given file system.
+func (db *DB) unaryConfig(ch Channel, fs xfs.FS) (unary.Config, error) {
+	cfg := unary.Config{
+		FS:              fs,
+		MetaCodec:       db.metaCodec,
+		Channel:         ch,
+		Instrumentation: db.options.Instrumentation,
+		FileSize:        db.options.fileSize,
+		GCThreshold:     db.options.gcCfg.GCThreshold,
+	}
+	if db.coldCfg != nil {
+		coldFS, err := db.coldCfg.FS.Sub(keyToDirName(ch.Key))
+		if err != nil {
+			return cfg, err
+		}
+		cfg.ColdFS = coldFS
+		cfg.ColdCacheSize = db.coldCfg.CacheSize
+	}
+	return cfg, nil
+}
+
 func (db *DB) openVirtualOrUnary(ch Channel) error {
 	fs, err := db.fs.Sub(strconv.Itoa(int(ch.Key)))
 	if err != nil {
@@ -25,6 +25,7 @@ type options struct {
 	fs        xfs.FS
 	metaCodec binary.Codec
 	gcCfg     *GCConfig
+	coldCfg   *ColdStorageConfig
 	fileSize  telem.Size
 }
 
@@ -62,6 +63,32 @@ func WithGC(config *GCConfig) Option {
 	}
 }
 
+// ColdStorageConfig configures the relocation of old domain files to a secondary file
+// system, such as a mounted object store.
+type ColdStorageConfig struct {
+	// FS is the file system that full domain files are relocated to. Each channel
+	// stores its files in a subdirectory named after its key.
+	// [REQUIRED]
+	FS xfs.FS
+	// After is the minimum age of the newest data in a full domain file before the
+	// file is relocated.
+	// [OPTIONAL] Default: 0
+	After telem.TimeSpan
+	// CacheSize is the maximum number of relocated files that each channel keeps
+	// cached locally after they are read.
+	// [OPTIONAL] Default: 5
+	CacheSize int
+}
+
+// WithColdStorage relocates full domain files to a secondary file system during garbage
+// collection, as configured by cfg. Relocated files are fetched on demand when read.
+// [OPTIONAL] Default: files are never relocated
+func WithColdStorage(cfg ColdStorageConfig) Option {
+	return func(o *options) {
+		o.coldCfg = &cfg
+	}
+}
+
 func WithInstrumentation(i alamos.Instrumentation) Option {
 	return func(o *options) {
 		o.Instrumentation = i
@@ -0,0 +1,90 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium
+
+import (
+	"context"
+
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Overwrite replaces the samples of the channel with the given key in the time range tr
+// with the samples in series. Unlike deleting the time range and writing it again,
+// Overwrite replaces the samples in a single step, so readers never observe the time
+// range without data.
+//
+// The series must contain exactly as many samples as the channel has in tr, and tr must
+// overlap with exactly one of the channel's domains. Overwrite returns an error if any
+// part of tr is controlled by a writer. Overwriting the timestamps of an index channel
+// is only allowed if the new timestamps are strictly increasing, stay within tr, and
+// remain within the domains of every channel indexed by it.
+func (db *DB) Overwrite(
+	ctx context.Context,
+	key ChannelKey,
+	tr telem.TimeRange,
+	series telem.Series,
+) error {
+	if db.closed.Load() {
+		return errDBClosed
+	}
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	udb, ok := db.mu.unaryDBs[key]
+	if !ok {
+		if vdb, vok := db.mu.virtualDBs[key]; vok {
+			return errors.Wrapf(
+				validate.Error,
+				"cannot overwrite time range of virtual channel %v",
+				vdb.Channel(),
+			)
+		}
+		return core.NewErrChannelNotFound(key)
+	}
+	if udb.Channel().IsIndex && series.Len() > 0 {
+		if err := db.validateIndexOverwrite(udb.Channel(), tr, series); err != nil {
+			return err
+		}
+	}
+	return udb.Overwrite(ctx, tr, series)
+}
+
+// validateIndexOverwrite checks that every sample indexed by the given index channel
+// remains within its domain after the index is overwritten with series.
+func (db *DB) validateIndexOverwrite(
+	idx Channel,
+	tr telem.TimeRange,
+	series telem.Series,
+) error {
+	stamps := telem.UnmarshalSlice[telem.TimeStamp](series.Data, series.DataType)
+	bounds := stamps[0].Range(stamps[len(stamps)-1] + 1)
+	for otherKey, otherDB := range db.mu.unaryDBs {
+		if otherKey == idx.Key || otherDB.Channel().Index != idx.Key {
+			continue
+		}
+		for _, domain := range otherDB.Domains(tr) {
+			if !domain.ContainsRange(bounds) {
+				return errors.Wrapf(
+					validate.Error,
+					"cannot overwrite index channel %v on the time range %s: "+
+						"timestamps in %s would move samples of channel %v outside of %s",
+					idx,
+					tr,
+					bounds,
+					otherDB.Channel(),
+					domain,
+				)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	"github.com/synnaxlabs/x/control"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Overwrite", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *cesium.DB
+				fs      xfs.FS
+				cleanUp func() error
+				index   cesium.ChannelKey
+				data    cesium.ChannelKey
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				db = openDBOnFS(fs)
+				index = testutil.GenerateChannelKey()
+				data = testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: data, Index: index, DataType: telem.Int64T},
+				)).To(Succeed())
+				Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{telem.NewSecondsTSV(10, 11, 12, 13), telem.NewSeriesV[int64](10, 11, 12, 13)},
+				))).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			read := func(key cesium.ChannelKey) []int64 {
+				var values []int64
+				for _, s := range MustSucceed(db.Read(ctx, telem.TimeRangeMax, key)).Get(key) {
+					values = append(values, telem.UnmarshalSlice[int64](s.Data, s.DataType)...)
+				}
+				return values
+			}
+
+			It("Should replace the samples of a data channel", func() {
+				Expect(db.Overwrite(ctx, data, (11 * telem.SecondTS).Range(13*telem.SecondTS), telem.NewSeriesV[int64](1, 2))).To(Succeed())
+				Expect(read(data)).To(Equal([]int64{10, 1, 2, 13}))
+			})
+
+			It("Should replace the timestamps of an index channel", func() {
+				Expect(db.Overwrite(ctx, index, (11 * telem.SecondTS).Range(13*telem.SecondTS), telem.NewSeriesV(
+					11*telem.SecondTS+500*telem.MillisecondTS,
+					12*telem.SecondTS+500*telem.MillisecondTS,
+				))).To(Succeed())
+				fr := MustSucceed(db.Read(ctx, (12 * telem.SecondTS).Range(14*telem.SecondTS), data))
+				Expect(fr.Get(data)).To(HaveLen(1))
+				Expect(telem.UnmarshalSlice[int64](fr.Get(data)[0].Data, telem.Int64T)).To(Equal([]int64{12, 13}))
+			})
+
+			It("Should not move the samples of a dependent channel outside of its domain", func() {
+				other := testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(ctx, cesium.Channel{Key: other, Index: index, DataType: telem.Int64T})).To(Succeed())
+				Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{other},
+					[]telem.Series{telem.NewSeriesV[int64](1, 2)},
+				))).To(Succeed())
+				Expect(db.Overwrite(ctx, index, (11 * telem.SecondTS).Range(13*telem.SecondTS), telem.NewSeriesV(
+					11*telem.SecondTS+500*telem.MillisecondTS,
+					12*telem.SecondTS+500*telem.MillisecondTS,
+				))).To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not overwrite with a series of a different length", func() {
+				Expect(db.Overwrite(ctx, data, (11 * telem.SecondTS).Range(13*telem.SecondTS), telem.NewSeriesV[int64](1, 2, 3))).
+					To(HaveOccurredAs(validate.Error))
+				Expect(read(data)).To(Equal([]int64{10, 11, 12, 13}))
+			})
+
+			It("Should not overwrite a time range controlled by a writer", func() {
+				w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{
+					Start:    20 * telem.SecondTS,
+					Channels: []cesium.ChannelKey{index, data},
+				}))
+				Expect(db.Overwrite(ctx, data, (11 * telem.SecondTS).Range(30*telem.SecondTS), telem.NewSeriesV[int64](1, 2, 3))).
+					To(HaveOccurredAs(control.Unauthorized))
+				Expect(w.Close()).To(Succeed())
+			})
+
+			It("Should not overwrite a virtual channel", func() {
+				virtual := testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(ctx, cesium.Channel{Key: virtual, Virtual: true, DataType: telem.Int64T})).To(Succeed())
+				Expect(db.Overwrite(ctx, virtual, telem.TimeRangeMax, telem.NewSeriesV[int64](1))).
+					To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not overwrite a channel that does not exist", func() {
+				Expect(db.Overwrite(ctx, testutil.GenerateChannelKey(), telem.TimeRangeMax, telem.NewSeriesV[int64](1))).
+					To(HaveOccurredAs(cesium.ErrChannelNotFound))
+			})
+		})
+	}
+})
@@ -0,0 +1,220 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	"math"
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Retention", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, Ordered, func() {
+			var (
+				db      *cesium.DB
+				fs      xfs.FS
+				cleanUp func() error
+			)
+			openDB := func() *cesium.DB {
+				return MustSucceed(cesium.Open("",
+					cesium.WithGC(&cesium.GCConfig{
+						MaxGoroutine:  10,
+						GCTryInterval: 10 * telem.Millisecond.Duration(),
+						GCThreshold:   math.SmallestNonzeroFloat32,
+					}),
+					cesium.WithFS(fs),
+					cesium.WithFileSize(40*telem.ByteSize),
+					cesium.WithInstrumentation(PanicLogger())))
+			}
+			BeforeAll(func() {
+				fs, cleanUp = makeFS()
+				db = openDB()
+			})
+			AfterAll(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			Describe("Validation", func() {
+				It("Should not allow a negative max age", func() {
+					Expect(db.CreateChannel(ctx, cesium.Channel{
+						Key:       testutil.GenerateChannelKey(),
+						DataType:  telem.Int64T,
+						Rate:      1 * telem.Hz,
+						Retention: cesium.RetentionPolicy{MaxAge: -telem.Second},
+					})).To(HaveOccurredAs(validate.Error))
+				})
+				It("Should not allow a retention policy on a virtual channel", func() {
+					Expect(db.CreateChannel(ctx, cesium.Channel{
+						Key:       testutil.GenerateChannelKey(),
+						DataType:  telem.Int64T,
+						Virtual:   true,
+						Retention: cesium.RetentionPolicy{MaxSize: telem.Kilobyte},
+					})).To(HaveOccurredAs(validate.Error))
+				})
+			})
+
+			It("Should expire data older than the max age on an index and its dependents", func() {
+				var (
+					index  = testutil.GenerateChannelKey()
+					data   = testutil.GenerateChannelKey()
+					keys   = []cesium.ChannelKey{index, data}
+					policy = cesium.RetentionPolicy{MaxAge: telem.Hour}
+					now    = telem.Now()
+				)
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT, Retention: policy},
+					cesium.Channel{Key: data, Index: index, DataType: telem.Int64T, Retention: policy},
+				)).To(Succeed())
+				Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(keys, []telem.Series{
+					telem.NewSecondsTSV(10, 11, 12),
+					telem.NewSeriesV[int64](1, 2, 3),
+				}))).To(Succeed())
+				Expect(db.Write(ctx, now, cesium.NewFrame(keys, []telem.Series{
+					telem.NewSeriesV[telem.TimeStamp](now, now+1),
+					telem.NewSeriesV[int64](4, 5),
+				}))).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, keys...))
+					g.Expect(f.Get(index)).To(HaveLen(1))
+					g.Expect(f.Get(data)).To(HaveLen(1))
+					g.Expect(f.Get(data)[0].Data).To(Equal(telem.NewSeriesV[int64](4, 5).Data))
+				}).Should(Succeed())
+
+				m := db.RetentionMetrics()
+				Expect(m.Channels[data]).To(Equal(cesium.RetentionStats{Domains: 1, Samples: 3, Size: 24}))
+				Expect(m.Channels[index]).To(Equal(cesium.RetentionStats{Domains: 1, Samples: 3, Size: 24}))
+			})
+
+			It("Should count the expired samples of a variable length channel", func() {
+				var (
+					index  = testutil.GenerateChannelKey()
+					data   = testutil.GenerateChannelKey()
+					keys   = []cesium.ChannelKey{index, data}
+					policy = cesium.RetentionPolicy{MaxAge: telem.Hour}
+					now    = telem.Now()
+				)
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT, Retention: policy},
+					cesium.Channel{Key: data, Index: index, DataType: telem.StringT, Retention: policy},
+				)).To(Succeed())
+				Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(keys, []telem.Series{
+					telem.NewSecondsTSV(10, 11, 12),
+					telem.NewStringsV("a", "bb", "ccc"),
+				}))).To(Succeed())
+				Expect(db.Write(ctx, now, cesium.NewFrame(keys, []telem.Series{
+					telem.NewSeriesV[telem.TimeStamp](now),
+					telem.NewStringsV("dddd"),
+				}))).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, keys...))
+					g.Expect(f.Get(data)).To(HaveLen(1))
+					g.Expect(f.Get(data)[0].Data).To(Equal(telem.NewStringsV("dddd").Data))
+				}).Should(Succeed())
+				m := db.RetentionMetrics().Channels[data]
+				Expect(m.Domains).To(Equal(1))
+				Expect(m.Samples).To(Equal(int64(3)))
+			})
+
+			It("Should not expire index data that a dependent channel still holds", func() {
+				var (
+					index = testutil.GenerateChannelKey()
+					data  = testutil.GenerateChannelKey()
+					keys  = []cesium.ChannelKey{index, data}
+				)
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT, Retention: cesium.RetentionPolicy{MaxAge: telem.Hour}},
+					cesium.Channel{Key: data, Index: index, DataType: telem.Int64T},
+				)).To(Succeed())
+				Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(keys, []telem.Series{
+					telem.NewSecondsTSV(10, 11, 12),
+					telem.NewSeriesV[int64](1, 2, 3),
+				}))).To(Succeed())
+
+				Consistently(func(g Gomega) {
+					f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, keys...))
+					g.Expect(f.Get(index)).To(HaveLen(1))
+					g.Expect(f.Get(data)).To(HaveLen(1))
+				}, 100*telem.Millisecond.Duration()).Should(Succeed())
+				Expect(db.RetentionMetrics().Channels).ToNot(HaveKey(index))
+			})
+
+			It("Should expire the oldest domains to stay within the max size and reclaim their files", func() {
+				key := testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(ctx, cesium.Channel{
+					Key:       key,
+					DataType:  telem.Int64T,
+					Rate:      1 * telem.Hz,
+					Retention: cesium.RetentionPolicy{MaxSize: 48 * telem.ByteSize},
+				})).To(Succeed())
+				for i := 1; i <= 3; i++ {
+					Expect(db.WriteArray(
+						ctx,
+						key,
+						telem.TimeStamp(10*i)*telem.SecondTS,
+						telem.NewSeriesV[int64](int64(i), int64(i), int64(i), int64(i), int64(i)),
+					)).To(Succeed())
+				}
+
+				Eventually(func(g Gomega) {
+					f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, key))
+					g.Expect(f.Get(key)).To(HaveLen(1))
+					g.Expect(f.Get(key)[0].Data).To(Equal(telem.NewSeriesV[int64](3, 3, 3, 3, 3).Data))
+				}).Should(Succeed())
+				Expect(db.RetentionMetrics().Channels[key]).To(Equal(cesium.RetentionStats{Domains: 2, Samples: 10, Size: 80}))
+
+				By("Reclaiming the space used by the expired domains")
+				Eventually(func() int64 {
+					return MustSucceed(fs.Stat(path.Join(channelKeyToPath(key), "1.domain"))).Size()
+				}).Should(BeZero())
+			})
+
+			It("Should set and persist the retention policy of an existing channel", func() {
+				key := testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(ctx, cesium.Channel{Key: key, DataType: telem.Int64T, Rate: 1 * telem.Hz})).To(Succeed())
+				Expect(db.WriteArray(ctx, key, 10*telem.SecondTS, telem.NewSeriesV[int64](1, 2, 3))).To(Succeed())
+				Expect(db.SetChannelRetention(ctx, key, cesium.RetentionPolicy{MaxAge: telem.Hour})).To(Succeed())
+				Eventually(func(g Gomega) {
+					f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, key))
+					g.Expect(f.Get(key)).To(BeEmpty())
+				}).Should(Succeed())
+
+				Expect(db.Close()).To(Succeed())
+				db = openDB()
+				ch := MustSucceed(db.RetrieveChannel(ctx, key))
+				Expect(ch.Retention).To(Equal(cesium.RetentionPolicy{MaxAge: telem.Hour}))
+			})
+
+			It("Should return an error when setting the retention policy of a virtual channel", func() {
+				key := testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(ctx, cesium.Channel{Key: key, DataType: telem.Int64T, Virtual: true})).To(Succeed())
+				Expect(db.SetChannelRetention(ctx, key, cesium.RetentionPolicy{MaxAge: telem.Hour})).To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should return an error when setting the retention policy of a channel that does not exist", func() {
+				Expect(db.SetChannelRetention(ctx, testutil.GenerateChannelKey(), cesium.RetentionPolicy{MaxAge: telem.Hour})).To(HaveOccurredAs(cesium.ErrChannelNotFound))
+			})
+		})
+	}
+})
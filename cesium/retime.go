@@ -0,0 +1,159 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium
+
+import (
+	"context"
+
+	"github.com/synnaxlabs/cesium/internal/core"
+	"github.com/synnaxlabs/cesium/internal/unary"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+	"go.uber.org/zap"
+)
+
+// Retiming describes how the timestamps in a time range are moved by RetimeIndex. A
+// timestamp ts in the time range tr is moved to
+//
+//	tr.Start + (ts - tr.Start) * Scale + Offset
+//
+// so the start of the time range is shifted by Offset, and the distance of every
+// other timestamp from it is multiplied by Scale.
+type Retiming struct {
+	// Offset is the span every timestamp is shifted by.
+	Offset telem.TimeSpan
+	// Scale is the factor the distance between timestamps is multiplied by. Scale must
+	// not be negative. A zero Scale is treated as 1.
+	Scale float64
+}
+
+func (r Retiming) validate() error {
+	if r.Scale < 0 {
+		return errors.Wrapf(validate.Error, "retiming scale cannot be negative, got %v", r.Scale)
+	}
+	return nil
+}
+
+// apply returns a function that retimes timestamps in the time range tr.
+func (r Retiming) apply(tr telem.TimeRange) func(telem.TimeStamp) telem.TimeStamp {
+	scale := r.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	return func(ts telem.TimeStamp) telem.TimeStamp {
+		return tr.Start + telem.TimeStamp(float64(ts-tr.Start)*scale) + telem.TimeStamp(r.Offset)
+	}
+}
+
+// RetimeIndex moves the timestamps of the index channel with the given key in the time
+// range tr as described by retiming. The domains of the index and of every channel
+// indexed by it are moved along with the timestamps, so samples remain aligned with
+// their timestamps after retiming.
+//
+// Every domain of the index and its channels that overlaps tr must be entirely
+// contained in tr, and no retimed domain may overlap with a domain outside of tr.
+// Retimed timestamps must remain strictly increasing. RetimeIndex validates and
+// acquires control over every channel before moving any data, and returns an error if
+// any part of the affected time ranges is controlled by a writer. If retiming any of
+// the channels fails, the channels that were already retimed are moved back.
+func (db *DB) RetimeIndex(
+	ctx context.Context,
+	key ChannelKey,
+	tr telem.TimeRange,
+	retiming Retiming,
+) (err error) {
+	if db.closed.Load() {
+		return errDBClosed
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	defer func() {
+		if err != nil {
+			db.L.Error(
+				"failed to retime index",
+				zap.Uint32("key", key),
+				zap.Stringer("time_range", tr),
+				zap.Error(err),
+			)
+		}
+	}()
+	return db.retimeIndex(ctx, key, tr, retiming)
+}
+
+func (db *DB) retimeIndex(
+	ctx context.Context,
+	key ChannelKey,
+	tr telem.TimeRange,
+	retiming Retiming,
+) error {
+	if err := retiming.validate(); err != nil {
+		return err
+	}
+	if !tr.Valid() {
+		return errors.Wrapf(
+			validate.Error,
+			"retime start %s cannot be after retime end %s",
+			tr.Start,
+			tr.End,
+		)
+	}
+	if _, ok := db.mu.virtualDBs[key]; ok {
+		return errors.Wrapf(validate.Error, "cannot retime virtual channel <%d>", key)
+	}
+	idx, ok := db.mu.unaryDBs[key]
+	if !ok {
+		return core.NewErrChannelNotFound(key)
+	}
+	if !idx.Channel().IsIndex {
+		return errors.Wrapf(
+			validate.Error,
+			"cannot retime channel %v because it is not an index channel",
+			idx.Channel(),
+		)
+	}
+	var (
+		retime     = retiming.apply(tr)
+		dependents = make([]unary.DB, 0, len(db.mu.unaryDBs))
+	)
+	for otherKey, otherDB := range db.mu.unaryDBs {
+		if otherKey != key && otherDB.Channel().Index == key {
+			dependents = append(dependents, otherDB)
+		}
+	}
+	// Acquire control over the affected time ranges of the index and all of its
+	// dependents before moving any data, so that none of them can be written to until
+	// they have all been retimed.
+	retimers := make([]*unary.Retimer, 0, len(dependents)+1)
+	defer func() {
+		for _, r := range retimers {
+			r.Close()
+		}
+	}()
+	for _, udb := range append([]unary.DB{idx}, dependents...) {
+		r, err := udb.OpenRetimer(tr, retime)
+		if err != nil {
+			return err
+		}
+		retimers = append(retimers, r)
+	}
+	// Retime the index first, as it is the only channel whose data is validated while
+	// retiming. If any channel fails to be retimed, every channel is moved back to its
+	// original time range.
+	for i, r := range retimers {
+		if err := r.Retime(ctx); err != nil {
+			for _, done := range retimers[:i+1] {
+				err = errors.Combine(err, done.Revert(ctx))
+			}
+			return err
+		}
+	}
+	return nil
+}
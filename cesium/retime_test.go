@@ -0,0 +1,169 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Retime", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *cesium.DB
+				fs      xfs.FS
+				cleanUp func() error
+				index   cesium.ChannelKey
+				data    cesium.ChannelKey
+				first   = (10 * telem.SecondTS).Range(12*telem.SecondTS + 1)
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				db = openDBOnFS(fs)
+				index = testutil.GenerateChannelKey()
+				data = testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: data, Index: index, DataType: telem.Int64T},
+				)).To(Succeed())
+				Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{telem.NewSecondsTSV(10, 11, 12), telem.NewSeriesV[int64](1, 2, 3)},
+				))).To(Succeed())
+				Expect(db.Write(ctx, 20*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, data},
+					[]telem.Series{telem.NewSecondsTSV(20, 21), telem.NewSeriesV[int64](4, 5)},
+				))).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			readStamps := func() []telem.TimeStamp {
+				var stamps []telem.TimeStamp
+				for _, s := range MustSucceed(db.Read(ctx, telem.TimeRangeMax, index)).Get(index) {
+					stamps = append(stamps, telem.UnmarshalSlice[telem.TimeStamp](s.Data, s.DataType)...)
+				}
+				return stamps
+			}
+
+			readData := func(tr telem.TimeRange) []int64 {
+				var values []int64
+				for _, s := range MustSucceed(db.Read(ctx, tr, data)).Get(data) {
+					values = append(values, telem.UnmarshalSlice[int64](s.Data, s.DataType)...)
+				}
+				return values
+			}
+
+			It("Should shift the timestamps of the index in the time range", func() {
+				Expect(db.RetimeIndex(ctx, index, first, cesium.Retiming{Offset: 5 * telem.Second})).To(Succeed())
+				Expect(readStamps()).To(Equal([]telem.TimeStamp{
+					15 * telem.SecondTS,
+					16 * telem.SecondTS,
+					17 * telem.SecondTS,
+					20 * telem.SecondTS,
+					21 * telem.SecondTS,
+				}))
+			})
+
+			It("Should rescale the timestamps of the index in the time range", func() {
+				Expect(db.RetimeIndex(ctx, index, first, cesium.Retiming{Scale: 2})).To(Succeed())
+				Expect(readStamps()[:3]).To(Equal([]telem.TimeStamp{
+					10 * telem.SecondTS,
+					12 * telem.SecondTS,
+					14 * telem.SecondTS,
+				}))
+			})
+
+			It("Should keep the channels indexed by the index aligned with it", func() {
+				Expect(db.RetimeIndex(ctx, index, first, cesium.Retiming{Offset: 5 * telem.Second})).To(Succeed())
+				Expect(readData((16 * telem.SecondTS).Range(21 * telem.SecondTS))).To(Equal([]int64{2, 3, 4}))
+				Expect(readData((10 * telem.SecondTS).Range(15 * telem.SecondTS))).To(BeEmpty())
+			})
+
+			It("Should persist the retimed index across reopening the database", func() {
+				Expect(db.RetimeIndex(ctx, index, first, cesium.Retiming{Offset: -5 * telem.Second})).To(Succeed())
+				Expect(db.Close()).To(Succeed())
+				db = openDBOnFS(fs)
+				Expect(readStamps()[0]).To(Equal(5 * telem.SecondTS))
+				Expect(readData((5 * telem.SecondTS).Range(6 * telem.SecondTS))).To(Equal([]int64{1}))
+			})
+
+			It("Should not retime the index to overlap a neighboring domain", func() {
+				Expect(db.RetimeIndex(ctx, index, first, cesium.Retiming{Offset: 9 * telem.Second})).
+					To(HaveOccurredAs(validate.Error))
+				Expect(readStamps()[0]).To(Equal(10 * telem.SecondTS))
+				Expect(readData(first)).To(Equal([]int64{1, 2, 3}))
+			})
+
+			It("Should not retime a time range that partially overlaps a domain", func() {
+				Expect(db.RetimeIndex(
+					ctx,
+					index,
+					(11 * telem.SecondTS).Range(15*telem.SecondTS),
+					cesium.Retiming{Offset: telem.Second},
+				)).To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not retime with a negative scale", func() {
+				Expect(db.RetimeIndex(ctx, index, first, cesium.Retiming{Scale: -1})).
+					To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should not retime a channel that is not an index", func() {
+				Expect(db.RetimeIndex(ctx, data, first, cesium.Retiming{Offset: telem.Second})).
+					To(HaveOccurredAs(validate.Error))
+			})
+
+			It("Should return an error if the channel does not exist", func() {
+				Expect(db.RetimeIndex(ctx, testutil.GenerateChannelKey(), first, cesium.Retiming{})).
+					To(HaveOccurredAs(cesium.ErrChannelNotFound))
+			})
+
+			It("Should not retime a time range that is being written to", func() {
+				w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{
+					Start:    13 * telem.SecondTS,
+					Channels: []cesium.ChannelKey{index, data},
+				}))
+				Expect(db.RetimeIndex(ctx, index, first, cesium.Retiming{Offset: 2 * telem.Second})).
+					To(HaveOccurred())
+				Expect(w.Close()).To(Succeed())
+				Expect(readStamps()[0]).To(Equal(10 * telem.SecondTS))
+			})
+
+			It("Should not retime the index when a channel indexed by it is being written to", func() {
+				w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{
+					Start:    13 * telem.SecondTS,
+					Channels: []cesium.ChannelKey{data},
+				}))
+				Expect(db.RetimeIndex(ctx, index, first, cesium.Retiming{Offset: 2 * telem.Second})).
+					To(HaveOccurred())
+				Expect(w.Close()).To(Succeed())
+				Expect(readStamps()).To(Equal([]telem.TimeStamp{
+					10 * telem.SecondTS,
+					11 * telem.SecondTS,
+					12 * telem.SecondTS,
+					20 * telem.SecondTS,
+					21 * telem.SecondTS,
+				}))
+				Expect(readData(first)).To(Equal([]int64{1, 2, 3}))
+			})
+		})
+	}
+})
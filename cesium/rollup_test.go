@@ -0,0 +1,106 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Rollup", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *cesium.DB
+				fs      xfs.FS
+				cleanUp func() error
+				index   cesium.ChannelKey
+				data    cesium.ChannelKey
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				db = openDBOnFS(fs)
+				index = testutil.GenerateChannelKey()
+				data = testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{
+						Key:      data,
+						Index:    index,
+						DataType: telem.Float64T,
+						Rollups:  []telem.TimeSpan{telem.Second, 10 * telem.Second},
+					},
+				)).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			It("Should update rollups as a writer commits", func() {
+				w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{
+					Start:    0,
+					Channels: []cesium.ChannelKey{index, data},
+				}))
+				for j := range 4 {
+					start := telem.TimeStamp(j) * 5 * telem.SecondTS
+					Expect(w.Write(cesium.NewFrame(
+						[]cesium.ChannelKey{index, data},
+						[]telem.Series{
+							telem.NewSecondsTSV(start/telem.SecondTS, start/telem.SecondTS+2, start/telem.SecondTS+4),
+							telem.NewSeriesV[float64](1, 2, 3),
+						},
+					))).To(BeTrue())
+					_, ok := w.Commit()
+					Expect(ok).To(BeTrue())
+				}
+				Expect(w.Close()).To(Succeed())
+
+				// The last commit ends at 19s, so only the buckets before it are complete.
+				for dir, n := range map[string]int64{"rollup-1000000000": 19, "rollup-10000000000": 1} {
+					tier := MustSucceed(fs.Open(path.Join(channelKeyToPath(data), dir, "1.domain"), 0))
+					Expect(MustSucceed(tier.Stat()).Size()).To(Equal(n * 48))
+					Expect(tier.Close()).To(Succeed())
+				}
+
+				i := MustSucceed(db.OpenIterator(cesium.IteratorConfig{
+					Bounds:      telem.TimeRangeMax,
+					Channels:    []cesium.ChannelKey{data},
+					Aggregation: cesium.Aggregation{Span: 10 * telem.Second, Func: cesium.AggregateMean},
+				}))
+				Expect(i.SeekFirst()).To(BeTrue())
+				Expect(i.Next(telem.TimeSpanMax)).To(BeTrue())
+				s := i.Value().Get(data)
+				Expect(s).To(HaveLen(1))
+				Expect(s[0].Data).To(Equal(telem.NewSeriesV[float64](2, 2).Data))
+				Expect(i.Close()).To(Succeed())
+			})
+
+			DescribeTable("Should not allow invalid rollups", func(ch cesium.Channel) {
+				ch.Key = testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(ctx, ch)).To(HaveOccurredAs(validate.Error))
+			},
+				Entry("Non-positive span", cesium.Channel{DataType: telem.Int64T, Rate: 1 * telem.Hz, Rollups: []telem.TimeSpan{0}}),
+				Entry("Duplicate span", cesium.Channel{DataType: telem.Int64T, Rate: 1 * telem.Hz, Rollups: []telem.TimeSpan{telem.Second, telem.Second}}),
+				Entry("Virtual", cesium.Channel{DataType: telem.Int64T, Virtual: true, Rollups: []telem.TimeSpan{telem.Second}}),
+				Entry("UUID", cesium.Channel{DataType: telem.UUIDT, Rate: 1 * telem.Hz, Rollups: []telem.TimeSpan{telem.Second}}),
+			)
+		})
+	}
+})
@@ -0,0 +1,161 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/synnaxlabs/cesium/internal/meta"
+	"github.com/synnaxlabs/cesium/internal/unary"
+	"github.com/synnaxlabs/x/errors"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/validate"
+	"go.uber.org/zap"
+)
+
+// restoreSuffix is appended to the directory names of channels while they are being
+// restored, so that a partially restored channel is never opened.
+const restoreSuffix = "-RESTORE"
+
+// Snapshot writes a consistent, point-in-time copy of the database to dst, which must
+// be empty. The copy contains all channels in the database, along with all data
+// committed at the time the snapshot is taken: the snapshot is aligned to a commit
+// boundary, so writes committed together on multiple channels are either all present
+// in the copy or all absent.
+//
+// Writers may continue to write and commit while the snapshot is being copied, although
+// commits are briefly paused while the snapshot is captured. Channels cannot be created,
+// deleted, or renamed, and files are not garbage collected or relocated to cold storage
+// while Snapshot is running.
+//
+// All files and directories in dst are synced to stable storage before Snapshot
+// returns. The resulting directory can be opened directly using Open, or copied into
+// the directory of a new database using Restore.
+func (db *DB) Snapshot(ctx context.Context, dst xfs.FS) (err error) {
+	if db.closed.Load() {
+		return errDBClosed
+	}
+	ctx, span := db.T.Bench(ctx, "snapshot")
+	defer func() { err = span.EndWith(err) }()
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	snapshots := make(map[ChannelKey]*unary.Snapshot, len(db.mu.unaryDBs))
+	defer func() {
+		for _, s := range snapshots {
+			err = errors.Combine(err, s.Close())
+		}
+	}()
+	for key, u := range db.mu.unaryDBs {
+		s, err := u.OpenSnapshot()
+		if err != nil {
+			return err
+		}
+		snapshots[key] = s
+	}
+
+	// Capture all snapshots while commits are paused so that they are aligned to the
+	// same commit boundary.
+	db.commitMu.Lock()
+	for _, s := range snapshots {
+		s.Capture()
+	}
+	db.commitMu.Unlock()
+
+	for key, s := range snapshots {
+		sub, err := dst.Sub(keyToDirName(key))
+		if err != nil {
+			return err
+		}
+		if err = s.WriteTo(ctx, sub); err != nil {
+			return err
+		}
+	}
+	for key, v := range db.mu.virtualDBs {
+		sub, err := dst.Sub(keyToDirName(key))
+		if err != nil {
+			return err
+		}
+		if err = meta.Create(sub, db.metaCodec, v.Channel()); err != nil {
+			return err
+		}
+		if err = xfs.SyncDir(sub, "."); err != nil {
+			return err
+		}
+	}
+	// Sync the directories of the channels in dst, so that the snapshot survives a
+	// crash.
+	if err = xfs.SyncDir(dst, "."); err != nil {
+		return err
+	}
+	db.L.Info(
+		"wrote snapshot",
+		zap.Int("unary_channels", len(snapshots)),
+		zap.Int("virtual_channels", len(db.mu.virtualDBs)),
+	)
+	return nil
+}
+
+// Restore copies a snapshot written by DB.Snapshot from src into dst, which is the
+// directory of a database that has not yet been opened. Restore returns a validation
+// error if dst already contains channels, as restoring into an existing database would
+// mix its data with the snapshot. Channels are copied into temporary directories and
+// only moved into place once all of them have been copied, so a restore that is
+// interrupted while copying can be safely re-run.
+func Restore(src xfs.FS, dst xfs.FS) error {
+	existing, err := dst.List("")
+	if err != nil {
+		return err
+	}
+	for _, e := range existing {
+		if _, err = strconv.Atoi(e.Name()); e.IsDir() && err == nil {
+			return errors.Wrapf(
+				validate.Error,
+				"cannot restore snapshot into a database that already contains channels (found channel %s)",
+				e.Name(),
+			)
+		}
+	}
+	entries, err := src.List("")
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if _, err = strconv.Atoi(e.Name()); !e.IsDir() || err != nil {
+			continue
+		}
+		tmpName := e.Name() + restoreSuffix
+		// Remove any partially restored channel left behind by a previous restore.
+		if err = dst.Remove(tmpName); err != nil {
+			return err
+		}
+		subSrc, err := src.Sub(e.Name())
+		if err != nil {
+			return err
+		}
+		subDst, err := dst.Sub(tmpName)
+		if err != nil {
+			return err
+		}
+		if err = xfs.CopyDir(subSrc, subDst); err != nil {
+			return err
+		}
+		names = append(names, e.Name())
+	}
+	for _, name := range names {
+		if err = dst.Rename(name+restoreSuffix, name); err != nil {
+			return err
+		}
+	}
+	return xfs.SyncDir(dst, ".")
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	"path"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Snapshot", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db             *cesium.DB
+				fs             xfs.FS
+				snapFS         xfs.FS
+				cleanUp        func() error
+				snapCleanUp    func() error
+				index          cesium.ChannelKey
+				data           cesium.ChannelKey
+				virtual        cesium.ChannelKey
+				keys           []cesium.ChannelKey
+				expectSnapshot = func(db *cesium.DB) {
+					f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, index, data))
+					Expect(f.Get(index)).To(HaveLen(1))
+					Expect(f.Get(data)).To(HaveLen(1))
+					Expect(f.Get(data)[0].Data).To(Equal(telem.NewSeriesV[int64](1, 2, 3).Data))
+					ch := MustSucceed(db.RetrieveChannel(ctx, virtual))
+					Expect(ch.Virtual).To(BeTrue())
+				}
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				snapFS, snapCleanUp = makeFS()
+				db = openDBOnFS(fs)
+				index = testutil.GenerateChannelKey()
+				data = testutil.GenerateChannelKey()
+				virtual = testutil.GenerateChannelKey()
+				keys = []cesium.ChannelKey{index, data}
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: data, Index: index, DataType: telem.Int64T},
+					cesium.Channel{Key: virtual, Virtual: true, DataType: telem.Int64T},
+				)).To(Succeed())
+				Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(keys, []telem.Series{
+					telem.NewSecondsTSV(10, 11, 12),
+					telem.NewSeriesV[int64](1, 2, 3),
+				}))).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+				Expect(snapCleanUp()).To(Succeed())
+			})
+
+			It("Should write a snapshot that can be opened as a database", func() {
+				Expect(db.Snapshot(ctx, snapFS)).To(Succeed())
+				snapDB := openDBOnFS(snapFS)
+				expectSnapshot(snapDB)
+				Expect(snapDB.Close()).To(Succeed())
+			})
+
+			It("Should not include writes committed after the snapshot", func() {
+				w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{
+					Start:    20 * telem.SecondTS,
+					Channels: keys,
+				}))
+				Expect(w.Write(cesium.NewFrame(keys, []telem.Series{
+					telem.NewSecondsTSV(20, 21),
+					telem.NewSeriesV[int64](4, 5),
+				}))).To(BeTrue())
+				Expect(db.Snapshot(ctx, snapFS)).To(Succeed())
+				_, ok := w.Commit()
+				Expect(ok).To(BeTrue())
+				Expect(w.Close()).To(Succeed())
+
+				snapDB := openDBOnFS(snapFS)
+				expectSnapshot(snapDB)
+				Expect(snapDB.Close()).To(Succeed())
+				f := MustSucceed(db.Read(ctx, telem.TimeRangeMax, data))
+				Expect(f.Get(data)).To(HaveLen(2))
+			})
+
+			It("Should include the rollup tiers of channels", func() {
+				rolled := testutil.GenerateChannelKey()
+				Expect(db.CreateChannel(ctx, cesium.Channel{
+					Key:      rolled,
+					Index:    index,
+					DataType: telem.Float64T,
+					Rollups:  []telem.TimeSpan{telem.Second},
+				})).To(Succeed())
+				Expect(db.Write(ctx, 20*telem.SecondTS, cesium.NewFrame(
+					[]cesium.ChannelKey{index, rolled},
+					[]telem.Series{
+						telem.NewSecondsTSV(20, 21, 22),
+						telem.NewSeriesV[float64](1, 2, 3),
+					},
+				))).To(Succeed())
+				Expect(db.Snapshot(ctx, snapFS)).To(Succeed())
+				tierSize := func(fs xfs.FS) int64 {
+					f := MustSucceed(fs.Open(path.Join(channelKeyToPath(rolled), "rollup-1000000000", "1.domain"), 0))
+					defer func() { Expect(f.Close()).To(Succeed()) }()
+					return MustSucceed(f.Stat()).Size()
+				}
+				Expect(tierSize(fs)).To(BeNumerically(">", 0))
+				Expect(tierSize(snapFS)).To(Equal(tierSize(fs)))
+			})
+
+			Describe("Restore", func() {
+				It("Should restore a snapshot into an empty database", func() {
+					Expect(db.Snapshot(ctx, snapFS)).To(Succeed())
+					restoreFS, restoreCleanUp := makeFS()
+					Expect(cesium.Restore(snapFS, restoreFS)).To(Succeed())
+					restoredDB := openDBOnFS(restoreFS)
+					expectSnapshot(restoredDB)
+					Expect(restoredDB.Close()).To(Succeed())
+					Expect(restoreCleanUp()).To(Succeed())
+				})
+
+				It("Should return an error when restoring into a database with channels", func() {
+					Expect(db.Snapshot(ctx, snapFS)).To(Succeed())
+					Expect(cesium.Restore(snapFS, fs)).To(HaveOccurredAs(validate.Error))
+				})
+			})
+		})
+	}
+})
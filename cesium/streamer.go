@@ -11,9 +11,13 @@ package cesium
 
 import (
 	"context"
+
 	"github.com/synnaxlabs/cesium/internal/core"
 	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
 )
 
 // StreamerRequest can be used to update the channel set a Streamer subscribes
@@ -29,8 +33,35 @@ type StreamerConfig struct {
 	Channels []core.ChannelKey
 	// OnSuccessfulStart is closed when the Streamer is successfully opened.
 	SendOpenAck bool
+	// From, if non-zero, makes the Streamer replay the persisted samples of its
+	// channels starting at the given timestamp before it starts sending live frames.
+	// Live frames written while the samples are replayed are held until the replay
+	// finishes, and the samples in them that were already replayed are dropped, so the
+	// streamer delivers every sample from From onwards exactly once. If more than
+	// MaxReplayPending live frames are written while replaying, the streamer fails
+	// with ErrReplayOverflow instead of holding them.
+	//
+	// Live series are written with leading alignments that are only replaced by the
+	// position of their domain once the domain is persisted, so the alignment of a live
+	// sample cannot be compared to the alignment of a replayed one. Live samples are
+	// instead matched to replayed samples by their timestamps, which are found in the
+	// index series with the same alignment in the same frame. Live series that were
+	// written without their index cannot be matched and are always sent, and
+	// persisted channels without an index cannot be replayed.
+	From telem.TimeStamp
 }
 
+// MaxReplayPending is the maximum number of live frames a Streamer holds while
+// replaying persisted samples.
+const MaxReplayPending = 1000
+
+// ErrReplayOverflow is returned by a Streamer when more than MaxReplayPending live
+// frames are written while it replays persisted samples.
+var ErrReplayOverflow = errors.Newf(
+	"streamer received more than %d live frames while replaying persisted samples",
+	MaxReplayPending,
+)
+
 // StreamerResponse contains a frame representing the series of all subscribed channels.
 // This Frame is guaranteed to only contain data for the channels that are currently
 // subscribed to.
@@ -63,13 +94,25 @@ func (db *DB) NewStreamer(_ context.Context, cfg StreamerConfig) (Streamer, erro
 	if db.closed.Load() {
 		return nil, errDBClosed
 	}
-	return &streamer{StreamerConfig: cfg, relay: db.relay}, nil
+	s := &streamer{StreamerConfig: cfg, relay: db.relay, db: db}
+	if !cfg.From.IsZero() {
+		if err := s.openReplay(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
 }
 
 type streamer struct {
 	StreamerConfig
 	confluence.AbstractLinear[StreamerRequest, StreamerResponse]
 	relay *relay
+	db    *DB
+	// replayKeys are the keys of the subscribed channels that have persisted samples
+	// to replay.
+	replayKeys []ChannelKey
+	// replayed tracks the replayed samples so that they are not sent again live.
+	replayed *telem.Replay[ChannelKey]
 }
 
 var _ Streamer = (*streamer)(nil)
@@ -90,6 +133,17 @@ func (s *streamer) Flow(sCtx signal.Context, opts ...confluence.Option) {
 				return err
 			}
 		}
+		if !s.From.IsZero() {
+			pending, err := s.replay(ctx, frames)
+			if err != nil {
+				return err
+			}
+			for _, f := range pending {
+				if err = s.send(ctx, f); err != nil {
+					return err
+				}
+			}
+		}
 		for {
 			select {
 			case <-ctx.Done():
@@ -100,18 +154,115 @@ func (s *streamer) Flow(sCtx signal.Context, opts ...confluence.Option) {
 				}
 				s.Channels = req.Channels
 			case f := <-frames.Outlet():
-				filtered := f.FilterKeys(s.Channels)
-				if len(filtered.Keys) == 0 {
-					continue
-				}
-				if err := signal.SendUnderContext(
-					ctx,
-					s.Out.Inlet(),
-					StreamerResponse{Frame: filtered},
-				); err != nil {
+				if err := s.send(ctx, f); err != nil {
 					return err
 				}
 			}
 		}
 	}, o.Signal...)
 }
+
+// send sends the series of a live frame that belong to the subscribed channels and
+// were not already replayed.
+func (s *streamer) send(ctx context.Context, f Frame) error {
+	if s.replayed != nil {
+		f.Keys, f.Series = s.replayed.Dedupe(f.Keys, f.Series)
+	}
+	filtered := f.FilterKeys(s.Channels)
+	if len(filtered.Keys) == 0 {
+		return nil
+	}
+	return signal.SendUnderContext(ctx, s.Out.Inlet(), StreamerResponse{Frame: filtered})
+}
+
+// replay sends the persisted samples of the subscribed channels from s.From onwards.
+// Live frames are read from frames while replaying so that the streamer does not
+// block writers, and are returned once the replay is complete. replay returns
+// ErrReplayOverflow if more than MaxReplayPending live frames are received.
+func (s *streamer) replay(ctx context.Context, frames confluence.Outlet[Frame]) ([]Frame, error) {
+	var (
+		pending          []Frame
+		overflowed       bool
+		stop             = make(chan struct{})
+		stopped          = make(chan struct{})
+		replayCtx, abort = context.WithCancel(ctx)
+	)
+	defer abort()
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-stop:
+				return
+			case f := <-frames.Outlet():
+				if len(pending) == MaxReplayPending {
+					overflowed = true
+					abort()
+					return
+				}
+				pending = append(pending, f)
+			}
+		}
+	}()
+	err := s.sendPersisted(replayCtx)
+	close(stop)
+	<-stopped
+	if overflowed {
+		return nil, ErrReplayOverflow
+	}
+	return pending, err
+}
+
+func (s *streamer) sendPersisted(ctx context.Context) (err error) {
+	if len(s.replayKeys) == 0 {
+		return nil
+	}
+	i, err := s.db.OpenIterator(IteratorConfig{
+		Bounds:   s.From.Range(telem.TimeStampMax),
+		Channels: s.replayKeys,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Combine(err, i.Close()) }()
+	for ok := i.SeekFirst(); ok && i.Next(AutoSpan); {
+		fr := i.Value()
+		for j, series := range fr.Series {
+			s.replayed.Add(fr.Keys[j], series)
+		}
+		if err = signal.SendUnderContext(ctx, s.Out.Inlet(), StreamerResponse{Frame: fr}); err != nil {
+			return err
+		}
+	}
+	return i.Error()
+}
+
+// openReplay finds the subscribed channels that have persisted samples to replay,
+// returning an error if any of them does not have an index.
+func (s *streamer) openReplay() error {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+	indexes := make(map[ChannelKey]ChannelKey, len(s.Channels))
+	for _, key := range s.Channels {
+		u, ok := s.db.mu.unaryDBs[key]
+		if !ok {
+			continue
+		}
+		ch := u.Channel()
+		switch {
+		case ch.IsIndex:
+			indexes[key] = key
+		case ch.Index != 0:
+			indexes[key] = ch.Index
+		default:
+			return errors.Wrapf(
+				validate.Error,
+				"cannot replay channel <%d> from a timestamp, as it does not have an index",
+				key,
+			)
+		}
+		s.replayKeys = append(s.replayKeys, key)
+	}
+	s.replayed = telem.NewReplay(indexes)
+	return nil
+}
@@ -21,7 +21,9 @@ import (
 	"github.com/synnaxlabs/x/signal"
 	"github.com/synnaxlabs/x/telem"
 	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
 	"runtime"
+	"time"
 
 	"github.com/synnaxlabs/cesium"
 )
@@ -194,6 +196,156 @@ var _ = Describe("Streamer Behavior", func() {
 				})
 			})
 
+			Describe("Replay", func() {
+				var (
+					idxKey  cesium.ChannelKey = 7
+					dataKey cesium.ChannelKey = 8
+					keys                      = []cesium.ChannelKey{idxKey, dataKey}
+				)
+				BeforeAll(func() {
+					Expect(db.CreateChannel(
+						ctx,
+						cesium.Channel{Key: idxKey, DataType: telem.TimeStampT, IsIndex: true},
+						cesium.Channel{Key: dataKey, DataType: telem.Int64T, Index: idxKey},
+					)).To(Succeed())
+					Expect(db.Write(ctx, 10*telem.SecondTS, cesium.NewFrame(
+						keys,
+						[]telem.Series{telem.NewSecondsTSV(10, 11, 12), telem.NewSeriesV[int64](1, 2, 3)},
+					))).To(Succeed())
+				})
+				receive := func(o confluence.Outlet[cesium.StreamerResponse]) map[cesium.ChannelKey][]int64 {
+					received := make(map[cesium.ChannelKey][]int64)
+					for {
+						var r cesium.StreamerResponse
+						select {
+						case r = <-o.Outlet():
+						case <-time.After(100 * time.Millisecond):
+							return received
+						}
+						for _, s := range r.Frame.Series {
+							Expect(s.Len()).To(BeNumerically(">", 0))
+						}
+						for _, s := range r.Frame.Get(dataKey) {
+							received[dataKey] = append(received[dataKey], telem.UnmarshalSlice[int64](s.Data, telem.Int64T)...)
+						}
+						for _, s := range r.Frame.Get(idxKey) {
+							for _, ts := range telem.UnmarshalSlice[telem.TimeStamp](s.Data, telem.TimeStampT) {
+								received[idxKey] = append(received[idxKey], int64(ts/telem.SecondTS))
+							}
+						}
+					}
+				}
+
+				It("Should replay persisted samples from the given timestamp before live frames", func() {
+					r := MustSucceed(db.NewStreamer(ctx, cesium.StreamerConfig{
+						Channels: keys,
+						From:     11 * telem.SecondTS,
+					}))
+					i, o := confluence.Attach(r, 1)
+					sCtx, cancel := signal.WithCancel(ctx)
+					defer cancel()
+					r.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+					Expect(receive(o)).To(Equal(map[cesium.ChannelKey][]int64{
+						idxKey:  {11, 12},
+						dataKey: {2, 3},
+					}))
+					w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{
+						Channels: keys,
+						Start:    13 * telem.SecondTS,
+					}))
+					Expect(w.Write(cesium.NewFrame(
+						keys,
+						[]telem.Series{telem.NewSecondsTSV(13), telem.NewSeriesV[int64](4)},
+					))).To(BeTrue())
+					Expect(receive(o)).To(Equal(map[cesium.ChannelKey][]int64{
+						idxKey:  {13},
+						dataKey: {4},
+					}))
+					Expect(w.Close()).To(Succeed())
+					i.Close()
+					Expect(sCtx.Wait()).To(Succeed())
+				})
+
+				It("Should not send live frames whose samples were already replayed", func() {
+					r := MustSucceed(db.NewStreamer(ctx, cesium.StreamerConfig{
+						Channels:    keys,
+						From:        12 * telem.SecondTS,
+						SendOpenAck: true,
+					}))
+					i, o := confluence.Attach(r)
+					sCtx, cancel := signal.WithCancel(ctx)
+					defer cancel()
+					r.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+					// The streamer is connected to live frames, but does not start
+					// replaying until the open acknowledgement is received, so the
+					// committed frame below is both replayed and sent live.
+					w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{
+						Channels: keys,
+						Start:    20 * telem.SecondTS,
+					}))
+					Expect(w.Write(cesium.NewFrame(
+						keys,
+						[]telem.Series{telem.NewSecondsTSV(20, 21), telem.NewSeriesV[int64](5, 6)},
+					))).To(BeTrue())
+					_, ok := w.Commit()
+					Expect(ok).To(BeTrue())
+					Expect(w.Write(cesium.NewFrame(
+						keys,
+						[]telem.Series{telem.NewSecondsTSV(22), telem.NewSeriesV[int64](7)},
+					))).To(BeTrue())
+					Eventually(o.Outlet()).Should(Receive())
+					Expect(receive(o)).To(Equal(map[cesium.ChannelKey][]int64{
+						idxKey:  {12, 20, 21, 22},
+						dataKey: {3, 5, 6, 7},
+					}))
+					Expect(w.Close()).To(Succeed())
+					i.Close()
+					Expect(sCtx.Wait()).To(Succeed())
+				})
+
+				It("Should not replay channels without an index", func() {
+					rateKey := cesium.ChannelKey(9)
+					Expect(db.CreateChannel(
+						ctx,
+						cesium.Channel{Key: rateKey, DataType: telem.Int64T, Rate: 1 * telem.Hz},
+					)).To(Succeed())
+					_, err := db.NewStreamer(ctx, cesium.StreamerConfig{
+						Channels: []cesium.ChannelKey{rateKey},
+						From:     10 * telem.SecondTS,
+					})
+					Expect(err).To(HaveOccurredAs(validate.Error))
+				})
+
+				It("Should fail if too many live frames are written while replaying", func() {
+					r := MustSucceed(db.NewStreamer(ctx, cesium.StreamerConfig{
+						Channels:    keys,
+						From:        10 * telem.SecondTS,
+						SendOpenAck: true,
+					}))
+					i, o := confluence.Attach(r)
+					sCtx, cancel := signal.WithCancel(ctx)
+					defer cancel()
+					r.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+					Eventually(o.Outlet()).Should(Receive())
+					// The replayed samples are never received, so the streamer holds
+					// every live frame written below.
+					w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{
+						Channels: keys,
+						Start:    100 * telem.SecondTS,
+					}))
+					for j := range cesium.MaxReplayPending + 1 {
+						ts := telem.TimeStamp(100+j) * telem.SecondTS
+						Expect(w.Write(cesium.NewFrame(
+							keys,
+							[]telem.Series{telem.NewSeriesV(ts), telem.NewSeriesV(int64(j))},
+						))).To(BeTrue())
+					}
+					Expect(sCtx.Wait()).To(HaveOccurredAs(cesium.ErrReplayOverflow))
+					Expect(w.Close()).To(Succeed())
+					i.Close()
+				})
+			})
+
 			Describe("Closed", func() {
 				It("Should not allow opening a streamer on a closed db", func() {
 					sub := MustSucceed(fs.Sub("closed-fs"))
@@ -0,0 +1,106 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/cesium/internal/testutil"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Variable Density Channels", func() {
+	for fsName, makeFS := range fileSystems {
+		Context("FS: "+fsName, func() {
+			var (
+				db      *cesium.DB
+				fs      xfs.FS
+				cleanUp func() error
+				index   cesium.ChannelKey
+				events  cesium.ChannelKey
+				keys    []cesium.ChannelKey
+			)
+			BeforeEach(func() {
+				fs, cleanUp = makeFS()
+				db = openDBOnFS(fs)
+				index = testutil.GenerateChannelKey()
+				events = testutil.GenerateChannelKey()
+				keys = []cesium.ChannelKey{index, events}
+				Expect(db.CreateChannel(
+					ctx,
+					cesium.Channel{Key: index, IsIndex: true, DataType: telem.TimeStampT},
+					cesium.Channel{Key: events, Index: index, DataType: telem.JSONT},
+				)).To(Succeed())
+				w := MustSucceed(db.OpenWriter(ctx, cesium.WriterConfig{Start: 10 * telem.SecondTS, Channels: keys}))
+				Expect(w.Write(cesium.NewFrame(keys, []telem.Series{
+					telem.NewSecondsTSV(10, 11),
+					{DataType: telem.JSONT, Data: []byte("{\"level\":\"info\"}\n{\"level\":\"warn\",\"code\":3}\n")},
+				}))).To(BeTrue())
+				Expect(w.Write(cesium.NewFrame(keys, []telem.Series{
+					telem.NewSecondsTSV(12, 13),
+					{DataType: telem.JSONT, Data: []byte("{}\n{\"level\":\"error\"}\n")},
+				}))).To(BeTrue())
+				_, ok := w.Commit()
+				Expect(ok).To(BeTrue())
+				Expect(w.Close()).To(Succeed())
+			})
+			AfterEach(func() {
+				Expect(db.Close()).To(Succeed())
+				Expect(cleanUp()).To(Succeed())
+			})
+
+			read := func(db *cesium.DB, tr telem.TimeRange) []string {
+				var values []string
+				for _, s := range MustSucceed(db.Read(ctx, tr, events)).Get(events) {
+					for _, v := range s.Split() {
+						values = append(values, string(v))
+					}
+				}
+				return values
+			}
+
+			It("Should read samples in a time range", func() {
+				Expect(read(db, (11 * telem.SecondTS).Range(13*telem.SecondTS))).To(Equal([]string{
+					`{"level":"warn","code":3}`,
+					`{}`,
+				}))
+			})
+
+			It("Should delete samples in a time range", func() {
+				Expect(db.DeleteTimeRange(ctx, []cesium.ChannelKey{events}, (10 * telem.SecondTS).Range(12*telem.SecondTS))).To(Succeed())
+				Expect(read(db, telem.TimeRangeMax)).To(Equal([]string{`{}`, `{"level":"error"}`}))
+			})
+
+			It("Should include offset tables in snapshots", func() {
+				snapFS, snapCleanUp := makeFS()
+				Expect(db.Snapshot(ctx, snapFS)).To(Succeed())
+				snapDB := openDBOnFS(snapFS)
+				Expect(read(snapDB, (13 * telem.SecondTS).Range(14*telem.SecondTS))).To(Equal([]string{`{"level":"error"}`}))
+				Expect(snapDB.Close()).To(Succeed())
+				Expect(snapCleanUp()).To(Succeed())
+			})
+
+			It("Should not create an index channel with a variable density data type", func() {
+				Expect(db.CreateChannel(ctx, cesium.Channel{
+					Key:      testutil.GenerateChannelKey(),
+					IsIndex:  true,
+					DataType: telem.StringT,
+				})).To(MatchError(validate.FieldError{
+					Field:   "data_type",
+					Message: "index channel must be of type timestamp",
+				}))
+			})
+		})
+	}
+})
@@ -0,0 +1,216 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package cesium
+
+import (
+	"context"
+
+	"github.com/synnaxlabs/cesium/internal/unary"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// backfill holds the state of an idxWriter that is writing into time ranges that may
+// already contain data.
+type backfill struct {
+	policy OverlapPolicy
+	// db is the database of the index channel, whose domains are used to find the
+	// existing data that written samples overlap with.
+	db unary.DB
+	// dependents are the databases of all channels indexed by the index channel.
+	dependents []unary.DB
+	// written is true once the writer has written at least one sample.
+	written bool
+	// next is the timestamp immediately after the last sample written, or the start of
+	// the writer if no samples have been written.
+	next telem.TimeStamp
+	// from is the start of the time range overwritten by the next commit. Only used
+	// with OverlapOverwrite.
+	from telem.TimeStamp
+}
+
+// backfillRun is a run of consecutive samples in a frame that are written to the same
+// domain.
+type backfillRun struct {
+	// start and end are the indexes of the first and last+1 samples in the run.
+	start, end int
+	// split is true if the run must be written to a new domain starting at the
+	// timestamp of its first sample.
+	split bool
+	// splitEnd is the end of the previous domain when split is true.
+	splitEnd telem.TimeStamp
+}
+
+// plan returns the runs of samples that should be written given their timestamps,
+// along with the timestamp immediately after the last sample to write. Samples that
+// overlap with existing data are either rejected or excluded from all runs, depending
+// on the overlap policy.
+func (b *backfill) plan(ts []telem.TimeStamp) ([]backfillRun, telem.TimeStamp, error) {
+	var (
+		runs     []backfillRun
+		next     = b.next
+		written  = b.written
+		existing []telem.TimeRange
+		// blocked is true if an existing domain lies between the last sample written
+		// and the current one, meaning they cannot be written to the same domain.
+		blocked bool
+	)
+	if b.policy != OverlapOverwrite && len(ts) > 0 {
+		existing = b.db.Domains(next.Range(ts[len(ts)-1] + 1))
+	}
+	d := 0
+	for j, t := range ts {
+		if written && t < next {
+			return nil, next, errors.Wrapf(
+				validate.Error,
+				"backfilled timestamps must be strictly increasing, but %s is before %s",
+				t,
+				next-1,
+			)
+		}
+		for ; d < len(existing) && existing[d].End <= t; d++ {
+			if existing[d].End > next {
+				blocked = true
+			}
+		}
+		if d < len(existing) && existing[d].Start <= t {
+			if b.policy == OverlapError {
+				return nil, next, errors.Wrapf(
+					ErrWriteConflict,
+					"sample at %s overlaps with existing data in %s",
+					t,
+					existing[d],
+				)
+			}
+			continue
+		}
+		if !written || blocked {
+			runs = append(runs, backfillRun{start: j, end: j + 1, split: true, splitEnd: next})
+		} else if len(runs) == 0 || runs[len(runs)-1].end != j {
+			runs = append(runs, backfillRun{start: j, end: j + 1})
+		} else {
+			runs[len(runs)-1].end = j + 1
+		}
+		blocked, written, next = false, true, t+1
+	}
+	return runs, next, nil
+}
+
+func (w *idxWriter) writeBackfill(fr Frame) (Frame, error) {
+	var idxSeries telem.Series
+	for i, k := range fr.Keys {
+		if k == w.idx.key {
+			idxSeries = fr.Series[i]
+		}
+	}
+	if idxSeries.DataType != telem.TimeStampT && idxSeries.DataType != telem.Int64T {
+		// The frame either has no series for the writer's channels, or the series for
+		// the index is invalid, which the default write path reports.
+		return w.write(fr)
+	}
+	ts := telem.UnmarshalSlice[telem.TimeStamp](idxSeries.Data, idxSeries.DataType)
+	runs, next, err := w.backfill.plan(ts)
+	if err != nil {
+		return fr, err
+	}
+	for j, r := range runs {
+		if r.split {
+			for _, chW := range w.internal {
+				if err = chW.Split(r.splitEnd, ts[r.start]); err != nil {
+					return fr, err
+				}
+			}
+		}
+		var sub Frame
+		for i, k := range fr.Keys {
+			if _, ok := w.internal[k]; ok {
+				sub = sub.Append(k, fr.Series[i].Slice(int64(r.start), int64(r.end)))
+			}
+		}
+		if sub, err = w.write(sub); err != nil {
+			return fr, err
+		}
+		if j != 0 {
+			continue
+		}
+		// Report the alignment of the first run written in the returned frame.
+		n := 0
+		for i, k := range fr.Keys {
+			if _, ok := w.internal[k]; ok {
+				fr.Series[i].Alignment = sub.Series[n].Alignment
+				n++
+			}
+		}
+	}
+	if len(runs) > 0 {
+		if !w.backfill.written {
+			w.backfill.from = ts[runs[0].start]
+		}
+		w.backfill.written = true
+		w.backfill.next = next
+	}
+	return fr, nil
+}
+
+// overwrite deletes the existing data in the given time range for all channels written
+// to by the writer, so that the writer can commit over it.
+func (w *idxWriter) overwrite(ctx context.Context, tr telem.TimeRange) error {
+	if !tr.Start.Before(tr.End) {
+		return nil
+	}
+	for _, db := range w.backfill.dependents {
+		if _, ok := w.internal[db.Channel().Key]; ok {
+			continue
+		}
+		hasData, err := db.HasDataFor(ctx, tr)
+		if err != nil {
+			return err
+		}
+		if hasData {
+			return errors.Wrapf(
+				validate.Error,
+				"cannot overwrite index channel %v with channel %v depending on it on the time range %s",
+				w.backfill.db.Channel(),
+				db.Channel(),
+				tr,
+			)
+		}
+	}
+	// Data channels must be overwritten before their index, as resolving which of
+	// their samples to delete requires the existing index data.
+	for key, chW := range w.internal {
+		if key != w.idx.key {
+			if err := chW.Overwrite(ctx, tr); err != nil {
+				return err
+			}
+		}
+	}
+	return w.internal[w.idx.key].Overwrite(ctx, tr)
+}
+
+func validateBackfill(
+	domainWriters map[ChannelKey]*idxWriter,
+	rateWriters map[telem.Rate]*idxWriter,
+) error {
+	if len(rateWriters) > 0 {
+		return errors.Wrap(validate.Error, "backfilling writers cannot write to rate based channels")
+	}
+	for key, w := range domainWriters {
+		if !w.writingToIdx {
+			return errors.Wrapf(
+				validate.Error,
+				"backfilling writers must write to the index channel %d of every channel they write to",
+				key,
+			)
+		}
+	}
+	return nil
+}
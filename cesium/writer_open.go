@@ -42,6 +42,22 @@ const (
 	WriterStreamOnly
 )
 
+// OverlapPolicy sets how a backfilling writer handles samples that overlap with data
+// already in the DB.
+type OverlapPolicy uint8
+
+const (
+	// OverlapError rejects any write containing a sample that overlaps with existing
+	// data.
+	OverlapError OverlapPolicy = iota + 1
+	// OverlapKeepExisting discards samples that overlap with existing data, and writes
+	// the remaining samples into the gaps around it.
+	OverlapKeepExisting
+	// OverlapOverwrite deletes all existing data in the time range spanned by the
+	// samples written between commits, replacing it with the written samples.
+	OverlapOverwrite
+)
+
 // WriterConfig sets the configuration used to open a new writer on the DB.
 type WriterConfig struct {
 	// Name sets the human-readable name for the writer, which is useful for identifying
@@ -89,6 +105,18 @@ type WriterConfig struct {
 	// OpenSignal is a channel that will be closed once the writer is successfully opened.
 	// [OPTIONAL] - Defaults to nil.
 	OpenSignal chan<- struct{}
+	// Backfill allows the writer to write into time ranges that already contain data,
+	// such as when uploading the data of a logger that was offline. Samples are
+	// written into the gaps between existing domains, and samples that overlap with
+	// existing data are handled according to OverlapPolicy. A backfilling writer must
+	// write to the index of every channel it writes to, and the timestamps it writes
+	// must be strictly increasing.
+	// [OPTIONAL] - Defaults to false.
+	Backfill *bool
+	// OverlapPolicy sets how a backfilling writer handles samples that overlap with
+	// existing data. See the OverlapPolicy documentation for more.
+	// [OPTIONAL] - Defaults to OverlapError.
+	OverlapPolicy OverlapPolicy
 }
 
 const AlwaysIndexPersistOnAutoCommit telem.TimeSpan = -1
@@ -106,6 +134,8 @@ func DefaultWriterConfig() WriterConfig {
 		Mode:                     WriterPersistStream,
 		EnableAutoCommit:         config.Bool(false),
 		AutoIndexPersistInterval: 1 * telem.Second,
+		Backfill:                 config.False(),
+		OverlapPolicy:            OverlapError,
 	}
 }
 
@@ -121,6 +151,12 @@ func (c WriterConfig) Validate() error {
 		len(c.Authorities) != len(c.Channels) && len(c.Authorities) != 1,
 		"authority count must be 1 or equal to channel count",
 	)
+	validate.NotNil(v, "Backfill", c.Backfill)
+	v.Ternary(
+		"overlap_policy",
+		c.OverlapPolicy < OverlapError || c.OverlapPolicy > OverlapOverwrite,
+		"invalid overlap policy",
+	)
 	return v.Error()
 }
 
@@ -137,6 +173,8 @@ func (c WriterConfig) Override(other WriterConfig) WriterConfig {
 	c.EnableAutoCommit = override.Nil(c.EnableAutoCommit, other.EnableAutoCommit)
 	c.AutoIndexPersistInterval = override.Zero(c.AutoIndexPersistInterval, other.AutoIndexPersistInterval)
 	c.OpenSignal = override.Nil(c.OpenSignal, other.OpenSignal)
+	c.Backfill = override.Nil(c.Backfill, other.Backfill)
+	c.OverlapPolicy = override.Numeric(c.OverlapPolicy, other.OverlapPolicy)
 	return c
 }
 
@@ -209,6 +247,7 @@ func (db *DB) newStreamWriter(ctx context.Context, cfgs ...WriterConfig) (w *str
 			Persist:                  config.Bool(cfg.Mode.Persist()),
 			Authority:                cfg.authority(i),
 			AlignmentDomainIndex:     domainAlignment,
+			AllowOverlap:             cfg.Backfill,
 		}
 	}
 
@@ -325,6 +364,12 @@ func (db *DB) newStreamWriter(ctx context.Context, cfgs ...WriterConfig) (w *str
 		idxW.internal[key] = &unaryWriterState{Writer: *unaryW}
 	}
 
+	if *cfg.Backfill {
+		if err = validateBackfill(domainWriters, rateWriters); err != nil {
+			return nil, err
+		}
+	}
+
 	if len(controlUpdate.Transfers) > 0 {
 		if err = db.updateControlDigests(ctx, controlUpdate); err != nil {
 			return nil, err
@@ -341,6 +386,7 @@ func (db *DB) newStreamWriter(ctx context.Context, cfgs ...WriterConfig) (w *str
 			defer db.mu.RUnlock()
 			return db.updateControlDigests(ctx, update)
 		},
+		commitMu: &db.commitMu,
 	}
 	for _, idx := range domainWriters {
 		w.internal = append(w.internal, idx)
@@ -365,6 +411,14 @@ func (db *DB) openDomainIdxWriter(
 	w.idx.highWaterMark = cfg.Start
 	w.writingToIdx = false
 	w.start = cfg.Start
+	if *cfg.Backfill {
+		w.backfill = &backfill{policy: cfg.OverlapPolicy, db: u, next: cfg.Start}
+		for _, other := range db.mu.unaryDBs {
+			if !other.Channel().IsIndex && other.Channel().Index == idxKey {
+				w.backfill.dependents = append(w.backfill.dependents, other)
+			}
+		}
+	}
 	return w, nil
 }
 
@@ -11,6 +11,7 @@ package cesium
 
 import (
 	"context"
+	"sync"
 
 	"github.com/synnaxlabs/cesium/internal/controller"
 	"github.com/synnaxlabs/cesium/internal/core"
@@ -101,6 +102,7 @@ type streamWriter struct {
 	seqNum          int
 	err             error
 	updateDBControl func(ctx context.Context, u ControlUpdate) error
+	commitMu        *sync.RWMutex
 }
 
 // Flow implements the confluence.Flow interface.
@@ -228,6 +230,10 @@ func (w *streamWriter) sendRes(req WriterRequest, ack bool, err error, end telem
 }
 
 func (w *streamWriter) write(ctx context.Context, req WriterRequest) (err error) {
+	if *w.EnableAutoCommit {
+		w.commitMu.RLock()
+		defer w.commitMu.RUnlock()
+	}
 	for _, idx := range w.internal {
 		req.Frame, err = idx.Write(req.Frame)
 		if err != nil {
@@ -263,6 +269,8 @@ func (w *streamWriter) write(ctx context.Context, req WriterRequest) (err error)
 }
 
 func (w *streamWriter) commit(ctx context.Context) (telem.TimeStamp, error) {
+	w.commitMu.RLock()
+	defer w.commitMu.RUnlock()
 	maxTS := telem.TimeStampMin
 	for _, idxW := range w.internal {
 		ts, err := idxW.Commit(ctx)
@@ -345,15 +353,23 @@ type idxWriter struct {
 	// a single logical channel. i.e. N channels with M samples will result in a sample
 	// count of M.
 	sampleCount int64
+	// backfill is non-nil if the writer is backfilling data. See
+	// WriterConfig.Backfill for more details.
+	backfill *backfill
 }
 
 func (w *idxWriter) Write(fr Frame) (Frame, error) {
 	w.numWriteCalls++
-	err := w.validateWrite(fr)
-	if err != nil {
+	if err := w.validateWrite(fr); err != nil {
 		return fr, err
 	}
+	if w.backfill != nil {
+		return w.writeBackfill(fr)
+	}
+	return w.write(fr)
+}
 
+func (w *idxWriter) write(fr Frame) (_ Frame, err error) {
 	var incrementedSampleCount bool
 
 	for i, series := range fr.Series {
@@ -395,11 +411,27 @@ func (w *idxWriter) Commit(ctx context.Context) (telem.TimeStamp, error) {
 	}
 	// because the range is exclusive, we need to add 1 nanosecond to the end
 	end.Lower++
+	if w.backfill != nil && w.backfill.policy == OverlapOverwrite {
+		if err = w.overwrite(ctx, w.backfill.from.Range(end.Lower)); err != nil {
+			return 0, err
+		}
+	}
 	c := errors.NewCatcher(errors.WithAggregation())
-	for _, chW := range w.internal {
-		c.Exec(func() error { return chW.CommitWithEnd(ctx, end.Lower) })
+	// The index must be committed first, as committing the channels it indexes may
+	// require resolving the timestamps of their newly committed samples (e.g. to
+	// update rollup tiers).
+	if idxW, ok := w.internal[w.idx.key]; ok {
+		c.Exec(func() error { return idxW.CommitWithEnd(ctx, end.Lower) })
+	}
+	for key, chW := range w.internal {
+		if key != w.idx.key {
+			c.Exec(func() error { return chW.CommitWithEnd(ctx, end.Lower) })
+		}
+	}
+	if err = c.Error(); err == nil && w.backfill != nil {
+		w.backfill.from = end.Lower
 	}
-	return end.Lower, c.Error()
+	return end.Lower, err
 }
 
 func (w *idxWriter) Close() (ControlUpdate, error) {
@@ -435,7 +467,7 @@ func (w *idxWriter) validateWrite(fr Frame) error {
 			s := fr.Series[i]
 			// Data type of first series must be known since we use it to calculate the
 			// length of series in the frame
-			if s.DataType.Density() == telem.DensityUnknown {
+			if s.DataType.Density() == telem.DensityUnknown && !s.DataType.IsVariable() {
 				return errors.Wrapf(
 					validate.Error,
 					"invalid data type for channel %d, expected %s, got %s",
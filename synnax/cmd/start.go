@@ -265,6 +265,7 @@ func start(cmd *cobra.Command) {
 				Instrumentation: ins.Child("framer"),
 				Framer:          dist.Framer,
 				Channel:         dist.Channel,
+				HostProvider:    dist.Cluster,
 			},
 		)
 		if err != nil {
@@ -412,6 +413,7 @@ func buildStorageConfig(
 		Instrumentation: ins.Child("storage"),
 		MemBacked:       config.Bool(viper.GetBool(memFlag)),
 		Dirname:         viper.GetString(dataFlag),
+		RestoreFrom:     viper.GetString(restoreFlag),
 	}
 }
 
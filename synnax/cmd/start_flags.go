@@ -19,6 +19,7 @@ const (
 	peersFlag               = "peers"
 	dataFlag                = "data"
 	memFlag                 = "mem"
+	restoreFlag             = "restore"
 	insecureFlag            = "insecure"
 	usernameFlag            = "username"
 	passwordFlag            = "password"
@@ -70,6 +71,12 @@ func configureStartFlags() {
 		"Use in-memory storage",
 	)
 
+	startCmd.Flags().String(
+		restoreFlag,
+		"",
+		"Dirname of a storage snapshot to restore into an empty node at startup.",
+	)
+
 	startCmd.Flags().BoolP(
 		insecureFlag,
 		"i",
@@ -150,6 +150,7 @@ type Transport struct {
 	FrameIterator freighter.StreamServer[FrameIteratorRequest, FrameIteratorResponse]
 	FrameStreamer freighter.StreamServer[FrameStreamerRequest, FrameStreamerResponse]
 	FrameDelete   freighter.UnaryServer[FrameDeleteRequest, types.Nil]
+	FrameImport   freighter.StreamServer[FrameImportRequest, FrameImportResponse]
 	// RANGE
 	RangeCreate       freighter.UnaryServer[RangeCreateRequest, RangeCreateResponse]
 	RangeRetrieve     freighter.UnaryServer[RangeRetrieveRequest, RangeRetrieveResponse]
@@ -289,6 +290,7 @@ func (a *API) BindTo(t Transport) {
 		t.FrameIterator,
 		t.FrameStreamer,
 		t.FrameDelete,
+		t.FrameImport,
 
 		// ONTOLOGY
 		t.OntologyRetrieve,
@@ -400,6 +402,7 @@ func (a *API) BindTo(t Transport) {
 	t.FrameIterator.BindHandler(a.Framer.Iterate)
 	t.FrameStreamer.BindHandler(a.Framer.Stream)
 	t.FrameDelete.BindHandler(a.Framer.FrameDelete)
+	t.FrameImport.BindHandler(a.Framer.Import)
 
 	// ONTOLOGY
 	t.OntologyRetrieve.BindHandler(a.Ontology.Retrieve)
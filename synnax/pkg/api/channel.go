@@ -32,19 +32,21 @@ type ChannelKey = channel.Key
 // Channel is an API-friendly version of the channel.Channel type. It is simplified for
 // use purely as a data container.
 type Channel struct {
-	Key         channel.Key          `json:"key" msgpack:"key"`
-	Name        string               `json:"name" msgpack:"name"`
-	Leaseholder distribution.NodeKey `json:"leaseholder" msgpack:"leaseholder"`
-	Rate        telem.Rate           `json:"rate" msgpack:"rate"`
-	DataType    telem.DataType       `json:"data_type" msgpack:"data_type" validate:"required"`
-	Density     telem.Density        `json:"density" msgpack:"density"`
-	IsIndex     bool                 `json:"is_index" msgpack:"is_index"`
-	Index       channel.Key          `json:"index" msgpack:"index"`
-	Alias       string               `json:"alias" msgpack:"alias"`
-	Virtual     bool                 `json:"virtual" msgpack:"virtual"`
-	Internal    bool                 `json:"internal" msgpack:"internal"`
-	Requires    channel.Keys         `json:"requires" msgpack:"requires"`
-	Expression  string               `json:"expression" msgpack:"expression"`
+	Key           channel.Key           `json:"key" msgpack:"key"`
+	Name          string                `json:"name" msgpack:"name"`
+	Leaseholder   distribution.NodeKey  `json:"leaseholder" msgpack:"leaseholder"`
+	Rate          telem.Rate            `json:"rate" msgpack:"rate"`
+	DataType      telem.DataType        `json:"data_type" msgpack:"data_type" validate:"required"`
+	Density       telem.Density         `json:"density" msgpack:"density"`
+	IsIndex       bool                  `json:"is_index" msgpack:"is_index"`
+	Index         channel.Key           `json:"index" msgpack:"index"`
+	Alias         string                `json:"alias" msgpack:"alias"`
+	Virtual       bool                  `json:"virtual" msgpack:"virtual"`
+	Internal      bool                  `json:"internal" msgpack:"internal"`
+	Requires      channel.Keys          `json:"requires" msgpack:"requires"`
+	Expression    string                `json:"expression" msgpack:"expression"`
+	Interpolation channel.Interpolation `json:"interpolation" msgpack:"interpolation"`
+	Materialized  bool                  `json:"materialized" msgpack:"materialized"`
 }
 
 // ChannelService is the central API for all things Channel related.
@@ -241,18 +243,20 @@ func translateChannelsForward(channels []channel.Channel) []Channel {
 	translated := make([]Channel, len(channels))
 	for i, ch := range channels {
 		translated[i] = Channel{
-			Key:         ch.Key(),
-			Name:        ch.Name,
-			Leaseholder: ch.Leaseholder,
-			Rate:        ch.Rate,
-			DataType:    ch.DataType,
-			IsIndex:     ch.IsIndex,
-			Index:       ch.Index(),
-			Density:     ch.DataType.Density(),
-			Virtual:     ch.Virtual,
-			Internal:    ch.Internal,
-			Expression:  ch.Expression,
-			Requires:    ch.Requires,
+			Key:           ch.Key(),
+			Name:          ch.Name,
+			Leaseholder:   ch.Leaseholder,
+			Rate:          ch.Rate,
+			DataType:      ch.DataType,
+			IsIndex:       ch.IsIndex,
+			Index:         ch.Index(),
+			Density:       ch.DataType.Density(),
+			Virtual:       ch.Virtual,
+			Internal:      ch.Internal,
+			Expression:    ch.Expression,
+			Requires:      ch.Requires,
+			Interpolation: ch.Interpolation,
+			Materialized:  ch.Materialized,
 		}
 	}
 	return translated
@@ -264,17 +268,19 @@ func translateChannelsBackward(channels []Channel) ([]channel.Channel, error) {
 	translated := make([]channel.Channel, len(channels))
 	for i, ch := range channels {
 		tCH := channel.Channel{
-			Name:        ch.Name,
-			Leaseholder: ch.Leaseholder,
-			Rate:        ch.Rate,
-			DataType:    ch.DataType,
-			IsIndex:     ch.IsIndex,
-			LocalIndex:  ch.Index.LocalKey(),
-			LocalKey:    ch.Key.LocalKey(),
-			Virtual:     ch.Virtual,
-			Internal:    ch.Internal,
-			Expression:  ch.Expression,
-			Requires:    ch.Requires,
+			Name:          ch.Name,
+			Leaseholder:   ch.Leaseholder,
+			Rate:          ch.Rate,
+			DataType:      ch.DataType,
+			IsIndex:       ch.IsIndex,
+			LocalIndex:    ch.Index.LocalKey(),
+			LocalKey:      ch.Key.LocalKey(),
+			Virtual:       ch.Virtual,
+			Internal:      ch.Internal,
+			Expression:    ch.Expression,
+			Requires:      ch.Requires,
+			Interpolation: ch.Interpolation,
+			Materialized:  ch.Materialized,
 		}
 		if ch.IsIndex {
 			tCH.LocalIndex = tCH.LocalKey
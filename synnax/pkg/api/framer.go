@@ -12,6 +12,7 @@ package api
 import (
 	"context"
 	"go/types"
+	"io"
 
 	"github.com/synnaxlabs/alamos"
 	"github.com/synnaxlabs/freighter"
@@ -23,6 +24,7 @@ import (
 	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
 	"github.com/synnaxlabs/synnax/pkg/service/access"
 	framesvc "github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/importer"
 	"github.com/synnaxlabs/synnax/pkg/storage/ts"
 	"github.com/synnaxlabs/x/config"
 	"github.com/synnaxlabs/x/confluence"
@@ -203,7 +205,16 @@ func (s *FrameService) openStreamer(
 	}); err != nil {
 		return nil, err
 	}
-	reader, err := s.Internal.NewStreamer(ctx, framer.StreamerConfig{Keys: req.Keys, DownsampleFactor: req.DownsampleFactor})
+	reader, err := s.Internal.NewStreamer(ctx, framer.StreamerConfig{
+		Keys:             req.Keys,
+		DownsampleFactor: req.DownsampleFactor,
+		DownsampleMode:   req.DownsampleMode,
+		DownsampleRate:   req.DownsampleRate,
+		From:             req.From,
+		OverflowPolicy:   req.OverflowPolicy,
+		BufferSize:       req.BufferSize,
+		Filters:          req.Filters,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -379,3 +390,81 @@ func (s *FrameService) openWriter(
 		Ack:     true,
 	})
 }
+
+// FrameImportRequest is a request to import a CSV file into a set of channels. The
+// first request must contain the Config for the import, and every request may contain
+// the next chunk of the file in Data.
+type FrameImportRequest struct {
+	Config importer.Request `json:"config" msgpack:"config"`
+	Data   []byte           `json:"data" msgpack:"data"`
+}
+
+type (
+	// FrameImportResponse reports the progress of an import after each commit.
+	FrameImportResponse = importer.Progress
+	FrameImportStream   = freighter.ServerStream[FrameImportRequest, FrameImportResponse]
+)
+
+// Import bulk imports a CSV file streamed by the client into channels, creating any
+// channels that do not exist. The client is expected to send an initial request
+// containing the import configuration, followed by the contents of the file in one
+// or more requests. The client must call CloseSend after sending the last chunk of the
+// file. The server sends a response reporting the progress of the import after each
+// commit, and closes the stream when the import is complete.
+func (s *FrameService) Import(ctx context.Context, stream FrameImportStream) error {
+	req, err := stream.Receive()
+	if err != nil {
+		return err
+	}
+	var (
+		keys       = make(channel.Keys, 0, len(req.Config.Columns)+1)
+		createsNew bool
+	)
+	for _, c := range append([]importer.Column{req.Config.Index}, req.Config.Columns...) {
+		if c.Channel == 0 {
+			createsNew = true
+			continue
+		}
+		keys = append(keys, c.Channel)
+	}
+	objects := framer.OntologyIDs(keys)
+	// Columns without a key are written to channels that may need to be created, so
+	// the subject must be allowed to create channels in addition to writing to them.
+	if createsNew {
+		objects = append(objects, channel.OntologyTypeID)
+	}
+	if err = s.access.Enforce(ctx, access.Request{
+		Subject: getSubject(ctx),
+		Action:  access.Create,
+		Objects: objects,
+	}); err != nil {
+		return err
+	}
+	pr, pw := io.Pipe()
+	defer func() { _ = pr.Close() }()
+	go func(data []byte) {
+		for {
+			if len(data) > 0 {
+				if _, err := pw.Write(data); err != nil {
+					return
+				}
+			}
+			next, err := stream.Receive()
+			if err != nil {
+				if errors.Is(err, freighter.EOF) {
+					err = nil
+				}
+				_ = pw.CloseWithError(err)
+				return
+			}
+			data = next.Data
+		}
+	}(req.Data)
+	var sendErr error
+	err = s.Internal.Importer.Import(ctx, pr, req.Config, func(p importer.Progress) {
+		if sendErr == nil {
+			sendErr = stream.Send(p)
+		}
+	})
+	return errors.Combine(err, sendErr)
+}
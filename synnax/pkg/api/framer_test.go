@@ -0,0 +1,100 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package api_test
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/freighter"
+	"github.com/synnaxlabs/synnax/pkg/api"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/ontology"
+	"github.com/synnaxlabs/synnax/pkg/service/access"
+	"github.com/synnaxlabs/synnax/pkg/service/access/rbac"
+	svcframer "github.com/synnaxlabs/synnax/pkg/service/framer"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/importer"
+	"github.com/synnaxlabs/synnax/pkg/service/user"
+	"github.com/synnaxlabs/synnax/pkg/storage"
+	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/kv/memkv"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+type importStream struct {
+	requests []api.FrameImportRequest
+}
+
+var _ api.FrameImportStream = (*importStream)(nil)
+
+func (s *importStream) Receive() (api.FrameImportRequest, error) {
+	if len(s.requests) == 0 {
+		return api.FrameImportRequest{}, freighter.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *importStream) Send(api.FrameImportResponse) error { return nil }
+
+var _ = Describe("Frame", func() {
+	Describe("Import", func() {
+		var (
+			ctx     context.Context
+			svc     *api.FrameService
+			subject = user.OntologyID(uuid.New())
+		)
+		BeforeEach(func() {
+			kv := memkv.New()
+			DeferCleanup(func() { Expect(kv.Close()).To(Succeed()) })
+			s := &storage.Storage{KV: kv}
+			rbacSvc := MustSucceed(rbac.NewService(rbac.Config{DB: gorp.Wrap(kv)}))
+			Expect(rbacSvc.NewWriter(nil).Create(context.Background(), &rbac.Policy{
+				Subjects: []ontology.ID{subject},
+				Objects:  []ontology.ID{{Type: framer.OntologyType}},
+				Actions:  []access.Action{access.Create},
+			})).To(Succeed())
+			svc = api.NewFrameService(api.NewProvider(api.Config{
+				Storage: s,
+				RBAC:    rbacSvc,
+				Framer:  &svcframer.Service{Importer: &importer.Service{}},
+			}))
+			params := freighter.Params{}
+			params.Set("Subject", subject)
+			ctx = freighter.Context{Context: context.Background(), Params: params}
+		})
+		It("Should reject a subject that cannot create channels importing a column without a channel", func() {
+			stream := &importStream{requests: []api.FrameImportRequest{{
+				Config: importer.Request{
+					Index: importer.Column{Name: "time", Channel: 1},
+					Columns: []importer.Column{{
+						Name:        "pressure",
+						ChannelName: "pressure",
+						DataType:    telem.Float64T,
+					}},
+				},
+				Data: []byte("time,pressure\n1,2\n"),
+			}}}
+			Expect(svc.Import(ctx, stream)).To(HaveOccurredAs(access.Denied))
+		})
+		It("Should only require write access to columns with an existing channel", func() {
+			stream := &importStream{requests: []api.FrameImportRequest{{
+				Config: importer.Request{Index: importer.Column{Name: "time", Channel: 1}},
+			}}}
+			err := svc.Import(ctx, stream)
+			Expect(err).ToNot(HaveOccurredAs(access.Denied))
+			Expect(err).To(MatchError(ContainSubstring("columns")))
+		})
+	})
+})
@@ -36,6 +36,9 @@ func New() (a api.Transport, transports []fgrpc.BindableTransport) {
 	a.ChannelRename = fnoop.UnaryServer[api.ChannelRenameRequest, types.Nil]{}
 	a.ChannelRetrieveGroup = fnoop.UnaryServer[api.ChannelRetrieveGroupRequest, api.ChannelRetrieveGroupResponse]{}
 
+	// FRAME
+	a.FrameImport = fnoop.StreamServer[api.FrameImportRequest, api.FrameImportResponse]{}
+
 	// USER
 	a.UserRename = fnoop.UnaryServer[api.UserRenameRequest, types.Nil]{}
 	a.UserChangeUsername = fnoop.UnaryServer[api.UserChangeUsernameRequest, types.Nil]{}
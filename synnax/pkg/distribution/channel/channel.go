@@ -207,12 +207,56 @@ type Channel struct {
 	// Expression is only used for calculated channels, and specifies the Lua expression
 	// to evaluate the calculated value.
 	Expression string `json:"expression" msgpack:"expression"`
+	// Interpolation is only used for calculated channels whose required channels have
+	// different indexes, and specifies how the samples of the required channels are
+	// aligned to the timestamps of the first required channel.
+	Interpolation Interpolation `json:"interpolation" msgpack:"interpolation"`
+	// Materialized is only used for calculated channels, and specifies that the
+	// calculated samples are persisted to the channel, indexed by its LocalIndex,
+	// instead of being calculated whenever the channel is streamed or read.
+	Materialized bool `json:"materialized" msgpack:"materialized"`
 }
 
+// Interpolation is a method of estimating the value of a channel at a timestamp
+// between its samples.
+type Interpolation uint8
+
+const (
+	// InterpolationHoldLast uses the last sample at or before the timestamp.
+	InterpolationHoldLast Interpolation = iota
+	// InterpolationLinear interpolates linearly between the samples before and after
+	// the timestamp.
+	InterpolationLinear
+	// InterpolationNearest uses the sample closest to the timestamp.
+	InterpolationNearest
+)
+
+// String implements fmt.Stringer.
+func (i Interpolation) String() string {
+	switch i {
+	case InterpolationHoldLast:
+		return "hold_last"
+	case InterpolationLinear:
+		return "linear"
+	case InterpolationNearest:
+		return "nearest"
+	default:
+		return fmt.Sprintf("Interpolation(%d)", i)
+	}
+}
+
+// IsCalculated returns true if the channel is calculated from the channels it
+// requires whenever it is streamed or read.
 func (c Channel) IsCalculated() bool {
 	return c.Virtual && c.Expression != ""
 }
 
+// IsMaterialized returns true if the channel is calculated from the channels it
+// requires as they are written, and the calculated samples are persisted.
+func (c Channel) IsMaterialized() bool {
+	return c.Materialized && !c.Virtual && c.Expression != ""
+}
+
 // Equals returns true if the two channels are meaningfully equal to each other. This
 // function should be used instead of a direct comparison, as it takes into account
 // the contents of the Requires field, ignoring the order of the keys.
@@ -234,6 +278,8 @@ func (c Channel) Equals(other Channel, exclude ...string) bool {
 		{"Concurrency", c.Concurrency == other.Concurrency},
 		{"Internal", c.Internal == other.Internal},
 		{"Expression", c.Expression == other.Expression},
+		{"Interpolation", c.Interpolation == other.Interpolation},
+		{"Materialized", c.Materialized == other.Materialized},
 	}
 
 	for _, comp := range comparisons {
@@ -0,0 +1,126 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package channel_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/cesium"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/core"
+	"github.com/synnaxlabs/synnax/pkg/distribution/core/mock"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Convert", Ordered, func() {
+	var (
+		services map[core.NodeKey]channel.Service
+		builder  *mock.CoreBuilder
+	)
+	BeforeAll(func() { builder, services, _ = provisionServices() })
+	AfterAll(func() {
+		Expect(builder.Close()).To(Succeed())
+		Expect(builder.Cleanup()).To(Succeed())
+	})
+	Context("Single channel", func() {
+		var ch channel.Channel
+		JustBeforeEach(func() {
+			ch = channel.Channel{
+				Name:        "SG01",
+				Rate:        5 * telem.Hz,
+				DataType:    telem.Int32T,
+				Leaseholder: ch.Leaseholder,
+			}
+			Expect(services[1].Create(ctx, &ch)).To(Succeed())
+			Expect(builder.Cores[ch.Leaseholder].Storage.TS.Write(
+				ctx,
+				10*telem.SecondTS,
+				cesium.NewFrame(
+					[]cesium.ChannelKey{ch.Key().StorageKey()},
+					[]telem.Series{telem.NewSeriesV[int32](1, 2, 3)},
+				),
+			)).To(Succeed())
+		})
+		expectConverted := func(svc channel.Service) {
+			Eventually(func(g Gomega) {
+				var resCh channel.Channel
+				g.Expect(svc.NewRetrieve().
+					WhereKeys(ch.Key()).
+					Entry(&resCh).
+					Exec(ctx, nil)).To(Succeed())
+				g.Expect(resCh.DataType).To(Equal(telem.Float64T))
+			}).Should(Succeed())
+			frame := MustSucceed(builder.Cores[ch.Leaseholder].Storage.TS.Read(
+				ctx,
+				telem.TimeRangeMax,
+				ch.Key().StorageKey(),
+			))
+			series := frame.Get(ch.Key().StorageKey())
+			Expect(series).To(HaveLen(1))
+			Expect(telem.UnmarshalSlice[float64](series[0].Data, series[0].DataType)).
+				To(Equal([]float64{1, 2, 3}))
+		}
+		Context("Node is local", func() {
+			BeforeEach(func() { ch.Leaseholder = 1 })
+			It("Should convert the channel and its data", func() {
+				Expect(services[1].ConvertDataType(ctx, ch.Key(), telem.Float64T, false)).To(Succeed())
+				expectConverted(services[1])
+			})
+		})
+		Context("Node is remote", func() {
+			BeforeEach(func() { ch.Leaseholder = 2 })
+			It("Should convert the channel and its data on the leaseholder", func() {
+				Expect(services[1].ConvertDataType(ctx, ch.Key(), telem.Float64T, false)).To(Succeed())
+				expectConverted(services[2])
+			})
+		})
+	})
+	It("Should not convert a free virtual channel", func() {
+		ch := channel.Channel{
+			Name:        "newton",
+			DataType:    telem.Float32T,
+			Leaseholder: core.Free,
+			Virtual:     true,
+		}
+		Expect(services[1].Create(ctx, &ch)).To(Succeed())
+		Expect(services[1].ConvertDataType(ctx, ch.Key(), telem.Float64T, false)).
+			To(HaveOccurredAs(validate.Error))
+	})
+	It("Should not change the data type of a channel whose data fails to convert", func() {
+		ch := channel.Channel{
+			Name:        "strings",
+			Rate:        5 * telem.Hz,
+			DataType:    telem.Int32T,
+			Leaseholder: 1,
+		}
+		Expect(services[1].Create(ctx, &ch)).To(Succeed())
+		Expect(services[1].ConvertDataType(ctx, ch.Key(), telem.StringT, false)).
+			To(HaveOccurredAs(validate.Error))
+		var resCh channel.Channel
+		Expect(services[1].NewRetrieve().
+			WhereKeys(ch.Key()).
+			Entry(&resCh).
+			Exec(ctx, nil)).To(Succeed())
+		Expect(resCh.DataType).To(Equal(telem.Int32T))
+	})
+	It("Should not convert an internal channel", func() {
+		ch := channel.Channel{
+			Name:     "internal",
+			Rate:     5 * telem.Hz,
+			DataType: telem.Int32T,
+			Internal: true,
+		}
+		Expect(services[1].Create(ctx, &ch)).To(Succeed())
+		Expect(services[1].ConvertDataType(ctx, ch.Key(), telem.Float64T, false)).
+			To(HaveOccurredAs(validate.Error))
+	})
+})
@@ -243,7 +243,7 @@ var _ = Describe("Create", Ordered, func() {
 			}
 			Expect(services[1].Create(ctx, &ch)).To(MatchError(query.NotFound))
 		})
-		It("Should return an error if all required channels do not share the same index", func() {
+		It("Should allow required channels with different indexes", func() {
 			idxCH1 := channel.Channel{
 				Name:     "time1",
 				DataType: telem.TimeStampT,
@@ -262,12 +262,80 @@ var _ = Describe("Create", Ordered, func() {
 				Expression: "return 1",
 				Requires:   []channel.Key{idxCH1.Key(), idxCH2.Key()},
 			}
+			Expect(services[1].Create(ctx, &ch)).To(Succeed())
+		})
+
+	})
+	Context("Materialized Calculated Channels", func() {
+		var base channel.Channel
+		BeforeEach(func() {
+			baseIdx := channel.Channel{Name: "base_time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(services[1].Create(ctx, &baseIdx)).To(Succeed())
+			base = channel.Channel{Name: "base", DataType: telem.Float64T, LocalIndex: baseIdx.LocalKey}
+			Expect(services[1].Create(ctx, &base)).To(Succeed())
+		})
+		It("Should create a stored calculated channel", func() {
+			idx := channel.Channel{Name: "materialized_time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(services[1].Create(ctx, &idx)).To(Succeed())
+			ch := channel.Channel{
+				Name:         "materialized",
+				DataType:     telem.Float64T,
+				Expression:   "return base * 2",
+				Requires:     []channel.Key{base.Key()},
+				LocalIndex:   idx.LocalKey,
+				Materialized: true,
+			}
+			Expect(services[1].Create(ctx, &ch)).To(Succeed())
+			Expect(ch.Virtual).To(BeFalse())
+			Expect(ch.Leaseholder).To(Equal(core.NodeKey(1)))
+			Expect(ch.IsMaterialized()).To(BeTrue())
+			Expect(ch.IsCalculated()).To(BeFalse())
+		})
+		It("Should return an error if the channel does not have an index", func() {
+			ch := channel.Channel{
+				Name:         "materialized",
+				DataType:     telem.Float64T,
+				Expression:   "return base * 2",
+				Requires:     []channel.Key{base.Key()},
+				Materialized: true,
+			}
+			Expect(services[1].Create(ctx, &ch)).To(MatchError(validate.FieldError{
+				Field:   "index",
+				Message: "materialized calculated channels must have an index",
+			}))
+		})
+		It("Should return an error if the channel shares the index of a required channel", func() {
+			ch := channel.Channel{
+				Name:         "materialized",
+				DataType:     telem.Float64T,
+				Expression:   "return base * 2",
+				Requires:     []channel.Key{base.Key()},
+				LocalIndex:   base.LocalIndex,
+				Materialized: true,
+			}
+			Expect(services[1].Create(ctx, &ch)).To(MatchError(validate.FieldError{
+				Field:   "index",
+				Message: "materialized calculated channels must have their own index",
+			}))
+		})
+		It("Should return an error if the channel requires a virtual channel", func() {
+			idx := channel.Channel{Name: "materialized_time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(services[1].Create(ctx, &idx)).To(Succeed())
+			v := channel.Channel{Name: "virtual", DataType: telem.Float64T, Virtual: true}
+			Expect(services[1].Create(ctx, &v)).To(Succeed())
+			ch := channel.Channel{
+				Name:         "materialized",
+				DataType:     telem.Float64T,
+				Expression:   "return virtual * 2",
+				Requires:     []channel.Key{v.Key()},
+				LocalIndex:   idx.LocalKey,
+				Materialized: true,
+			}
 			Expect(services[1].Create(ctx, &ch)).To(MatchError(validate.FieldError{
 				Field:   "requires",
-				Message: "all required channels must share the same index",
+				Message: "materialized calculated channels can only require stored channels with an index",
 			}))
 		})
-
 	})
 	Context("Updating a channel", func() {
 		var ch channel.Channel
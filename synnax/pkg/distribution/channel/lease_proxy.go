@@ -11,6 +11,7 @@ package channel
 
 import (
 	"context"
+	"fmt"
 	"go/types"
 
 	"github.com/samber/lo"
@@ -22,6 +23,7 @@ import (
 	"github.com/synnaxlabs/x/gorp"
 	"github.com/synnaxlabs/x/query"
 	"github.com/synnaxlabs/x/set"
+	"github.com/synnaxlabs/x/telem"
 	xtypes "github.com/synnaxlabs/x/types"
 	"github.com/synnaxlabs/x/validate"
 )
@@ -84,6 +86,7 @@ func newLeaseProxy(
 	p.Transport.CreateServer().BindHandler(p.createHandler)
 	p.Transport.DeleteServer().BindHandler(p.deleteHandler)
 	p.Transport.RenameServer().BindHandler(p.renameHandler)
+	p.Transport.ConvertServer().BindHandler(p.convertHandler)
 	return p, nil
 }
 
@@ -114,13 +117,22 @@ func (lp *leaseProxy) renameHandler(ctx context.Context, msg RenameRequest) (typ
 	return types.Nil{}, txn.Commit(ctx)
 }
 
+func (lp *leaseProxy) convertHandler(ctx context.Context, msg ConvertRequest) (types.Nil, error) {
+	txn := lp.ClusterDB.OpenTx()
+	err := lp.convert(ctx, txn, msg.Key, msg.DataType, false)
+	if err != nil {
+		return types.Nil{}, err
+	}
+	return types.Nil{}, txn.Commit(ctx)
+}
+
 func (lp *leaseProxy) create(ctx context.Context, tx gorp.Tx, _channels *[]Channel, retrieveIfNameExists bool) error {
 	channels := *_channels
 	for i, ch := range channels {
 		if ch.Leaseholder == 0 {
 			channels[i].Leaseholder = lp.HostResolver.HostKey()
 		}
-		if ch.Expression != "" {
+		if ch.Expression != "" && !ch.Materialized {
 			channels[i].Leaseholder = core.Free
 			channels[i].Virtual = true
 		} else if ch.LocalKey != 0 {
@@ -191,6 +203,7 @@ func (lp *leaseProxy) createAndUpdateFreeVirtual(
 				c.Name = ic.Name
 				c.Requires = ic.Requires
 				c.Expression = ic.Expression
+				c.Interpolation = ic.Interpolation
 				return c, nil
 			}).
 		Exec(ctx, tx); err != nil && !errors.Is(err, query.NotFound) {
@@ -222,6 +235,12 @@ func (lp *leaseProxy) validateFreeVirtual(
 					Message: "calculated channels must require at least one channel",
 				}
 			}
+			if ch.Interpolation > InterpolationNearest {
+				return validate.FieldError{
+					Field:   "interpolation",
+					Message: fmt.Sprintf("unknown interpolation %v", ch.Interpolation),
+				}
+			}
 			var required []Channel
 			if err := gorp.NewRetrieve[Key, Channel]().WhereKeys(ch.Requires...).Entries(&required).Exec(ctx, tx); err != nil {
 				return err
@@ -234,11 +253,63 @@ func (lp *leaseProxy) validateFreeVirtual(
 						Message: "cannot use a mix of virtual and non-virtual channels in calculations",
 					}
 				}
-				if r.LocalIndex != idx {
-					return validate.FieldError{
-						Field:   "requires",
-						Message: "all required channels must share the same index",
-					}
+			}
+		}
+	}
+	return nil
+}
+
+// validateMaterialized validates that materialized calculated channels are stored,
+// have their own index, and only require stored channels with an index, so that their
+// samples can be calculated from the samples of their required channels in storage.
+func (lp *leaseProxy) validateMaterialized(
+	ctx context.Context,
+	channels *[]Channel,
+	tx gorp.Tx,
+) error {
+	for _, ch := range *channels {
+		if !ch.Materialized || ch.Expression == "" {
+			continue
+		}
+		if ch.Virtual {
+			return validate.FieldError{
+				Field:   "virtual",
+				Message: "materialized calculated channels cannot be virtual",
+			}
+		}
+		if ch.LocalIndex == 0 {
+			return validate.FieldError{
+				Field:   "index",
+				Message: "materialized calculated channels must have an index",
+			}
+		}
+		if len(ch.Requires) == 0 {
+			return validate.FieldError{
+				Field:   "requires",
+				Message: "calculated channels must require at least one channel",
+			}
+		}
+		if ch.Interpolation > InterpolationNearest {
+			return validate.FieldError{
+				Field:   "interpolation",
+				Message: fmt.Sprintf("unknown interpolation %v", ch.Interpolation),
+			}
+		}
+		var required []Channel
+		if err := gorp.NewRetrieve[Key, Channel]().WhereKeys(ch.Requires...).Entries(&required).Exec(ctx, tx); err != nil {
+			return err
+		}
+		for _, r := range required {
+			if r.Virtual || r.Index() == 0 {
+				return validate.FieldError{
+					Field:   "requires",
+					Message: "materialized calculated channels can only require stored channels with an index",
+				}
+			}
+			if r.Index() == ch.Index() {
+				return validate.FieldError{
+					Field:   "index",
+					Message: "materialized calculated channels must have their own index",
 				}
 			}
 		}
@@ -302,6 +373,9 @@ func (lp *leaseProxy) createGateway(
 	channels *[]Channel,
 	retrieveIfNameExists bool,
 ) error {
+	if err := lp.validateMaterialized(ctx, channels, tx); err != nil {
+		return err
+	}
 	toCreate, err := lp.retrieveExistingAndAssignKeys(ctx, tx, channels, lp.leasedCounter, retrieveIfNameExists)
 	if err != nil {
 		return err
@@ -554,3 +628,54 @@ func (lp *leaseProxy) renameGateway(ctx context.Context, tx gorp.Tx, keys Keys,
 	}
 	return lp.TSChannel.RenameChannels(ctx, keys.Storage(), names)
 }
+
+func (lp *leaseProxy) convert(
+	ctx context.Context,
+	tx gorp.Tx,
+	key Key,
+	dataType telem.DataType,
+	allowInternal bool,
+) error {
+	if key.Free() {
+		return errors.Wrapf(validate.Error, "cannot convert the data type of virtual channel %v", key)
+	}
+	if key.Lease() != lp.HostResolver.HostKey() {
+		return lp.convertRemote(ctx, key, dataType)
+	}
+	return lp.convertGateway(ctx, tx, key, dataType, allowInternal)
+}
+
+func (lp *leaseProxy) convertRemote(ctx context.Context, key Key, dataType telem.DataType) error {
+	addr, err := lp.HostResolver.Resolve(key.Lease())
+	if err != nil {
+		return err
+	}
+	_, err = lp.Transport.ConvertClient().Send(ctx, addr, ConvertRequest{Key: key, DataType: dataType})
+	return err
+}
+
+func (lp *leaseProxy) convertGateway(
+	ctx context.Context,
+	tx gorp.Tx,
+	key Key,
+	dataType telem.DataType,
+	allowInternal bool,
+) error {
+	var c Channel
+	if err := gorp.NewRetrieve[Key, Channel]().WhereKeys(key).Entry(&c).Exec(ctx, tx); err != nil {
+		return err
+	}
+	if c.Internal && !allowInternal {
+		return errors.Wrapf(validate.Error, "cannot convert internal channel %v", c)
+	}
+	if c.Virtual {
+		return errors.Wrapf(validate.Error, "cannot convert the data type of virtual channel %v", c)
+	}
+	// Convert the channel's data before updating its data type, so that the channel
+	// is never stored with a data type that doesn't match its data.
+	if err := lp.TSChannel.ConvertChannel(ctx, key.StorageKey(), dataType); err != nil {
+		return err
+	}
+	c.DataType = dataType
+	return gorp.NewCreate[Key, Channel]().Entry(&c).Exec(ctx, tx)
+}
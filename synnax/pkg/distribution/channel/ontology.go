@@ -23,6 +23,10 @@ import (
 
 const OntologyType ontology.Type = "channel"
 
+// OntologyTypeID is the ontology.ID of the channel type, used to grant or check access
+// to channels that do not exist yet.
+var OntologyTypeID = ontology.ID{Type: OntologyType, Key: ""}
+
 // OntologyID returns a unique identifier for a Channel for use within a resource
 // ontology.
 func OntologyID(k Key) ontology.ID {
@@ -13,15 +13,18 @@ import (
 	"go/types"
 
 	"github.com/synnaxlabs/freighter"
+	"github.com/synnaxlabs/x/telem"
 )
 
 type (
-	CreateTransportClient = freighter.UnaryClient[CreateMessage, CreateMessage]
-	CreateTransportServer = freighter.UnaryServer[CreateMessage, CreateMessage]
-	DeleteTransportClient = freighter.UnaryClient[DeleteRequest, types.Nil]
-	DeleteTransportServer = freighter.UnaryServer[DeleteRequest, types.Nil]
-	RenameTransportServer = freighter.UnaryServer[RenameRequest, types.Nil]
-	RenameTransportClient = freighter.UnaryClient[RenameRequest, types.Nil]
+	CreateTransportClient  = freighter.UnaryClient[CreateMessage, CreateMessage]
+	CreateTransportServer  = freighter.UnaryServer[CreateMessage, CreateMessage]
+	DeleteTransportClient  = freighter.UnaryClient[DeleteRequest, types.Nil]
+	DeleteTransportServer  = freighter.UnaryServer[DeleteRequest, types.Nil]
+	RenameTransportServer  = freighter.UnaryServer[RenameRequest, types.Nil]
+	RenameTransportClient  = freighter.UnaryClient[RenameRequest, types.Nil]
+	ConvertTransportServer = freighter.UnaryServer[ConvertRequest, types.Nil]
+	ConvertTransportClient = freighter.UnaryClient[ConvertRequest, types.Nil]
 )
 
 type Transport interface {
@@ -31,6 +34,8 @@ type Transport interface {
 	DeleteServer() DeleteTransportServer
 	RenameClient() RenameTransportClient
 	RenameServer() RenameTransportServer
+	ConvertClient() ConvertTransportClient
+	ConvertServer() ConvertTransportServer
 }
 
 type CreateMessage struct {
@@ -43,6 +48,11 @@ type RenameRequest struct {
 	Names []string
 }
 
+type ConvertRequest struct {
+	Key      Key
+	DataType telem.DataType
+}
+
 type DeleteRequest struct {
 	Keys Keys
 }
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/synnaxlabs/x/gorp"
+	"github.com/synnaxlabs/x/telem"
 )
 
 type Writer interface {
@@ -25,6 +26,9 @@ type Writer interface {
 	DeleteManyByNames(ctx context.Context, names []string, allowInternal bool) error
 	Rename(ctx context.Context, key Key, newName string, allowInternal bool) error
 	RenameMany(ctx context.Context, keys []Key, newNames []string, allowInternal bool) error
+	// ConvertDataType changes the data type of the channel with the given key, converting
+	// all of its existing samples to the new data type on the channel's leaseholder.
+	ConvertDataType(ctx context.Context, key Key, dataType telem.DataType, allowInternal bool) error
 }
 
 type writer struct {
@@ -95,6 +99,15 @@ func (w writer) RenameMany(
 	return w.proxy.rename(ctx, w.tx, keys, newNames, allowInternal)
 }
 
+func (w writer) ConvertDataType(
+	ctx context.Context,
+	key Key,
+	dataType telem.DataType,
+	allowInternal bool,
+) error {
+	return w.proxy.convert(ctx, w.tx, key, dataType, allowInternal)
+}
+
 func applyAdjustments(c Channel) Channel {
 	c.Name = strings.TrimSpace(c.Name)
 	return c
@@ -0,0 +1,249 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package calculator
+
+import (
+	"sort"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/x/computron"
+	"github.com/synnaxlabs/x/telem"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// maxPendingSamples is the number of samples of the first required channel that are
+// held while waiting for the samples of the other required channels needed to align
+// them. Once exceeded, the oldest samples are aligned using the samples that have
+// been received so far.
+const maxPendingSamples = 1 << 16
+
+// maxTimelineSamples is the number of samples of each of the other required channels
+// that are held while waiting for the samples of the first required channel. Once
+// exceeded, the oldest samples are discarded, so that the other required channels do
+// not accumulate samples without bound when the first stops receiving them.
+const maxTimelineSamples = 1 << 16
+
+// needsAlignment returns true if the required channels of a calculation have
+// different indexes and must be aligned by their timestamps. Calculations whose
+// required channels share an index, or that require channels without an index, are
+// calculated sample by sample.
+func needsAlignment(requires []channel.Channel) bool {
+	indexes := make(map[channel.Key]struct{}, len(requires))
+	for _, ch := range requires {
+		idx := ch.Index()
+		if idx == 0 {
+			return false
+		}
+		indexes[idx] = struct{}{}
+	}
+	return len(indexes) > 1
+}
+
+// timeline holds the recent samples of a required channel along with their
+// timestamps.
+type timeline struct {
+	stamps []telem.TimeStamp
+	values []lua.LValue
+}
+
+// at returns the value of the timeline at the given timestamp using the given
+// interpolation. Timestamps before the first sample take the value of the first
+// sample. at returns false if the value cannot be determined until later samples are
+// received, unless force is true, in which case the value is determined using the
+// samples received so far.
+func (t *timeline) at(
+	ts telem.TimeStamp,
+	interp channel.Interpolation,
+	force bool,
+) (lua.LValue, bool) {
+	n := len(t.stamps)
+	if n == 0 {
+		return lua.LNil, force
+	}
+	// i is the position of the first sample at or after ts.
+	i := sort.Search(n, func(i int) bool { return t.stamps[i] >= ts })
+	if i < n && t.stamps[i] == ts {
+		return t.values[i], true
+	}
+	if i == 0 {
+		return t.values[0], true
+	}
+	if interp == channel.InterpolationHoldLast || i == n {
+		return t.values[i-1], interp == channel.InterpolationHoldLast || force
+	}
+	before, after := t.values[i-1], t.values[i]
+	if interp == channel.InterpolationNearest {
+		if ts-t.stamps[i-1] <= t.stamps[i]-ts {
+			return before, true
+		}
+		return after, true
+	}
+	b, bOk := before.(lua.LNumber)
+	a, aOk := after.(lua.LNumber)
+	if !bOk || !aOk {
+		return before, true
+	}
+	frac := float64(ts-t.stamps[i-1]) / float64(t.stamps[i]-t.stamps[i-1])
+	return b + lua.LNumber(frac)*(a-b), true
+}
+
+// trim removes the samples that are not needed to find the values of the timeline at
+// or after the given timestamp.
+func (t *timeline) trim(ts telem.TimeStamp) {
+	i := sort.Search(len(t.stamps), func(i int) bool { return t.stamps[i] > ts }) - 1
+	if i <= 0 {
+		return
+	}
+	t.stamps = t.stamps[i:]
+	t.values = t.values[i:]
+}
+
+// limit discards the oldest samples of the timeline so that it holds at most n
+// samples.
+func (t *timeline) limit(n int) {
+	if excess := len(t.stamps) - n; excess > 0 {
+		t.stamps = t.stamps[excess:]
+		t.values = t.values[excess:]
+	}
+}
+
+// alignedSample is a sample of the first required channel of a calculation along
+// with the values of the other required channels at its timestamp.
+type alignedSample struct {
+	stamp     telem.TimeStamp
+	alignment telem.AlignmentPair
+	// values are the values of the required channels, in the order of the
+	// calculated channel's Requires.
+	values []lua.LValue
+}
+
+// aligner aligns the samples of the required channels of a calculation to the
+// timestamps of the first required channel.
+type aligner struct {
+	interpolation channel.Interpolation
+	// requires are the required channels, in the order of the calculated channel's
+	// Requires.
+	requires  []channel.Channel
+	timelines map[channel.Key]*timeline
+	// pending are the samples of the first required channel that have not been
+	// aligned.
+	pending []alignedSample
+}
+
+func newAligner(interp channel.Interpolation, requires []channel.Channel) *aligner {
+	a := &aligner{
+		interpolation: interp,
+		requires:      requires,
+		timelines:     make(map[channel.Key]*timeline, len(requires)),
+	}
+	for _, ch := range requires[1:] {
+		a.timelines[ch.Key()] = &timeline{}
+	}
+	return a
+}
+
+// add adds the samples of the required channels in the frame. The timestamps of each
+// series are read from the series of its index with the same alignment, and series
+// without their index in the frame are ignored.
+func (a *aligner) add(fr core.Frame) {
+	for i, key := range fr.Keys {
+		series := fr.Series[i]
+		var (
+			ch channel.Channel
+			ok bool
+		)
+		for _, r := range a.requires {
+			if r.Key() == key {
+				ch, ok = r, true
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		index, ok := findMatching(fr, ch.Index(), series)
+		if !ok {
+			continue
+		}
+		tl := a.timelines[key]
+		for j := range series.Len() {
+			ts := telem.ValueAt[telem.TimeStamp](index, j)
+			v := computron.LValueFromSeries(series, j)
+			if tl == nil {
+				a.pending = append(a.pending, alignedSample{
+					stamp:     ts,
+					alignment: series.Alignment.AddSamples(uint32(j)),
+					values:    []lua.LValue{v},
+				})
+				continue
+			}
+			tl.stamps = append(tl.stamps, ts)
+			tl.values = append(tl.values, v)
+		}
+		if tl != nil {
+			tl.limit(maxTimelineSamples)
+		}
+	}
+}
+
+// aligned returns the pending samples that can be aligned with the samples of the
+// other required channels received so far, removing them from the pending samples.
+// If flush is true, every pending sample is aligned using the samples received so far.
+func (a *aligner) aligned(flush bool) []alignedSample {
+	var n int
+	for ; n < len(a.pending); n++ {
+		var (
+			s     = &a.pending[n]
+			force = flush || len(a.pending)-n > maxPendingSamples
+			ready = true
+		)
+		s.values = s.values[:1]
+		for _, ch := range a.requires[1:] {
+			v, ok := a.timelines[ch.Key()].at(s.stamp, a.interpolation, force)
+			if !ok {
+				ready = false
+				break
+			}
+			s.values = append(s.values, v)
+		}
+		if !ready {
+			break
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	aligned := a.pending[:n:n]
+	a.pending = a.pending[n:]
+	for _, tl := range a.timelines {
+		tl.trim(aligned[n-1].stamp)
+	}
+	return aligned
+}
+
+// findMatching returns the series of the channel with the given key in the frame that
+// holds the samples at the same timestamps as the given series of a channel with the
+// same index, such as the series of the index itself. Series written together share
+// the same alignment, and series read from storage over the same range of time share
+// the same time range.
+func findMatching(fr core.Frame, key channel.Key, series telem.Series) (telem.Series, bool) {
+	for i, k := range fr.Keys {
+		s := fr.Series[i]
+		if k != key || s.Len() != series.Len() {
+			continue
+		}
+		if s.Alignment == series.Alignment ||
+			(!s.TimeRange.IsZero() && s.TimeRange == series.TimeRange) {
+			return s, true
+		}
+	}
+	return telem.Series{}, false
+}
@@ -0,0 +1,271 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package calculator evaluates the expressions of calculated channels over the samples
+// of the channels they require, both on live streams and on historical reads.
+package calculator
+
+import (
+	"slices"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/x/computron"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// Calculator calculates the samples of a calculated channel from the samples of the
+// channels it requires. The samples of materialized channels are returned along with
+// a series of their timestamps for the channel's index, so that they can be written.
+type Calculator struct {
+	ch          channel.Channel
+	calculation *computron.Calculator
+	// requires are the required channels, in the order of the calculated channel's
+	// Requires.
+	requires []channel.Channel
+	// aligner is set when the required channels have different indexes, and aligns
+	// their samples to the timestamps of the first required channel.
+	aligner *aligner
+	// evaluator aligns the samples of the required channels passed to Evaluate, and
+	// holds the last samples of each required channel between calls.
+	evaluator *aligner
+}
+
+// Open opens a calculator for the calculated channel ch, whose required channels are
+// given by requires. The calculator must be closed when it is no longer needed.
+func Open(ch channel.Channel, requires []channel.Channel) (*Calculator, error) {
+	if !ch.IsCalculated() && !ch.IsMaterialized() {
+		return nil, errors.Newf("channel %v is not calculated", ch)
+	}
+	// Order the required channels as they are in the calculated channel, as the
+	// first one determines the timestamps of the calculated samples.
+	requires = slices.Clone(requires)
+	slices.SortFunc(requires, func(a, b channel.Channel) int {
+		return slices.Index(ch.Requires, a.Key()) - slices.Index(ch.Requires, b.Key())
+	})
+	calculation, err := computron.Open(ch.Expression)
+	if err != nil {
+		return nil, err
+	}
+	c := &Calculator{ch: ch, calculation: calculation, requires: requires}
+	// The samples of materialized channels are written along with their timestamps,
+	// so they are always aligned to the timestamps of the first required channel.
+	// Stateful expressions are aligned when possible so that the time of each sample
+	// is known.
+	if ch.IsMaterialized() ||
+		needsAlignment(requires) ||
+		(calculation.Stateful() && indexed(requires)) {
+		c.aligner = newAligner(ch.Interpolation, requires)
+	}
+	// Stateful expressions over channels without an index are calculated sample by
+	// sample, so the time between samples is taken from the rate of the first
+	// required channel.
+	if calculation.Stateful() && !indexed(requires) {
+		if len(requires) == 0 || requires[0].Rate <= 0 {
+			calculation.Close()
+			return nil, errors.Wrapf(
+				validate.Error,
+				"stateful expression of channel %v requires channels with an index or a rate",
+				ch,
+			)
+		}
+		calculation.SetPeriod(requires[0].Rate.Period())
+	}
+	return c, nil
+}
+
+// Channel returns the calculated channel.
+func (c *Calculator) Channel() channel.Channel { return c.ch }
+
+// Keys returns the keys of the channels whose samples must be streamed to Calculate:
+// the required channels, along with their indexes when the required channels must be
+// aligned by their timestamps.
+func (c *Calculator) Keys() channel.Keys {
+	keys := slices.Clone(c.ch.Requires)
+	if c.aligner == nil {
+		return keys
+	}
+	return c.withIndexes(keys)
+}
+
+// ReadKeys returns the keys of the channels that must be read from storage to
+// Evaluate: the required channels along with their indexes.
+func (c *Calculator) ReadKeys() channel.Keys {
+	return c.withIndexes(slices.Clone(c.ch.Requires))
+}
+
+func (c *Calculator) withIndexes(keys channel.Keys) channel.Keys {
+	for _, r := range c.requires {
+		if idx := r.Index(); idx != 0 && !slices.Contains(keys, idx) {
+			keys = append(keys, idx)
+		}
+	}
+	return keys
+}
+
+// Close closes the calculator.
+func (c *Calculator) Close() { c.calculation.Close() }
+
+// Calculate calculates the samples of the channel from a frame of samples streamed
+// from the required channels. When the required channels must be aligned by their
+// timestamps, samples that cannot be aligned yet are held until the samples of the
+// other required channels arrive in later frames.
+func (c *Calculator) Calculate(fr core.Frame) (of core.Frame, err error) {
+	if c.aligner != nil {
+		c.aligner.add(fr)
+		return c.calculateAligned(c.aligner.aligned(false))
+	}
+	if len(fr.Series) == 0 {
+		return
+	}
+	for _, ch := range c.requires {
+		if series := fr.Get(ch.Key()); len(series) > 0 {
+			c.calculation.SetSeries(ch.Name, series[0])
+		}
+	}
+	os, err := c.calculation.RunSeries(c.ch.DataType, fr.Series[0].Len())
+	if err != nil {
+		return of, err
+	}
+	// Mark the alignment of the output series as the same as the input series. Input
+	// channels with different indexes are aligned by calculateAligned, so the input
+	// channels here share the same index.
+	os.Alignment = fr.Series[0].Alignment
+	of.Keys = []channel.Key{c.ch.Key()}
+	of.Series = []telem.Series{os}
+	return of, nil
+}
+
+// Evaluate calculates the samples of the channel from a frame holding the samples of
+// the required channels, and their indexes, read from storage over a range of time.
+// Unlike Calculate, Evaluate calculates every sample of the first required channel in
+// the frame. When the required channels must be aligned by their timestamps, the
+// samples of the required channels are aligned to the timestamps of the first
+// required channel using the samples in the frame, along with the samples of each
+// required channel passed to previous calls, so that consecutive frames are aligned
+// as if they were read together. Samples that depend on samples of the other required
+// channels that have not been evaluated yet are held until those samples are
+// evaluated, or until Flush is called. Reset must be called before evaluating a frame
+// that does not follow the previous one in time. Otherwise, the channel is calculated
+// series by series: the series of required channels sharing an index are matched by
+// the time range of their samples, and the series of required channels without an
+// index in the order they appear in the frame.
+func (c *Calculator) Evaluate(fr core.Frame) (of core.Frame, err error) {
+	if c.aligner != nil {
+		if c.evaluator == nil {
+			c.evaluator = newAligner(c.ch.Interpolation, c.requires)
+		}
+		c.evaluator.add(fr)
+		return c.evaluateAligned(false)
+	}
+	if len(c.requires) == 0 {
+		return
+	}
+	shared := indexed(c.requires)
+	for i, first := range fr.Get(c.requires[0].Key()) {
+		for _, ch := range c.requires {
+			var s telem.Series
+			if shared {
+				s, _ = findMatching(fr, ch.Key(), first)
+			} else if series := fr.Get(ch.Key()); i < len(series) {
+				s = series[i]
+			}
+			c.calculation.SetSeries(ch.Name, s)
+		}
+		os, err := c.calculation.RunSeries(c.ch.DataType, first.Len())
+		if err != nil {
+			return of, err
+		}
+		os.Alignment = first.Alignment
+		os.TimeRange = first.TimeRange
+		of.Keys = append(of.Keys, c.ch.Key())
+		of.Series = append(of.Series, os)
+	}
+	return of, nil
+}
+
+// Flush calculates the samples held by Evaluate from previous calls, aligning them
+// using the samples of the required channels evaluated so far. Flush should be called
+// once there are no more frames to evaluate.
+func (c *Calculator) Flush() (core.Frame, error) {
+	if c.evaluator == nil {
+		return core.Frame{}, nil
+	}
+	return c.evaluateAligned(true)
+}
+
+// evaluateAligned calculates the samples held by the evaluator that can be aligned,
+// setting the time range of the calculated series to the timestamps of the samples.
+func (c *Calculator) evaluateAligned(flush bool) (of core.Frame, err error) {
+	samples := c.evaluator.aligned(flush)
+	if of, err = c.calculateAligned(samples); err != nil || len(samples) == 0 {
+		return of, err
+	}
+	for i := range of.Series {
+		of.Series[i].TimeRange = telem.TimeRange{
+			Start: samples[0].stamp,
+			End:   samples[len(samples)-1].stamp + 1,
+		}
+	}
+	return of, nil
+}
+
+// Reset discards the samples held by Evaluate from previous calls, along with the
+// state of any stateful functions in the expression.
+func (c *Calculator) Reset() {
+	c.evaluator = nil
+	c.calculation.Reset()
+}
+
+// calculateAligned calculates a sample for each sample of the first required channel
+// whose timestamp the samples of the other required channels have been aligned to.
+func (c *Calculator) calculateAligned(samples []alignedSample) (of core.Frame, err error) {
+	if len(samples) == 0 {
+		return
+	}
+	os := telem.AllocSeries(c.ch.DataType, int64(len(samples)))
+	os.Alignment = samples[0].alignment
+	of.Keys = []channel.Key{c.ch.Key()}
+	of.Series = []telem.Series{os}
+	for i, s := range samples {
+		c.calculation.SetTime(s.stamp)
+		for j, ch := range c.requires {
+			c.calculation.Set(ch.Name, s.values[j])
+		}
+		res, err := c.calculation.Run()
+		if err != nil {
+			return of, err
+		}
+		computron.SetLValueOnSeries(res, os, int64(i))
+	}
+	if c.ch.IsMaterialized() {
+		stamps := make([]telem.TimeStamp, len(samples))
+		for i, s := range samples {
+			stamps[i] = s.stamp
+		}
+		index := telem.NewSeries(stamps)
+		index.Alignment = os.Alignment
+		of.Keys = append(of.Keys, c.ch.Index())
+		of.Series = append(of.Series, index)
+	}
+	return of, nil
+}
+
+// indexed returns true if there is at least one required channel, and every required
+// channel has an index.
+func indexed(requires []channel.Channel) bool {
+	for _, r := range requires {
+		if r.Index() == 0 {
+			return false
+		}
+	}
+	return len(requires) > 0
+}
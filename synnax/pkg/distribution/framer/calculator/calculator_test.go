@@ -0,0 +1,196 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package calculator_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/core"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/calculator"
+	fcore "github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/telem/testutil"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Calculator", func() {
+	var (
+		a = channel.Channel{Name: "a", Leaseholder: 1, LocalKey: 1, DataType: telem.Int64T, Rate: telem.Hz}
+		b = channel.Channel{Name: "b", Leaseholder: 1, LocalKey: 2, DataType: telem.Int64T, Rate: telem.Hz}
+	)
+	calculated := func(expression string) channel.Channel {
+		return channel.Channel{
+			Name:        "calculated",
+			Leaseholder: core.Free,
+			LocalKey:    3,
+			DataType:    telem.Int64T,
+			Virtual:     true,
+			Requires:    []channel.Key{a.Key(), b.Key()},
+			Expression:  expression,
+		}
+	}
+
+	It("Should not open a calculator for a channel that is not calculated", func() {
+		_, err := calculator.Open(a, nil)
+		Expect(err).To(MatchError(ContainSubstring("is not calculated")))
+	})
+
+	Describe("Evaluate", func() {
+		It("Should evaluate the series of required channels without an index in order", func() {
+			c := MustSucceed(calculator.Open(calculated("return a + b"), []channel.Channel{b, a}))
+			defer c.Close()
+			tr := (10 * telem.SecondTS).Range(13 * telem.SecondTS)
+			first := telem.NewSeriesV[int64](1, 2, 3)
+			first.TimeRange = tr
+			fr := MustSucceed(c.Evaluate(fcore.Frame{
+				Keys: channel.Keys{a.Key(), b.Key(), a.Key(), b.Key()},
+				Series: []telem.Series{
+					first,
+					telem.NewSeriesV[int64](10, 20, 30),
+					telem.NewSeriesV[int64](4, 5),
+					telem.NewSeriesV[int64](40),
+				},
+			}))
+			Expect(fr.Keys).To(HaveLen(2))
+			Expect(fr.Series[0].Data).To(EqualUnmarshal([]int64{11, 22, 33}))
+			Expect(fr.Series[0].TimeRange).To(Equal(tr))
+			Expect(fr.Series[1].Data).To(EqualUnmarshal([]int64{44, 45}))
+		})
+
+		It("Should match the series of required channels sharing an index", func() {
+			var (
+				x = channel.Channel{Name: "x", Leaseholder: 1, LocalKey: 5, DataType: telem.Int64T, LocalIndex: 4}
+				y = channel.Channel{Name: "y", Leaseholder: 1, LocalKey: 6, DataType: telem.Int64T, LocalIndex: 4}
+			)
+			c := MustSucceed(calculator.Open(channel.Channel{
+				Name:        "sum",
+				Leaseholder: core.Free,
+				LocalKey:    7,
+				DataType:    telem.Int64T,
+				Virtual:     true,
+				Requires:    []channel.Key{x.Key(), y.Key()},
+				Expression:  "return x + y",
+			}, []channel.Channel{x, y}))
+			defer c.Close()
+			first := (10 * telem.SecondTS).Range(12 * telem.SecondTS)
+			second := (20 * telem.SecondTS).Range(22 * telem.SecondTS)
+			series := func(tr telem.TimeRange, values ...int64) telem.Series {
+				s := telem.NewSeries(values)
+				s.TimeRange = tr
+				if tr == second {
+					s.Alignment = telem.NewAlignmentPair(1, 0)
+				}
+				return s
+			}
+			fr := MustSucceed(c.Evaluate(fcore.Frame{
+				Keys: channel.Keys{x.Key(), x.Key(), y.Key(), y.Key()},
+				Series: []telem.Series{
+					series(first, 1, 2),
+					series(second, 3, 4),
+					series(second, 30, 40),
+					series(first, 10, 20),
+				},
+			}))
+			Expect(fr.Series).To(HaveLen(2))
+			Expect(fr.Series[0].Data).To(EqualUnmarshal([]int64{11, 22}))
+			Expect(fr.Series[0].TimeRange).To(Equal(first))
+			Expect(fr.Series[1].Data).To(EqualUnmarshal([]int64{33, 44}))
+			Expect(fr.Series[1].TimeRange).To(Equal(second))
+		})
+
+		It("Should return an error when the expression fails", func() {
+			c := MustSucceed(calculator.Open(calculated("return a + nothing"), []channel.Channel{a, b}))
+			defer c.Close()
+			_, err := c.Evaluate(fcore.Frame{
+				Keys:   channel.Keys{a.Key(), b.Key()},
+				Series: []telem.Series{telem.NewSeriesV[int64](1), telem.NewSeriesV[int64](2)},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Describe("Stateful Expressions", func() {
+		var (
+			idx = channel.Channel{Name: "idx", Leaseholder: 1, LocalKey: 4, DataType: telem.TimeStampT, IsIndex: true}
+			x   = channel.Channel{Name: "x", Leaseholder: 1, LocalKey: 5, DataType: telem.Int64T, LocalIndex: 4}
+		)
+		integral := channel.Channel{
+			Name:        "integral",
+			Leaseholder: core.Free,
+			LocalKey:    6,
+			DataType:    telem.Int64T,
+			Virtual:     true,
+			Requires:    []channel.Key{x.Key()},
+			Expression:  "return integrate(x)",
+		}
+		frame := func(stamps []telem.TimeStamp, values []int64) fcore.Frame {
+			s := telem.NewSeries(stamps)
+			s.Alignment = telem.NewAlignmentPair(1, 0)
+			v := telem.NewSeries(values)
+			v.Alignment = s.Alignment
+			return fcore.Frame{Keys: channel.Keys{idx.Key(), x.Key()}, Series: []telem.Series{s, v}}
+		}
+
+		It("Should take the time between samples from the rate of required channels without an index", func() {
+			r := channel.Channel{Name: "r", Leaseholder: 1, LocalKey: 7, DataType: telem.Float64T, Rate: 2 * telem.Hz}
+			c := MustSucceed(calculator.Open(channel.Channel{
+				Name:        "integral",
+				Leaseholder: core.Free,
+				LocalKey:    8,
+				DataType:    telem.Float64T,
+				Virtual:     true,
+				Requires:    []channel.Key{r.Key()},
+				Expression:  "return integrate(r)",
+			}, []channel.Channel{r}))
+			defer c.Close()
+			fr := MustSucceed(c.Evaluate(fcore.Frame{
+				Keys:   channel.Keys{r.Key()},
+				Series: []telem.Series{telem.NewSeriesV[float64](1, 1, 1)},
+			}))
+			Expect(fr.Series[0].Data).To(EqualUnmarshal([]float64{0, 0.5, 1}))
+		})
+
+		It("Should not open a calculator for a stateful expression over channels without an index or rate", func() {
+			v := channel.Channel{Name: "v", Leaseholder: 1, LocalKey: 7, DataType: telem.Float64T, Virtual: true}
+			_, err := calculator.Open(channel.Channel{
+				Name:        "integral",
+				Leaseholder: core.Free,
+				LocalKey:    8,
+				DataType:    telem.Float64T,
+				Virtual:     true,
+				Requires:    []channel.Key{v.Key()},
+				Expression:  "return integrate(v)",
+			}, []channel.Channel{v})
+			Expect(err).To(HaveOccurredAs(validate.Error))
+		})
+
+		It("Should stream the index of the required channels", func() {
+			c := MustSucceed(calculator.Open(integral, []channel.Channel{x}))
+			defer c.Close()
+			Expect(c.Keys()).To(ConsistOf(x.Key(), idx.Key()))
+		})
+
+		It("Should keep the state of the expression across frames", func() {
+			c := MustSucceed(calculator.Open(integral, []channel.Channel{x}))
+			defer c.Close()
+			fr := MustSucceed(c.Calculate(frame(
+				[]telem.TimeStamp{0, 2 * telem.SecondTS},
+				[]int64{3, 3},
+			)))
+			Expect(fr.Get(integral.Key())[0].Data).To(EqualUnmarshal([]int64{0, 6}))
+			fr = MustSucceed(c.Calculate(frame(
+				[]telem.TimeStamp{3 * telem.SecondTS},
+				[]int64{5},
+			)))
+			Expect(fr.Get(integral.Key())[0].Data).To(EqualUnmarshal([]int64{10}))
+		})
+	})
+})
@@ -0,0 +1,214 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package iterator
+
+import (
+	"context"
+	"slices"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/calculator"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// openCalculators opens a calculator for each of the given calculated channels. The
+// channels they require must be stored, as calculated channels are evaluated from the
+// samples of their required channels read from storage.
+func (s *Service) openCalculators(
+	ctx context.Context,
+	calculated []channel.Channel,
+) (calculators []*calculator.Calculator, err error) {
+	defer func() {
+		if err != nil {
+			for _, c := range calculators {
+				c.Close()
+			}
+		}
+	}()
+	for _, ch := range calculated {
+		var requires []channel.Channel
+		if err = s.ChannelReader.NewRetrieve().
+			WhereKeys(ch.Requires...).
+			Entries(&requires).
+			Exec(ctx, nil); err != nil {
+			return calculators, err
+		}
+		for _, r := range requires {
+			if r.Virtual {
+				return calculators, errors.Wrapf(
+					validate.Error,
+					"cannot read calculated channel %v, as it requires virtual channel %v",
+					ch,
+					r,
+				)
+			}
+		}
+		c, err := calculator.Open(ch, requires)
+		if err != nil {
+			return calculators, err
+		}
+		calculators = append(calculators, c)
+	}
+	return calculators, nil
+}
+
+// calculation evaluates calculated channels over the samples of their required
+// channels read by an iterator. The data responses for each request are merged and
+// evaluated when the request is acknowledged, so that the samples of the required
+// channels read from different nodes are evaluated together. Samples of channels that
+// were read only to evaluate calculated channels are not sent to the caller.
+type calculation struct {
+	confluence.AbstractLinear[Response, Response]
+	calculators []*calculator.Calculator
+	// keys are the keys of the channels requested by the caller.
+	keys channel.Keys
+	// err is the accumulated error from evaluating calculated channels, returned in
+	// response to an Error request.
+	err error
+}
+
+var _ confluence.Segment[Response, Response] = (*calculation)(nil)
+
+func newCalculation(calculators []*calculator.Calculator, keys channel.Keys) *calculation {
+	return &calculation{calculators: calculators, keys: keys}
+}
+
+// Flow implements confluence.Flow.
+func (c *calculation) Flow(sCtx signal.Context, opts ...confluence.Option) {
+	o := confluence.NewOptions(opts)
+	o.AttachClosables(c.Out)
+	sCtx.Go(func(ctx context.Context) error {
+		defer func() {
+			for _, calc := range c.calculators {
+				calc.Close()
+			}
+		}()
+		var data []Response
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case res, ok := <-c.In.Outlet():
+				if !ok {
+					return nil
+				}
+				if res.Variant == DataResponse {
+					data = append(data, res)
+					continue
+				}
+				if err := c.ack(ctx, data, res); err != nil {
+					return err
+				}
+				data = nil
+			}
+		}
+	}, o.Signal...)
+}
+
+// ack evaluates the calculated channels over the data responses received for a
+// request, and sends the result to the caller followed by the acknowledgement.
+func (c *calculation) ack(ctx context.Context, data []Response, ack Response) error {
+	var flush bool
+	switch ack.Command {
+	case Next:
+		// Samples held by the calculators until the samples they are aligned with are
+		// read are calculated once the iterator is exhausted. The caller only reads
+		// the samples of a Next request that is acknowledged, so the request is
+		// acknowledged when there are samples to return.
+		flush = !ack.Ack
+	case Valid, Error:
+	default:
+		// The samples read after any other command do not follow the samples read
+		// before it, so the calculators cannot align them with earlier samples.
+		for _, calc := range c.calculators {
+			calc.Reset()
+		}
+		flush = true
+	}
+	if len(data) > 0 || flush {
+		frames := make([]core.Frame, len(data))
+		for i, res := range data {
+			frames[i] = res.Frame
+		}
+		fr, err := c.evaluate(core.MergeFrames(frames), flush)
+		if err != nil {
+			c.err = errors.Combine(c.err, err)
+			ack.Ack = false
+		} else if ack.Command == Next && len(fr.Keys) > 0 {
+			ack.Ack = true
+		}
+		if len(fr.Keys) > 0 {
+			res := Response{
+				Variant: DataResponse,
+				Command: ack.Command,
+				Frame:   fr,
+				NodeKey: ack.NodeKey,
+				SeqNum:  ack.SeqNum,
+			}
+			if len(data) > 0 {
+				res.NodeKey = data[0].NodeKey
+			}
+			if err = signal.SendUnderContext(ctx, c.Out.Inlet(), res); err != nil {
+				return err
+			}
+		}
+	}
+	if ack.Command == Error {
+		ack.Error = errors.Combine(ack.Error, c.err)
+	}
+	return signal.SendUnderContext(ctx, c.Out.Inlet(), ack)
+}
+
+// evaluate returns the series of the requested channels in the frame, along with the
+// series of the calculated channels evaluated from it. If flush is true, the samples
+// held by the calculators are calculated as well.
+func (c *calculation) evaluate(fr core.Frame, flush bool) (core.Frame, error) {
+	out := fr.FilterKeys(c.keys)
+	for _, calc := range c.calculators {
+		calculated, err := calc.Evaluate(fr)
+		if err != nil {
+			return out, err
+		}
+		out = out.Extend(calculated)
+		if !flush {
+			continue
+		}
+		if calculated, err = calc.Flush(); err != nil {
+			return out, err
+		}
+		out = out.Extend(calculated)
+	}
+	return out, nil
+}
+
+// withCalculatedRequires returns the keys of the channels that must be read to serve
+// the given keys, replacing the calculated channels with the channels required to
+// evaluate them.
+func withCalculatedRequires(
+	keys channel.Keys,
+	calculators []*calculator.Calculator,
+) channel.Keys {
+	read := make(channel.Keys, 0, len(keys))
+	for _, k := range keys {
+		if !slices.ContainsFunc(calculators, func(c *calculator.Calculator) bool {
+			return c.Channel().Key() == k
+		}) {
+			read = append(read, k)
+		}
+	}
+	for _, c := range calculators {
+		read = append(read, c.ReadKeys()...)
+	}
+	return read.Unique()
+}
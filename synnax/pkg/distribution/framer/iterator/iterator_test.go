@@ -16,6 +16,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
 	dcore "github.com/synnaxlabs/synnax/pkg/distribution/core"
+	"github.com/synnaxlabs/synnax/pkg/distribution/core/mock"
 	"github.com/synnaxlabs/synnax/pkg/distribution/framer/core"
 	"github.com/synnaxlabs/synnax/pkg/distribution/framer/iterator"
 	"github.com/synnaxlabs/synnax/pkg/distribution/framer/writer"
@@ -108,6 +109,307 @@ var _ = Describe("Iterator", func() {
 			})
 		}
 	})
+
+	Describe("Parallel", Ordered, func() {
+		var (
+			builder *mock.CoreBuilder
+			svc     serviceContainer
+			keys    channel.Keys
+		)
+		BeforeAll(func() {
+			var services map[dcore.NodeKey]serviceContainer
+			builder, services = provision(3)
+			svc = services[1]
+			channels := []channel.Channel{
+				{Name: "gateway", Rate: 1 * telem.Hz, DataType: telem.Int64T},
+				{Name: "peer", Rate: 1 * telem.Hz, DataType: telem.Int64T, Leaseholder: 2},
+			}
+			Expect(svc.channel.NewWriter(nil).CreateMany(ctx, &channels)).To(Succeed())
+			Eventually(func(g Gomega) {
+				var chs []channel.Channel
+				g.Expect(svc.channel.NewRetrieve().
+					Entries(&chs).
+					WhereKeys(channel.KeysFromChannels(channels)...).
+					Exec(ctx, nil)).To(Succeed())
+				g.Expect(chs).To(HaveLen(len(channels)))
+			}).Should(Succeed())
+			keys = channel.KeysFromChannels(channels)
+			write := func(key channel.Key, start telem.TimeStamp, series telem.Series) {
+				w := MustSucceed(svc.writer.New(ctx, writer.Config{
+					Keys:  channel.Keys{key},
+					Start: start,
+				}))
+				Expect(w.Write(core.Frame{
+					Keys:   channel.Keys{key},
+					Series: []telem.Series{series},
+				})).To(BeTrue())
+				Expect(w.Commit()).To(BeTrue())
+				Expect(w.Close()).To(Succeed())
+			}
+			write(keys[0], 10*telem.SecondTS, telem.NewSeriesV[int64](10, 11, 12, 13, 14, 15))
+			write(keys[1], 14*telem.SecondTS, telem.NewSeriesV[int64](14, 15, 16, 17, 18, 19))
+		})
+		AfterAll(func() { Expect(builder.Close()).To(Succeed()) })
+
+		It("Should merge the chunks of each node in time order", func() {
+			iter := MustSucceed(svc.iter.New(ctx, iterator.Config{
+				Keys:           keys,
+				Bounds:         telem.TimeRangeMax,
+				ChunkSize:      3,
+				PrefetchWindow: 2,
+			}))
+			Expect(iter.SeekFirst()).To(BeTrue())
+			Expect(iter.Next(iterator.AutoSpan)).To(BeTrue())
+			Expect(iter.Value().Keys).To(Equal(channel.Keys{keys[0]}))
+			Expect(iter.Value().Series[0].Data).To(EqualUnmarshal([]int64{10, 11, 12}))
+			Expect(iter.Next(iterator.AutoSpan)).To(BeTrue())
+			fr := iter.Value()
+			Expect(fr.Get(keys[0])[0].Data).To(EqualUnmarshal([]int64{13, 14, 15}))
+			Expect(fr.Get(keys[1])[0].Data).To(EqualUnmarshal([]int64{14, 15, 16}))
+			Expect(iter.Next(iterator.AutoSpan)).To(BeTrue())
+			Expect(iter.Value().Keys).To(Equal(channel.Keys{keys[1]}))
+			Expect(iter.Value().Series[0].Data).To(EqualUnmarshal([]int64{17, 18, 19}))
+			Expect(iter.Next(iterator.AutoSpan)).To(BeFalse())
+			Expect(iter.Close()).To(Succeed())
+		})
+
+		It("Should return nodes to the position of the caller after prefetching", func() {
+			iter := MustSucceed(svc.iter.New(ctx, iterator.Config{
+				Keys:      keys,
+				Bounds:    telem.TimeRangeMax,
+				ChunkSize: 3,
+			}))
+			Expect(iter.SeekFirst()).To(BeTrue())
+			Expect(iter.Next(iterator.AutoSpan)).To(BeTrue())
+			Expect(iter.Value().Series[0].Data).To(EqualUnmarshal([]int64{10, 11, 12}))
+			Expect(iter.Next(2 * telem.Second)).To(BeTrue())
+			fr := iter.Value()
+			Expect(fr.Get(keys[0])[0].Data).To(EqualUnmarshal([]int64{13, 14}))
+			Expect(fr.Get(keys[1])[0].Data).To(EqualUnmarshal([]int64{14, 15}))
+			Expect(iter.Close()).To(Succeed())
+		})
+
+		It("Should continue merging chunks after commands that do not move the iterator", func() {
+			iter := MustSucceed(svc.iter.New(ctx, iterator.Config{
+				Keys:      keys,
+				Bounds:    telem.TimeRangeMax,
+				ChunkSize: 3,
+			}))
+			Expect(iter.SeekFirst()).To(BeTrue())
+			Expect(iter.Next(iterator.AutoSpan)).To(BeTrue())
+			Expect(iter.Value().Series[0].Data).To(EqualUnmarshal([]int64{10, 11, 12}))
+			iter.Valid()
+			Expect(iter.Next(iterator.AutoSpan)).To(BeTrue())
+			fr := iter.Value()
+			Expect(fr.Get(keys[0])[0].Data).To(EqualUnmarshal([]int64{13, 14, 15}))
+			Expect(fr.Get(keys[1])[0].Data).To(EqualUnmarshal([]int64{14, 15, 16}))
+			iter.Valid()
+			// The gateway has no samples in the next two seconds, so only the samples
+			// of the peer are read.
+			iter.Next(2 * telem.Second)
+			Expect(iter.Value().Keys).To(Equal(channel.Keys{keys[1]}))
+			Expect(iter.Value().Series[0].Data).To(EqualUnmarshal([]int64{17, 18}))
+			Expect(iter.Close()).To(Succeed())
+		})
+	})
+
+	Describe("Calculated", Ordered, func() {
+		var (
+			builder  *mock.CoreBuilder
+			svc      serviceContainer
+			pressure channel.Channel
+			temp     channel.Channel
+			virtual  channel.Channel
+		)
+		write := func(keys channel.Keys, stamps []telem.TimeStamp, series telem.Series) {
+			w := MustSucceed(svc.writer.New(ctx, writer.Config{Keys: keys, Start: stamps[0]}))
+			Expect(w.Write(core.Frame{
+				Keys:   keys,
+				Series: []telem.Series{telem.NewSeries(stamps), series},
+			})).To(BeTrue())
+			Expect(w.Commit()).To(BeTrue())
+			Expect(w.Close()).To(Succeed())
+		}
+		BeforeAll(func() {
+			var services map[dcore.NodeKey]serviceContainer
+			builder, services = provision(1)
+			svc = services[1]
+			create := func(ch *channel.Channel) {
+				Expect(svc.channel.NewWriter(nil).Create(ctx, ch)).To(Succeed())
+			}
+			pressureTime := channel.Channel{Name: "pressure_time", DataType: telem.TimeStampT, IsIndex: true}
+			create(&pressureTime)
+			pressure = channel.Channel{Name: "pressure", DataType: telem.Int64T, LocalIndex: pressureTime.LocalKey}
+			create(&pressure)
+			tempTime := channel.Channel{Name: "temp_time", DataType: telem.TimeStampT, IsIndex: true}
+			create(&tempTime)
+			temp = channel.Channel{Name: "temp", DataType: telem.Int64T, LocalIndex: tempTime.LocalKey}
+			create(&temp)
+			virtual = channel.Channel{Name: "virtual", DataType: telem.Int64T, Virtual: true}
+			create(&virtual)
+			write(
+				channel.Keys{pressureTime.Key(), pressure.Key()},
+				[]telem.TimeStamp{10 * telem.SecondTS, 20 * telem.SecondTS, 30 * telem.SecondTS, 40 * telem.SecondTS},
+				telem.NewSeriesV[int64](1, 2, 3, 4),
+			)
+			write(
+				channel.Keys{tempTime.Key(), temp.Key()},
+				[]telem.TimeStamp{15 * telem.SecondTS, 25 * telem.SecondTS, 35 * telem.SecondTS},
+				telem.NewSeriesV[int64](100, 200, 300),
+			)
+		})
+		AfterAll(func() { Expect(builder.Close()).To(Succeed()) })
+
+		calculated := func(expression string, requires ...channel.Key) channel.Channel {
+			ch := channel.Channel{
+				Name:        "calculated",
+				DataType:    telem.Int64T,
+				Virtual:     true,
+				Leaseholder: dcore.Free,
+				Requires:    requires,
+				Expression:  expression,
+			}
+			Expect(svc.channel.NewWriter(nil).Create(ctx, &ch)).To(Succeed())
+			return ch
+		}
+
+		It("Should evaluate a calculated channel over stored samples", func() {
+			calc := calculated("return pressure * 2", pressure.Key())
+			iter := MustSucceed(svc.iter.New(ctx, iterator.Config{
+				Keys:      channel.Keys{calc.Key()},
+				Bounds:    telem.TimeRangeMax,
+				ChunkSize: 2,
+			}))
+			Expect(iter.SeekFirst()).To(BeTrue())
+			Expect(iter.Next(iterator.AutoSpan)).To(BeTrue())
+			Expect(iter.Value().Keys).To(Equal(channel.Keys{calc.Key()}))
+			Expect(iter.Value().Series[0].Data).To(EqualUnmarshal([]int64{2, 4}))
+			Expect(iter.Next(iterator.AutoSpan)).To(BeTrue())
+			Expect(iter.Value().Series[0].Data).To(EqualUnmarshal([]int64{6, 8}))
+			Expect(iter.Next(iterator.AutoSpan)).To(BeFalse())
+			Expect(iter.Close()).To(Succeed())
+		})
+
+		It("Should align required channels with different indexes", func() {
+			calc := calculated("return pressure + temp", pressure.Key(), temp.Key())
+			iter := MustSucceed(svc.iter.New(ctx, iterator.Config{
+				Keys:   channel.Keys{calc.Key(), pressure.Key()},
+				Bounds: telem.TimeRangeMax,
+			}))
+			Expect(iter.SeekFirst()).To(BeTrue())
+			Expect(iter.Next(telem.Minute)).To(BeTrue())
+			fr := iter.Value()
+			Expect(fr.Get(temp.Key())).To(BeEmpty())
+			Expect(fr.Get(pressure.Key())[0].Data).To(EqualUnmarshal([]int64{1, 2, 3, 4}))
+			s := fr.Get(calc.Key())[0]
+			Expect(s.Data).To(EqualUnmarshal([]int64{101, 102, 203, 304}))
+			Expect(s.TimeRange).To(Equal((10 * telem.SecondTS).Range(40*telem.SecondTS + 1)))
+			Expect(iter.Close()).To(Succeed())
+		})
+
+		It("Should align required channels with the samples of previous chunks", func() {
+			calc := calculated("return pressure + temp", pressure.Key(), temp.Key())
+			iter := MustSucceed(svc.iter.New(ctx, iterator.Config{
+				Keys:   channel.Keys{calc.Key()},
+				Bounds: telem.TimeRangeMax,
+			}))
+			Expect(iter.SeekFirst()).To(BeTrue())
+			var samples []int64
+			for iter.Next(10 * telem.Second) {
+				for _, s := range iter.Value().Get(calc.Key()) {
+					samples = append(samples, telem.Unmarshal[int64](s)...)
+				}
+			}
+			Expect(samples).To(Equal([]int64{101, 102, 203, 304}))
+			Expect(iter.Close()).To(Succeed())
+		})
+
+		It("Should interpolate the same samples regardless of the chunk size", func() {
+			create := func(ch *channel.Channel) {
+				Expect(svc.channel.NewWriter(nil).Create(ctx, ch)).To(Succeed())
+			}
+			sparseTime := channel.Channel{Name: "sparse_time", DataType: telem.TimeStampT, IsIndex: true}
+			create(&sparseTime)
+			sparse := channel.Channel{Name: "sparse", DataType: telem.Int64T, LocalIndex: sparseTime.LocalKey}
+			create(&sparse)
+			denseTime := channel.Channel{Name: "dense_time", DataType: telem.TimeStampT, IsIndex: true}
+			create(&denseTime)
+			dense := channel.Channel{Name: "dense", DataType: telem.Int64T, LocalIndex: denseTime.LocalKey}
+			create(&dense)
+			write(
+				channel.Keys{sparseTime.Key(), sparse.Key()},
+				[]telem.TimeStamp{100 * telem.SecondTS, 110 * telem.SecondTS},
+				telem.NewSeriesV[int64](1, 2),
+			)
+			denseStamps := make([]telem.TimeStamp, 11)
+			denseValues := make([]int64, 11)
+			for i := range denseStamps {
+				denseStamps[i] = telem.TimeStamp(100+i) * telem.SecondTS
+				denseValues[i] = int64(i) * 10
+			}
+			write(
+				channel.Keys{denseTime.Key(), dense.Key()},
+				denseStamps,
+				telem.NewSeries(denseValues),
+			)
+			calc := channel.Channel{
+				Name:          "interpolated",
+				DataType:      telem.Int64T,
+				Virtual:       true,
+				Leaseholder:   dcore.Free,
+				Requires:      []channel.Key{sparse.Key(), dense.Key()},
+				Expression:    "return sparse + dense",
+				Interpolation: channel.InterpolationLinear,
+			}
+			create(&calc)
+			for _, chunkSize := range []int64{1, 2, 5, 20} {
+				iter := MustSucceed(svc.iter.New(ctx, iterator.Config{
+					Keys:      channel.Keys{calc.Key()},
+					Bounds:    telem.TimeRangeMax,
+					ChunkSize: chunkSize,
+				}))
+				Expect(iter.SeekFirst()).To(BeTrue())
+				var samples []int64
+				for iter.Next(iterator.AutoSpan) {
+					for _, s := range iter.Value().Get(calc.Key()) {
+						samples = append(samples, telem.Unmarshal[int64](s)...)
+					}
+				}
+				Expect(samples).To(Equal([]int64{1, 102}), "chunk size %d", chunkSize)
+				Expect(iter.Close()).To(Succeed())
+			}
+		})
+
+		It("Should restart stateful expressions when the iterator seeks", func() {
+			calc := calculated("return integrate(pressure * pressure)", pressure.Key())
+			iter := MustSucceed(svc.iter.New(ctx, iterator.Config{
+				Keys:      channel.Keys{calc.Key()},
+				Bounds:    telem.TimeRangeMax,
+				ChunkSize: 2,
+			}))
+			read := func() (samples []int64) {
+				Expect(iter.SeekFirst()).To(BeTrue())
+				for iter.Next(iterator.AutoSpan) {
+					for _, s := range iter.Value().Get(calc.Key()) {
+						samples = append(samples, telem.Unmarshal[int64](s)...)
+					}
+				}
+				return samples
+			}
+			Expect(read()).To(Equal([]int64{0, 25, 90, 215}))
+			Expect(read()).To(Equal([]int64{0, 25, 90, 215}))
+			Expect(iter.Close()).To(Succeed())
+		})
+
+		It("Should not read a calculated channel that requires virtual channels", func() {
+			calc := calculated("return virtual * 2", virtual.Key())
+			_, err := svc.iter.New(ctx, iterator.Config{
+				Keys:   channel.Keys{calc.Key()},
+				Bounds: telem.TimeRangeMax,
+			})
+			Expect(err).To(MatchError(ContainSubstring("requires virtual channel")))
+		})
+	})
 })
 
 type scenario struct {
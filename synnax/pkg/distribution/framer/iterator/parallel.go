@@ -0,0 +1,356 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package iterator
+
+import (
+	"context"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// DefaultPrefetchWindow is the number of chunks read ahead from each node when
+// Config.PrefetchWindow is not set.
+const DefaultPrefetchWindow = 4
+
+// chunk is the result of a Next(AutoSpan) request executed by a single node.
+type chunk struct {
+	data   []Response
+	bounds telem.TimeRange
+	// uniform is true when the series of every channel in the chunk span bounds, so
+	// the node can be returned to the position after the chunk with a single seek.
+	uniform bool
+}
+
+// node is the state of the iterator over the channels leased by a single node.
+type node struct {
+	requests  confluence.Inlet[Request]
+	responses confluence.Outlet[Response]
+	// chunks are the prefetched chunks that have not been sent to the caller.
+	chunks []chunk
+	// pending is the number of prefetched chunks that have been requested but not
+	// received.
+	pending int
+	// sent is the number of Next(AutoSpan) requests sent to the node since the last
+	// seek, and consumed is the number of those requests whose results were sent to
+	// the caller. The node is ahead of the caller when sent is greater than consumed.
+	sent, consumed int
+	// anchor holds the requests that return the node to the position after the last
+	// uniform chunk consumed by the caller, or to the last seek if there is none, and
+	// sinceAnchor is the number of chunks the caller consumed after it.
+	anchor      []Request
+	sinceAnchor int
+	// exhausted is true when the node has no more chunks to read.
+	exhausted bool
+}
+
+func (n *node) send(ctx context.Context, req Request) error {
+	return signal.SendUnderContext(ctx, n.requests.Inlet(), req)
+}
+
+// receive receives the data responses and the acknowledgement for the next request
+// executed by the node.
+func (n *node) receive(ctx context.Context) (data []Response, ack Response, err error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ack, ctx.Err()
+		case res, ok := <-n.responses.Outlet():
+			if !ok {
+				return nil, ack, errors.New("[iterator] - node closed before acknowledging request")
+			}
+			if res.Variant == AckResponse {
+				return data, res, nil
+			}
+			data = append(data, res)
+		}
+	}
+}
+
+// receiveChunk receives the next prefetched chunk from the node.
+func (n *node) receiveChunk(ctx context.Context) error {
+	data, ack, err := n.receive(ctx)
+	if err != nil {
+		return err
+	}
+	n.pending--
+	if n.exhausted {
+		return nil
+	}
+	if !ack.Ack {
+		n.exhausted = true
+		return nil
+	}
+	c := chunk{data: data}
+	channelBounds := make(map[channel.Key]telem.TimeRange)
+	for _, res := range data {
+		for i, s := range res.Frame.Series {
+			c.bounds = union(c.bounds, s.TimeRange)
+			key := res.Frame.Keys[i]
+			channelBounds[key] = union(channelBounds[key], s.TimeRange)
+		}
+	}
+	c.uniform = len(channelBounds) > 0
+	for _, b := range channelBounds {
+		c.uniform = c.uniform && b == c.bounds
+	}
+	n.chunks = append(n.chunks, c)
+	return nil
+}
+
+// union returns the smallest time range containing a and b, treating a zero range
+// as empty.
+func union(a, b telem.TimeRange) telem.TimeRange {
+	if a.IsZero() {
+		return b
+	}
+	return telem.TimeRange{Start: min(a.Start, b.Start), End: max(a.End, b.End)}
+}
+
+// consume removes the first prefetched chunk of the node, recording that it was sent
+// to the caller.
+func (n *node) consume() chunk {
+	c := n.chunks[0]
+	n.chunks = n.chunks[1:]
+	n.consumed++
+	if !c.uniform {
+		n.sinceAnchor++
+		return c
+	}
+	// Seeking to the start of the chunk and moving forward by its span leaves the
+	// node with the same view as reading the chunk did.
+	n.anchor = []Request{
+		{Command: SeekGE, Stamp: c.bounds.Start},
+		{Command: Next, Span: c.bounds.Span()},
+	}
+	n.sinceAnchor = 0
+	return c
+}
+
+// drain receives all prefetched chunks that have been requested from the node.
+func (n *node) drain(ctx context.Context) error {
+	for n.pending > 0 {
+		if err := n.receiveChunk(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parallel is an iterator over channels leased by several nodes. Requests other than
+// Next(AutoSpan) are executed by every node in parallel, and are acknowledged once all
+// nodes have acknowledged them. After a seek, Next(AutoSpan) requests are served from
+// chunks that are prefetched from each node in parallel, up to window chunks ahead
+// of the caller. Each response holds the earliest prefetched chunk, along with the
+// chunks from the other nodes that start before it ends, so the caller receives the
+// chunks of all nodes in time order and never more than the chunk size of samples
+// for any channel.
+//
+// Since prefetching moves the iterators on each node ahead of the caller, nodes are
+// returned to the position the caller expects before executing any other request
+// that depends on it. Each node is re-seeked to the bounds of the last chunk the
+// caller consumed. When the channels of a node were read up to different times in
+// that chunk, a single seek cannot restore all of them, so the node is re-seeked to
+// the last chunk that can be restored and the chunks consumed after it are replayed.
+type parallel struct {
+	confluence.AbstractLinear[Request, Response]
+	nodes  []confluence.Segment[Request, Response]
+	window int
+	// merging is true when only Next(AutoSpan) requests have been executed since the
+	// last seek.
+	merging bool
+	seqNum  int
+}
+
+var _ confluence.Segment[Request, Response] = (*parallel)(nil)
+
+func newParallel(nodes []confluence.Segment[Request, Response], window int) *parallel {
+	if window <= 0 {
+		window = DefaultPrefetchWindow
+	}
+	return &parallel{nodes: nodes, window: window}
+}
+
+// Flow implements confluence.Flow.
+func (p *parallel) Flow(sCtx signal.Context, opts ...confluence.Option) {
+	o := confluence.NewOptions(opts)
+	o.AttachClosables(p.Out)
+	nodes := make([]*node, len(p.nodes))
+	for i, seg := range p.nodes {
+		req := confluence.NewStream[Request](p.window + 1)
+		res := confluence.NewStream[Response](2 * p.window)
+		seg.InFrom(req)
+		seg.OutTo(res)
+		seg.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+		nodes[i] = &node{requests: req, responses: res}
+	}
+	sCtx.Go(func(ctx context.Context) error {
+		defer func() {
+			for _, n := range nodes {
+				n.requests.Close()
+			}
+			for _, n := range nodes {
+				for range n.responses.Outlet() {
+				}
+			}
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case req, ok := <-p.In.Outlet():
+				if !ok {
+					return nil
+				}
+				p.seqNum++
+				if err := p.exec(ctx, nodes, req); err != nil {
+					return err
+				}
+			}
+		}
+	}, o.Signal...)
+}
+
+func (p *parallel) exec(ctx context.Context, nodes []*node, req Request) error {
+	if req.Command == Next && req.Span == AutoSpan && p.merging {
+		return p.next(ctx, nodes)
+	}
+	seek := req.Command == SeekFirst ||
+		req.Command == SeekLast ||
+		req.Command == SeekLE ||
+		req.Command == SeekGE
+	for _, n := range nodes {
+		if err := n.drain(ctx); err != nil {
+			return err
+		}
+		if seek {
+			n.chunks, n.sent, n.consumed, n.exhausted = nil, 0, 0, false
+			n.anchor, n.sinceAnchor = []Request{req}, 0
+			continue
+		}
+		// Errors are not affected by the position of the iterator, so the
+		// prefetched chunks can still be used afterwards.
+		if req.Command == Error {
+			continue
+		}
+		if err := p.resync(ctx, n); err != nil {
+			return err
+		}
+	}
+	if seek {
+		p.merging = true
+	} else if req.Command == Next || req.Command == Prev || req.Command == SetBounds {
+		p.merging = false
+	}
+	for _, n := range nodes {
+		if err := n.send(ctx, req); err != nil {
+			return err
+		}
+	}
+	res := Response{Variant: AckResponse, Command: req.Command, Ack: true, SeqNum: p.seqNum}
+	for _, n := range nodes {
+		data, ack, err := n.receive(ctx)
+		if err != nil {
+			return err
+		}
+		if err = p.sendData(ctx, data); err != nil {
+			return err
+		}
+		res.Ack = res.Ack && ack.Ack
+		res.Error = errors.Combine(res.Error, ack.Error)
+	}
+	return signal.SendUnderContext(ctx, p.Out.Inlet(), res)
+}
+
+// next sends the next chunks in time order from the prefetched chunks of each node.
+func (p *parallel) next(ctx context.Context, nodes []*node) error {
+	for _, n := range nodes {
+		for !n.exhausted && len(n.chunks)+n.pending < p.window {
+			if err := n.send(ctx, Request{Command: Next, Span: AutoSpan}); err != nil {
+				return err
+			}
+			n.pending++
+			n.sent++
+		}
+	}
+	var first *node
+	for _, n := range nodes {
+		for len(n.chunks) == 0 && n.pending > 0 && !n.exhausted {
+			if err := n.receiveChunk(ctx); err != nil {
+				return err
+			}
+		}
+		if len(n.chunks) == 0 {
+			continue
+		}
+		if first == nil || n.chunks[0].bounds.Start < first.chunks[0].bounds.Start {
+			first = n
+		}
+	}
+	res := Response{Variant: AckResponse, Command: Next, SeqNum: p.seqNum}
+	if first == nil {
+		// Every node has run out of chunks, so the caller has seen each node fail to
+		// read its next chunk.
+		for _, n := range nodes {
+			n.consumed = n.sent
+		}
+		return signal.SendUnderContext(ctx, p.Out.Inlet(), res)
+	}
+	end := first.chunks[0].bounds.End
+	for _, n := range nodes {
+		if len(n.chunks) == 0 || (n != first && n.chunks[0].bounds.Start >= end) {
+			continue
+		}
+		if err := p.sendData(ctx, n.consume().data); err != nil {
+			return err
+		}
+	}
+	res.Ack = true
+	return signal.SendUnderContext(ctx, p.Out.Inlet(), res)
+}
+
+// resync returns a node that has read ahead of the caller to the position the caller
+// expects by re-seeking to its anchor and replaying the Next(AutoSpan) requests the
+// caller consumed after it.
+func (p *parallel) resync(ctx context.Context, n *node) error {
+	if n.sent == n.consumed {
+		return nil
+	}
+	n.chunks = nil
+	n.exhausted = false
+	n.sent = n.consumed
+	replay := make([]Request, 0, len(n.anchor)+n.sinceAnchor)
+	replay = append(replay, n.anchor...)
+	for range n.sinceAnchor {
+		replay = append(replay, Request{Command: Next, Span: AutoSpan})
+	}
+	for _, req := range replay {
+		if err := n.send(ctx, req); err != nil {
+			return err
+		}
+		if _, _, err := n.receive(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parallel) sendData(ctx context.Context, data []Response) error {
+	for _, res := range data {
+		res.SeqNum = p.seqNum
+		if err := signal.SendUnderContext(ctx, p.Out.Inlet(), res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
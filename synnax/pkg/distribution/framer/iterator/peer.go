@@ -11,36 +11,47 @@ package iterator
 
 import (
 	"context"
+	"slices"
+
+	"github.com/samber/lo"
 	"github.com/synnaxlabs/freighter/freightfluence"
 	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
 	"github.com/synnaxlabs/synnax/pkg/distribution/core"
 	"github.com/synnaxlabs/x/address"
-	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/confluence/plumber"
 )
 
-func (s *Service) openManyPeers(
+func (s *Service) openPeers(
 	ctx context.Context,
-	bounds telem.TimeRange,
-	chunkSize int64,
+	cfg Config,
 	targets map[core.NodeKey][]channel.Key,
-) (*freightfluence.MultiSender[Request], []*freightfluence.Receiver[Response], error) {
-	var (
-		sender    = &freightfluence.MultiSender[Request]{}
-		receivers = make([]*freightfluence.Receiver[Response], 0, len(targets))
-	)
-	for nodeKey, keys := range targets {
+) ([]confluence.Segment[Request, Response], error) {
+	nodeKeys := lo.Keys(targets)
+	slices.Sort(nodeKeys)
+	peers := make([]confluence.Segment[Request, Response], 0, len(targets))
+	for _, nodeKey := range nodeKeys {
 		target, err := s.HostResolver.Resolve(nodeKey)
 		if err != nil {
-			return sender, receivers, err
+			return peers, err
 		}
-		client, err := s.openPeerClient(ctx, target, Config{Keys: keys, Bounds: bounds, ChunkSize: chunkSize})
+		client, err := s.openPeerClient(ctx, target, Config{
+			Keys:      targets[nodeKey],
+			Bounds:    cfg.Bounds,
+			ChunkSize: cfg.ChunkSize,
+		})
 		if err != nil {
-			return sender, receivers, err
+			return peers, err
 		}
-		sender.Senders = append(sender.Senders, client)
-		receivers = append(receivers, &freightfluence.Receiver[Response]{Receiver: client})
+		pipe := plumber.New()
+		plumber.SetSink[Request](pipe, "sender", &freightfluence.Sender[Request]{Sender: client})
+		plumber.SetSource[Response](pipe, "receiver", &freightfluence.Receiver[Response]{Receiver: client})
+		seg := &plumber.Segment[Request, Response]{Pipeline: pipe}
+		lo.Must0(seg.RouteInletTo("sender"))
+		lo.Must0(seg.RouteOutletFrom("receiver"))
+		peers = append(peers, seg)
 	}
-	return sender, receivers, nil
+	return peers, nil
 }
 
 func (s *Service) openPeerClient(ctx context.Context, target address.Address, cfg Config) (ClientStream, error) {
@@ -34,6 +34,10 @@ type Config struct {
 	Keys      channel.Keys    `json:"keys" msgpack:"keys"`
 	Bounds    telem.TimeRange `json:"bounds" msgpack:"bounds"`
 	ChunkSize int64           `json:"chunk_size" msgpack:"chunk_size"`
+	// PrefetchWindow is the number of chunks read ahead from each node when
+	// iterating over channels leased by other nodes using AutoSpan. Defaults to
+	// DefaultPrefetchWindow.
+	PrefetchWindow int `json:"prefetch_window" msgpack:"prefetch_window"`
 }
 
 type ServiceConfig struct {
@@ -83,10 +87,10 @@ func OpenService(configs ...ServiceConfig) (*Service, error) {
 }
 
 const (
-	peerSenderAddr   address.Address = "peerSender"
 	gatewayIterAddr  address.Address = "gatewayWriter"
-	broadcasterAddr  address.Address = "broadcaster"
 	synchronizerAddr address.Address = "synchronizer"
+	iteratorAddr     address.Address = "iterator"
+	calculationAddr  address.Address = "calculation"
 )
 
 func (s *Service) New(ctx context.Context, cfg Config) (*Iterator, error) {
@@ -109,97 +113,117 @@ func (s *Service) New(ctx context.Context, cfg Config) (*Iterator, error) {
 }
 
 func (s *Service) NewStream(ctx context.Context, cfg Config) (StreamIterator, error) {
-	if err := s.validateChannelKeys(ctx, cfg.Keys); err != nil {
+	calculated, err := s.validateChannelKeys(ctx, cfg.Keys)
+	if err != nil {
 		return nil, err
 	}
 	cfg.Keys = cfg.Keys.Unique()
+	if len(calculated) == 0 {
+		return s.newStream(ctx, cfg)
+	}
+	// Calculated channels are evaluated from the samples of the channels they
+	// require, so read those in their place.
+	calculators, err := s.openCalculators(ctx, calculated)
+	if err != nil {
+		return nil, err
+	}
+	keys := cfg.Keys
+	cfg.Keys = withCalculatedRequires(keys, calculators)
+	internal, err := s.newStream(ctx, cfg)
+	if err != nil {
+		for _, c := range calculators {
+			c.Close()
+		}
+		return nil, err
+	}
+	pipe := plumber.New()
+	plumber.SetSegment[Request, Response](pipe, iteratorAddr, internal)
+	plumber.SetSegment[Response, Response](
+		pipe,
+		calculationAddr,
+		newCalculation(calculators, keys),
+	)
+	plumber.MustConnect[Response](pipe, iteratorAddr, calculationAddr, 1)
+	seg := &plumber.Segment[Request, Response]{Pipeline: pipe}
+	lo.Must0(seg.RouteOutletFrom(calculationAddr))
+	lo.Must0(seg.RouteInletTo(iteratorAddr))
+	return seg, nil
+}
 
+func (s *Service) newStream(ctx context.Context, cfg Config) (StreamIterator, error) {
 	var (
-		hostID             = s.HostResolver.HostKey()
-		batch              = proxy.BatchFactory[channel.Key]{Host: hostID}.Batch(cfg.Keys)
-		pipe               = plumber.New()
-		needPeerRouting    = len(batch.Peers) > 0
-		needGatewayRouting = len(batch.Gateway) > 0
-		receiverAddresses  []address.Address
-		routeInletTo       address.Address
+		hostID = s.HostResolver.HostKey()
+		batch  = proxy.BatchFactory[channel.Key]{Host: hostID}.Batch(cfg.Keys)
 	)
 
-	if needPeerRouting {
-		routeInletTo = peerSenderAddr
-		sender, receivers, err := s.openManyPeers(ctx, cfg.Bounds, cfg.ChunkSize, batch.Peers)
+	if len(batch.Peers) == 0 {
+		gatewayIter, err := s.newGateway(cfg)
 		if err != nil {
 			return nil, err
 		}
-		plumber.SetSink[Request](pipe, peerSenderAddr, sender)
-		receiverAddresses = make([]address.Address, len(receivers))
-		for i, c := range receivers {
-			addr := address.Newf("client-%v", i+1)
-			receiverAddresses[i] = addr
-			plumber.SetSource[Response](pipe, addr, c)
-		}
+		pipe := plumber.New()
+		plumber.SetSegment[Request, Response](pipe, gatewayIterAddr, gatewayIter)
+		plumber.SetSegment[Response, Response](
+			pipe,
+			synchronizerAddr,
+			newSynchronizer(1),
+		)
+		plumber.MustConnect[Response](pipe, gatewayIterAddr, synchronizerAddr, 1)
+		seg := &plumber.Segment[Request, Response]{Pipeline: pipe}
+		lo.Must0(seg.RouteOutletFrom(synchronizerAddr))
+		lo.Must0(seg.RouteInletTo(gatewayIterAddr))
+		return seg, nil
 	}
 
-	if needGatewayRouting {
-		routeInletTo = gatewayIterAddr
-		gatewayIter, err := s.newGateway(Config{Keys: batch.Gateway, Bounds: cfg.Bounds, ChunkSize: cfg.ChunkSize})
+	// Reads from other nodes are prefetched and merged in parallel to avoid waiting
+	// on a network round trip for every request.
+	nodes, err := s.openPeers(ctx, cfg, batch.Peers)
+	if err != nil {
+		return nil, err
+	}
+	if len(batch.Gateway) > 0 {
+		gatewayIter, err := s.newGateway(Config{
+			Keys:      batch.Gateway,
+			Bounds:    cfg.Bounds,
+			ChunkSize: cfg.ChunkSize,
+		})
 		if err != nil {
 			return nil, err
 		}
-		plumber.SetSegment[Request, Response](pipe, gatewayIterAddr, gatewayIter)
-		receiverAddresses = append(receiverAddresses, gatewayIterAddr)
-	}
-
-	if needPeerRouting && needGatewayRouting {
-		routeInletTo = broadcasterAddr
-		plumber.SetSegment[Request, Request](
-			pipe,
-			broadcasterAddr,
-			&confluence.DeltaMultiplier[Request]{},
-		)
-		plumber.MultiRouter[Request]{
-			SourceTargets: []address.Address{broadcasterAddr},
-			SinkTargets:   []address.Address{peerSenderAddr, gatewayIterAddr},
-			Stitch:        plumber.StitchWeave,
-			Capacity:      2,
-		}.MustRoute(pipe)
+		nodes = append(nodes, gatewayIter)
 	}
-
-	plumber.SetSegment[Response, Response](
-		pipe,
-		synchronizerAddr,
-		newSynchronizer(len(cfg.Keys.UniqueLeaseholders())),
-	)
-
-	plumber.MultiRouter[Response]{
-		SourceTargets: receiverAddresses,
-		SinkTargets:   []address.Address{synchronizerAddr},
-		Stitch:        plumber.StitchUnary,
-		Capacity:      len(receiverAddresses),
-	}.MustRoute(pipe)
-
-	seg := &plumber.Segment[Request, Response]{Pipeline: pipe}
-	lo.Must0(seg.RouteOutletFrom(synchronizerAddr))
-	lo.Must0(seg.RouteInletTo(routeInletTo))
-	return seg, nil
+	return newParallel(nodes, cfg.PrefetchWindow), nil
 }
 
-func (s *Service) validateChannelKeys(ctx context.Context, keys channel.Keys) error {
+// validateChannelKeys validates that the channels with the given keys exist and can be
+// read, returning the calculated channels among them.
+func (s *Service) validateChannelKeys(
+	ctx context.Context,
+	keys channel.Keys,
+) ([]channel.Channel, error) {
 	v := validate.New("distribution.framer.Iterator")
 	if validate.NotEmptySlice(v, "Keys", keys) {
-		return v.Error()
+		return nil, v.Error()
 	}
-	for _, k := range keys {
-		if k.Free() {
-			return errors.Wrapf(validate.Error, "cannot read from free channel %v", k)
-		}
+	var channels []channel.Channel
+	if err := s.ChannelReader.NewRetrieve().
+		WhereKeys(keys...).
+		Entries(&channels).
+		Exec(ctx, nil); err != nil {
+		return nil, err
 	}
-	q := s.ChannelReader.NewRetrieve().WhereKeys(keys...)
-	exists, err := q.Exists(ctx, nil)
-	if err != nil {
-		return err
+	if len(channels) != len(keys.Unique()) {
+		return nil, errors.Wrapf(query.NotFound, "some channel keys %v not found", keys)
 	}
-	if !exists {
-		return errors.Wrapf(query.NotFound, "some channel keys %v not found", keys)
+	var calculated []channel.Channel
+	for _, ch := range channels {
+		if ch.IsCalculated() {
+			calculated = append(calculated, ch)
+			continue
+		}
+		if ch.Key().Free() {
+			return nil, errors.Wrapf(validate.Error, "cannot read from free channel %v", ch.Key())
+		}
 	}
-	return nil
+	return calculated, nil
 }
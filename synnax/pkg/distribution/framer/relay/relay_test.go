@@ -28,6 +28,7 @@ import (
 	"github.com/synnaxlabs/x/signal"
 	"github.com/synnaxlabs/x/telem"
 	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
 	"io"
 	"time"
 )
@@ -100,6 +101,103 @@ var _ = Describe("Relay", func() {
 			})
 		}
 	})
+	Describe("Overflow", func() {
+		var s scenario
+		BeforeEach(func() { s = gatewayOnlyScenario() })
+		AfterEach(func() { Expect(s.close.Close()).To(Succeed()) })
+		// overflow writes five frames to a streamer with the given overflow policy and a
+		// buffer of two frames before receiving any of them, returning the responses
+		// the streamer sends.
+		overflow := func(policy relay.OverflowPolicy) ([]relay.Response, error) {
+			reader := MustSucceed(s.relay.NewStreamer(ctx, relay.StreamerConfig{
+				Keys:           s.keys,
+				SendOpenAck:    true,
+				OverflowPolicy: policy,
+				BufferSize:     2,
+			}))
+			sCtx, cancel := signal.Isolated()
+			defer cancel()
+			streamerReq, readerRes := confluence.Attach(reader)
+			reader.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+			Eventually(readerRes.Outlet()).Should(Receive())
+			w := MustSucceed(s.writer.New(ctx, writer.Config{
+				Keys:  s.keys,
+				Start: 10 * telem.SecondTS,
+			}))
+			for i := range 5 {
+				Expect(w.Write(core.Frame{
+					Keys: s.keys,
+					Series: []telem.Series{
+						telem.NewSeriesV(int64(i)),
+						telem.NewSeriesV(int64(i)),
+						telem.NewSeriesV(int64(i)),
+					},
+				})).To(BeTrue())
+			}
+			Expect(w.Close()).To(Succeed())
+			// Give the streamer time to receive every written frame before reading.
+			time.Sleep(100 * time.Millisecond)
+			var responses []relay.Response
+			for {
+				select {
+				case res, ok := <-readerRes.Outlet():
+					if ok {
+						responses = append(responses, res)
+						continue
+					}
+				case <-time.After(100 * time.Millisecond):
+					streamerReq.Close()
+					confluence.Drain(readerRes)
+				}
+				return responses, sCtx.Wait()
+			}
+		}
+		values := func(responses []relay.Response) []int64 {
+			v := make([]int64, len(responses))
+			for i, res := range responses {
+				v[i] = telem.ValueAt[int64](res.Frame.Series[0], 0)
+			}
+			return v
+		}
+		It("Should drop the newest frames when the buffer is full", func() {
+			responses := MustSucceed(overflow(relay.OverflowDropNewest))
+			Expect(values(responses)).To(Equal([]int64{0, 1}))
+			Expect(responses[1].Dropped).To(Equal(int64(3)))
+		})
+		It("Should drop the oldest frames when the buffer is full", func() {
+			responses := MustSucceed(overflow(relay.OverflowDropOldest))
+			Expect(values(responses)).To(Equal([]int64{3, 4}))
+			Expect(responses[1].Dropped).To(Equal(int64(3)))
+		})
+		It("Should drop every other frame when the buffer is full", func() {
+			responses := MustSucceed(overflow(relay.OverflowDecimate))
+			Expect(values(responses)).To(Equal([]int64{0, 4}))
+			Expect(responses[1].Dropped).To(Equal(int64(3)))
+		})
+		It("Should block until the consumer catches up", func() {
+			responses := MustSucceed(overflow(relay.OverflowBlock))
+			Expect(values(responses)).To(Equal([]int64{0, 1, 2, 3, 4}))
+			Expect(responses[4].Dropped).To(BeZero())
+		})
+		It("Should close the streamer when the buffer is full", func() {
+			_, err := overflow(relay.OverflowDisconnect)
+			Expect(err).To(HaveOccurredAs(relay.ErrSlowConsumer))
+		})
+		It("Should not open a streamer with an unknown overflow policy", func() {
+			_, err := s.relay.NewStreamer(ctx, relay.StreamerConfig{
+				Keys:           s.keys,
+				OverflowPolicy: 100,
+			})
+			Expect(err).To(HaveOccurredAs(validate.Error))
+		})
+		It("Should not open a streamer with a buffer larger than the maximum", func() {
+			_, err := s.relay.NewStreamer(ctx, relay.StreamerConfig{
+				Keys:       s.keys,
+				BufferSize: relay.MaxBufferSize + 1,
+			})
+			Expect(err).To(HaveOccurredAs(validate.Error))
+		})
+	})
 	Describe("Errors", func() {
 		It("Should raise an error if a channel is not found", func() {
 			builder, services := provision(1)
@@ -149,10 +247,12 @@ func gatewayOnlyScenario() scenario {
 		writer:   svc.writer,
 		close: xio.CloserFunc(func() error {
 			e := errors.NewCatcher(errors.WithAggregation())
-			e.Exec(builder.Close)
+			// Close the relays first so that their taps on the storage layer are
+			// closed before the storage layer itself.
 			for _, svc := range services {
 				e.Exec(svc.relay.Close)
 			}
+			e.Exec(builder.Close)
 			return e.Error()
 		}),
 	}
@@ -181,10 +281,12 @@ func peerOnlyScenario() scenario {
 		writer:   svc.writer,
 		close: xio.CloserFunc(func() error {
 			e := errors.NewCatcher(errors.WithAggregation())
-			e.Exec(builder.Close)
+			// Close the relays first so that their taps on the storage layer are
+			// closed before the storage layer itself.
 			for _, svc := range services {
 				e.Exec(svc.relay.Close)
 			}
+			e.Exec(builder.Close)
 			return e.Error()
 		}),
 	}
@@ -212,10 +314,12 @@ func mixedScenario() scenario {
 		writer:   svc.writer,
 		close: xio.CloserFunc(func() error {
 			e := errors.NewCatcher(errors.WithAggregation())
-			e.Exec(builder.Close)
+			// Close the relays first so that their taps on the storage layer are
+			// closed before the storage layer itself.
 			for _, svc := range services {
 				e.Exec(svc.relay.Close)
 			}
+			e.Exec(builder.Close)
 			return e.Error()
 		}),
 	}
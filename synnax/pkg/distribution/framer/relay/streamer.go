@@ -17,7 +17,9 @@ import (
 	"github.com/synnaxlabs/x/address"
 	"github.com/synnaxlabs/x/change"
 	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/validate"
 )
 
 type Streamer = confluence.Segment[Request, Response]
@@ -28,14 +30,88 @@ type streamer struct {
 	demands confluence.Inlet[demand]
 	relay   *Relay
 	cfg     StreamerConfig
+	// queue holds the responses that have been received from the relay but not yet
+	// sent to the consumer.
+	queue []Response
+	// dropped is the number of responses dropped by the overflow policy.
+	dropped int64
 }
 
+// OverflowPolicy determines what a Streamer does when its consumer does not receive
+// frames as fast as they are written to the relay, and the buffer of the Streamer is
+// full.
+type OverflowPolicy uint8
+
+const (
+	// OverflowBlock stops the Streamer from receiving frames from the relay until the
+	// consumer catches up. Blocked streamers slow down the delivery of frames to every
+	// other streamer on the node, and frames are eventually dropped by the relay if
+	// the consumer does not catch up.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest buffered frame to make room for the newest
+	// frame.
+	OverflowDropOldest
+	// OverflowDropNewest drops the newest frame, keeping the buffered frames.
+	OverflowDropNewest
+	// OverflowDecimate drops every other buffered frame, halving the rate at which
+	// frames are delivered to the consumer until it catches up.
+	OverflowDecimate
+	// OverflowDisconnect closes the Streamer with ErrSlowConsumer.
+	OverflowDisconnect
+)
+
+// String implements fmt.Stringer.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowDropOldest:
+		return "drop_oldest"
+	case OverflowDropNewest:
+		return "drop_newest"
+	case OverflowDecimate:
+		return "decimate"
+	case OverflowDisconnect:
+		return "disconnect"
+	}
+	return "unknown"
+}
+
+// ErrSlowConsumer is returned by a Streamer using OverflowDisconnect when its consumer
+// does not receive frames fast enough.
+var ErrSlowConsumer = errors.New("streamer consumer could not keep up with incoming frames")
+
+// MaxBufferSize is the maximum number of frames a Streamer can buffer for its
+// consumer.
+const MaxBufferSize = 1000
+
 type StreamerConfig struct {
 	Keys        channel.Keys
 	SendOpenAck bool
+	// OverflowPolicy is the policy applied when the buffer of the streamer is full.
+	// [OPTIONAL] - Defaults to OverflowBlock.
+	OverflowPolicy OverflowPolicy
+	// BufferSize is the number of frames the streamer buffers for its consumer before
+	// applying the OverflowPolicy. Must not exceed MaxBufferSize.
+	// [OPTIONAL] - Defaults to 25.
+	BufferSize int
 }
 
 func (r *Relay) NewStreamer(ctx context.Context, cfg StreamerConfig) (Streamer, error) {
+	if cfg.OverflowPolicy > OverflowDisconnect {
+		return nil, errors.Wrapf(validate.Error, "unknown overflow policy %d", cfg.OverflowPolicy)
+	}
+	if cfg.BufferSize > MaxBufferSize {
+		return nil, errors.Wrapf(
+			validate.Error,
+			"streamer buffer size %d exceeds the maximum of %d",
+			cfg.BufferSize,
+			MaxBufferSize,
+		)
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultBuffer
+	}
 	err := r.cfg.ChannelReader.NewRetrieve().WhereKeys(cfg.Keys...).Exec(ctx, nil)
 	return &streamer{
 		cfg:     cfg,
@@ -80,6 +156,23 @@ func (r *streamer) Flow(ctx signal.Context, opts ...confluence.Option) {
 			}
 		}
 		for {
+			var (
+				// out is only set when there is a response to send, and in is only unset
+				// when a blocking streamer's buffer is full, so that the select below
+				// never sends an empty response or receives more responses than the
+				// streamer can hold.
+				out  chan<- Response
+				in   = responses.Outlet()
+				next Response
+			)
+			if len(r.queue) > 0 {
+				out = r.Out.Inlet()
+				next = r.queue[0]
+				next.Dropped = r.dropped
+			}
+			if r.cfg.OverflowPolicy == OverflowBlock && len(r.queue) >= r.cfg.BufferSize {
+				in = nil
+			}
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
@@ -93,17 +186,50 @@ func (r *streamer) Flow(ctx signal.Context, opts ...confluence.Option) {
 				if err := signal.SendUnderContext(ctx, r.demands.Inlet(), d); err != nil {
 					return err
 				}
-			case f := <-responses.Outlet():
+			case out <- next:
+				r.queue[0] = Response{}
+				r.queue = r.queue[1:]
+			case f := <-in:
 				filtered := f.Frame.FilterKeys(r.cfg.Keys)
 				// Don't send if the frame is empty.
 				if len(filtered.Keys) == 0 {
 					continue
 				}
-				res := Response{Error: f.Error, Frame: f.Frame.FilterKeys(r.cfg.Keys)}
-				if err := signal.SendUnderContext(ctx, r.Out.Inlet(), res); err != nil {
+				if err := r.enqueue(Response{Error: f.Error, Frame: filtered}); err != nil {
 					return err
 				}
 			}
 		}
 	}, o.Signal...)
 }
+
+// enqueue adds a response to the queue of responses to send to the consumer, applying
+// the overflow policy of the streamer if the queue is full.
+func (r *streamer) enqueue(res Response) error {
+	if len(r.queue) < r.cfg.BufferSize {
+		r.queue = append(r.queue, res)
+		return nil
+	}
+	switch r.cfg.OverflowPolicy {
+	case OverflowDropOldest:
+		r.queue = append(r.queue[1:], res)
+		r.dropped++
+	case OverflowDropNewest:
+		r.dropped++
+	case OverflowDecimate:
+		// Keep every other response, ending with the newest one.
+		r.queue = append(r.queue, res)
+		kept := r.queue[:0]
+		for i := (len(r.queue) - 1) % 2; i < len(r.queue); i += 2 {
+			kept = append(kept, r.queue[i])
+		}
+		r.dropped += int64(len(r.queue) - len(kept))
+		clear(r.queue[len(kept):])
+		r.queue = kept
+	case OverflowDisconnect:
+		return ErrSlowConsumer
+	default:
+		r.queue = append(r.queue, res)
+	}
+	return nil
+}
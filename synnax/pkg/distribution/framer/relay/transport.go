@@ -23,6 +23,9 @@ type Request struct {
 type Response struct {
 	Frame core.Frame `json:"frame" msgpack:"frame"`
 	Error error      `json:"error" msgpack:"error"`
+	// Dropped is the number of frames that the streamer sending the response has
+	// dropped since it was opened because its consumer could not keep up.
+	Dropped int64 `json:"dropped" msgpack:"dropped"`
 }
 
 func reqToStorage(req Request) (ts.StreamerRequest, error) {
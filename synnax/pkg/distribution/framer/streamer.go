@@ -19,8 +19,11 @@ import (
 	"github.com/synnaxlabs/synnax/pkg/distribution/framer/relay"
 	"github.com/synnaxlabs/synnax/pkg/storage/ts"
 	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/reflect"
 	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
 )
 
 type Streamer = confluence.Segment[StreamerRequest, StreamerResponse]
@@ -29,6 +32,7 @@ type streamer struct {
 	ts                 *ts.DB
 	sendControlDigests bool
 	controlStateKey    channel.Key
+	sendOpenAck        bool
 	confluence.AbstractUnarySink[StreamerRequest]
 	confluence.AbstractUnarySource[StreamerResponse]
 	iter struct {
@@ -41,6 +45,8 @@ type streamer struct {
 		requests  confluence.Inlet[relay.Request]
 		responses confluence.Outlet[relay.Response]
 	}
+	// replayed tracks the replayed samples so that they are not sent again live.
+	replayed *telem.Replay[channel.Key]
 }
 
 // Flow implements confluence.Flow.
@@ -53,38 +59,26 @@ func (l *streamer) Flow(sCtx signal.Context, opts ...confluence.Option) {
 	o.AttachClosables(l.Out)
 
 	sCtx.Go(func(ctx context.Context) error {
-		if hasIter {
-			// start off by exhausting the iterator
-		o:
-			for {
-				l.iter.requests.Inlet() <- IteratorRequest{
-					Command: iterator.Next,
-					Span:    iterator.AutoSpan,
-				}
-
-				for res := range l.iter.responses.Outlet() {
-					if res.Variant == iterator.AckResponse {
-						if res.Ack == false {
-							break o
-						}
-						break
-					}
-					l.Out.Inlet() <- StreamerResponse{
-						Frame: res.Frame,
-						Error: res.Error,
-					}
-				}
-			}
+		l.relay.flow.Flow(sCtx, append(opts, confluence.WithAddress("relay-reader"))...)
+		closeRelay := func() {
+			l.relay.requests.Close()
+			confluence.Drain(l.relay.responses)
 		}
 
-		// Close the iterator and drain the response channel
 		if hasIter {
-			l.iter.requests.Close()
-			confluence.Drain(l.iter.responses)
+			pending, err := l.replay(ctx)
+			if err != nil {
+				closeRelay()
+				return err
+			}
+			for _, res := range pending {
+				if err = l.send(ctx, res); err != nil {
+					closeRelay()
+					return err
+				}
+			}
 		}
 
-		l.relay.flow.Flow(sCtx, append(opts, confluence.WithAddress("relay-reader"))...)
-
 		if l.sendControlDigests {
 			u := l.ts.ControlUpdateToFrame(ctx, l.ts.ControlStates())
 			l.Out.Inlet() <- StreamerResponse{Frame: core.NewFrameFromStorage(u)}
@@ -94,22 +88,19 @@ func (l *streamer) Flow(sCtx signal.Context, opts ...confluence.Option) {
 		for {
 			select {
 			case <-ctx.Done():
-				l.relay.requests.Close()
-				confluence.Drain(l.relay.responses)
+				closeRelay()
 				return ctx.Err()
 			case res, ok := <-l.relay.responses.Outlet():
 				if !ok {
 					return nil
 				}
-				if err := signal.SendUnderContext(ctx, l.Out.Inlet(), StreamerResponse{Frame: res.Frame}); err != nil {
-					l.relay.requests.Close()
-					confluence.Drain(l.relay.responses)
+				if err := l.send(ctx, res); err != nil {
+					closeRelay()
 					return err
 				}
 			case req, ok := <-l.In.Outlet():
 				if !ok {
-					l.relay.requests.Close()
-					confluence.Drain(l.relay.responses)
+					closeRelay()
 					return nil
 				}
 				if !l.sendControlDigests && lo.Contains(req.Keys, l.controlStateKey) {
@@ -118,8 +109,7 @@ func (l *streamer) Flow(sCtx signal.Context, opts ...confluence.Option) {
 					l.Out.Inlet() <- StreamerResponse{Frame: core.NewFrameFromStorage(u)}
 				}
 				if err := signal.SendUnderContext(ctx, l.relay.requests.Inlet(), relay.Request{Keys: req.Keys}); err != nil {
-					l.relay.requests.Close()
-					confluence.Drain(l.relay.responses)
+					closeRelay()
 					return err
 				}
 			}
@@ -127,10 +117,216 @@ func (l *streamer) Flow(sCtx signal.Context, opts ...confluence.Option) {
 	}, o.Signal...)
 }
 
+// send sends the series of a live relay response that were not already replayed.
+func (l *streamer) send(ctx context.Context, res relay.Response) error {
+	if l.replayed != nil {
+		var fr Frame
+		fr.Keys, fr.Series = l.replayed.Dedupe(res.Frame.Keys, res.Frame.Series)
+		if len(fr.Keys) == 0 && len(res.Frame.Keys) > 0 {
+			return nil
+		}
+		res.Frame = fr
+	}
+	return signal.SendUnderContext(ctx, l.Out.Inlet(), res)
+}
+
+// replay exhausts the iterator, sending the persisted samples of the streamed channels
+// from StreamerConfig.From onwards. The relay is connected before replaying so that no
+// live samples are missed, and the live responses received while replaying are
+// returned once the replay is complete. replay returns ts.ErrReplayOverflow if more
+// than ts.MaxReplayPending live responses are received while replaying.
+func (l *streamer) replay(ctx context.Context) ([]relay.Response, error) {
+	defer func() {
+		l.iter.requests.Close()
+		confluence.Drain(l.iter.responses)
+	}()
+	// Wait for the relay to acknowledge that it is connected.
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-l.relay.responses.Outlet():
+	}
+	if l.sendOpenAck {
+		if err := signal.SendUnderContext(ctx, l.Out.Inlet(), StreamerResponse{}); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		pending          []relay.Response
+		overflowed       bool
+		stop             = make(chan struct{})
+		stopped          = make(chan struct{})
+		replayCtx, abort = context.WithCancel(ctx)
+	)
+	defer abort()
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-stop:
+				return
+			case res, ok := <-l.relay.responses.Outlet():
+				if !ok {
+					return
+				}
+				if len(pending) == ts.MaxReplayPending {
+					overflowed = true
+					abort()
+					return
+				}
+				pending = append(pending, res)
+			}
+		}
+	}()
+	err := l.exhaustIterator(replayCtx)
+	close(stop)
+	<-stopped
+	if overflowed {
+		return nil, ts.ErrReplayOverflow
+	}
+	return pending, err
+}
+
+func (l *streamer) exhaustIterator(ctx context.Context) error {
+	cmd := iterator.SeekFirst
+	for {
+		if err := signal.SendUnderContext(
+			ctx,
+			l.iter.requests.Inlet(),
+			IteratorRequest{Command: cmd, Span: iterator.AutoSpan},
+		); err != nil {
+			return err
+		}
+		for res := range l.iter.responses.Outlet() {
+			if res.Variant == iterator.AckResponse {
+				if !res.Ack {
+					return res.Error
+				}
+				break
+			}
+			for i, s := range res.Frame.Series {
+				l.replayed.Add(res.Frame.Keys[i], s)
+			}
+			if err := signal.SendUnderContext(
+				ctx,
+				l.Out.Inlet(),
+				StreamerResponse{Frame: res.Frame, Error: res.Error},
+			); err != nil {
+				return err
+			}
+		}
+		cmd = iterator.Next
+	}
+}
+
 type StreamerConfig struct {
 	Keys             channel.Keys `json:"keys" msgpack:"keys"`
 	DownsampleFactor int          `json:"downsample_factor" msgpack:"downsample_factor"`
-	SendOpenAck      bool         `json:"send_open_ack" msgpack:"send_open_ack"`
+	// DownsampleMode is the method used to reduce the number of samples by
+	// DownsampleFactor. Like DownsampleFactor, it is applied by the service layer.
+	DownsampleMode DownsampleMode `json:"downsample_mode" msgpack:"downsample_mode"`
+	// DownsampleRate, if non-zero, makes the service layer deliver at most
+	// DownsampleRate samples per second for each channel instead of downsampling by
+	// DownsampleFactor. The samples of index channels and the channels they index are
+	// kept together, and channels without an index are downsampled using their rate.
+	// DownsampleRate cannot be set along with a DownsampleFactor greater than 1.
+	DownsampleRate telem.Rate `json:"downsample_rate" msgpack:"downsample_rate"`
+	SendOpenAck    bool       `json:"send_open_ack" msgpack:"send_open_ack"`
+	// From, if non-zero, makes the streamer replay the persisted samples of its
+	// channels starting at the given timestamp before it starts sending live frames.
+	// Live samples that were already replayed are dropped, so the streamer sends every
+	// sample from From onwards exactly once. If more than ts.MaxReplayPending live
+	// frames are written while replaying, the streamer fails with
+	// ts.ErrReplayOverflow. Live samples are matched to replayed samples by the
+	// timestamps of their index, so persisted channels without an index, or whose
+	// index is not in Keys, cannot be replayed.
+	From telem.TimeStamp `json:"from" msgpack:"from"`
+	// OverflowPolicy determines what the streamer does when the caller does not
+	// receive frames as fast as they are written. See relay.OverflowPolicy for the
+	// available policies. The number of frames dropped by the streamer is reported in
+	// StreamerResponse.Dropped.
+	OverflowPolicy relay.OverflowPolicy `json:"overflow_policy" msgpack:"overflow_policy"`
+	// BufferSize is the number of frames the streamer buffers before applying its
+	// OverflowPolicy. Must not exceed relay.MaxBufferSize.
+	BufferSize int `json:"buffer_size" msgpack:"buffer_size"`
+	// Filters are predicates on the samples of the streamed channels. When a channel
+	// has filters, only its samples that match at least one of them are sent. Filters
+	// are evaluated by the service layer after any downsampling, and are ignored by
+	// the distribution layer.
+	Filters []Filter `json:"filters" msgpack:"filters"`
+}
+
+// DownsampleMode is a method of downsampling streamed samples.
+type DownsampleMode uint8
+
+const (
+	// DownsampleDecimate keeps every DownsampleFactor-th sample.
+	DownsampleDecimate DownsampleMode = iota
+	// DownsampleMinMax keeps the smallest and largest samples in each bucket of
+	// DownsampleFactor samples, so that spikes are not hidden.
+	DownsampleMinMax
+	// DownsampleMean averages each bucket of DownsampleFactor samples.
+	DownsampleMean
+	// DownsampleLTTB keeps the samples chosen by the Largest-Triangle-Three-Buckets
+	// algorithm, which preserves the visual shape of the data.
+	DownsampleLTTB
+)
+
+// String implements fmt.Stringer.
+func (m DownsampleMode) String() string {
+	switch m {
+	case DownsampleDecimate:
+		return "decimate"
+	case DownsampleMinMax:
+		return "min_max"
+	case DownsampleMean:
+		return "mean"
+	case DownsampleLTTB:
+		return "lttb"
+	}
+	return "unknown"
+}
+
+// FilterVariant is the kind of predicate evaluated by a Filter.
+type FilterVariant uint8
+
+const (
+	// FilterLimits matches samples that are outside the range [Lower, Upper].
+	FilterLimits FilterVariant = iota + 1
+	// FilterDeadband matches samples that differ from the last matching sample by
+	// more than Deadband. The first sample of the channel always matches.
+	FilterDeadband
+	// FilterOnChange matches samples that are different from the previous sample of
+	// the channel. The first sample of the channel always matches.
+	FilterOnChange
+)
+
+// String implements fmt.Stringer.
+func (v FilterVariant) String() string {
+	switch v {
+	case FilterLimits:
+		return "limits"
+	case FilterDeadband:
+		return "deadband"
+	case FilterOnChange:
+		return "on_change"
+	}
+	return "unknown"
+}
+
+// Filter is a predicate on the samples of a streamed channel.
+type Filter struct {
+	// Channel is the key of the channel whose samples are filtered.
+	Channel channel.Key `json:"channel" msgpack:"channel"`
+	// Variant is the kind of predicate evaluated on the samples of the channel.
+	Variant FilterVariant `json:"variant" msgpack:"variant"`
+	// Lower is the lower limit of a FilterLimits predicate.
+	Lower float64 `json:"lower" msgpack:"lower"`
+	// Upper is the upper limit of a FilterLimits predicate.
+	Upper float64 `json:"upper" msgpack:"upper"`
+	// Deadband is the minimum change between matching samples of a FilterDeadband
+	// predicate.
+	Deadband float64 `json:"deadband" msgpack:"deadband"`
 }
 
 type StreamerRequest = StreamerConfig
@@ -140,8 +336,21 @@ func (s *Service) NewStreamer(ctx context.Context, cfg StreamerConfig) (Streamer
 		ts:                 s.iterator.TS,
 		controlStateKey:    s.controlStateKey,
 		sendControlDigests: lo.Contains(cfg.Keys, s.controlStateKey),
+		sendOpenAck:        cfg.SendOpenAck,
+	}
+	if !cfg.From.IsZero() {
+		if err := s.openReplay(ctx, l, cfg); err != nil {
+			return nil, err
+		}
 	}
-	rel, err := s.Relay.NewStreamer(ctx, relay.StreamerConfig{Keys: cfg.Keys, SendOpenAck: cfg.SendOpenAck})
+	rel, err := s.Relay.NewStreamer(ctx, relay.StreamerConfig{
+		Keys: cfg.Keys,
+		// When replaying, the streamer waits for the relay to connect before
+		// replaying, and sends its own open acknowledgement.
+		SendOpenAck:    cfg.SendOpenAck || !reflect.IsNil(l.iter.flow),
+		OverflowPolicy: cfg.OverflowPolicy,
+		BufferSize:     cfg.BufferSize,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -153,3 +362,59 @@ func (s *Service) NewStreamer(ctx context.Context, cfg StreamerConfig) (Streamer
 	l.relay.responses = relayRes
 	return l, err
 }
+
+// openReplay opens the iterator used to replay the persisted samples of the channels
+// in cfg from cfg.From onwards.
+func (s *Service) openReplay(ctx context.Context, l *streamer, cfg StreamerConfig) error {
+	var channels []channel.Channel
+	if err := s.config.ChannelReader.NewRetrieve().
+		WhereKeys(cfg.Keys...).
+		Entries(&channels).
+		Exec(ctx, nil); err != nil {
+		return err
+	}
+	keys := make(channel.Keys, 0, len(channels))
+	indexes := make(map[channel.Key]channel.Key, len(channels))
+	for _, ch := range channels {
+		if ch.Virtual {
+			continue
+		}
+		keys = append(keys, ch.Key())
+		if ch.IsIndex {
+			indexes[ch.Key()] = ch.Key()
+			continue
+		}
+		if ch.Index() == 0 {
+			return errors.Wrapf(
+				validate.Error,
+				"cannot replay channel %v from a timestamp, as it does not have an index",
+				ch.Key(),
+			)
+		}
+		if !lo.Contains(cfg.Keys, ch.Index()) {
+			return errors.Wrapf(
+				validate.Error,
+				"cannot replay channel %v from a timestamp without streaming its index %v",
+				ch.Key(),
+				ch.Index(),
+			)
+		}
+		indexes[ch.Key()] = ch.Index()
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	l.replayed = telem.NewReplay(indexes)
+	iter, err := s.NewStreamIterator(ctx, IteratorConfig{
+		Keys:   keys,
+		Bounds: cfg.From.Range(telem.TimeStampMax),
+	})
+	if err != nil {
+		return err
+	}
+	iterReq, iterRes := confluence.Attach(iter, 1)
+	l.iter.flow = iter
+	l.iter.requests = iterReq
+	l.iter.responses = iterRes
+	return nil
+}
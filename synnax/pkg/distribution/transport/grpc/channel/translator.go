@@ -21,15 +21,17 @@ import (
 )
 
 type (
-	createMessageTranslator struct{}
-	deleteRequestTranslator struct{}
-	renameMessageTranslator struct{}
+	createMessageTranslator  struct{}
+	deleteRequestTranslator  struct{}
+	renameMessageTranslator  struct{}
+	convertRequestTranslator struct{}
 )
 
 var (
-	_ fgrpc.Translator[channel.CreateMessage, *channelv1.CreateMessage] = (*createMessageTranslator)(nil)
-	_ fgrpc.Translator[channel.DeleteRequest, *channelv1.DeleteRequest] = (*deleteRequestTranslator)(nil)
-	_ fgrpc.Translator[channel.RenameRequest, *channelv1.RenameRequest] = (*renameMessageTranslator)(nil)
+	_ fgrpc.Translator[channel.CreateMessage, *channelv1.CreateMessage]   = (*createMessageTranslator)(nil)
+	_ fgrpc.Translator[channel.DeleteRequest, *channelv1.DeleteRequest]   = (*deleteRequestTranslator)(nil)
+	_ fgrpc.Translator[channel.RenameRequest, *channelv1.RenameRequest]   = (*renameMessageTranslator)(nil)
+	_ fgrpc.Translator[channel.ConvertRequest, *channelv1.ConvertRequest] = (*convertRequestTranslator)(nil)
 )
 
 func (c createMessageTranslator) Forward(
@@ -109,3 +111,23 @@ func (r renameMessageTranslator) Backward(
 		Keys:  channel.KeysFromUint32(msg.Keys),
 	}, nil
 }
+
+func (c convertRequestTranslator) Forward(
+	_ context.Context,
+	msg channel.ConvertRequest,
+) (*channelv1.ConvertRequest, error) {
+	return &channelv1.ConvertRequest{
+		Key:      uint32(msg.Key),
+		DataType: string(msg.DataType),
+	}, nil
+}
+
+func (c convertRequestTranslator) Backward(
+	_ context.Context,
+	msg *channelv1.ConvertRequest,
+) (channel.ConvertRequest, error) {
+	return channel.ConvertRequest{
+		Key:      channel.Key(msg.Key),
+		DataType: telem.DataType(msg.DataType),
+	}, nil
+}
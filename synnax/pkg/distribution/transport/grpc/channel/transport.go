@@ -59,17 +59,31 @@ type (
 		types.Nil,
 		*emptypb.Empty,
 	]
+	convertClient = fgrpc.UnaryClient[
+		channel.ConvertRequest,
+		*channelv1.ConvertRequest,
+		types.Nil,
+		*emptypb.Empty,
+	]
+	convertServer = fgrpc.UnaryServer[
+		channel.ConvertRequest,
+		*channelv1.ConvertRequest,
+		types.Nil,
+		*emptypb.Empty,
+	]
 )
 
 // Transport is a grpc backed implementation of the channel.Transport interface.
 type Transport struct {
 	alamos.ReportProvider
-	createClient *createClient
-	createServer *createServer
-	deleteClient *deleteClient
-	deleteServer *deleteServer
-	renameClient *renameClient
-	renameServer *renameServer
+	createClient  *createClient
+	createServer  *createServer
+	deleteClient  *deleteClient
+	deleteServer  *deleteServer
+	renameClient  *renameClient
+	renameServer  *renameServer
+	convertClient *convertClient
+	convertServer *convertServer
 }
 
 // CreateClient implements the channel.Transport interface.
@@ -86,11 +100,16 @@ func (t Transport) RenameClient() channel.RenameTransportClient { return t.renam
 
 func (t Transport) RenameServer() channel.RenameTransportServer { return t.renameServer }
 
+func (t Transport) ConvertClient() channel.ConvertTransportClient { return t.convertClient }
+
+func (t Transport) ConvertServer() channel.ConvertTransportServer { return t.convertServer }
+
 // BindTo implements the fgrpc.BindableTransport interface.
 func (t Transport) BindTo(reg grpc.ServiceRegistrar) {
 	t.createServer.BindTo(reg)
 	t.deleteServer.BindTo(reg)
 	t.renameServer.BindTo(reg)
+	t.convertServer.BindTo(reg)
 }
 
 var (
@@ -158,6 +177,24 @@ func New(pool *fgrpc.Pool) Transport {
 		ResponseTranslator: fgrpc.EmptyTranslator{},
 		ServiceDesc:        &channelv1.ChannelRenameService_ServiceDesc,
 	}
+	convertClient := &convertClient{
+		Pool:               pool,
+		RequestTranslator:  convertRequestTranslator{},
+		ResponseTranslator: fgrpc.EmptyTranslator{},
+		Exec: func(
+			ctx context.Context,
+			conn grpc.ClientConnInterface,
+			req *channelv1.ConvertRequest,
+		) (*emptypb.Empty, error) {
+			return channelv1.NewChannelConvertServiceClient(conn).Exec(ctx, req)
+		},
+		ServiceDesc: &channelv1.ChannelConvertService_ServiceDesc,
+	}
+	convertServer := &convertServer{
+		RequestTranslator:  convertRequestTranslator{},
+		ResponseTranslator: fgrpc.EmptyTranslator{},
+		ServiceDesc:        &channelv1.ChannelConvertService_ServiceDesc,
+	}
 	return Transport{
 		ReportProvider: fgrpc.Reporter,
 		createClient:   createClient,
@@ -166,6 +203,8 @@ func New(pool *fgrpc.Pool) Transport {
 		deleteServer:   deleteServer,
 		renameClient:   renameClient,
 		renameServer:   renameServer,
+		convertClient:  convertClient,
+		convertServer:  convertServer,
 	}
 }
 
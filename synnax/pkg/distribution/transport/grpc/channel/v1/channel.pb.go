@@ -170,6 +170,58 @@ func (x *RenameRequest) GetNames() []string {
 	return nil
 }
 
+type ConvertRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           uint32                 `protobuf:"varint,1,opt,name=key,proto3" json:"key,omitempty"`
+	DataType      string                 `protobuf:"bytes,2,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	mi := &file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ConvertRequest) GetKey() uint32 {
+	if x != nil {
+		return x.Key
+	}
+	return 0
+}
+
+func (x *ConvertRequest) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
 type Channel struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -188,7 +240,7 @@ type Channel struct {
 
 func (x *Channel) Reset() {
 	*x = Channel{}
-	mi := &file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[3]
+	mi := &file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -200,7 +252,7 @@ func (x *Channel) String() string {
 func (*Channel) ProtoMessage() {}
 
 func (x *Channel) ProtoReflect() protoreflect.Message {
-	mi := &file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[3]
+	mi := &file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -213,7 +265,7 @@ func (x *Channel) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Channel.ProtoReflect.Descriptor instead.
 func (*Channel) Descriptor() ([]byte, []int) {
-	return file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDescGZIP(), []int{3}
+	return file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Channel) GetName() string {
@@ -299,7 +351,10 @@ const file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDe
 	"\x04keys\x18\x03 \x03(\rR\x04keys\"9\n" +
 	"\rRenameRequest\x12\x12\n" +
 	"\x04keys\x18\x01 \x03(\rR\x04keys\x12\x14\n" +
-	"\x05names\x18\x02 \x03(\tR\x05names\"\xa1\x02\n" +
+	"\x05names\x18\x02 \x03(\tR\x05names\"?\n" +
+	"\x0eConvertRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\x12\x1b\n" +
+	"\tdata_type\x18\x02 \x01(\tR\bdataType\"\xa1\x02\n" +
 	"\aChannel\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vleaseholder\x18\x02 \x01(\x05R\vleaseholder\x12\x1b\n" +
@@ -318,7 +373,9 @@ const file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDe
 	"\x14ChannelDeleteService\x12;\n" +
 	"\x04Exec\x12\x19.channel.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\"\x002S\n" +
 	"\x14ChannelRenameService\x12;\n" +
-	"\x04Exec\x12\x19.channel.v1.RenameRequest\x1a\x16.google.protobuf.Empty\"\x00B\xb0\x01\n" +
+	"\x04Exec\x12\x19.channel.v1.RenameRequest\x1a\x16.google.protobuf.Empty\"\x002U\n" +
+	"\x15ChannelConvertService\x12<\n" +
+	"\x04Exec\x12\x1a.channel.v1.ConvertRequest\x1a\x16.google.protobuf.Empty\"\x00B\xb0\x01\n" +
 	"\x0ecom.channel.v1B\fChannelProtoP\x01ZGgithub.com/synnaxlabs/synnax/pkg/distribution/transport/grpc/channel/v1\xa2\x02\x03CXX\xaa\x02\n" +
 	"Channel.V1\xca\x02\n" +
 	"Channel\\V1\xe2\x02\x16Channel\\V1\\GPBMetadata\xea\x02\vChannel::V1b\x06proto3"
@@ -335,24 +392,27 @@ func file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDes
 	return file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDescData
 }
 
-var file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_goTypes = []any{
-	(*CreateMessage)(nil), // 0: channel.v1.CreateMessage
-	(*DeleteRequest)(nil), // 1: channel.v1.DeleteRequest
-	(*RenameRequest)(nil), // 2: channel.v1.RenameRequest
-	(*Channel)(nil),       // 3: channel.v1.Channel
-	(*emptypb.Empty)(nil), // 4: google.protobuf.Empty
+	(*CreateMessage)(nil),  // 0: channel.v1.CreateMessage
+	(*DeleteRequest)(nil),  // 1: channel.v1.DeleteRequest
+	(*RenameRequest)(nil),  // 2: channel.v1.RenameRequest
+	(*ConvertRequest)(nil), // 3: channel.v1.ConvertRequest
+	(*Channel)(nil),        // 4: channel.v1.Channel
+	(*emptypb.Empty)(nil),  // 5: google.protobuf.Empty
 }
 var file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_depIdxs = []int32{
-	3, // 0: channel.v1.CreateMessage.channels:type_name -> channel.v1.Channel
+	4, // 0: channel.v1.CreateMessage.channels:type_name -> channel.v1.Channel
 	0, // 1: channel.v1.ChannelCreateService.Exec:input_type -> channel.v1.CreateMessage
 	1, // 2: channel.v1.ChannelDeleteService.Exec:input_type -> channel.v1.DeleteRequest
 	2, // 3: channel.v1.ChannelRenameService.Exec:input_type -> channel.v1.RenameRequest
-	0, // 4: channel.v1.ChannelCreateService.Exec:output_type -> channel.v1.CreateMessage
-	4, // 5: channel.v1.ChannelDeleteService.Exec:output_type -> google.protobuf.Empty
-	4, // 6: channel.v1.ChannelRenameService.Exec:output_type -> google.protobuf.Empty
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
+	3, // 4: channel.v1.ChannelConvertService.Exec:input_type -> channel.v1.ConvertRequest
+	0, // 5: channel.v1.ChannelCreateService.Exec:output_type -> channel.v1.CreateMessage
+	5, // 6: channel.v1.ChannelDeleteService.Exec:output_type -> google.protobuf.Empty
+	5, // 7: channel.v1.ChannelRenameService.Exec:output_type -> google.protobuf.Empty
+	5, // 8: channel.v1.ChannelConvertService.Exec:output_type -> google.protobuf.Empty
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
 	1, // [1:1] is the sub-list for extension extendee
 	0, // [0:1] is the sub-list for field type_name
@@ -369,9 +429,9 @@ func file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_init()
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDesc), len(file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   4,
 		},
 		GoTypes:           file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_goTypes,
 		DependencyIndexes: file_synnax_pkg_distribution_transport_grpc_channel_v1_channel_proto_depIdxs,
@@ -282,3 +282,91 @@ var ChannelRenameService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "synnax/pkg/distribution/transport/grpc/channel/v1/channel.proto",
 }
+
+const (
+	ChannelConvertService_Exec_FullMethodName = "/channel.v1.ChannelConvertService/Exec"
+)
+
+// ChannelConvertServiceClient is the client API for ChannelConvertService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ChannelConvertServiceClient interface {
+	Exec(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type channelConvertServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChannelConvertServiceClient(cc grpc.ClientConnInterface) ChannelConvertServiceClient {
+	return &channelConvertServiceClient{cc}
+}
+
+func (c *channelConvertServiceClient) Exec(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ChannelConvertService_Exec_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChannelConvertServiceServer is the server API for ChannelConvertService service.
+// All implementations should embed UnimplementedChannelConvertServiceServer
+// for forward compatibility
+type ChannelConvertServiceServer interface {
+	Exec(context.Context, *ConvertRequest) (*emptypb.Empty, error)
+}
+
+// UnimplementedChannelConvertServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedChannelConvertServiceServer struct {
+}
+
+func (UnimplementedChannelConvertServiceServer) Exec(context.Context, *ConvertRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+
+// UnsafeChannelConvertServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChannelConvertServiceServer will
+// result in compilation errors.
+type UnsafeChannelConvertServiceServer interface {
+	mustEmbedUnimplementedChannelConvertServiceServer()
+}
+
+func RegisterChannelConvertServiceServer(s grpc.ServiceRegistrar, srv ChannelConvertServiceServer) {
+	s.RegisterService(&ChannelConvertService_ServiceDesc, srv)
+}
+
+func _ChannelConvertService_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChannelConvertServiceServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChannelConvertService_Exec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChannelConvertServiceServer).Exec(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ChannelConvertService_ServiceDesc is the grpc.ServiceDesc for ChannelConvertService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChannelConvertService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "channel.v1.ChannelConvertService",
+	HandlerType: (*ChannelConvertServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Exec",
+			Handler:    _ChannelConvertService_Exec_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "synnax/pkg/distribution/transport/grpc/channel/v1/channel.proto",
+}
@@ -17,37 +17,43 @@ import (
 )
 
 type ChannelNetwork struct {
-	CreateNet *fmock.Network[channel.CreateMessage, channel.CreateMessage]
-	DeleteNet *fmock.Network[channel.DeleteRequest, types.Nil]
-	RenameNet *fmock.Network[channel.RenameRequest, types.Nil]
+	CreateNet  *fmock.Network[channel.CreateMessage, channel.CreateMessage]
+	DeleteNet  *fmock.Network[channel.DeleteRequest, types.Nil]
+	RenameNet  *fmock.Network[channel.RenameRequest, types.Nil]
+	ConvertNet *fmock.Network[channel.ConvertRequest, types.Nil]
 }
 
 func (c *ChannelNetwork) New(add address.Address) channel.Transport {
 	return &ChannelTransport{
-		createClient: c.CreateNet.UnaryClient(),
-		createServer: c.CreateNet.UnaryServer(add),
-		deleteClient: c.DeleteNet.UnaryClient(),
-		deleteServer: c.DeleteNet.UnaryServer(add),
-		renameClient: c.RenameNet.UnaryClient(),
-		renameServer: c.RenameNet.UnaryServer(add),
+		createClient:  c.CreateNet.UnaryClient(),
+		createServer:  c.CreateNet.UnaryServer(add),
+		deleteClient:  c.DeleteNet.UnaryClient(),
+		deleteServer:  c.DeleteNet.UnaryServer(add),
+		renameClient:  c.RenameNet.UnaryClient(),
+		renameServer:  c.RenameNet.UnaryServer(add),
+		convertClient: c.ConvertNet.UnaryClient(),
+		convertServer: c.ConvertNet.UnaryServer(add),
 	}
 }
 
 func NewChannelNetwork() *ChannelNetwork {
 	return &ChannelNetwork{
-		CreateNet: fmock.NewNetwork[channel.CreateMessage, channel.CreateMessage](),
-		DeleteNet: fmock.NewNetwork[channel.DeleteRequest, types.Nil](),
-		RenameNet: fmock.NewNetwork[channel.RenameRequest, types.Nil](),
+		CreateNet:  fmock.NewNetwork[channel.CreateMessage, channel.CreateMessage](),
+		DeleteNet:  fmock.NewNetwork[channel.DeleteRequest, types.Nil](),
+		RenameNet:  fmock.NewNetwork[channel.RenameRequest, types.Nil](),
+		ConvertNet: fmock.NewNetwork[channel.ConvertRequest, types.Nil](),
 	}
 }
 
 type ChannelTransport struct {
-	createClient channel.CreateTransportClient
-	createServer channel.CreateTransportServer
-	deleteClient channel.DeleteTransportClient
-	deleteServer channel.DeleteTransportServer
-	renameClient channel.RenameTransportClient
-	renameServer channel.RenameTransportServer
+	createClient  channel.CreateTransportClient
+	createServer  channel.CreateTransportServer
+	deleteClient  channel.DeleteTransportClient
+	deleteServer  channel.DeleteTransportServer
+	renameClient  channel.RenameTransportClient
+	renameServer  channel.RenameTransportServer
+	convertClient channel.ConvertTransportClient
+	convertServer channel.ConvertTransportServer
 }
 
 var _ channel.Transport = (*ChannelTransport)(nil)
@@ -63,3 +69,7 @@ func (c ChannelTransport) DeleteServer() channel.DeleteTransportServer { return
 func (c ChannelTransport) RenameClient() channel.RenameTransportClient { return c.renameClient }
 
 func (c ChannelTransport) RenameServer() channel.RenameTransportServer { return c.renameServer }
+
+func (c ChannelTransport) ConvertClient() channel.ConvertTransportClient { return c.convertClient }
+
+func (c ChannelTransport) ConvertServer() channel.ConvertTransportServer { return c.convertServer }
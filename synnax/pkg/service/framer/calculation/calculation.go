@@ -17,13 +17,12 @@ import (
 	"io"
 	"sync"
 
-	"github.com/samber/lo"
 	"github.com/synnaxlabs/alamos"
 	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
 	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/calculator"
 	"github.com/synnaxlabs/synnax/pkg/distribution/framer/writer"
 	"github.com/synnaxlabs/x/change"
-	"github.com/synnaxlabs/x/computron"
 	"github.com/synnaxlabs/x/config"
 	"github.com/synnaxlabs/x/confluence"
 	"github.com/synnaxlabs/x/confluence/plumber"
@@ -51,6 +50,10 @@ type Config struct {
 	// ChannelObservable is used to listen to real-time changes in calculated channels
 	// so the calculation routines can be updated accordingly.
 	ChannelObservable observe.Observable[gorp.TxReader[channel.Key, channel.Channel]]
+	// HostProvider is used to materialize only the materialized calculated channels
+	// leased by this node.
+	// [REQUIRED]
+	HostProvider core.HostProvider
 }
 
 var (
@@ -65,6 +68,7 @@ func (c Config) Validate() error {
 	validate.NotNil(v, "Framer", c.Framer)
 	validate.NotNil(v, "Channel", c.Channel)
 	validate.NotNil(v, "ChannelObservable", c.ChannelObservable)
+	validate.NotNil(v, "HostProvider", c.HostProvider)
 	return v.Error()
 }
 
@@ -74,6 +78,7 @@ func (c Config) Override(other Config) Config {
 	c.Framer = override.Nil(c.Framer, other.Framer)
 	c.Channel = override.Nil(c.Channel, other.Channel)
 	c.ChannelObservable = override.Nil(c.ChannelObservable, other.ChannelObservable)
+	c.HostProvider = override.Nil(c.HostProvider, other.HostProvider)
 	return c
 }
 
@@ -101,6 +106,9 @@ type Service struct {
 	mu  struct {
 		sync.Mutex
 		entries map[channel.Key]*entry
+		// materialized are the running materializations of the materialized
+		// calculated channels leased by this node.
+		materialized map[channel.Key]*materialization
 	}
 	disconnectFromChannelChanges observe.Disconnect
 	stateKey                     channel.Key
@@ -163,9 +171,27 @@ func Open(ctx context.Context, cfgs ...Config) (*Service, error) {
 	}
 
 	s := &Service{cfg: cfg, w: w, stateKey: calculationStateCh.Key()}
-	s.disconnectFromChannelChanges = cfg.ChannelObservable.OnChange(s.handleChange)
 	s.mu.entries = make(map[channel.Key]*entry)
-
+	s.mu.materialized = make(map[channel.Key]*materialization)
+	var leased []channel.Channel
+	if err = cfg.Channel.NewRetrieve().
+		WhereNodeKey(cfg.HostProvider.HostKey()).
+		WhereVirtual(false).
+		Entries(&leased).
+		Exec(ctx, nil); err != nil {
+		return nil, errors.Combine(err, w.Close())
+	}
+	s.mu.Lock()
+	for _, ch := range leased {
+		if !ch.IsMaterialized() {
+			continue
+		}
+		if err := s.startMaterializer(ctx, ch); err != nil {
+			cfg.L.Error("failed to start materialized channel", zap.Error(err), zap.Stringer("key", ch))
+		}
+	}
+	s.mu.Unlock()
+	s.disconnectFromChannelChanges = cfg.ChannelObservable.OnChange(s.handleChange)
 	return s, nil
 }
 
@@ -173,10 +199,16 @@ func (s *Service) handleChange(
 	ctx context.Context,
 	reader gorp.TxReader[channel.Key, channel.Channel],
 ) {
-	c, ok := reader.Next(ctx)
-	if !ok {
-		return
+	for c, ok := reader.Next(ctx); ok; c, ok = reader.Next(ctx) {
+		s.handleMaterializedChange(ctx, c)
+		s.handleCalculatedChange(ctx, c)
 	}
+}
+
+func (s *Service) handleCalculatedChange(
+	ctx context.Context,
+	c change.Change[channel.Key, channel.Channel],
+) {
 	// Don't stop calculating if the channel is deleted. The calculation will be
 	// automatically shut down when it is no longer needed.
 	if c.Variant != change.Set || !c.Value.IsCalculated() {
@@ -194,6 +226,35 @@ func (s *Service) handleChange(
 	s.update(ctx, c.Value)
 }
 
+// handleMaterializedChange starts, restarts, or stops materializing a channel leased
+// by this node when it is created, changed, or deleted.
+func (s *Service) handleMaterializedChange(
+	ctx context.Context,
+	c change.Change[channel.Key, channel.Channel],
+) {
+	if c.Key.Leaseholder() != s.cfg.HostProvider.HostKey() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, found := s.mu.materialized[c.Key]
+	if c.Variant == change.Delete {
+		s.stopMaterializer(c.Key)
+		return
+	}
+	if !c.Value.IsMaterialized() {
+		s.stopMaterializer(c.Key)
+		return
+	}
+	if found && existing.ch.Equals(c.Value, "Name") {
+		return
+	}
+	s.stopMaterializer(c.Key)
+	if err := s.startMaterializer(ctx, c.Value); err != nil {
+		s.cfg.L.Error("failed to start materialized channel", zap.Error(err), zap.Stringer("key", c.Value))
+	}
+}
+
 func (s *Service) update(ctx context.Context, ch channel.Channel) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -210,6 +271,7 @@ func (s *Service) update(ctx context.Context, ch channel.Channel) {
 		s.cfg.L.Error("failed to restart calculated channel", zap.Error(err), zap.Stringer("key", ch))
 		e.ch.Requires = ch.Requires
 		e.ch.Expression = ch.Expression
+		e.ch.Interpolation = ch.Interpolation
 		s.mu.entries[ch.Key()] = e
 	}
 }
@@ -246,6 +308,9 @@ func (s *Service) Close() error {
 	for _, e := range s.mu.entries {
 		c.Exec(e.shutdown.Close)
 	}
+	for _, m := range s.mu.materialized {
+		c.Exec(m.Close)
+	}
 	c.Exec(s.w.Close)
 	return c.Error()
 }
@@ -294,6 +359,15 @@ func (s *Service) startCalculation(
 		Exec(ctx, nil); err != nil {
 		return nil, err
 	}
+	calc, err := calculator.Open(ch, requires)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			calc.Close()
+		}
+	}()
 
 	writer_, err := s.cfg.Framer.NewStreamWriter(ctx, framer.WriterConfig{
 		Keys:  channel.Keys{ch.Key()},
@@ -302,7 +376,7 @@ func (s *Service) startCalculation(
 	if err != nil {
 		return nil, err
 	}
-	streamer_, err := s.cfg.Framer.NewStreamer(ctx, framer.StreamerConfig{Keys: ch.Requires})
+	streamer_, err := s.cfg.Framer.NewStreamer(ctx, framer.StreamerConfig{Keys: calc.Keys()})
 	if err != nil {
 		return nil, err
 	}
@@ -310,24 +384,13 @@ func (s *Service) startCalculation(
 	plumber.SetSegment(p, "streamer", streamer_)
 	plumber.SetSegment(p, "writer", writer_)
 
-	calculation, err := computron.Open(ch.Expression)
-	if err != nil {
-		return nil, err
-	}
-	c := &Calculator{
-		ch:          ch,
-		calculation: calculation,
-		requires: lo.SliceToMap(requires, func(item channel.Channel) (channel.Key, channel.Channel) {
-			return item.Key(), item
-		}),
-	}
-	sc := &streamCalculator{internal: c, cfg: s.cfg, setState: s.SetState}
+	sc := &streamCalculator{internal: calc, cfg: s.cfg, setState: s.SetState}
 	sc.Transform = sc.transform
 	plumber.SetSegment[framer.StreamerResponse, framer.WriterRequest](
 		p,
 		"calculator",
 		sc,
-		confluence.Defer(sc.internal.close),
+		confluence.Defer(sc.internal.Close),
 	)
 
 	o := confluence.NewObservableSubscriber[framer.WriterResponse]()
@@ -357,7 +420,7 @@ func (s *Service) startCalculation(
 }
 
 type streamCalculator struct {
-	internal *Calculator
+	internal *calculator.Calculator
 	cfg      Config
 	lastErr  error
 	confluence.LinearTransform[framer.StreamerResponse, framer.WriterRequest]
@@ -369,52 +432,10 @@ func (s *streamCalculator) transform(ctx context.Context, i framer.StreamerRespo
 	if err != nil {
 		s.cfg.L.Error("calculation error",
 			zap.Error(err),
-			zap.String("channel_name", s.internal.ch.Name),
-			zap.String("expression", s.internal.ch.Expression))
-		s.setState(ctx, s.internal.ch.Key(), "error", err.Error())
+			zap.String("channel_name", s.internal.Channel().Name),
+			zap.String("expression", s.internal.Channel().Expression))
+		s.setState(ctx, s.internal.Channel().Key(), "error", err.Error())
 		return framer.WriterRequest{}, false, nil
 	}
 	return framer.WriterRequest{Command: writer.Data, Frame: frame}, true, nil
 }
-
-type Calculator struct {
-	ch          channel.Channel
-	calculation *computron.Calculator
-	requires    map[channel.Key]channel.Channel
-}
-
-func (c Calculator) close() { c.calculation.Close() }
-
-func (c Calculator) Calculate(fr framer.Frame) (of framer.Frame, err error) {
-	if len(fr.Series) == 0 {
-		return
-	}
-	os := telem.AllocSeries(c.ch.DataType, fr.Series[0].Len())
-	// Mark the alignment of the output series as the same as the input series. Right now, we assume that all the
-	// input channels share the same index.
-	os.Alignment = fr.Series[0].Alignment
-	of.Keys = []channel.Key{c.ch.Key()}
-	of.Series = []telem.Series{os}
-	for i := range os.Len() {
-		for _, k := range c.ch.Requires {
-			sArray := fr.Get(k)
-			if len(sArray) == 0 {
-				continue
-			}
-			s := sArray[0]
-			idx := i
-			if idx >= s.Len() {
-				idx = s.Len() - 1
-			}
-			if ch, found := c.requires[k]; found {
-				c.calculation.Set(ch.Name, computron.LValueFromSeries(sArray[0], idx))
-			}
-		}
-		res, err := c.calculation.Run()
-		if err != nil {
-			return of, err
-		}
-		computron.SetLValueOnSeries(res, os, i)
-	}
-	return of, nil
-}
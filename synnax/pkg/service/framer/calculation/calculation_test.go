@@ -45,6 +45,7 @@ var _ = Describe("Calculation", Ordered, func() {
 			Framer:            dist.Framer,
 			Channel:           dist.Channel,
 			ChannelObservable: dist.Channel.NewObservable(),
+			HostProvider:      dist.Cluster,
 		}))
 	})
 
@@ -353,4 +354,250 @@ var _ = Describe("Calculation", Ordered, func() {
 		Expect(state.Variant).To(Equal("error"))
 		Expect(state.Message).To(ContainSubstring("cannot perform add operation between nil and nil"))
 	})
+
+	Describe("Alignment", func() {
+		// calculate calculates pressure + temperature for pressure samples one second
+		// apart and temperature samples four seconds apart, writing the temperature
+		// samples first if temperatureFirst is true, and returns the calculated samples.
+		calculate := func(interp channel.Interpolation, temperatureFirst bool) []float64 {
+			var (
+				pressureIdx    = channel.Channel{Name: "pressure_time", DataType: telem.TimeStampT, IsIndex: true}
+				temperatureIdx = channel.Channel{Name: "temperature_time", DataType: telem.TimeStampT, IsIndex: true}
+			)
+			Expect(dist.Channel.Create(ctx, &pressureIdx)).To(Succeed())
+			Expect(dist.Channel.Create(ctx, &temperatureIdx)).To(Succeed())
+			pressure := channel.Channel{Name: "pressure", DataType: telem.Float64T, LocalIndex: pressureIdx.LocalKey}
+			temperature := channel.Channel{Name: "temperature", DataType: telem.Float64T, LocalIndex: temperatureIdx.LocalKey}
+			Expect(dist.Channel.Create(ctx, &pressure)).To(Succeed())
+			Expect(dist.Channel.Create(ctx, &temperature)).To(Succeed())
+			calculatedCH := channel.Channel{
+				Name:          "aligned",
+				DataType:      telem.Float64T,
+				Virtual:       true,
+				Leaseholder:   core.Free,
+				Requires:      []channel.Key{pressure.Key(), temperature.Key()},
+				Expression:    "return pressure + temperature",
+				Interpolation: interp,
+			}
+			Expect(dist.Channel.Create(ctx, &calculatedCH)).To(Succeed())
+			closer := MustSucceed(c.Request(ctx, calculatedCH.Key()))
+			defer func() { Expect(closer.Close()).To(Succeed()) }()
+			sCtx, cancel := signal.WithCancel(ctx)
+			defer cancel()
+			streamer := MustSucceed(dist.Framer.NewStreamer(ctx, framer.StreamerConfig{
+				Keys: []channel.Key{calculatedCH.Key()},
+			}))
+			_, sOutlet := confluence.Attach[framer.StreamerRequest, framer.StreamerResponse](streamer, 1, 10)
+			streamer.Flow(sCtx)
+			time.Sleep(sleepInterval)
+			start := telem.Now()
+			write := func(idx, data channel.Channel, stamps []telem.TimeStamp, values telem.Series) {
+				w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+					Keys:  channel.Keys{idx.Key(), data.Key()},
+					Start: start,
+				}))
+				Expect(w.Write(framer.Frame{
+					Keys:   channel.Keys{idx.Key(), data.Key()},
+					Series: []telem.Series{telem.NewSeries(stamps), values},
+				})).To(BeTrue())
+				Expect(w.Close()).To(Succeed())
+			}
+			writePressure := func() {
+				stamps := make([]telem.TimeStamp, 5)
+				for i := range stamps {
+					stamps[i] = start.Add(telem.TimeSpan(i) * telem.Second)
+				}
+				write(pressureIdx, pressure, stamps, telem.NewSeriesV[float64](0, 10, 20, 30, 40))
+			}
+			writeTemperature := func() {
+				write(
+					temperatureIdx,
+					temperature,
+					[]telem.TimeStamp{start, start.Add(4 * telem.Second)},
+					telem.NewSeriesV[float64](100, 500),
+				)
+			}
+			if temperatureFirst {
+				writeTemperature()
+				writePressure()
+			} else {
+				writePressure()
+				writeTemperature()
+			}
+			var samples []float64
+			Eventually(func(g Gomega) {
+				var res framer.StreamerResponse
+				g.Eventually(sOutlet.Outlet()).Should(Receive(&res))
+				for _, series := range res.Frame.Series {
+					samples = append(samples, telem.Unmarshal[float64](series)...)
+				}
+				g.Expect(samples).To(HaveLen(5))
+			}, 5*time.Second).Should(Succeed())
+			return samples
+		}
+
+		It("Should hold the last sample of each required channel", func() {
+			Expect(calculate(channel.InterpolationHoldLast, true)).
+				To(Equal([]float64{100, 110, 120, 130, 540}))
+		})
+
+		It("Should interpolate linearly between samples", func() {
+			Expect(calculate(channel.InterpolationLinear, false)).
+				To(Equal([]float64{100, 210, 320, 430, 540}))
+		})
+
+		It("Should use the nearest sample", func() {
+			Expect(calculate(channel.InterpolationNearest, false)).
+				To(Equal([]float64{100, 110, 120, 530, 540}))
+		})
+	})
+
+	Describe("Materialized", func() {
+		It("Should persist calculated samples and recalculate re-written ranges", func() {
+			baseIdx := channel.Channel{Name: "base_time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(dist.Channel.Create(ctx, &baseIdx)).To(Succeed())
+			base := channel.Channel{Name: "base", DataType: telem.Int64T, LocalIndex: baseIdx.LocalKey}
+			Expect(dist.Channel.Create(ctx, &base)).To(Succeed())
+			write := func(start telem.TimeStamp, values telem.Series) {
+				stamps := make([]telem.TimeStamp, values.Len())
+				for i := range stamps {
+					stamps[i] = start.Add(telem.TimeSpan(i) * telem.Second)
+				}
+				keys := channel.Keys{baseIdx.Key(), base.Key()}
+				w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{Keys: keys, Start: start}))
+				Expect(w.Write(framer.Frame{
+					Keys:   keys,
+					Series: []telem.Series{telem.NewSeries(stamps), values},
+				})).To(BeTrue())
+				Expect(w.Commit()).To(BeTrue())
+				Expect(w.Close()).To(Succeed())
+			}
+			write(10*telem.SecondTS, telem.NewSeriesV[int64](1, 2, 3))
+
+			idx := channel.Channel{Name: "doubled_time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(dist.Channel.Create(ctx, &idx)).To(Succeed())
+			doubled := channel.Channel{
+				Name:         "doubled",
+				DataType:     telem.Int64T,
+				LocalIndex:   idx.LocalKey,
+				Requires:     []channel.Key{base.Key()},
+				Expression:   "return base * 2",
+				Materialized: true,
+			}
+			Expect(dist.Channel.Create(ctx, &doubled)).To(Succeed())
+			// read returns the seconds and values of the materialized samples.
+			read := func(g Gomega) ([]int64, []int64) {
+				iter := MustSucceed(dist.Framer.OpenIterator(ctx, framer.IteratorConfig{
+					Keys:   channel.Keys{idx.Key(), doubled.Key()},
+					Bounds: telem.TimeRangeMax,
+				}))
+				defer func() { g.Expect(iter.Close()).To(Succeed()) }()
+				var seconds, values []int64
+				for ok := iter.SeekFirst(); ok && iter.Next(telem.TimeSpanMax); {
+					for _, s := range iter.Value().Get(idx.Key()) {
+						for _, ts := range telem.Unmarshal[telem.TimeStamp](s) {
+							seconds = append(seconds, int64(ts/telem.SecondTS))
+						}
+					}
+					for _, s := range iter.Value().Get(doubled.Key()) {
+						values = append(values, telem.Unmarshal[int64](s)...)
+					}
+				}
+				return seconds, values
+			}
+
+			By("Calculating the history of the required channel")
+			Eventually(func(g Gomega) {
+				seconds, values := read(g)
+				g.Expect(seconds).To(Equal([]int64{10, 11, 12}))
+				g.Expect(values).To(Equal([]int64{2, 4, 6}))
+			}, 5*time.Second).Should(Succeed())
+
+			By("Calculating new samples as they are written")
+			write(20*telem.SecondTS, telem.NewSeriesV[int64](4, 5))
+			Eventually(func(g Gomega) {
+				seconds, values := read(g)
+				g.Expect(seconds).To(Equal([]int64{10, 11, 12, 20, 21}))
+				g.Expect(values).To(Equal([]int64{2, 4, 6, 8, 10}))
+			}, 5*time.Second).Should(Succeed())
+
+			By("Recalculating samples over a re-written range")
+			Expect(dist.Framer.NewDeleter().DeleteTimeRangeMany(
+				ctx,
+				channel.Keys{base.Key(), baseIdx.Key()},
+				(10 * telem.SecondTS).Range(13*telem.SecondTS),
+			)).To(Succeed())
+			write(10*telem.SecondTS, telem.NewSeriesV[int64](100, 200, 300))
+			Eventually(func(g Gomega) {
+				seconds, values := read(g)
+				g.Expect(seconds).To(Equal([]int64{10, 11, 12, 20, 21}))
+				g.Expect(values).To(Equal([]int64{200, 400, 600, 8, 10}))
+			}, 5*time.Second).Should(Succeed())
+		})
+
+		It("Should calculate samples written while calculating the history once", func() {
+			baseIdx := channel.Channel{Name: "backfill_time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(dist.Channel.Create(ctx, &baseIdx)).To(Succeed())
+			base := channel.Channel{Name: "backfill", DataType: telem.Int64T, LocalIndex: baseIdx.LocalKey}
+			Expect(dist.Channel.Create(ctx, &base)).To(Succeed())
+			keys := channel.Keys{baseIdx.Key(), base.Key()}
+			w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+				Keys:  keys,
+				Start: telem.SecondTS,
+			}))
+			// write writes n samples starting at the given second, with values equal
+			// to their second.
+			write := func(start, n int) {
+				stamps := make([]telem.TimeStamp, n)
+				values := make([]int64, n)
+				for i := range n {
+					stamps[i] = telem.TimeStamp(start+i) * telem.SecondTS
+					values[i] = int64(start + i)
+				}
+				Expect(w.Write(framer.Frame{
+					Keys:   keys,
+					Series: []telem.Series{telem.NewSeries(stamps), telem.NewSeries(values)},
+				})).To(BeTrue())
+				Expect(w.Commit()).To(BeTrue())
+			}
+			const history = 5000
+			write(1, history)
+
+			idx := channel.Channel{Name: "backfill_doubled_time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(dist.Channel.Create(ctx, &idx)).To(Succeed())
+			doubled := channel.Channel{
+				Name:         "backfill_doubled",
+				DataType:     telem.Int64T,
+				LocalIndex:   idx.LocalKey,
+				Requires:     []channel.Key{base.Key()},
+				Expression:   "return backfill * 2",
+				Materialized: true,
+			}
+			Expect(dist.Channel.Create(ctx, &doubled)).To(Succeed())
+			const live = 100
+			for i := range live {
+				write(history+1+i, 1)
+			}
+			Expect(w.Close()).To(Succeed())
+
+			expected := make([]int64, history+live)
+			for i := range expected {
+				expected[i] = int64(i+1) * 2
+			}
+			Eventually(func(g Gomega) {
+				iter := MustSucceed(dist.Framer.OpenIterator(ctx, framer.IteratorConfig{
+					Keys:   channel.Keys{doubled.Key()},
+					Bounds: telem.TimeRangeMax,
+				}))
+				defer func() { g.Expect(iter.Close()).To(Succeed()) }()
+				var values []int64
+				for ok := iter.SeekFirst(); ok && iter.Next(telem.TimeSpanMax); {
+					for _, s := range iter.Value().Get(doubled.Key()) {
+						values = append(values, telem.Unmarshal[int64](s)...)
+					}
+				}
+				g.Expect(values).To(Equal(expected))
+			}, 10*time.Second).Should(Succeed())
+		})
+	})
 })
@@ -0,0 +1,495 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package calculation
+
+import (
+	"context"
+	"io"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/calculator"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer/iterator"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+	"go.uber.org/zap"
+)
+
+// materializer keeps the samples of a materialized calculated channel up to date with
+// the samples of the channels it requires. When started, it calculates the samples of
+// the channel from the history of its required channels that has not been
+// materialized yet, and then calculates samples as the required channels are written.
+// When samples of a required channel are written at or before samples that have
+// already been received, as happens when a range of the channel is deleted and
+// re-written, the samples of the calculated channel over that range are deleted and
+// recalculated from storage.
+type materializer struct {
+	cfg Config
+	ch  channel.Channel
+	// live calculates samples from the frames streamed from the required channels,
+	// while history calculates samples from the history of the required channels read
+	// from storage. They are kept separate so that reading history does not reset the
+	// state of stateful expressions calculated from the stream.
+	live, history *calculator.Calculator
+	// end is the timestamp after the last materialized sample.
+	end telem.TimeStamp
+	// indexes are the keys of the indexes of the required channels, by the key of the
+	// required channel.
+	indexes map[channel.Key]channel.Key
+	// latest is the timestamp of the latest sample received for each index of the
+	// required channels.
+	latest map[channel.Key]telem.TimeStamp
+	// writer writes the samples calculated from the samples streamed from the required
+	// channels. It is nil until the first samples are calculated, and after samples
+	// are recalculated.
+	writer   *framer.Writer
+	setState func(ctx context.Context, key channel.Key, variant string, message string) error
+}
+
+// materialization is used to stop a running materializer.
+type materialization struct {
+	ch       channel.Channel
+	requests confluence.Inlet[framer.StreamerRequest]
+	shutdown io.Closer
+}
+
+func (m *materialization) Close() error {
+	m.requests.Close()
+	return m.shutdown.Close()
+}
+
+// startMaterializer starts materializing the given channel. The caller must hold the
+// service's mutex.
+func (s *Service) startMaterializer(ctx context.Context, ch channel.Channel) (err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Combine(err, s.SetState(ctx, ch.Key(), "error", err.Error()))
+		}
+	}()
+	var requires []channel.Channel
+	if err = s.cfg.Channel.NewRetrieve().
+		WhereKeys(ch.Requires...).
+		Entries(&requires).
+		Exec(ctx, nil); err != nil {
+		return err
+	}
+	live, err := calculator.Open(ch, requires)
+	if err != nil {
+		return err
+	}
+	history, err := calculator.Open(ch, requires)
+	if err != nil {
+		live.Close()
+		return err
+	}
+	// Open the streamer before reading the history of the required channels so that
+	// no samples are missed between the two.
+	streamer, err := s.cfg.Framer.NewStreamer(ctx, framer.StreamerConfig{Keys: live.Keys()})
+	if err != nil {
+		live.Close()
+		history.Close()
+		return err
+	}
+	m := &materializer{
+		cfg:      s.cfg,
+		ch:       ch,
+		live:     live,
+		history:  history,
+		indexes:  make(map[channel.Key]channel.Key, len(requires)),
+		latest:   make(map[channel.Key]telem.TimeStamp),
+		setState: s.SetState,
+	}
+	for _, r := range requires {
+		m.indexes[r.Key()] = r.Index()
+	}
+	sCtx, cancel := signal.Isolated(signal.WithInstrumentation(s.cfg.Instrumentation))
+	requests := confluence.NewStream[framer.StreamerRequest](1)
+	responses := confluence.NewStream[framer.StreamerResponse](defaultPipelineBufferSize)
+	streamer.InFrom(requests)
+	streamer.OutTo(responses)
+	streamer.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+	sCtx.Go(func(ctx context.Context) error {
+		defer func() {
+			live.Close()
+			history.Close()
+		}()
+		return m.run(ctx, responses.Outlet())
+	})
+	s.mu.materialized[ch.Key()] = &materialization{
+		ch:       ch,
+		requests: requests,
+		shutdown: signal.NewHardShutdown(sCtx, cancel),
+	}
+	s.cfg.L.Debug("started materialized channel", zap.Stringer("key", ch))
+	return nil
+}
+
+// stopMaterializer stops materializing the channel with the given key, if it is being
+// materialized. The caller must hold the service's mutex.
+func (s *Service) stopMaterializer(key channel.Key) {
+	m, ok := s.mu.materialized[key]
+	if !ok {
+		return
+	}
+	delete(s.mu.materialized, key)
+	if err := m.Close(); err != nil {
+		s.cfg.L.Error("failed to close materialized channel", zap.Error(err), zap.Stringer("key", m.ch))
+	}
+}
+
+func (m *materializer) run(ctx context.Context, responses <-chan framer.StreamerResponse) error {
+	defer func() {
+		if ctx.Err() != nil && m.writer != nil {
+			// The writer was opened under ctx and has stopped, so it cannot commit.
+			m.report(ctx, m.writer.Close())
+			m.writer = nil
+			return
+		}
+		m.report(ctx, m.closeWriter())
+	}()
+	end, err := m.lastMaterialized(ctx)
+	if err != nil {
+		m.report(ctx, err)
+		return err
+	}
+	m.end = end
+	frames, closed := m.backfill(ctx, responses)
+	for _, fr := range frames {
+		if !m.backfilled(fr) {
+			m.report(ctx, m.process(ctx, fr))
+		}
+	}
+	if closed {
+		return nil
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case res, ok := <-responses:
+			if !ok {
+				return nil
+			}
+			m.report(ctx, m.process(ctx, res.Frame))
+		}
+	}
+}
+
+// maxBackfillFrames is the maximum number of frames streamed from the required
+// channels that are held while materializing their history.
+const maxBackfillFrames = 1000
+
+// backfill materializes the history of the required channels from the end of the
+// materialized samples, returning the frames streamed from the required channels in
+// the meantime, and whether the stream of responses was closed. Responses are received
+// while materializing so that a long backfill does not stall the writers of the
+// required channels. If more than maxBackfillFrames frames are streamed, they are
+// discarded and the history written since the backfill started is materialized
+// again, as the samples of the discarded frames are persisted.
+func (m *materializer) backfill(
+	ctx context.Context,
+	responses <-chan framer.StreamerResponse,
+) ([]framer.Frame, bool) {
+	for {
+		var (
+			frames     []framer.Frame
+			overflowed bool
+			closed     bool
+			stop       = make(chan struct{})
+			stopped    = make(chan struct{})
+		)
+		go func() {
+			defer close(stopped)
+			for {
+				select {
+				case <-stop:
+					return
+				case res, ok := <-responses:
+					if !ok {
+						closed = true
+						return
+					}
+					if overflowed {
+						continue
+					}
+					if len(frames) == maxBackfillFrames {
+						frames, overflowed = nil, true
+						continue
+					}
+					frames = append(frames, res.Frame)
+				}
+			}
+		}()
+		err := m.materialize(ctx, telem.TimeRange{Start: m.end, End: telem.TimeStampMax})
+		close(stop)
+		<-stopped
+		m.report(ctx, err)
+		if !overflowed || closed || err != nil {
+			return frames, closed
+		}
+	}
+}
+
+// backfilled returns true if the samples of every index in the frame were read while
+// materializing the history of the required channels.
+func (m *materializer) backfilled(fr framer.Frame) bool {
+	covered := false
+	for i, key := range fr.Keys {
+		s := fr.Series[i]
+		if s.Len() == 0 || !m.isIndex(key) {
+			continue
+		}
+		latest, seen := m.latest[key]
+		if !seen || telem.ValueAt[telem.TimeStamp](s, s.Len()-1) > latest {
+			return false
+		}
+		covered = true
+	}
+	return covered
+}
+
+// report logs and reports the given error as the state of the calculated channel.
+func (m *materializer) report(ctx context.Context, err error) {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	m.cfg.L.Error("materialization error",
+		zap.Error(err),
+		zap.String("channel_name", m.ch.Name),
+		zap.String("expression", m.ch.Expression))
+	if sErr := m.setState(ctx, m.ch.Key(), "error", err.Error()); sErr != nil {
+		m.cfg.L.Error("failed to set state of materialized channel", zap.Error(sErr))
+	}
+}
+
+// lastMaterialized returns the timestamp after the last materialized sample of the
+// channel, or zero if no samples have been materialized. Since iterators cannot move
+// backwards in chunks, the iterator moves backwards from the end of the channel's
+// index over doubling spans of time until a sample is found.
+func (m *materializer) lastMaterialized(ctx context.Context) (end telem.TimeStamp, err error) {
+	iter, err := m.cfg.Framer.OpenIterator(ctx, framer.IteratorConfig{
+		Keys:   channel.Keys{m.ch.Index()},
+		Bounds: telem.TimeRangeMax,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errors.Combine(err, iter.Close()) }()
+	if !iter.SeekLast() {
+		return 0, nil
+	}
+	for span := telem.Second; span < telem.TimeSpanMax/2; span *= 2 {
+		if !iter.Prev(span) {
+			continue
+		}
+		for _, s := range iter.Value().Series {
+			if s.Len() > 0 {
+				end = max(end, telem.ValueAt[telem.TimeStamp](s, s.Len()-1)+1)
+			}
+		}
+		return end, nil
+	}
+	return 0, nil
+}
+
+// materialize calculates the samples of the channel over the given time range from
+// the samples of the required channels in storage, and writes them to the channel.
+func (m *materializer) materialize(ctx context.Context, tr telem.TimeRange) (err error) {
+	iter, err := m.cfg.Framer.OpenIterator(ctx, framer.IteratorConfig{
+		Keys:   m.history.ReadKeys(),
+		Bounds: tr,
+	})
+	if err != nil {
+		return err
+	}
+	var w *framer.Writer
+	defer func() {
+		err = errors.Combine(err, iter.Close())
+		if w != nil {
+			err = errors.Combine(err, commitAndClose(w))
+		}
+	}()
+	m.history.Reset()
+	write := func(out framer.Frame) error {
+		first, last, ok := m.stamps(out)
+		if !ok {
+			return nil
+		}
+		if w == nil {
+			if w, err = m.openWriter(ctx, first); err != nil {
+				return err
+			}
+		}
+		if !w.Write(out) {
+			return w.Error()
+		}
+		m.end = max(m.end, last+1)
+		return nil
+	}
+	for ok := iter.SeekFirst(); ok && iter.Next(iterator.AutoSpan); {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fr := iter.Value()
+		m.observe(fr)
+		out, err := m.history.Evaluate(fr)
+		if err != nil {
+			return err
+		}
+		if err = write(out); err != nil {
+			return err
+		}
+	}
+	if err = iter.Error(); err != nil {
+		return err
+	}
+	out, err := m.history.Flush()
+	if err != nil {
+		return err
+	}
+	return write(out)
+}
+
+// process calculates the samples of the channel from a frame streamed from the
+// required channels and writes them to the channel. If any samples in the frame are
+// at or before samples that have already been received, the samples of the channel
+// over the time range of the frame are recalculated from storage instead.
+func (m *materializer) process(ctx context.Context, fr framer.Frame) error {
+	rewritten := m.rewritten(fr)
+	m.observe(fr)
+	if !rewritten.IsZero() {
+		return m.recalculate(ctx, rewritten)
+	}
+	out, err := m.live.Calculate(fr)
+	if err != nil {
+		return err
+	}
+	first, last, ok := m.stamps(out)
+	if !ok {
+		return nil
+	}
+	if m.writer == nil {
+		if m.writer, err = m.openWriter(ctx, first); err != nil {
+			return err
+		}
+	}
+	if !m.writer.Write(out) {
+		err := m.writer.Error()
+		m.report(ctx, m.closeWriter())
+		return err
+	}
+	m.end = max(m.end, last+1)
+	return nil
+}
+
+// rewritten returns the time range of the samples in the frame of the required
+// channels that are at or before samples that have already been received, or a zero
+// time range if the frame only holds new samples.
+func (m *materializer) rewritten(fr framer.Frame) (tr telem.TimeRange) {
+	firstIndex := m.indexes[m.ch.Requires[0]]
+	for i, key := range fr.Keys {
+		s := fr.Series[i]
+		if s.Len() == 0 || !m.isIndex(key) {
+			continue
+		}
+		start := telem.ValueAt[telem.TimeStamp](s, 0)
+		end := telem.ValueAt[telem.TimeStamp](s, s.Len()-1) + 1
+		latest, seen := m.latest[key]
+		if !(seen && start <= latest) && !(key == firstIndex && start < m.end) {
+			continue
+		}
+		if tr.IsZero() {
+			tr = start.Range(end)
+			continue
+		}
+		tr.Start = min(tr.Start, start)
+		tr.End = max(tr.End, end)
+	}
+	return tr
+}
+
+// recalculate deletes the samples of the channel over the given time range and
+// recalculates them from the samples of the required channels in storage.
+func (m *materializer) recalculate(ctx context.Context, tr telem.TimeRange) error {
+	if err := m.closeWriter(); err != nil {
+		return err
+	}
+	if tr.Start < m.end {
+		if err := m.cfg.Framer.NewDeleter().DeleteTimeRangeMany(
+			ctx,
+			channel.Keys{m.ch.Key(), m.ch.Index()},
+			tr.Start.Range(min(tr.End, m.end)),
+		); err != nil {
+			return err
+		}
+	}
+	return m.materialize(ctx, tr)
+}
+
+// observe records the timestamp of the latest sample of each index in the frame.
+func (m *materializer) observe(fr framer.Frame) {
+	for i, key := range fr.Keys {
+		s := fr.Series[i]
+		if s.Len() == 0 || !m.isIndex(key) {
+			continue
+		}
+		m.latest[key] = max(m.latest[key], telem.ValueAt[telem.TimeStamp](s, s.Len()-1))
+	}
+}
+
+// isIndex returns true if the channel with the given key is the index of one of the
+// required channels.
+func (m *materializer) isIndex(key channel.Key) bool {
+	for _, idx := range m.indexes {
+		if idx == key {
+			return true
+		}
+	}
+	return false
+}
+
+// stamps returns the first and last timestamps of the calculated samples in the
+// frame, or false if the frame holds no samples.
+func (m *materializer) stamps(fr framer.Frame) (first, last telem.TimeStamp, ok bool) {
+	for i, key := range fr.Keys {
+		s := fr.Series[i]
+		if key != m.ch.Index() || s.Len() == 0 {
+			continue
+		}
+		return telem.ValueAt[telem.TimeStamp](s, 0), telem.ValueAt[telem.TimeStamp](s, s.Len()-1), true
+	}
+	return 0, 0, false
+}
+
+func (m *materializer) openWriter(ctx context.Context, start telem.TimeStamp) (*framer.Writer, error) {
+	autoCommit := true
+	return m.cfg.Framer.OpenWriter(ctx, framer.WriterConfig{
+		Keys:             channel.Keys{m.ch.Index(), m.ch.Key()},
+		Start:            start,
+		EnableAutoCommit: &autoCommit,
+	})
+}
+
+// closeWriter commits and closes the writer of streamed samples, if it is open.
+func (m *materializer) closeWriter() error {
+	if m.writer == nil {
+		return nil
+	}
+	w := m.writer
+	m.writer = nil
+	return commitAndClose(w)
+}
+
+func commitAndClose(w *framer.Writer) error {
+	w.Commit()
+	return errors.Combine(w.Error(), w.Close())
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package downsampler
+
+import (
+	"math"
+
+	"github.com/synnaxlabs/x/telem"
+)
+
+// meanSeries returns a series holding the mean of each bucket of factor samples in
+// series. Timestamps are averaged without losing precision. Samples that cannot be
+// averaged, such as strings, are replaced by the first sample in each bucket.
+func meanSeries(series telem.Series, factor int64) telem.Series {
+	out := telem.Series{
+		TimeRange: series.TimeRange,
+		DataType:  series.DataType,
+		Alignment: series.Alignment,
+	}
+	samples := series.Split()
+	toFloat, fromFloat := telem.UnmarshalFloat64F(series.DataType), fromFloat(series.DataType)
+	for start := int64(0); start < int64(len(samples)); start += factor {
+		bucket := samples[start:min(start+factor, int64(len(samples)))]
+		switch {
+		case series.DataType == telem.TimeStampT:
+			first := telem.UnmarshalF[telem.TimeStamp](telem.TimeStampT)(bucket[0])
+			var offsets telem.TimeStamp
+			for _, sample := range bucket {
+				offsets += telem.UnmarshalF[telem.TimeStamp](telem.TimeStampT)(sample) - first
+			}
+			mean := first + offsets/telem.TimeStamp(len(bucket))
+			out.Data = telem.ByteOrder.AppendUint64(out.Data, uint64(mean))
+		case toFloat != nil:
+			var sum float64
+			for _, sample := range bucket {
+				sum += toFloat(sample)
+			}
+			out.Data = fromFloat(out.Data, sum/float64(len(bucket)))
+		default:
+			out.Data = appendSample(out.Data, series.DataType, bucket[0])
+		}
+	}
+	return out
+}
+
+// minMaxSeries returns a series holding the smallest and largest samples of each
+// bucket of factor samples in series, in the order they occurred. For index channels
+// and samples that cannot be compared, the first and last samples of each bucket are
+// kept instead, so that every series in a row has the same number of samples.
+func minMaxSeries(series telem.Series, factor int64, isIndex bool) telem.Series {
+	out := telem.Series{
+		TimeRange: series.TimeRange,
+		DataType:  series.DataType,
+		Alignment: series.Alignment,
+	}
+	samples := series.Split()
+	toFloat := telem.UnmarshalFloat64F(series.DataType)
+	for start := int64(0); start < int64(len(samples)); start += factor {
+		bucket := samples[start:min(start+factor, int64(len(samples)))]
+		if len(bucket) == 1 {
+			out.Data = appendSample(out.Data, series.DataType, bucket[0])
+			continue
+		}
+		first, second := 0, len(bucket)-1
+		if !isIndex && toFloat != nil {
+			lo, hi := 0, 0
+			for i, sample := range bucket {
+				v := toFloat(sample)
+				if v < toFloat(bucket[lo]) {
+					lo = i
+				}
+				if v > toFloat(bucket[hi]) {
+					hi = i
+				}
+			}
+			first, second = min(lo, hi), max(lo, hi)
+		}
+		out.Data = appendSample(out.Data, series.DataType, bucket[first])
+		out.Data = appendSample(out.Data, series.DataType, bucket[second])
+	}
+	return out
+}
+
+// pick returns a series holding the samples of series at the given positions.
+func pick(series telem.Series, positions []int64) telem.Series {
+	out := telem.Series{
+		TimeRange: series.TimeRange,
+		DataType:  series.DataType,
+		Alignment: series.Alignment,
+	}
+	samples := series.Split()
+	for _, i := range positions {
+		out.Data = appendSample(out.Data, series.DataType, samples[i])
+	}
+	return out
+}
+
+// lttb returns the positions of the threshold points chosen by the
+// Largest-Triangle-Three-Buckets algorithm from the points (x[i], y[i]). The first and
+// last points are always chosen.
+func lttb(x, y []float64, threshold int) []int64 {
+	n := len(y)
+	if threshold = max(threshold, 3); threshold >= n {
+		chosen := make([]int64, n)
+		for i := range chosen {
+			chosen[i] = int64(i)
+		}
+		return chosen
+	}
+	var (
+		chosen = make([]int64, 0, threshold)
+		// Every point except the first and last is split into threshold-2 buckets.
+		bucketSize = float64(n-2) / float64(threshold-2)
+		a          = 0
+	)
+	chosen = append(chosen, 0)
+	for i := 0; i < threshold-2; i++ {
+		// The third point of the triangle is the average of the next bucket.
+		nextStart := int(float64(i+1)*bucketSize) + 1
+		nextEnd := min(int(float64(i+2)*bucketSize)+1, n)
+		var avgX, avgY float64
+		for j := nextStart; j < nextEnd; j++ {
+			avgX += x[j]
+			avgY += y[j]
+		}
+		count := float64(nextEnd - nextStart)
+		avgX, avgY = avgX/count, avgY/count
+		start := int(float64(i)*bucketSize) + 1
+		end := int(float64(i+1)*bucketSize) + 1
+		maxArea, next := -1.0, start
+		for j := start; j < end; j++ {
+			area := math.Abs((x[a]-avgX)*(y[j]-y[a]) - (x[a]-x[j])*(avgY-y[a]))
+			if area > maxArea {
+				maxArea, next = area, j
+			}
+		}
+		chosen = append(chosen, int64(next))
+		a = next
+	}
+	return append(chosen, int64(n-1))
+}
+
+// appendSample appends an encoded sample to data, terminating samples of variable
+// density data types.
+func appendSample(data []byte, dt telem.DataType, sample []byte) []byte {
+	data = append(data, sample...)
+	if dt.IsVariable() {
+		data = append(data, '\n')
+	}
+	return data
+}
+
+// fromFloat returns a function that appends a float64 to data as a sample of the given
+// numeric data type, rounding it to the nearest integer for integer data types.
+func fromFloat(dt telem.DataType) func(data []byte, v float64) []byte {
+	marshal := func(data []byte, put func(b []byte)) []byte {
+		n := len(data)
+		data = append(data, make([]byte, dt.Density())...)
+		put(data[n:])
+		return data
+	}
+	switch dt {
+	case telem.Float64T, telem.Float32T:
+		return func(data []byte, v float64) []byte {
+			return marshal(data, func(b []byte) { telem.MarshalF[float64](dt)(b, v) })
+		}
+	case telem.Int64T, telem.Int32T, telem.Int16T, telem.Int8T:
+		return func(data []byte, v float64) []byte {
+			return marshal(data, func(b []byte) { telem.MarshalF[int64](dt)(b, int64(math.Round(v))) })
+		}
+	case telem.Uint64T, telem.Uint32T, telem.Uint16T, telem.Uint8T:
+		return func(data []byte, v float64) []byte {
+			return marshal(data, func(b []byte) { telem.MarshalF[uint64](dt)(b, uint64(math.Round(v))) })
+		}
+	}
+	return nil
+}
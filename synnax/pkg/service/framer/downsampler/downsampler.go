@@ -12,11 +12,17 @@ package downsampler
 import (
 	"bytes"
 	"context"
+	"maps"
+	"sync"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
 	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
 	"github.com/synnaxlabs/x/address"
 	"github.com/synnaxlabs/x/confluence"
 	"github.com/synnaxlabs/x/confluence/plumber"
+	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
 )
 
 const defaultBuffer = 25
@@ -25,7 +31,30 @@ func NewStreamer(
 	ctx context.Context,
 	cfg framer.StreamerConfig,
 	service *framer.Service,
+	channels channel.Readable,
 ) (framer.Streamer, error) {
+	if cfg.DownsampleMode > framer.DownsampleLTTB {
+		return nil, errors.Wrapf(validate.Error, "unknown downsample mode %d", cfg.DownsampleMode)
+	}
+	if cfg.DownsampleFactor > 1 && cfg.DownsampleRate > 0 {
+		return nil, errors.Wrapf(
+			validate.Error,
+			"downsample factor and downsample rate cannot both be set",
+		)
+	}
+	d := &downsampler{
+		factor:   cfg.DownsampleFactor,
+		mode:     cfg.DownsampleMode,
+		rate:     cfg.DownsampleRate,
+		readable: channels,
+	}
+	d.mu.channels = make(map[channel.Key]channel.Channel)
+	if d.rate > 0 {
+		var err error
+		if cfg.Keys, err = d.withIndexes(ctx, cfg.Keys); err != nil {
+			return nil, err
+		}
+	}
 	s, err := service.NewStreamer(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -39,8 +68,11 @@ func NewStreamer(
 			ok bool,
 			err error,
 		) {
-			i = downsample(ctx, i, cfg.DownsampleFactor)
-			return i, true, nil
+			if d.rate > 0 {
+				return d.downsampleRate(ctx, i)
+			}
+			i, err = d.downsample(ctx, i)
+			return i, err == nil, err
 		},
 	}
 
@@ -67,22 +99,206 @@ func NewStreamer(
 		RouteInletsTo:    []address.Address{"dist-streamer"},
 		RouteOutletsFrom: []address.Address{"downsampler"},
 	}
+	if d.rate > 0 {
+		// Streaming at a target rate requires the timestamps of every streamed
+		// channel, so the indexes of requested channels are streamed along with
+		// them.
+		indexer := &confluence.LinearTransform[
+			framer.StreamerRequest,
+			framer.StreamerRequest,
+		]{
+			Transform: func(ctx context.Context, req framer.StreamerRequest) (
+				framer.StreamerRequest,
+				bool,
+				error,
+			) {
+				var err error
+				req.Keys, err = d.withIndexes(ctx, req.Keys)
+				return req, err == nil, err
+			},
+		}
+		plumber.SetSegment[framer.StreamerRequest, framer.StreamerRequest](
+			pipe,
+			"indexer",
+			indexer,
+		)
+		plumber.MustConnect[framer.StreamerRequest](
+			pipe,
+			"indexer",
+			"dist-streamer",
+			defaultBuffer,
+		)
+		seg.RouteInletsTo = []address.Address{"indexer"}
+	}
 
 	return seg, nil
 }
 
-func downsample(
+type downsampler struct {
+	factor   int
+	mode     framer.DownsampleMode
+	rate     telem.Rate
+	readable channel.Readable
+	mu       struct {
+		sync.Mutex
+		// channels holds every channel that has been streamed.
+		channels map[channel.Key]channel.Channel
+		// requested is the set of channels requested by the caller, which does not
+		// include the indexes streamed when downsampling to a target rate.
+		requested map[channel.Key]bool
+	}
+	// next is the timestamp of the next sample to keep for the channels indexed by
+	// each index when downsampling to a target rate.
+	next map[channel.Key]telem.TimeStamp
+	// offsets is the position of the next sample to keep in the next series of each
+	// rate-based channel when downsampling to a target rate.
+	offsets map[channel.Key]int64
+}
+
+func (d *downsampler) downsample(
 	ctx context.Context,
 	response framer.StreamerResponse,
-	factor int,
-) framer.StreamerResponse {
-	if factor <= 1 {
-		return response
+) (framer.StreamerResponse, error) {
+	if d.factor <= 1 {
+		return response, nil
 	}
+	if d.mode == framer.DownsampleDecimate {
+		for i, series := range response.Frame.Series {
+			response.Frame.Series[i] = downsampleSeries(series, d.factor)
+		}
+		return response, nil
+	}
+	channels, err := d.retrieve(ctx, response.Frame.Keys)
+	if err != nil {
+		return response, err
+	}
+	// The series of channels with the same index, alignment, and length hold the
+	// samples of a single write, so they are downsampled together to keep index
+	// channels consistent with the samples chosen for the channels they index.
+	rows := make(map[row][]int)
 	for i, series := range response.Frame.Series {
-		response.Frame.Series[i] = downsampleSeries(series, factor)
+		r := newRow(channels[response.Frame.Keys[i]], series)
+		rows[r] = append(rows[r], i)
+	}
+	fr := response.Frame
+	for r, positions := range rows {
+		if r.len <= 1 {
+			continue
+		}
+		switch d.mode {
+		case framer.DownsampleMean:
+			for _, i := range positions {
+				fr.Series[i] = meanSeries(fr.Series[i], int64(d.factor))
+			}
+		case framer.DownsampleMinMax:
+			for _, i := range positions {
+				fr.Series[i] = minMaxSeries(fr.Series[i], int64(d.factor), channels[fr.Keys[i]].IsIndex)
+			}
+		case framer.DownsampleLTTB:
+			chosen := lttbPositions(fr, channels, positions, int64(d.factor))
+			for _, i := range positions {
+				fr.Series[i] = pick(fr.Series[i], chosen)
+			}
+		}
+	}
+	return response, nil
+}
+
+// retrieve returns the channels with the given keys, retrieving the channels that
+// have not been streamed before.
+func (d *downsampler) retrieve(
+	ctx context.Context,
+	keys channel.Keys,
+) (map[channel.Key]channel.Channel, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var unknown channel.Keys
+	for _, key := range keys {
+		if _, ok := d.mu.channels[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		var channels []channel.Channel
+		if err := d.readable.NewRetrieve().
+			WhereKeys(unknown...).
+			Entries(&channels).
+			Exec(ctx, nil); err != nil {
+			return nil, err
+		}
+		for _, ch := range channels {
+			d.mu.channels[ch.Key()] = ch
+		}
+	}
+	return maps.Clone(d.mu.channels), nil
+}
+
+// lttbPositions returns the positions of the samples chosen by the
+// Largest-Triangle-Three-Buckets algorithm for the row of series at the given
+// positions in fr. The samples are chosen using the first numeric channel in the row
+// that is not an index, plotted against the index in the row if there is one. If the
+// row has no such channel, every factor-th sample is chosen.
+func lttbPositions(
+	fr framer.Frame,
+	channels map[channel.Key]channel.Channel,
+	positions []int,
+	factor int64,
+) []int64 {
+	var (
+		x, y []float64
+		n    = fr.Series[positions[0]].Len()
+	)
+	for _, i := range positions {
+		series := fr.Series[i]
+		if channels[fr.Keys[i]].IsIndex {
+			if x == nil {
+				start := telem.ValueAt[telem.TimeStamp](series, 0)
+				x = make([]float64, n)
+				for j := range x {
+					x[j] = float64(telem.ValueAt[telem.TimeStamp](series, int64(j)) - start)
+				}
+			}
+			continue
+		}
+		if toFloat := telem.UnmarshalFloat64F(series.DataType); toFloat != nil && y == nil {
+			y = make([]float64, n)
+			for j, sample := range series.Split() {
+				y[j] = toFloat(sample)
+			}
+		}
+	}
+	if y == nil {
+		chosen := make([]int64, 0, n/factor+1)
+		for j := int64(0); j < n; j += factor {
+			chosen = append(chosen, j)
+		}
+		return chosen
+	}
+	if x == nil {
+		x = make([]float64, n)
+		for j := range x {
+			x[j] = float64(j)
+		}
+	}
+	return lttb(x, y, int((n+factor-1)/factor))
+}
+
+// row identifies the series in a frame that hold the samples of a single write to the
+// channels indexed by the same index.
+type row struct {
+	index     channel.Key
+	alignment telem.AlignmentPair
+	len       int64
+}
+
+// newRow returns the row of the series s of the channel ch. A channel without an index
+// is in a row of its own.
+func newRow(ch channel.Channel, s telem.Series) row {
+	index := ch.Index()
+	if index == 0 {
+		index = ch.Key()
 	}
-	return response
+	return row{index: index, alignment: s.Alignment, len: s.Len()}
 }
 
 func downsampleSeries(series telem.Series, factor int) telem.Series {
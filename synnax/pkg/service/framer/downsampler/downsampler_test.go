@@ -0,0 +1,274 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package downsampler_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/mock"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/downsampler"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/control"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Downsampler", Ordered, func() {
+	var (
+		builder *mock.Builder
+		dist    distribution.Distribution
+		idx     channel.Channel
+		data    channel.Channel
+		keys    channel.Keys
+		start   telem.TimeStamp
+	)
+	BeforeAll(func() {
+		builder = mock.NewBuilder()
+		dist = builder.New(ctx)
+		idx = channel.Channel{Name: "time", DataType: telem.TimeStampT, IsIndex: true}
+		Expect(dist.Channel.Create(ctx, &idx)).To(Succeed())
+		data = channel.Channel{Name: "pressure", DataType: telem.Int64T, LocalIndex: idx.LocalKey}
+		Expect(dist.Channel.Create(ctx, &data)).To(Succeed())
+		keys = channel.Keys{idx.Key(), data.Key()}
+	})
+	AfterAll(func() {
+		Expect(builder.Close()).To(Succeed())
+		Expect(builder.Cleanup()).To(Succeed())
+	})
+
+	// stream writes the given samples to the data channel, one second apart, while
+	// streaming it with the given downsampling mode and a factor of 3, returning the
+	// timestamps, as seconds since the start of the write, and samples received.
+	stream := func(mode framer.DownsampleMode, values telem.Series) ([]float64, []int64) {
+		cfg := framer.StreamerConfig{
+			Keys:             keys,
+			DownsampleFactor: 3,
+			DownsampleMode:   mode,
+			SendOpenAck:      true,
+		}
+		s := MustSucceed(downsampler.NewStreamer(ctx, cfg, dist.Framer, dist.Channel))
+		sCtx, cancel := signal.WithCancel(ctx)
+		defer cancel()
+		req, res := confluence.Attach(s, 10)
+		s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+		Eventually(res.Outlet()).Should(Receive())
+		start += 100 * telem.SecondTS
+		w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+			Keys:           keys,
+			Start:          start,
+			ControlSubject: control.Subject{Name: "downsampler"},
+		}))
+		timestamps := make([]telem.TimeStamp, values.Len())
+		for i := range timestamps {
+			timestamps[i] = start + telem.TimeStamp(i)*telem.SecondTS
+		}
+		Expect(w.Write(framer.Frame{
+			Keys:   keys,
+			Series: []telem.Series{telem.NewSeries(timestamps), values},
+		})).To(BeTrue())
+		Expect(w.Close()).To(Succeed())
+		var r framer.StreamerResponse
+		Eventually(res.Outlet()).Should(Receive(&r))
+		req.Close()
+		confluence.Drain(res)
+		Expect(sCtx.Wait()).To(Succeed())
+		var seconds []float64
+		for _, ts := range telem.Unmarshal[telem.TimeStamp](r.Frame.Get(idx.Key())[0]) {
+			seconds = append(seconds, float64(ts-start)/float64(telem.SecondTS))
+		}
+		return seconds, telem.Unmarshal[int64](r.Frame.Get(data.Key())[0])
+	}
+
+	It("Should keep every nth sample", func() {
+		seconds, samples := stream(framer.DownsampleDecimate, telem.NewSeriesV[int64](1, 9, 2, 3, 4, -8, 5))
+		Expect(seconds).To(Equal([]float64{0, 3, 6}))
+		Expect(samples).To(Equal([]int64{1, 3, 5}))
+	})
+
+	It("Should keep the smallest and largest samples of each bucket", func() {
+		seconds, samples := stream(framer.DownsampleMinMax, telem.NewSeriesV[int64](1, 9, 2, 3, 4, -8, 5))
+		Expect(seconds).To(Equal([]float64{0, 2, 3, 5, 6}))
+		Expect(samples).To(Equal([]int64{1, 9, 4, -8, 5}))
+	})
+
+	It("Should average each bucket", func() {
+		seconds, samples := stream(framer.DownsampleMean, telem.NewSeriesV[int64](1, 9, 2, 3, 4, -8, 5))
+		Expect(seconds).To(Equal([]float64{1, 4, 6}))
+		Expect(samples).To(Equal([]int64{4, 0, 5}))
+	})
+
+	It("Should keep the samples chosen by LTTB with their timestamps", func() {
+		seconds, samples := stream(framer.DownsampleLTTB, telem.NewSeriesV[int64](0, 0, 0, 10, 0, 0, 0, 0, 0))
+		Expect(samples).To(Equal([]int64{0, 10, 0}))
+		Expect(seconds).To(Equal([]float64{0, 3, 8}))
+	})
+
+	It("Should choose the samples of channels with different indexes separately", func() {
+		var (
+			channels []channel.Channel
+			spikes   = []int{3, 6}
+		)
+		for range spikes {
+			index := channel.Channel{Name: "time", DataType: telem.TimeStampT, IsIndex: true}
+			Expect(dist.Channel.Create(ctx, &index)).To(Succeed())
+			ch := channel.Channel{Name: "pressure", DataType: telem.Int64T, LocalIndex: index.LocalKey}
+			Expect(dist.Channel.Create(ctx, &ch)).To(Succeed())
+			channels = append(channels, index, ch)
+		}
+		streamed := channel.KeysFromChannels(channels)
+		s := MustSucceed(downsampler.NewStreamer(ctx, framer.StreamerConfig{
+			Keys:             streamed,
+			DownsampleFactor: 3,
+			DownsampleMode:   framer.DownsampleLTTB,
+			SendOpenAck:      true,
+		}, dist.Framer, dist.Channel))
+		sCtx, cancel := signal.WithCancel(ctx)
+		defer cancel()
+		req, res := confluence.Attach(s, 10)
+		s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+		Eventually(res.Outlet()).Should(Receive())
+		start += 100 * telem.SecondTS
+		w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+			Keys:           streamed,
+			Start:          start,
+			ControlSubject: control.Subject{Name: "downsampler"},
+		}))
+		fr := framer.Frame{Keys: streamed}
+		for _, spike := range spikes {
+			timestamps := make([]telem.TimeStamp, 9)
+			values := make([]int64, 9)
+			for i := range timestamps {
+				timestamps[i] = start + telem.TimeStamp(i)*telem.SecondTS
+			}
+			values[spike] = 10
+			fr.Series = append(fr.Series, telem.NewSeries(timestamps), telem.NewSeries(values))
+		}
+		Expect(w.Write(fr)).To(BeTrue())
+		Expect(w.Close()).To(Succeed())
+		var r framer.StreamerResponse
+		Eventually(res.Outlet()).Should(Receive(&r))
+		req.Close()
+		confluence.Drain(res)
+		Expect(sCtx.Wait()).To(Succeed())
+		Expect(telem.Unmarshal[int64](r.Frame.Get(channels[1].Key())[0])).To(Equal([]int64{0, 10, 0}))
+		Expect(telem.Unmarshal[int64](r.Frame.Get(channels[3].Key())[0])).To(Equal([]int64{0, 10, 0}))
+	})
+
+	It("Should not open a streamer with an unknown mode", func() {
+		_, err := downsampler.NewStreamer(ctx, framer.StreamerConfig{
+			Keys:             keys,
+			DownsampleFactor: 3,
+			DownsampleMode:   100,
+		}, dist.Framer, dist.Channel)
+		Expect(err).To(MatchError(ContainSubstring("unknown downsample mode")))
+	})
+
+	It("Should not open a streamer with both a downsample factor and rate", func() {
+		_, err := downsampler.NewStreamer(ctx, framer.StreamerConfig{
+			Keys:             keys,
+			DownsampleFactor: 3,
+			DownsampleRate:   telem.Hz,
+		}, dist.Framer, dist.Channel)
+		Expect(err).To(HaveOccurredAs(validate.Error))
+	})
+
+	Describe("Target Rate", func() {
+		// streamRate streams the given channels at the given target rate while writing
+		// the given frames, returning the frame received for each write.
+		streamRate := func(
+			rate telem.Rate,
+			streamed channel.Keys,
+			written channel.Keys,
+			frames ...framer.Frame,
+		) []framer.Frame {
+			s := MustSucceed(downsampler.NewStreamer(ctx, framer.StreamerConfig{
+				Keys:           streamed,
+				DownsampleRate: rate,
+				SendOpenAck:    true,
+			}, dist.Framer, dist.Channel))
+			sCtx, cancel := signal.WithCancel(ctx)
+			defer cancel()
+			req, res := confluence.Attach(s, 10)
+			s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+			Eventually(res.Outlet()).Should(Receive())
+			w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+				Keys:           written,
+				Start:          start,
+				ControlSubject: control.Subject{Name: "downsampler"},
+			}))
+			received := make([]framer.Frame, len(frames))
+			for i, fr := range frames {
+				Expect(w.Write(fr)).To(BeTrue())
+				var r framer.StreamerResponse
+				Eventually(res.Outlet()).Should(Receive(&r))
+				received[i] = r.Frame
+			}
+			Expect(w.Close()).To(Succeed())
+			req.Close()
+			confluence.Drain(res)
+			Expect(sCtx.Wait()).To(Succeed())
+			return received
+		}
+
+		It("Should keep the samples of a channel aligned with its index", func() {
+			start += 100 * telem.SecondTS
+			timestamps := func(from, to int) telem.Series {
+				ts := make([]telem.TimeStamp, 0, to-from)
+				for i := from; i < to; i++ {
+					ts = append(ts, start+telem.TimeStamp(i)*telem.SecondTS)
+				}
+				return telem.NewSeries(ts)
+			}
+			frames := streamRate(
+				telem.Hz/2,
+				channel.Keys{data.Key()},
+				keys,
+				framer.Frame{
+					Keys:   keys,
+					Series: []telem.Series{timestamps(0, 3), telem.NewSeriesV[int64](0, 1, 2)},
+				},
+				framer.Frame{
+					Keys:   keys,
+					Series: []telem.Series{timestamps(3, 7), telem.NewSeriesV[int64](3, 4, 5, 6)},
+				},
+			)
+			Expect(frames[0].Keys).To(Equal(channel.Keys{data.Key()}))
+			Expect(telem.Unmarshal[int64](frames[0].Series[0])).To(Equal([]int64{0, 2}))
+			Expect(frames[1].Keys).To(Equal(channel.Keys{data.Key()}))
+			Expect(telem.Unmarshal[int64](frames[1].Series[0])).To(Equal([]int64{4, 6}))
+		})
+
+		It("Should decimate channels without an index using their rate", func() {
+			start += 100 * telem.SecondTS
+			rated := channel.Channel{Name: "rated", DataType: telem.Int64T, Rate: 4 * telem.Hz}
+			Expect(dist.Channel.Create(ctx, &rated)).To(Succeed())
+			frames := streamRate(
+				telem.Hz,
+				channel.Keys{rated.Key()},
+				channel.Keys{rated.Key()},
+				framer.Frame{
+					Keys:   channel.Keys{rated.Key()},
+					Series: []telem.Series{telem.NewSeriesV[int64](0, 1, 2, 3, 4, 5)},
+				},
+				framer.Frame{
+					Keys:   channel.Keys{rated.Key()},
+					Series: []telem.Series{telem.NewSeriesV[int64](6, 7, 8, 9, 10, 11)},
+				},
+			)
+			Expect(telem.Unmarshal[int64](frames[0].Series[0])).To(Equal([]int64{0, 4}))
+			Expect(telem.Unmarshal[int64](frames[1].Series[0])).To(Equal([]int64{8}))
+		})
+	})
+})
@@ -0,0 +1,158 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package downsampler
+
+import (
+	"context"
+	"math"
+
+	"github.com/samber/lo"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/x/telem"
+)
+
+// withIndexes records keys as the channels requested by the caller, and returns them
+// along with the keys of their indexes.
+func (d *downsampler) withIndexes(
+	ctx context.Context,
+	keys channel.Keys,
+) (channel.Keys, error) {
+	channels, err := d.retrieve(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	requested := make(map[channel.Key]bool, len(keys))
+	withIndexes := make(channel.Keys, 0, len(keys))
+	for _, key := range keys {
+		requested[key] = true
+		withIndexes = append(withIndexes, key)
+	}
+	for _, key := range keys {
+		if idx := channels[key].Index(); idx != 0 && !lo.Contains(withIndexes, idx) {
+			withIndexes = append(withIndexes, idx)
+		}
+	}
+	d.mu.Lock()
+	d.mu.requested = requested
+	d.mu.Unlock()
+	return withIndexes, nil
+}
+
+// downsampleRate downsamples the response so that each channel has at most d.rate
+// samples per second. Samples are kept when at least one period of the target rate has
+// passed since the previous kept sample of the same index, and the samples at the same
+// positions are kept for the channels written with that index. Channels without an
+// index are decimated using their rate. Series of channels that have neither an index
+// nor a rate, and series written without their index, are sent unchanged.
+func (d *downsampler) downsampleRate(
+	ctx context.Context,
+	response framer.StreamerResponse,
+) (framer.StreamerResponse, bool, error) {
+	if len(response.Frame.Keys) == 0 {
+		return response, true, nil
+	}
+	channels, err := d.retrieve(ctx, response.Frame.Keys)
+	if err != nil {
+		return response, false, err
+	}
+	d.mu.Lock()
+	requested := d.mu.requested
+	d.mu.Unlock()
+	if d.next == nil {
+		d.next = make(map[channel.Key]telem.TimeStamp)
+		d.offsets = make(map[channel.Key]int64)
+	}
+	var (
+		period = d.rate.Period()
+		in     = response.Frame
+		kept   = make(map[row][]int64)
+	)
+	for i, series := range in.Series {
+		key := in.Keys[i]
+		if !channels[key].IsIndex {
+			continue
+		}
+		r := row{index: key, alignment: series.Alignment, len: series.Len()}
+		if _, ok := kept[r]; ok {
+			continue
+		}
+		positions := make([]int64, 0)
+		for j := int64(0); j < series.Len(); j++ {
+			if ts := telem.ValueAt[telem.TimeStamp](series, j); ts >= d.next[key] {
+				positions = append(positions, j)
+				d.next[key] = ts.Add(period)
+			}
+		}
+		kept[r] = positions
+	}
+	var out framer.Frame
+	for i, series := range in.Series {
+		key := in.Keys[i]
+		if !requested[key] {
+			continue
+		}
+		ch := channels[key]
+		idx := ch.Index()
+		if idx != 0 {
+			r := row{index: idx, alignment: series.Alignment, len: series.Len()}
+			if positions, ok := kept[r]; ok {
+				series = pick(series, positions)
+			}
+		} else if ch.Rate > 0 {
+			series = d.decimateRate(key, ch.Rate, series)
+		}
+		out.Keys = append(out.Keys, key)
+		out.Series = append(out.Series, series)
+	}
+	if len(out.Keys) == 0 && response.Error == nil {
+		return response, false, nil
+	}
+	response.Frame = out
+	return response, true, nil
+}
+
+// decimateRate keeps every k-th sample of the series of a channel written at the given
+// rate, where k is the smallest step that brings the rate down to the target rate.
+// The position of the next sample to keep is carried over to the next series of the
+// channel, and the time range of the series is narrowed to the kept samples.
+func (d *downsampler) decimateRate(
+	key channel.Key,
+	rate telem.Rate,
+	series telem.Series,
+) telem.Series {
+	step := int64(math.Ceil(float64(rate / d.rate)))
+	if step <= 1 {
+		return series
+	}
+	var (
+		n         = series.Len()
+		offset    = d.offsets[key]
+		positions = make([]int64, 0, max(n-offset, 0)/step+1)
+	)
+	for j := offset; j < n; j += step {
+		positions = append(positions, j)
+	}
+	if len(positions) == 0 {
+		d.offsets[key] = offset - n
+	} else {
+		d.offsets[key] = positions[len(positions)-1] + step - n
+	}
+	out := pick(series, positions)
+	if !series.TimeRange.IsZero() && len(positions) > 0 {
+		p := rate.Period()
+		start := series.TimeRange.Start
+		out.TimeRange = telem.TimeRange{
+			Start: start.Add(p * telem.TimeSpan(positions[0])),
+			End:   start.Add(p*telem.TimeSpan(positions[len(positions)-1]) + 1),
+		}
+	}
+	return out
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package filter implements server-side filtering of streamed frames, so that only the
+// samples matching the framer.Filter predicates of a streamer are sent to its client.
+package filter
+
+import (
+	"bytes"
+	"context"
+	"math"
+
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/x/address"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/confluence/plumber"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+const defaultBuffer = 25
+
+// NewStreamer wraps the given streamer so that it only sends the samples matching the
+// filters in cfg. Samples are filtered by row: the series in a frame that share an
+// index and have the same alignment and length hold the samples of a single write, and
+// a sample is kept in all of them if it matches a filter in any of them. This keeps the
+// timestamps of an index channel aligned with the samples of the channels it indexes.
+func NewStreamer(
+	ctx context.Context,
+	cfg framer.StreamerConfig,
+	streamer framer.Streamer,
+	channels channel.Readable,
+) (framer.Streamer, error) {
+	f, err := newFrameFilter(ctx, cfg, channels)
+	if err != nil {
+		return nil, err
+	}
+	filter := &confluence.LinearTransform[framer.StreamerResponse, framer.StreamerResponse]{
+		Transform: func(
+			_ context.Context,
+			res framer.StreamerResponse,
+		) (framer.StreamerResponse, bool, error) {
+			if res.Error != nil || len(res.Frame.Keys) == 0 {
+				return res, true, nil
+			}
+			res.Frame = f.filter(res.Frame)
+			return res, len(res.Frame.Keys) > 0, nil
+		},
+	}
+	pipe := plumber.New()
+	plumber.SetSegment[framer.StreamerRequest, framer.StreamerResponse](
+		pipe,
+		"streamer",
+		streamer,
+	)
+	plumber.SetSegment[framer.StreamerResponse, framer.StreamerResponse](
+		pipe,
+		"filter",
+		filter,
+	)
+	plumber.MustConnect[framer.StreamerResponse](pipe, "streamer", "filter", defaultBuffer)
+	return &plumber.Segment[framer.StreamerRequest, framer.StreamerResponse]{
+		Pipeline:         pipe,
+		RouteInletsTo:    []address.Address{"streamer"},
+		RouteOutletsFrom: []address.Address{"filter"},
+	}, nil
+}
+
+// predicate evaluates a filter on the samples of a channel, keeping track of the
+// samples it has already evaluated.
+type predicate struct {
+	framer.Filter
+	toFloat func(b []byte) float64
+	// last is the last matching sample for FilterDeadband predicates, and the previous
+	// sample for FilterOnChange predicates.
+	last      float64
+	lastBytes []byte
+	started   bool
+}
+
+// frameFilter filters the frames of a streamer by the predicates of its channels.
+type frameFilter struct {
+	predicates map[channel.Key][]*predicate
+	// indexes maps the key of each streamed channel to the key of its index, or to its
+	// own key if it has no index.
+	indexes map[channel.Key]channel.Key
+}
+
+func newFrameFilter(
+	ctx context.Context,
+	cfg framer.StreamerConfig,
+	channels channel.Readable,
+) (*frameFilter, error) {
+	f := &frameFilter{}
+	if len(cfg.Filters) == 0 {
+		return f, nil
+	}
+	keys := make(channel.Keys, 0, len(cfg.Keys)+len(cfg.Filters))
+	keys = append(keys, cfg.Keys...)
+	for _, flt := range cfg.Filters {
+		keys = append(keys, flt.Channel)
+	}
+	var retrieved []channel.Channel
+	if err := channels.NewRetrieve().
+		WhereKeys(keys.Unique()...).
+		Entries(&retrieved).
+		Exec(ctx, nil); err != nil {
+		return nil, err
+	}
+	dataTypes := make(map[channel.Key]telem.DataType, len(retrieved))
+	f.indexes = make(map[channel.Key]channel.Key, len(retrieved))
+	for _, ch := range retrieved {
+		dataTypes[ch.Key()] = ch.DataType
+		if f.indexes[ch.Key()] = ch.Index(); ch.Index() == 0 {
+			f.indexes[ch.Key()] = ch.Key()
+		}
+	}
+	v := validate.New("streamer.filters")
+	f.predicates = make(map[channel.Key][]*predicate, len(cfg.Filters))
+	for _, flt := range cfg.Filters {
+		dt := dataTypes[flt.Channel]
+		numeric := flt.Variant == framer.FilterLimits || flt.Variant == framer.FilterDeadband
+		v.Ternaryf("channel", !cfg.Keys.Contains(flt.Channel),
+			"cannot filter channel %s because it is not streamed", flt.Channel)
+		v.Ternaryf("variant", flt.Variant < framer.FilterLimits || flt.Variant > framer.FilterOnChange,
+			"unknown filter variant %d", flt.Variant)
+		v.Ternaryf("lower", flt.Variant == framer.FilterLimits && flt.Lower > flt.Upper,
+			"lower limit %v is greater than upper limit %v", flt.Lower, flt.Upper)
+		v.Ternaryf("deadband", flt.Deadband < 0, "deadband must be non-negative")
+		v.Ternaryf("variant", numeric && telem.UnmarshalFloat64F(dt) == nil,
+			"cannot evaluate %s filter on channel %s with data type %s", flt.Variant, flt.Channel, dt)
+		f.predicates[flt.Channel] = append(f.predicates[flt.Channel], &predicate{Filter: flt, toFloat: telem.UnmarshalFloat64F(dt)})
+	}
+	return f, v.Error()
+}
+
+// match returns true if the sample b matches the predicate.
+func (p *predicate) match(b []byte) bool {
+	switch p.Variant {
+	case framer.FilterLimits:
+		v := p.toFloat(b)
+		return v < p.Lower || v > p.Upper
+	case framer.FilterDeadband:
+		v := p.toFloat(b)
+		if p.started && math.Abs(v-p.last) <= p.Deadband {
+			return false
+		}
+		p.last, p.started = v, true
+		return true
+	case framer.FilterOnChange:
+		changed := !p.started || !bytes.Equal(b, p.lastBytes)
+		p.lastBytes, p.started = append(p.lastBytes[:0], b...), true
+		return changed
+	}
+	return false
+}
+
+// row identifies the series in a frame that hold the samples of a single write.
+type row struct {
+	index     channel.Key
+	alignment telem.AlignmentPair
+	len       int64
+}
+
+// row returns the row of the series s of the channel with the given key.
+func (f *frameFilter) row(key channel.Key, s telem.Series) row {
+	return row{index: f.indexes[key], alignment: s.Alignment, len: s.Len()}
+}
+
+// filter removes the samples in fr that do not match the predicates of their channel,
+// or of any other channel in the same row.
+func (f *frameFilter) filter(fr framer.Frame) framer.Frame {
+	if len(f.predicates) == 0 {
+		return fr
+	}
+	masks := make(map[row][]bool)
+	for i, key := range fr.Keys {
+		preds, ok := f.predicates[key]
+		if !ok {
+			continue
+		}
+		s := fr.Series[i]
+		r := f.row(key, s)
+		mask, ok := masks[r]
+		if !ok {
+			mask = make([]bool, r.len)
+			masks[r] = mask
+		}
+		for j, sample := range s.Split() {
+			for _, p := range preds {
+				// Every predicate is evaluated so that deadband and on-change
+				// predicates see every sample.
+				if p.match(sample) {
+					mask[j] = true
+				}
+			}
+		}
+	}
+	if len(masks) == 0 {
+		return fr
+	}
+	var filtered framer.Frame
+	for i, key := range fr.Keys {
+		s := fr.Series[i]
+		if mask, ok := masks[f.row(key, s)]; ok {
+			if s = applyMask(s, mask); s.Len() == 0 {
+				continue
+			}
+		}
+		filtered.Keys = append(filtered.Keys, key)
+		filtered.Series = append(filtered.Series, s)
+	}
+	return filtered
+}
+
+// applyMask returns a series holding the samples of s whose position in mask is true.
+func applyMask(s telem.Series, mask []bool) telem.Series {
+	out := telem.Series{TimeRange: s.TimeRange, DataType: s.DataType, Alignment: s.Alignment}
+	for i, sample := range s.Split() {
+		if !mask[i] {
+			continue
+		}
+		out.Data = append(out.Data, sample...)
+		if s.DataType.IsVariable() {
+			out.Data = append(out.Data, '\n')
+		}
+	}
+	return out
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package filter_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/mock"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/filter"
+	"github.com/synnaxlabs/x/confluence"
+	"github.com/synnaxlabs/x/control"
+	"github.com/synnaxlabs/x/signal"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("Filter", Ordered, func() {
+	var (
+		builder *mock.Builder
+		dist    distribution.Distribution
+		idx     channel.Channel
+		data    channel.Channel
+		keys    channel.Keys
+		start   telem.TimeStamp
+	)
+	BeforeAll(func() {
+		builder = mock.NewBuilder()
+		dist = builder.New(ctx)
+		idx = channel.Channel{Name: "time", DataType: telem.TimeStampT, IsIndex: true}
+		Expect(dist.Channel.Create(ctx, &idx)).To(Succeed())
+		data = channel.Channel{Name: "pressure", DataType: telem.Int64T, LocalIndex: idx.LocalKey}
+		Expect(dist.Channel.Create(ctx, &data)).To(Succeed())
+		keys = channel.Keys{idx.Key(), data.Key()}
+	})
+	AfterAll(func() {
+		Expect(builder.Close()).To(Succeed())
+		Expect(builder.Cleanup()).To(Succeed())
+	})
+
+	// stream writes each of the given series of samples to the data channel while
+	// streaming it with the given filters, returning the timestamps and samples that
+	// were received.
+	stream := func(filters []framer.Filter, values ...telem.Series) ([]telem.TimeStamp, []int64) {
+		cfg := framer.StreamerConfig{Keys: keys, Filters: filters, SendOpenAck: true}
+		s := MustSucceed(dist.Framer.NewStreamer(ctx, cfg))
+		s = MustSucceed(filter.NewStreamer(ctx, cfg, s, dist.Channel))
+		sCtx, cancel := signal.WithCancel(ctx)
+		defer cancel()
+		req, res := confluence.Attach(s, 10)
+		s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+		Eventually(res.Outlet()).Should(Receive())
+		start += 100 * telem.SecondTS
+		w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+			Keys:           keys,
+			Start:          start,
+			ControlSubject: control.Subject{Name: "filter"},
+		}))
+		ts := start
+		for _, v := range values {
+			timestamps := make([]telem.TimeStamp, v.Len())
+			for i := range timestamps {
+				timestamps[i] = ts
+				ts += telem.SecondTS
+			}
+			Expect(w.Write(framer.Frame{
+				Keys:   keys,
+				Series: []telem.Series{telem.NewSeries(timestamps), v},
+			})).To(BeTrue())
+		}
+		Expect(w.Close()).To(Succeed())
+		var (
+			timestamps []telem.TimeStamp
+			samples    []int64
+		)
+		for {
+			select {
+			case r := <-res.Outlet():
+				for _, s := range r.Frame.Get(idx.Key()) {
+					timestamps = append(timestamps, telem.Unmarshal[telem.TimeStamp](s)...)
+				}
+				for _, s := range r.Frame.Get(data.Key()) {
+					samples = append(samples, telem.Unmarshal[int64](s)...)
+				}
+				continue
+			case <-time.After(100 * time.Millisecond):
+			}
+			req.Close()
+			confluence.Drain(res)
+			Expect(sCtx.Wait()).To(Succeed())
+			return timestamps, samples
+		}
+	}
+
+	It("Should only send samples outside of the limits", func() {
+		timestamps, samples := stream(
+			[]framer.Filter{{Channel: data.Key(), Variant: framer.FilterLimits, Lower: -2, Upper: 2}},
+			telem.NewSeriesV[int64](0, 3, 1),
+			telem.NewSeriesV[int64](-1, 2),
+			telem.NewSeriesV[int64](-3, 5),
+		)
+		Expect(samples).To(Equal([]int64{3, -3, 5}))
+		Expect(timestamps).To(Equal([]telem.TimeStamp{
+			start + telem.SecondTS,
+			start + 5*telem.SecondTS,
+			start + 6*telem.SecondTS,
+		}))
+	})
+
+	It("Should only send samples that change by more than the deadband", func() {
+		_, samples := stream(
+			[]framer.Filter{{Channel: data.Key(), Variant: framer.FilterDeadband, Deadband: 2}},
+			telem.NewSeriesV[int64](10, 11, 12, 13),
+			telem.NewSeriesV[int64](9, 8, 7),
+		)
+		Expect(samples).To(Equal([]int64{10, 13, 9}))
+	})
+
+	It("Should only send samples that change", func() {
+		_, samples := stream(
+			[]framer.Filter{{Channel: data.Key(), Variant: framer.FilterOnChange}},
+			telem.NewSeriesV[int64](1, 1, 2),
+			telem.NewSeriesV[int64](2, 2, 1),
+		)
+		Expect(samples).To(Equal([]int64{1, 2, 1}))
+	})
+
+	It("Should send samples that match any of the filters of a channel", func() {
+		_, samples := stream(
+			[]framer.Filter{
+				{Channel: data.Key(), Variant: framer.FilterLimits, Lower: 0, Upper: 10},
+				{Channel: data.Key(), Variant: framer.FilterOnChange},
+			},
+			telem.NewSeriesV[int64](5, 5, 20, 20, 5),
+		)
+		Expect(samples).To(Equal([]int64{5, 20, 20, 5}))
+	})
+
+	It("Should not filter the samples of channels with a different index", func() {
+		idx2 := channel.Channel{Name: "time2", DataType: telem.TimeStampT, IsIndex: true}
+		Expect(dist.Channel.Create(ctx, &idx2)).To(Succeed())
+		data2 := channel.Channel{Name: "pressure2", DataType: telem.Int64T, LocalIndex: idx2.LocalKey}
+		Expect(dist.Channel.Create(ctx, &data2)).To(Succeed())
+		idx3 := channel.Channel{Name: "time3", DataType: telem.TimeStampT, IsIndex: true}
+		Expect(dist.Channel.Create(ctx, &idx3)).To(Succeed())
+		data3 := channel.Channel{Name: "pressure3", DataType: telem.Int64T, LocalIndex: idx3.LocalKey}
+		Expect(dist.Channel.Create(ctx, &data3)).To(Succeed())
+		groupKeys := channel.Keys{idx2.Key(), data2.Key(), idx3.Key(), data3.Key()}
+		cfg := framer.StreamerConfig{
+			Keys:        groupKeys,
+			SendOpenAck: true,
+			Filters: []framer.Filter{
+				{Channel: data2.Key(), Variant: framer.FilterLimits, Lower: -2, Upper: 2},
+			},
+		}
+		s := MustSucceed(dist.Framer.NewStreamer(ctx, cfg))
+		s = MustSucceed(filter.NewStreamer(ctx, cfg, s, dist.Channel))
+		sCtx, cancel := signal.WithCancel(ctx)
+		defer cancel()
+		req, res := confluence.Attach(s, 10)
+		s.Flow(sCtx, confluence.CloseOutputInletsOnExit())
+		Eventually(res.Outlet()).Should(Receive())
+		w := MustSucceed(dist.Framer.OpenWriter(ctx, framer.WriterConfig{
+			Keys:           groupKeys,
+			Start:          telem.SecondTS,
+			ControlSubject: control.Subject{Name: "filter"},
+		}))
+		timestamps := telem.NewSeriesV(telem.SecondTS, 2*telem.SecondTS, 3*telem.SecondTS)
+		Expect(w.Write(framer.Frame{
+			Keys: groupKeys,
+			Series: []telem.Series{
+				timestamps,
+				telem.NewSeriesV[int64](0, 3, 1),
+				timestamps,
+				telem.NewSeriesV[int64](0, 0, 0),
+			},
+		})).To(BeTrue())
+		Expect(w.Close()).To(Succeed())
+		var r framer.StreamerResponse
+		Eventually(res.Outlet()).Should(Receive(&r))
+		Expect(r.Frame.Get(data2.Key())).To(HaveLen(1))
+		Expect(telem.Unmarshal[int64](r.Frame.Get(data2.Key())[0])).To(Equal([]int64{3}))
+		Expect(r.Frame.Get(data3.Key())).To(HaveLen(1))
+		Expect(telem.Unmarshal[int64](r.Frame.Get(data3.Key())[0])).To(Equal([]int64{0, 0, 0}))
+		Expect(r.Frame.Get(idx3.Key())[0].Len()).To(Equal(int64(3)))
+		req.Close()
+		confluence.Drain(res)
+		Expect(sCtx.Wait()).To(Succeed())
+	})
+
+	It("Should not open a streamer with invalid filters", func() {
+		cfg := framer.StreamerConfig{Keys: keys, Filters: []framer.Filter{
+			{Channel: data.Key(), Variant: framer.FilterLimits, Lower: 2, Upper: 1},
+		}}
+		s := MustSucceed(dist.Framer.NewStreamer(ctx, cfg))
+		_, err := filter.NewStreamer(ctx, cfg, s, dist.Channel)
+		Expect(err).To(MatchError(ContainSubstring("lower limit")))
+	})
+
+	It("Should not open a streamer with a filter on a channel that is not streamed", func() {
+		cfg := framer.StreamerConfig{Keys: channel.Keys{idx.Key()}, Filters: []framer.Filter{
+			{Channel: data.Key(), Variant: framer.FilterOnChange},
+		}}
+		s := MustSucceed(dist.Framer.NewStreamer(ctx, cfg))
+		_, err := filter.NewStreamer(ctx, cfg, s, dist.Channel)
+		Expect(err).To(MatchError(ContainSubstring("not streamed")))
+	})
+
+	It("Should not open a streamer with a numeric filter on a non-numeric channel", func() {
+		label := channel.Channel{Name: "label", DataType: telem.StringT, LocalIndex: idx.LocalKey}
+		Expect(dist.Channel.Create(ctx, &label)).To(Succeed())
+		cfg := framer.StreamerConfig{Keys: channel.Keys{label.Key()}, Filters: []framer.Filter{
+			{Channel: label.Key(), Variant: framer.FilterDeadband, Deadband: 1},
+		}}
+		s := MustSucceed(dist.Framer.NewStreamer(ctx, cfg))
+		_, err := filter.NewStreamer(ctx, cfg, s, dist.Channel)
+		Expect(err).To(MatchError(ContainSubstring("cannot evaluate deadband filter")))
+	})
+})
@@ -0,0 +1,184 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package importer
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+)
+
+// batch accumulates the parsed rows of an imported file until they are written.
+type batch struct {
+	req Request
+	// keys are the keys of the channels that each column is imported into, starting
+	// with the index channel.
+	keys channel.Keys
+	// names are the names of each imported column.
+	names []string
+	// fields are the positions of each imported column in the rows of the file.
+	fields []int
+	// dataTypes are the data types of each imported column.
+	dataTypes []telem.DataType
+	// data holds the encoded samples of each fixed density column.
+	data [][]byte
+	// strs holds the samples of each variable density column.
+	strs [][]string
+	// rows is the number of rows in the batch.
+	rows int
+	// line is the number of rows read from the file, including the header.
+	line int
+}
+
+func newBatch(header []string, req Request, channels []channel.Channel) (*batch, error) {
+	columns := append([]Column{req.Index}, req.Columns...)
+	b := &batch{
+		req:       req,
+		keys:      make(channel.Keys, len(columns)),
+		names:     make([]string, len(columns)),
+		fields:    make([]int, len(columns)),
+		dataTypes: make([]telem.DataType, len(columns)),
+		data:      make([][]byte, len(columns)),
+		strs:      make([][]string, len(columns)),
+		line:      1,
+	}
+	for i, c := range columns {
+		b.fields[i] = slices.Index(header, c.Name)
+		if b.fields[i] == -1 {
+			return nil, errors.Wrapf(
+				validate.Error,
+				"column %s does not exist in the imported file",
+				c.Name,
+			)
+		}
+		b.keys[i] = channels[i].Key()
+		b.names[i] = c.Name
+		b.dataTypes[i] = channels[i].DataType
+	}
+	return b, nil
+}
+
+func (b *batch) len() int { return b.rows }
+
+// start returns the timestamp of the first row in the batch.
+func (b *batch) start() telem.TimeStamp {
+	return telem.UnmarshalF[telem.TimeStamp](telem.TimeStampT)(b.data[0])
+}
+
+// add parses the imported columns of a row of the file and adds them to the batch.
+func (b *batch) add(record []string) error {
+	b.line++
+	for i, field := range b.fields {
+		if field >= len(record) {
+			return b.errorf(i, "missing value")
+		}
+		value := record[field]
+		dt := b.dataTypes[i]
+		if dt.IsVariable() {
+			if strings.ContainsRune(value, '\n') {
+				return b.errorf(i, "values of data type %s cannot contain newlines", dt)
+			}
+			b.strs[i] = append(b.strs[i], value)
+			continue
+		}
+		var err error
+		if b.data[i], err = b.appendValue(b.data[i], dt, value); err != nil {
+			return b.errorf(i, "invalid %s value %q", dt, value)
+		}
+	}
+	b.rows++
+	return nil
+}
+
+// flush returns a frame holding the rows in the batch and resets it.
+func (b *batch) flush() framer.Frame {
+	fr := framer.Frame{Keys: b.keys, Series: make([]telem.Series, len(b.keys))}
+	for i, dt := range b.dataTypes {
+		if dt.IsVariable() {
+			fr.Series[i] = telem.Series{DataType: dt, Data: telem.MarshalStrings(b.strs[i], dt)}
+			b.strs[i] = b.strs[i][:0]
+			continue
+		}
+		fr.Series[i] = telem.Series{DataType: dt, Data: b.data[i]}
+		b.data[i] = make([]byte, 0, len(b.data[i]))
+	}
+	b.rows = 0
+	return fr
+}
+
+func (b *batch) errorf(column int, format string, args ...any) error {
+	return errors.Wrapf(
+		validate.Error,
+		"line %d, column %s: "+format,
+		append([]any{b.line, b.names[column]}, args...)...,
+	)
+}
+
+// appendValue parses value as a sample of the given data type and appends its encoded
+// bytes to data.
+func (b *batch) appendValue(data []byte, dt telem.DataType, value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	switch dt {
+	case telem.TimeStampT:
+		ts, err := b.parseTimeStamp(value)
+		return telem.ByteOrder.AppendUint64(data, uint64(ts)), err
+	case telem.Float64T, telem.Float32T:
+		v, err := strconv.ParseFloat(value, int(dt.Density())*8)
+		if err != nil {
+			return data, err
+		}
+		return appendNumeric(data, dt, v), nil
+	case telem.Int64T, telem.Int32T, telem.Int16T, telem.Int8T:
+		v, err := strconv.ParseInt(value, 10, int(dt.Density())*8)
+		if err != nil {
+			return data, err
+		}
+		return appendNumeric(data, dt, v), nil
+	case telem.Uint64T, telem.Uint32T, telem.Uint16T, telem.Uint8T:
+		v, err := strconv.ParseUint(value, 10, int(dt.Density())*8)
+		if err != nil {
+			return data, err
+		}
+		return appendNumeric(data, dt, v), nil
+	case telem.UUIDT:
+		v, err := uuid.Parse(value)
+		return append(data, v[:]...), err
+	}
+	return data, errors.Newf("unsupported data type %s", dt)
+}
+
+func appendNumeric[T float64 | int64 | uint64](data []byte, dt telem.DataType, v T) []byte {
+	n := len(data)
+	data = append(data, make([]byte, dt.Density())...)
+	telem.MarshalF[T](dt)(data[n:], v)
+	return data
+}
+
+// parseTimeStamp parses a timestamp in the imported file using the time layout or time
+// unit of the request.
+func (b *batch) parseTimeStamp(value string) (telem.TimeStamp, error) {
+	if b.req.TimeLayout != "" {
+		t, err := time.Parse(b.req.TimeLayout, value)
+		return telem.NewTimeStamp(t), err
+	}
+	if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return telem.TimeStamp(v) * telem.TimeStamp(b.req.TimeUnit), nil
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	return telem.TimeStamp(v * float64(b.req.TimeUnit)), err
+}
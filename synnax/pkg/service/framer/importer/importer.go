@@ -0,0 +1,335 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+// Package importer bulk imports tabular telemetry, such as CSV files exported by
+// legacy data acquisition systems, into Synnax channels.
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+
+	"github.com/synnaxlabs/alamos"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/x/config"
+	"github.com/synnaxlabs/x/control"
+	"github.com/synnaxlabs/x/errors"
+	"github.com/synnaxlabs/x/override"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/validate"
+	"go.uber.org/zap"
+)
+
+// Config is the configuration for opening the import service.
+type Config struct {
+	alamos.Instrumentation
+	// Framer is used to write imported samples.
+	// [REQUIRED]
+	Framer *framer.Service
+	// Channel is used to retrieve and create the channels that samples are imported
+	// into.
+	// [REQUIRED]
+	Channel channel.Service
+}
+
+var (
+	_ config.Config[Config] = Config{}
+	// DefaultConfig is the default configuration for opening the import service.
+	DefaultConfig = Config{}
+)
+
+// Validate implements config.Config.
+func (c Config) Validate() error {
+	v := validate.New("importer")
+	validate.NotNil(v, "framer", c.Framer)
+	validate.NotNil(v, "channel", c.Channel)
+	return v.Error()
+}
+
+// Override implements config.Config.
+func (c Config) Override(other Config) Config {
+	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
+	c.Framer = override.Nil(c.Framer, other.Framer)
+	c.Channel = override.Nil(c.Channel, other.Channel)
+	return c
+}
+
+// Column maps a column of an imported file to a channel.
+type Column struct {
+	// Name is the name of the column in the header of the file.
+	// [REQUIRED]
+	Name string `json:"name" msgpack:"name"`
+	// Channel is the key of an existing channel to import the column into. If zero,
+	// the column is imported into the channel named ChannelName, which is created if
+	// it does not exist.
+	// [OPTIONAL]
+	Channel channel.Key `json:"channel" msgpack:"channel"`
+	// ChannelName is the name of the channel to import the column into when Channel is
+	// zero.
+	// [OPTIONAL] - Defaults to Name.
+	ChannelName string `json:"channel_name" msgpack:"channel_name"`
+	// DataType is the data type of the column's channel. The data type of the index
+	// column is always telem.TimeStampT.
+	// [REQUIRED] - For non-index columns.
+	DataType telem.DataType `json:"data_type" msgpack:"data_type"`
+}
+
+func (c Column) channelName() string {
+	if c.ChannelName != "" {
+		return c.ChannelName
+	}
+	return c.Name
+}
+
+// Request is a request to import a file.
+type Request struct {
+	// Index is the column holding the timestamps of each row. It is imported into an
+	// index channel.
+	// [REQUIRED]
+	Index Column `json:"index" msgpack:"index"`
+	// Columns are the data columns to import. Columns in the file that are not in
+	// Columns are ignored.
+	// [REQUIRED]
+	Columns []Column `json:"columns" msgpack:"columns"`
+	// TimeLayout is the time.Parse layout of timestamps in the file. If empty,
+	// timestamps are parsed as numbers of TimeUnit since the unix epoch.
+	// [OPTIONAL]
+	TimeLayout string `json:"time_layout" msgpack:"time_layout"`
+	// TimeUnit is the unit of numeric timestamps in the file.
+	// [OPTIONAL] - Defaults to telem.Nanosecond.
+	TimeUnit telem.TimeSpan `json:"time_unit" msgpack:"time_unit"`
+	// Delimiter is the character separating the fields of each row.
+	// [OPTIONAL] - Defaults to ','.
+	Delimiter rune `json:"delimiter" msgpack:"delimiter"`
+	// CommitSize is the number of rows written in each commit.
+	// [OPTIONAL] - Defaults to 100000.
+	CommitSize int `json:"commit_size" msgpack:"commit_size"`
+}
+
+var (
+	_ config.Config[Request] = Request{}
+	// DefaultRequest is the default request for importing a file.
+	DefaultRequest = Request{
+		TimeUnit:   telem.Nanosecond,
+		Delimiter:  ',',
+		CommitSize: 1e5,
+	}
+)
+
+// Override implements config.Config.
+func (r Request) Override(other Request) Request {
+	r.Index = override.If(r.Index, other.Index, other.Index != (Column{}))
+	r.Columns = override.Slice(r.Columns, other.Columns)
+	r.TimeLayout = override.String(r.TimeLayout, other.TimeLayout)
+	r.TimeUnit = override.Numeric(r.TimeUnit, other.TimeUnit)
+	r.Delimiter = override.Numeric(r.Delimiter, other.Delimiter)
+	r.CommitSize = override.Numeric(r.CommitSize, other.CommitSize)
+	return r
+}
+
+// Validate implements config.Config.
+func (r Request) Validate() error {
+	v := validate.New("importer.request")
+	validate.NotEmptyString(v, "index.name", r.Index.Name)
+	validate.NotEmptySlice(v, "columns", r.Columns)
+	validate.Positive(v, "time_unit", r.TimeUnit)
+	validate.Positive(v, "commit_size", r.CommitSize)
+	for _, c := range r.Columns {
+		validate.NotEmptyString(v, "columns.name", c.Name)
+		v.Ternaryf("columns.data_type", c.Channel == 0 && c.DataType == telem.UnknownT,
+			"data type must be provided for column %s", c.Name)
+	}
+	return v.Error()
+}
+
+// Progress reports the progress of an import.
+type Progress struct {
+	// Keys are the keys of the channels that samples are imported into, starting with
+	// the index channel followed by the channels of each column in Request.Columns.
+	Keys channel.Keys `json:"keys" msgpack:"keys"`
+	// Rows is the number of rows that have been committed.
+	Rows int64 `json:"rows" msgpack:"rows"`
+	// Commits is the number of commits that have been made.
+	Commits int `json:"commits" msgpack:"commits"`
+}
+
+// Service imports files into Synnax channels.
+type Service struct{ cfg Config }
+
+// NewService opens a new import service using the provided configuration.
+func NewService(cfgs ...Config) (*Service, error) {
+	cfg, err := config.New(DefaultConfig, cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	return &Service{cfg: cfg}, nil
+}
+
+// Import reads the CSV file in r, whose first row is a header naming each column, and
+// writes the columns in req to their channels, creating any channels that do not
+// exist. Rows are written in commits of req.CommitSize rows, and onProgress is called
+// after each commit. onProgress may be nil.
+func (s *Service) Import(
+	ctx context.Context,
+	r io.Reader,
+	req Request,
+	onProgress func(Progress),
+) (err error) {
+	if req, err = config.New(DefaultRequest, req); err != nil {
+		return err
+	}
+	channels, err := s.retrieveChannels(ctx, req)
+	if err != nil {
+		return err
+	}
+	cr := csv.NewReader(r)
+	cr.Comma = req.Delimiter
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err != nil {
+		return errors.Wrap(err, "failed to read header of imported file")
+	}
+	b, err := newBatch(header, req, channels)
+	if err != nil {
+		return err
+	}
+	var (
+		w        *framer.Writer
+		progress = Progress{Keys: b.keys}
+	)
+	defer func() {
+		if w != nil {
+			err = errors.Combine(err, w.Close())
+		}
+	}()
+	flush := func() error {
+		if b.len() == 0 {
+			return nil
+		}
+		if w == nil {
+			var openErr error
+			if w, openErr = s.cfg.Framer.OpenWriter(ctx, framer.WriterConfig{
+				ControlSubject: control.Subject{Name: "importer"},
+				Keys:           b.keys,
+				Start:          b.start(),
+			}); openErr != nil {
+				return openErr
+			}
+		}
+		rows := b.len()
+		if !w.Write(b.flush()) {
+			return w.Error()
+		}
+		if !w.Commit() {
+			return w.Error()
+		}
+		progress.Rows += int64(rows)
+		progress.Commits++
+		s.cfg.L.Debug("committed imported rows", zap.Int64("rows", progress.Rows))
+		if onProgress != nil {
+			onProgress(progress)
+		}
+		return nil
+	}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err = b.add(record); err != nil {
+			return err
+		}
+		if b.len() >= req.CommitSize {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// retrieveChannels retrieves or creates the channels that the index and data columns
+// of req are imported into, returning the index channel first.
+func (s *Service) retrieveChannels(ctx context.Context, req Request) ([]channel.Channel, error) {
+	req.Index.DataType = telem.TimeStampT
+	index, err := s.retrieveChannel(ctx, req.Index, channel.Channel{
+		Name:     req.Index.channelName(),
+		DataType: telem.TimeStampT,
+		IsIndex:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	channels := []channel.Channel{index}
+	for _, c := range req.Columns {
+		ch, err := s.retrieveChannel(ctx, c, channel.Channel{
+			Name:        c.channelName(),
+			DataType:    c.DataType,
+			Leaseholder: index.Leaseholder,
+			LocalIndex:  index.LocalKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if ch.Index() != index.Key() {
+			return nil, errors.Wrapf(
+				validate.Error,
+				"column %s cannot be imported into channel %s because it is not indexed by channel %s",
+				c.Name,
+				ch.Name,
+				index.Name,
+			)
+		}
+		channels = append(channels, ch)
+	}
+	return channels, nil
+}
+
+// retrieveChannel retrieves the channel that column c is imported into, creating it
+// from toCreate if c does not reference an existing channel and no channel with the
+// same name exists.
+func (s *Service) retrieveChannel(
+	ctx context.Context,
+	c Column,
+	toCreate channel.Channel,
+) (ch channel.Channel, err error) {
+	if c.Channel != 0 {
+		err = s.cfg.Channel.NewRetrieve().WhereKeys(c.Channel).Entry(&ch).Exec(ctx, nil)
+	} else {
+		ch = toCreate
+		err = s.cfg.Channel.Create(ctx, &ch, channel.RetrieveIfNameExists(true))
+	}
+	if err != nil {
+		return ch, err
+	}
+	if ch.Virtual || ch.IsIndex != toCreate.IsIndex {
+		return ch, errors.Wrapf(
+			validate.Error,
+			"column %s cannot be imported into channel %s",
+			c.Name,
+			ch.Name,
+		)
+	}
+	if c.DataType != telem.UnknownT && ch.DataType != c.DataType {
+		return ch, errors.Wrapf(
+			validate.Error,
+			"column %s has data type %s, but channel %s has data type %s",
+			c.Name,
+			c.DataType,
+			ch.Name,
+			ch.DataType,
+		)
+	}
+	return ch, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package importer_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/synnax/pkg/distribution"
+	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
+	"github.com/synnaxlabs/synnax/pkg/distribution/mock"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/importer"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
+)
+
+var _ = Describe("Importer", Ordered, func() {
+	var (
+		builder *mock.Builder
+		dist    distribution.Distribution
+		svc     *importer.Service
+	)
+	BeforeAll(func() {
+		builder = mock.NewBuilder()
+		dist = builder.New(ctx)
+		svc = MustSucceed(importer.NewService(importer.Config{
+			Framer:  dist.Framer,
+			Channel: dist.Channel,
+		}))
+	})
+	AfterAll(func() {
+		Expect(builder.Close()).To(Succeed())
+		Expect(builder.Cleanup()).To(Succeed())
+	})
+
+	read := func(key channel.Key) telem.Series {
+		i := MustSucceed(dist.Framer.OpenIterator(ctx, framer.IteratorConfig{
+			Keys:   []channel.Key{key},
+			Bounds: telem.TimeRangeMax,
+		}))
+		series := telem.Series{}
+		for i.SeekFirst(); i.Next(telem.TimeSpanMax); {
+			for _, s := range i.Value().Get(key) {
+				series.DataType = s.DataType
+				series.Data = append(series.Data, s.Data...)
+			}
+		}
+		Expect(i.Close()).To(Succeed())
+		return series
+	}
+
+	It("Should create channels and import rows in commits", func() {
+		file := "time,pressure,valve,note\n" +
+			"1,1.5,1,a\n" +
+			"2,2.5,0,b\n" +
+			"3,3.5,1,c\n" +
+			"4,4.5,0,d\n" +
+			"5,5.5,1,e\n"
+		var progress []importer.Progress
+		Expect(svc.Import(ctx, strings.NewReader(file), importer.Request{
+			Index: importer.Column{Name: "time", ChannelName: "import_time"},
+			Columns: []importer.Column{
+				{Name: "pressure", ChannelName: "import_pressure", DataType: telem.Float64T},
+				{Name: "note", ChannelName: "import_note", DataType: telem.StringT},
+			},
+			TimeUnit:   telem.Second,
+			CommitSize: 2,
+		}, func(p importer.Progress) {
+			progress = append(progress, p)
+		})).To(Succeed())
+		Expect(progress).To(HaveLen(3))
+		Expect(progress[2].Rows).To(Equal(int64(5)))
+		Expect(progress[2].Commits).To(Equal(3))
+		Expect(progress[0].Rows).To(Equal(int64(2)))
+		keys := progress[0].Keys
+		Expect(keys).To(HaveLen(3))
+		var channels []channel.Channel
+		Expect(dist.Channel.NewRetrieve().WhereKeys(keys...).Entries(&channels).Exec(ctx, nil)).To(Succeed())
+		Expect(channels).To(HaveLen(3))
+		Expect(channels[0].IsIndex).To(BeTrue())
+		Expect(channels[1].Index()).To(Equal(keys[0]))
+		Expect(read(keys[0])).To(Equal(telem.NewSecondsTSV(1, 2, 3, 4, 5)))
+		Expect(read(keys[1])).To(Equal(telem.NewSeriesV(1.5, 2.5, 3.5, 4.5, 5.5)))
+		Expect(telem.UnmarshalStrings(read(keys[2]).Data)).To(Equal([]string{"a", "b", "c", "d", "e"}))
+	})
+
+	It("Should import into existing channels", func() {
+		idx := channel.Channel{Name: "existing_time", DataType: telem.TimeStampT, IsIndex: true}
+		Expect(dist.Channel.Create(ctx, &idx)).To(Succeed())
+		data := channel.Channel{Name: "existing_data", DataType: telem.Int32T, LocalIndex: idx.LocalKey}
+		Expect(dist.Channel.Create(ctx, &data)).To(Succeed())
+		t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		file := "timestamp;count\n" +
+			t0.Format(time.RFC3339) + ";-4\n" +
+			t0.Add(time.Second).Format(time.RFC3339) + ";7\n"
+		Expect(svc.Import(ctx, strings.NewReader(file), importer.Request{
+			Index:      importer.Column{Name: "timestamp", Channel: idx.Key()},
+			Columns:    []importer.Column{{Name: "count", Channel: data.Key()}},
+			TimeLayout: time.RFC3339,
+			Delimiter:  ';',
+		}, nil)).To(Succeed())
+		start := telem.NewTimeStamp(t0)
+		Expect(read(idx.Key())).To(Equal(telem.NewSeriesV(start, start.Add(telem.Second))))
+		Expect(read(data.Key())).To(Equal(telem.NewSeriesV[int32](-4, 7)))
+	})
+
+	It("Should parse fractional timestamps", func() {
+		file := "t,v\n1.5,1\n2.25,2\n"
+		var keys channel.Keys
+		Expect(svc.Import(ctx, strings.NewReader(file), importer.Request{
+			Index:    importer.Column{Name: "t", ChannelName: "fractional_time"},
+			Columns:  []importer.Column{{Name: "v", ChannelName: "fractional_v", DataType: telem.Uint8T}},
+			TimeUnit: telem.Second,
+		}, func(p importer.Progress) { keys = p.Keys })).To(Succeed())
+		Expect(read(keys[0])).To(Equal(telem.NewSeriesV(
+			telem.TimeStamp(1500*telem.Millisecond),
+			telem.TimeStamp(2250*telem.Millisecond),
+		)))
+	})
+
+	It("Should return an error when a column does not exist in the file", func() {
+		Expect(svc.Import(ctx, strings.NewReader("time,a\n1,1\n"), importer.Request{
+			Index:   importer.Column{Name: "time", ChannelName: "missing_time"},
+			Columns: []importer.Column{{Name: "b", ChannelName: "missing_b", DataType: telem.Float32T}},
+		}, nil)).To(HaveOccurredAs(validate.Error))
+	})
+
+	It("Should return an error when a value cannot be parsed", func() {
+		err := svc.Import(ctx, strings.NewReader("time,a\n1,1\n2,cat\n"), importer.Request{
+			Index:   importer.Column{Name: "time", ChannelName: "invalid_time"},
+			Columns: []importer.Column{{Name: "a", ChannelName: "invalid_a", DataType: telem.Int64T}},
+		}, nil)
+		Expect(err).To(HaveOccurredAs(validate.Error))
+		Expect(err.Error()).To(ContainSubstring("line 3"))
+	})
+
+	It("Should return an error when a column has a different data type than its channel", func() {
+		idx := channel.Channel{Name: "mismatch_time", DataType: telem.TimeStampT, IsIndex: true}
+		Expect(dist.Channel.Create(ctx, &idx)).To(Succeed())
+		data := channel.Channel{Name: "mismatch_data", DataType: telem.Int32T, LocalIndex: idx.LocalKey}
+		Expect(dist.Channel.Create(ctx, &data)).To(Succeed())
+		Expect(svc.Import(ctx, strings.NewReader("time,a\n1,1\n"), importer.Request{
+			Index:   importer.Column{Name: "time", Channel: idx.Key()},
+			Columns: []importer.Column{{Name: "a", Channel: data.Key(), DataType: telem.Float64T}},
+		}, nil)).To(HaveOccurredAs(validate.Error))
+	})
+
+	It("Should validate the request", func() {
+		Expect(svc.Import(ctx, strings.NewReader(""), importer.Request{
+			Index: importer.Column{Name: "time"},
+		}, nil)).To(MatchError(ContainSubstring("columns")))
+	})
+})
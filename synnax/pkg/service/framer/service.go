@@ -13,9 +13,12 @@ import (
 	"context"
 	"github.com/synnaxlabs/alamos"
 	"github.com/synnaxlabs/synnax/pkg/distribution/channel"
+	"github.com/synnaxlabs/synnax/pkg/distribution/core"
 	"github.com/synnaxlabs/synnax/pkg/distribution/framer"
 	"github.com/synnaxlabs/synnax/pkg/service/framer/calculation"
 	"github.com/synnaxlabs/synnax/pkg/service/framer/downsampler"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/filter"
+	"github.com/synnaxlabs/synnax/pkg/service/framer/importer"
 	"github.com/synnaxlabs/x/address"
 	"github.com/synnaxlabs/x/config"
 	"github.com/synnaxlabs/x/confluence"
@@ -32,6 +35,9 @@ type Config struct {
 	// Distribution layer framer service.
 	Framer  *framer.Service
 	Channel channel.Service
+	// HostProvider is used to determine which materialized calculated channels are
+	// leased by this node.
+	HostProvider core.HostProvider
 }
 
 var (
@@ -44,6 +50,7 @@ func (c Config) Validate() error {
 	v := validate.New("framer")
 	validate.NotNil(v, "framer", c.Framer)
 	validate.NotNil(v, "channel", c.Channel)
+	validate.NotNil(v, "host_provider", c.HostProvider)
 	return v.Error()
 }
 
@@ -52,12 +59,14 @@ func (c Config) Override(other Config) Config {
 	c.Instrumentation = override.Zero(c.Instrumentation, other.Instrumentation)
 	c.Framer = override.Nil(c.Framer, other.Framer)
 	c.Channel = override.Nil(c.Channel, other.Channel)
+	c.HostProvider = override.Nil(c.HostProvider, other.HostProvider)
 	return c
 }
 
 type Service struct {
 	Config
 	Calculation *calculation.Service
+	Importer    *importer.Service
 }
 
 func (s *Service) OpenIterator(ctx context.Context, cfg framer.IteratorConfig) (*framer.Iterator, error) {
@@ -90,11 +99,14 @@ func (s *Service) NewStreamer(ctx context.Context, cfg framer.StreamerConfig) (f
 
 	var streamer framer.Streamer
 	var err error
-	if cfg.DownsampleFactor > 1 {
-		streamer, err = downsampler.NewStreamer(ctx, cfg, s.Framer)
+	if cfg.DownsampleFactor > 1 || cfg.DownsampleRate > 0 {
+		streamer, err = downsampler.NewStreamer(ctx, cfg, s.Framer, s.Channel)
 	} else {
 		streamer, err = s.Framer.NewStreamer(ctx, cfg)
 	}
+	if err == nil && len(cfg.Filters) > 0 {
+		streamer, err = filter.NewStreamer(ctx, cfg, streamer, s.Channel)
+	}
 
 	if err != nil {
 		return nil, err
@@ -170,7 +182,16 @@ func OpenService(ctx context.Context, cfgs ...Config) (*Service, error) {
 		Channel:           cfg.Channel,
 		Framer:            cfg.Framer,
 		ChannelObservable: cfg.Channel.NewObservable(),
+		HostProvider:      cfg.HostProvider,
 	})
 	s.Calculation = calc
+	if err != nil {
+		return s, err
+	}
+	s.Importer, err = importer.NewService(importer.Config{
+		Instrumentation: cfg.Instrumentation.Child("importer"),
+		Framer:          cfg.Framer,
+		Channel:         cfg.Channel,
+	})
 	return s, err
 }
@@ -24,6 +24,7 @@
 package storage
 
 import (
+	"context"
 	"io"
 	"io/fs"
 	"os"
@@ -80,6 +81,8 @@ type Storage struct {
 	KV kv.DB
 	// TS is the time-series engine for the node.
 	TS *cesium.DB
+	// pebble is the engine backing KV, used to checkpoint the key-value store.
+	pebble *pebble.DB
 	// lock is the lock held on the storage directory.
 	lock io.Closer
 }
@@ -96,6 +99,37 @@ func (s *Storage) Gorpify() *gorp.DB {
 	)
 }
 
+// Snapshot writes a consistent copy of the key-value store and the time-series engine
+// to the directory with the given name, which must not exist. The snapshot can be
+// restored into a new node by setting Config.RestoreFrom. Snapshot can be called
+// while the node is running, and returns an error if the Storage is memory-backed.
+func (s *Storage) Snapshot(ctx context.Context, dirname string) error {
+	if *s.MemBacked {
+		return errors.Wrap(validate.Error, "[storage] - cannot snapshot memory-backed storage")
+	}
+	exists, err := xfs.Default.Exists(dirname)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Wrapf(validate.Error, "[storage] - snapshot directory %s already exists", dirname)
+	}
+	// Channels are created in the time-series engine before they are added to the
+	// key-value store, so checkpointing the key-value store first guarantees that every
+	// channel it holds is also in the time-series snapshot.
+	if err = s.pebble.Checkpoint(
+		filepath.Join(dirname, kvDirname),
+		pebble.WithFlushedWAL(),
+	); err != nil {
+		return errors.Wrapf(err, "[storage] - failed to checkpoint key-value store")
+	}
+	tsFS, err := xfs.Default.Sub(filepath.Join(dirname, cesiumDirname))
+	if err != nil {
+		return err
+	}
+	return s.TS.Snapshot(ctx, tsFS)
+}
+
 // Close closes the Storage, releasing the lock on the storage directory. Close
 // MUST be called when the Storage is no longer in use. The caller must ensure that
 // all processes interacting the Storage have finished before calling Close.
@@ -124,6 +158,11 @@ type Config struct {
 	KVEngine KVEngine
 	// TSEngine is the time-series engine storage will use.
 	TSEngine TSEngine
+	// RestoreFrom is the directory of a snapshot written by Storage.Snapshot to
+	// restore before opening the storage engines. The snapshot can only be restored
+	// into storage that does not contain any key-value or time-series data, and cannot
+	// be restored into memory-backed storage.
+	RestoreFrom string
 }
 
 var (
@@ -144,6 +183,7 @@ func (cfg Config) Override(other Config) Config {
 	cfg.KVEngine = override.Numeric(cfg.KVEngine, other.KVEngine)
 	cfg.TSEngine = override.Numeric(cfg.TSEngine, other.TSEngine)
 	cfg.MemBacked = override.Nil(cfg.MemBacked, other.MemBacked)
+	cfg.RestoreFrom = override.String(cfg.RestoreFrom, other.RestoreFrom)
 	cfg.Instrumentation = override.Zero(cfg.Instrumentation, other.Instrumentation)
 	if cfg.MemBacked != nil && *cfg.MemBacked {
 		cfg.Dirname = ""
@@ -158,17 +198,19 @@ func (cfg Config) Validate() error {
 	v.Ternaryf("kvEngine", !lo.Contains(kvEngines, cfg.KVEngine), "invalid key-value engine %s", cfg.KVEngine)
 	v.Ternaryf("tsEngine", !lo.Contains(tsEngines, cfg.TSEngine), "invalid time-series engine %s", cfg.TSEngine)
 	v.Ternary("permissions", cfg.Perm == 0, "insufficient permission bits on directory")
+	v.Ternary("restoreFrom", *cfg.MemBacked && cfg.RestoreFrom != "", "cannot restore a snapshot into memory-backed storage")
 	return v.Error()
 }
 
 // Report implements the alamos.ReportProvider interface.
 func (cfg Config) Report() alamos.Report {
 	return alamos.Report{
-		"dirname":     cfg.Dirname,
-		"permissions": cfg.Perm,
-		"mem_backed":  cfg.MemBacked,
-		"kv_engine":   cfg.KVEngine.String(),
-		"ts_engine":   cfg.TSEngine.String(),
+		"dirname":      cfg.Dirname,
+		"permissions":  cfg.Perm,
+		"mem_backed":   cfg.MemBacked,
+		"kv_engine":    cfg.KVEngine.String(),
+		"ts_engine":    cfg.TSEngine.String(),
+		"restore_from": cfg.RestoreFrom,
 	}
 }
 
@@ -210,10 +252,18 @@ func Open(cfg Config) (s *Storage, err error) {
 	// Allow the caller to release the lock when they finish using the storage.
 	s.lock = releaser
 
+	// Restore both storage engines from a snapshot before opening them.
+	if cfg.RestoreFrom != "" {
+		if err = restore(cfg); err != nil {
+			return s, errors.Combine(err, s.lock.Close())
+		}
+	}
+
 	// Open the key-value storage engine.
-	if s.KV, err = openKV(cfg, baseVFS); err != nil {
+	if s.pebble, err = openKV(cfg, baseVFS); err != nil {
 		return s, errors.Combine(err, s.lock.Close())
 	}
+	s.KV = pebblekv.Wrap(s.pebble)
 
 	// Open the time-series engine.
 	if s.TS, err = openTS(cfg, baseXFS); err != nil {
@@ -228,6 +278,9 @@ const (
 	kvDirname     = "kv"
 	lockFileName  = "LOCK"
 	cesiumDirname = "cesium"
+	// restoreSuffix is appended to the name of the key-value store directory while
+	// it is being restored from a snapshot.
+	restoreSuffix = "-RESTORE"
 )
 
 func openBaseFS(cfg Config) (vfs.FS, xfs.FS) {
@@ -298,7 +351,7 @@ func acquireLock(cfg Config, fs vfs.FS) (io.Closer, error) {
 	return release, errors.Wrapf(err, failedToAcquireLockMsg, cfg.Dirname)
 }
 
-func openKV(cfg Config, fs vfs.FS) (kv.DB, error) {
+func openKV(cfg Config, fs vfs.FS) (*pebble.DB, error) {
 	if cfg.KVEngine != PebbleKV {
 		return nil, errors.Newf("[storage]- unsupported key-value engine: %s", cfg.KVEngine)
 	}
@@ -326,7 +379,75 @@ func openKV(cfg Config, fs vfs.FS) (kv.DB, error) {
 			dirname,
 		)
 	}
-	return pebblekv.Wrap(db), err
+	return db, err
+}
+
+// restore restores the snapshot in cfg.RestoreFrom into the storage directory. The
+// key-value store is copied into a temporary directory and only moved into place once
+// the time-series data has been restored, so a restore that is interrupted while
+// copying can be safely re-run.
+func restore(cfg Config) error {
+	srcKV := filepath.Join(cfg.RestoreFrom, kvDirname)
+	srcTS := filepath.Join(cfg.RestoreFrom, cesiumDirname)
+	for _, dir := range []string{srcKV, srcTS} {
+		exists, err := xfs.Default.Exists(dir)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errors.Newf("[storage] - snapshot directory %s does not exist", dir)
+		}
+	}
+	exists, err := xfs.Default.Exists(filepath.Join(cfg.Dirname, kvDirname))
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Wrapf(
+			validate.Error,
+			"[storage] - cannot restore snapshot into %s, as it already contains a key-value store",
+			cfg.Dirname,
+		)
+	}
+	root, err := xfs.Default.Sub(cfg.Dirname)
+	if err != nil {
+		return err
+	}
+	tmpKV := kvDirname + restoreSuffix
+	// Remove any partially restored store left behind by a previous restore.
+	if err = root.Remove(tmpKV); err != nil {
+		return err
+	}
+	srcKVFS, err := xfs.Default.Sub(srcKV)
+	if err != nil {
+		return err
+	}
+	dstKVFS, err := root.Sub(tmpKV)
+	if err != nil {
+		return err
+	}
+	if err = xfs.CopyDir(srcKVFS, dstKVFS); err != nil {
+		return err
+	}
+	srcTSFS, err := xfs.Default.Sub(srcTS)
+	if err != nil {
+		return err
+	}
+	dstTSFS, err := root.Sub(cesiumDirname)
+	if err != nil {
+		return err
+	}
+	if err = cesium.Restore(srcTSFS, dstTSFS); err != nil {
+		return err
+	}
+	if err = root.Rename(tmpKV, kvDirname); err != nil {
+		return err
+	}
+	if err = xfs.SyncDir(root, "."); err != nil {
+		return err
+	}
+	cfg.L.Info("restored snapshot", zap.String("snapshot", cfg.RestoreFrom))
+	return nil
 }
 
 func openTS(cfg Config, fs xfs.FS) (*ts.DB, error) {
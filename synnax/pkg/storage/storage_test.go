@@ -10,17 +10,25 @@
 package storage_test
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/synnaxlabs/synnax/pkg/storage"
+	"github.com/synnaxlabs/synnax/pkg/storage/ts"
 	"github.com/synnaxlabs/x/config"
 	xfs "github.com/synnaxlabs/x/io/fs"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
+	"github.com/synnaxlabs/x/validate"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 )
 
+var ctx = context.Background()
+
 var _ = Describe("storage", func() {
 	Describe("Open", func() {
 		var (
@@ -84,6 +92,58 @@ var _ = Describe("storage", func() {
 				Expect(store.Close()).To(Succeed())
 			})
 		})
+		Describe("Snapshot", func() {
+			var snapDir string
+			BeforeEach(func() { snapDir = filepath.Join(tempDir, "snapshot") })
+			It("Should restore a snapshot of both storage engines into a new node", func() {
+				store := MustSucceed(storage.Open(cfg))
+				ch := ts.Channel{Key: 1, Virtual: true, DataType: telem.Int64T}
+				Expect(store.TS.CreateChannel(ctx, ch)).To(Succeed())
+				Expect(store.KV.Set(ctx, []byte("key"), []byte("value"))).To(Succeed())
+				Expect(store.Snapshot(ctx, snapDir)).To(Succeed())
+				Expect(store.Close()).To(Succeed())
+
+				cfg.Dirname = filepath.Join(tempDir, "restored")
+				cfg.RestoreFrom = snapDir
+				store = MustSucceed(storage.Open(cfg))
+				Expect(MustSucceed(store.TS.RetrieveChannel(ctx, ch.Key)).Virtual).To(BeTrue())
+				v, closer := MustSucceed2(store.KV.Get(ctx, []byte("key")))
+				Expect(v).To(Equal([]byte("value")))
+				Expect(closer.Close()).To(Succeed())
+				Expect(store.Close()).To(Succeed())
+			})
+			It("Should return an error if the snapshot directory already exists", func() {
+				store := MustSucceed(storage.Open(cfg))
+				Expect(os.Mkdir(snapDir, xfs.OS_USER_RWX)).To(Succeed())
+				Expect(store.Snapshot(ctx, snapDir)).To(HaveOccurredAs(validate.Error))
+				Expect(store.Close()).To(Succeed())
+			})
+			It("Should return an error if the snapshot does not contain a key-value store", func() {
+				store := MustSucceed(storage.Open(cfg))
+				tsDir := filepath.Join(snapDir, "cesium")
+				Expect(store.TS.Snapshot(ctx, MustSucceed(xfs.Default.Sub(tsDir)))).To(Succeed())
+				Expect(store.Close()).To(Succeed())
+
+				cfg.Dirname = filepath.Join(tempDir, "restored")
+				cfg.RestoreFrom = snapDir
+				_, err := storage.Open(cfg)
+				Expect(err).To(MatchError(ContainSubstring("does not exist")))
+			})
+			It("Should return an error when restoring into a node with a key-value store", func() {
+				store := MustSucceed(storage.Open(cfg))
+				Expect(store.Snapshot(ctx, snapDir)).To(Succeed())
+				Expect(store.Close()).To(Succeed())
+
+				cfg.RestoreFrom = snapDir
+				_, err := storage.Open(cfg)
+				Expect(err).To(HaveOccurredAs(validate.Error))
+			})
+			It("Should return an error if the snapshot directory does not exist", func() {
+				cfg.RestoreFrom = filepath.Join(tempDir, "missing")
+				_, err := storage.Open(cfg)
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 	Describe("ServiceConfig", func() {
 		DescribeTable("Validate", func(
@@ -130,6 +190,14 @@ var _ = Describe("storage", func() {
 				},
 				"permissions",
 			),
+			Entry("Restoring into memory-backed storage",
+				func(cfg storage.Config) storage.Config {
+					cfg.MemBacked = config.Bool(true)
+					cfg.RestoreFrom = "snapshot"
+					return cfg
+				},
+				"restoreFrom",
+			),
 		)
 	})
 })
@@ -68,8 +68,13 @@ var (
 		FS: xfs.Default,
 	}
 	ErrChannelNotfound = cesium.ErrChannelNotFound
+	ErrReplayOverflow  = cesium.ErrReplayOverflow
 )
 
+// MaxReplayPending is the maximum number of live frames a streamer holds while
+// replaying persisted samples.
+const MaxReplayPending = cesium.MaxReplayPending
+
 // Validate implements config.Config.
 func (c Config) Validate() error {
 	v := validate.New("ts")
@@ -0,0 +1,72 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package compress
+
+import "github.com/synnaxlabs/x/errors"
+
+var errShortBuffer = errors.New("compress: unexpected end of compressed data")
+
+// bitWriter appends values of arbitrary bit width to a byte slice, most significant
+// bit first.
+type bitWriter struct {
+	buf []byte
+	// free is the number of unused bits remaining in the last byte of buf.
+	free uint8
+}
+
+func (w *bitWriter) writeBit(bit bool) {
+	if w.free == 0 {
+		w.buf = append(w.buf, 0)
+		w.free = 8
+	}
+	w.free--
+	if bit {
+		w.buf[len(w.buf)-1] |= 1 << w.free
+	}
+}
+
+// writeBits writes the lowest n bits of v.
+func (w *bitWriter) writeBits(v uint64, n uint8) {
+	for n > 0 {
+		n--
+		w.writeBit((v>>n)&1 == 1)
+	}
+}
+
+// bitReader reads values written by a bitWriter.
+type bitReader struct {
+	buf []byte
+	// pos is the index of the next bit to read.
+	pos int
+}
+
+func (r *bitReader) readBit() (bool, error) {
+	if r.pos >= len(r.buf)*8 {
+		return false, errShortBuffer
+	}
+	b := r.buf[r.pos/8]&(1<<(7-r.pos%8)) != 0
+	r.pos++
+	return b, nil
+}
+
+func (r *bitReader) readBits(n uint8) (uint64, error) {
+	var v uint64
+	for ; n > 0; n-- {
+		b, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		v <<= 1
+		if b {
+			v |= 1
+		}
+	}
+	return v, nil
+}
@@ -10,9 +10,13 @@
 package compress_test
 
 import (
+	"math"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/synnaxlabs/x/binary/compress"
+	"github.com/synnaxlabs/x/telem"
+	. "github.com/synnaxlabs/x/testutil"
 )
 
 var _ = Describe("Compress", func() {
@@ -104,3 +108,82 @@ var _ = Describe("Compress", func() {
 		})
 	})
 })
+
+var _ = Describe("RunLength", func() {
+	var cd compress.CompressorDecompressor = compress.RunLength{}
+	DescribeTable("Round Trip", func(values []byte) {
+		compressed := MustSucceed(cd.Compress(values))
+		Expect(cd.Decompress(compressed)).To(Equal(values))
+	},
+		Entry("Empty", []byte(nil)),
+		Entry("Alternating", []byte{0, 1, 0, 1, 0, 1}),
+		Entry("Starting with one", []byte{1, 1, 1, 0, 0, 1, 1, 1, 1, 0}),
+		Entry("Single", []byte{1}),
+	)
+	It("Should correctly round trip runs longer than a byte", func() {
+		bytes := append(make([]byte, 300), 1, 1, 1, 0)
+		bytes = append(bytes, make([]byte, 70000)...)
+		compressed := MustSucceed(cd.Compress(bytes))
+		Expect(len(compressed)).To(BeNumerically("<", 10))
+		Expect(cd.Decompress(compressed)).To(Equal(bytes))
+	})
+	It("Should return an error for values that are not 0 or 1", func() {
+		_, err := cd.Compress([]byte{0, 1, 2})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("XOR", func() {
+	DescribeTable("Round Trip", func(width int, values []byte) {
+		x := compress.XOR{Width: width}
+		compressed, err := x.Compress(values)
+		Expect(err).NotTo(HaveOccurred())
+		result, err := x.Decompress(compressed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(values))
+	},
+		Entry("Empty", 8, []byte{}),
+		Entry("Float64", 8, telem.NewSeriesV[float64](1.5, 1.5, 1.75, 2.0, -12.125, 1e300, 0).Data),
+		Entry("Float32", 4, telem.NewSeriesV[float32](1.5, 1.5, 1.75, 2.0, -12.125, 3e38, 0).Data),
+		Entry("Single", 8, telem.NewSeriesV[float64](42).Data),
+	)
+	It("Should compress slowly changing values", func() {
+		values := make([]float64, 1000)
+		for i := range values {
+			values[i] = 20 + float64(i%10)*0.5
+		}
+		s := telem.NewSeries(values)
+		compressed := MustSucceed(compress.XOR{Width: 8}.Compress(s.Data))
+		Expect(len(compressed)).To(BeNumerically("<", len(s.Data)/2))
+		Expect(compress.XOR{Width: 8}.Decompress(compressed)).To(Equal(s.Data))
+	})
+	It("Should return an error when the length is not a multiple of the width", func() {
+		_, err := compress.XOR{Width: 8}.Compress([]byte{1, 2, 3})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("DeltaOfDelta", func() {
+	DescribeTable("Round Trip", func(values []byte) {
+		compressed, err := compress.DeltaOfDelta{}.Compress(values)
+		Expect(err).NotTo(HaveOccurred())
+		result, err := compress.DeltaOfDelta{}.Decompress(compressed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(values))
+	},
+		Entry("Empty", []byte{}),
+		Entry("Regular", telem.NewSecondsTSV(1, 2, 3, 4, 5, 6).Data),
+		Entry("Irregular", telem.NewSeriesV[int64](5, -3, 100, 101, 1<<40, -(1<<62), 1<<62, 7).Data),
+		Entry("Extremes", telem.NewSeriesV[int64](math.MaxInt64, math.MinInt64, 0, math.MaxInt64).Data),
+	)
+	It("Should compress regularly spaced timestamps to a fraction of their size", func() {
+		stamps := make([]telem.TimeStamp, 10000)
+		for i := range stamps {
+			stamps[i] = telem.TimeStamp(i) * telem.MillisecondTS
+		}
+		s := telem.NewSeries(stamps)
+		compressed := MustSucceed(compress.DeltaOfDelta{}.Compress(s.Data))
+		Expect(len(compressed)).To(BeNumerically("<", len(s.Data)/32))
+		Expect(compress.DeltaOfDelta{}.Decompress(compressed)).To(Equal(s.Data))
+	})
+})
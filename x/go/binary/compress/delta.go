@@ -0,0 +1,126 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package compress
+
+import (
+	"encoding/binary"
+
+	"github.com/synnaxlabs/x/errors"
+)
+
+// DeltaOfDelta compresses little-endian 64-bit integers (such as nanosecond
+// timestamps) by storing the difference between consecutive deltas in a variable
+// number of bits. Regularly spaced series compress to roughly a single bit per value.
+type DeltaOfDelta struct{}
+
+var _ CompressorDecompressor = DeltaOfDelta{}
+
+const deltaOfDeltaWidth = 8
+
+// deltaOfDeltaBuckets are the bit widths used to store zig-zag encoded deltas of
+// deltas. A zero value is stored as a single '0' bit. A non-zero value stored in the
+// n-th bucket is prefixed by n+1 '1' bits and a terminating '0' bit, which is omitted
+// for the last bucket.
+var deltaOfDeltaBuckets = []uint8{7, 9, 12, 32, 64}
+
+func zigZag(v int64) uint64 { return uint64((v << 1) ^ (v >> 63)) }
+
+func unZigZag(v uint64) int64 { return int64(v>>1) ^ -int64(v&1) }
+
+// Compress implements Compressor.
+func (d DeltaOfDelta) Compress(src []byte) ([]byte, error) {
+	if len(src)%deltaOfDeltaWidth != 0 {
+		return nil, errors.Newf(
+			"delta of delta compression: length %d is not a multiple of %d",
+			len(src),
+			deltaOfDeltaWidth,
+		)
+	}
+	count := len(src) / deltaOfDeltaWidth
+	w := bitWriter{buf: binary.LittleEndian.AppendUint32(nil, uint32(count))}
+	if count == 0 {
+		return w.buf, nil
+	}
+	var (
+		prev      = int64(binary.LittleEndian.Uint64(src))
+		prevDelta int64
+	)
+	w.writeBits(uint64(prev), 64)
+	for i := 1; i < count; i++ {
+		v := int64(binary.LittleEndian.Uint64(src[i*deltaOfDeltaWidth:]))
+		delta := v - prev
+		dod := zigZag(delta - prevDelta)
+		prev, prevDelta = v, delta
+		if dod == 0 {
+			w.writeBit(false)
+			continue
+		}
+		for j, size := range deltaOfDeltaBuckets {
+			w.writeBit(true)
+			last := j == len(deltaOfDeltaBuckets)-1
+			if last || dod < 1<<size {
+				if !last {
+					w.writeBit(false)
+				}
+				w.writeBits(dod, size)
+				break
+			}
+		}
+	}
+	return w.buf, nil
+}
+
+// Decompress implements Decompressor.
+func (d DeltaOfDelta) Decompress(src []byte) ([]byte, error) {
+	if len(src) < 4 {
+		return nil, errShortBuffer
+	}
+	count := int(binary.LittleEndian.Uint32(src))
+	dst := make([]byte, 0, count*deltaOfDeltaWidth)
+	if count == 0 {
+		return dst, nil
+	}
+	r := bitReader{buf: src[4:]}
+	first, err := r.readBits(64)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		prev      = int64(first)
+		prevDelta int64
+	)
+	dst = binary.LittleEndian.AppendUint64(dst, first)
+	for i := 1; i < count; i++ {
+		var dod uint64
+		for j, size := range deltaOfDeltaBuckets {
+			more, err := r.readBit()
+			if err != nil {
+				return nil, err
+			}
+			if !more {
+				if j > 0 {
+					if dod, err = r.readBits(deltaOfDeltaBuckets[j-1]); err != nil {
+						return nil, err
+					}
+				}
+				break
+			}
+			if j == len(deltaOfDeltaBuckets)-1 {
+				if dod, err = r.readBits(size); err != nil {
+					return nil, err
+				}
+			}
+		}
+		prevDelta += unZigZag(dod)
+		prev += prevDelta
+		dst = binary.LittleEndian.AppendUint64(dst, uint64(prev))
+	}
+	return dst, nil
+}
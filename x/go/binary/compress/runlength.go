@@ -0,0 +1,72 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package compress
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/synnaxlabs/x/errors"
+)
+
+// RunLength is a run-length encoder for byte slices whose values are restricted to 0
+// or 1, such as boolean channels stored as uint8. Unlike Bool, it supports runs of any
+// length. The first byte of the compressed output is the value of the first run, and
+// is followed by the uvarint encoded lengths of each run, alternating between values.
+type RunLength struct{}
+
+var _ CompressorDecompressor = RunLength{}
+
+// Compress implements Compressor. It returns an error if src contains a byte that is
+// not 0 or 1.
+func (r RunLength) Compress(src []byte) (dst []byte, err error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+	dst = make([]byte, 1, 1+binary.MaxVarintLen64)
+	dst[0] = src[0]
+	var (
+		prev  = src[0]
+		count uint64
+	)
+	for i, x := range src {
+		if x > 1 {
+			return nil, errors.Newf("run-length compression: value %d at position %d is not 0 or 1", x, i)
+		}
+		if x != prev {
+			dst = binary.AppendUvarint(dst, count)
+			count = 0
+			prev = x
+		}
+		count++
+	}
+	return binary.AppendUvarint(dst, count), nil
+}
+
+// Decompress implements Decompressor.
+func (r RunLength) Decompress(src []byte) (dst []byte, err error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+	cur := src[0]
+	if cur > 1 {
+		return nil, errors.Newf("run-length decompression: invalid starting value %d", cur)
+	}
+	for i := 1; i < len(src); {
+		count, n := binary.Uvarint(src[i:])
+		if n <= 0 {
+			return nil, errors.New("run-length decompression: malformed run length")
+		}
+		i += n
+		dst = append(dst, bytes.Repeat([]byte{cur}, int(count))...)
+		cur ^= 1
+	}
+	return dst, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package compress
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/synnaxlabs/x/errors"
+)
+
+// XOR implements the floating point compression scheme described in Facebook's Gorilla
+// paper. Each value is XORed with its predecessor, and only the meaningful bits of the
+// result are stored. XOR works best on slowly changing floating point series, and
+// operates on little-endian values of Width bytes. Width must be 4 or 8.
+type XOR struct {
+	// Width is the size of each value in bytes.
+	Width int
+}
+
+var _ CompressorDecompressor = XOR{}
+
+func (x XOR) validate(n int) error {
+	if x.Width != 4 && x.Width != 8 {
+		return errors.Newf("xor compression: unsupported value width %d", x.Width)
+	}
+	if n%x.Width != 0 {
+		return errors.Newf("xor compression: length %d is not a multiple of value width %d", n, x.Width)
+	}
+	return nil
+}
+
+func (x XOR) load(b []byte) uint64 {
+	if x.Width == 4 {
+		return uint64(binary.LittleEndian.Uint32(b))
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (x XOR) store(b []byte, v uint64) []byte {
+	if x.Width == 4 {
+		return binary.LittleEndian.AppendUint32(b, uint32(v))
+	}
+	return binary.LittleEndian.AppendUint64(b, v)
+}
+
+// Compress implements Compressor.
+func (x XOR) Compress(src []byte) ([]byte, error) {
+	if err := x.validate(len(src)); err != nil {
+		return nil, err
+	}
+	count := len(src) / x.Width
+	w := bitWriter{buf: binary.LittleEndian.AppendUint32(nil, uint32(count))}
+	if count == 0 {
+		return w.buf, nil
+	}
+	var (
+		width                  = uint8(x.Width * 8)
+		prev                   = x.load(src)
+		prevLeading, prevTrail = uint8(255), uint8(0)
+	)
+	w.writeBits(prev, width)
+	for i := 1; i < count; i++ {
+		v := x.load(src[i*x.Width:])
+		xor := v ^ prev
+		prev = v
+		if xor == 0 {
+			w.writeBit(false)
+			continue
+		}
+		w.writeBit(true)
+		leading := uint8(bits.LeadingZeros64(xor)) - (64 - width)
+		trailing := uint8(bits.TrailingZeros64(xor))
+		if prevLeading != 255 && leading >= prevLeading && trailing >= prevTrail {
+			// The meaningful bits fall within the previous block, so we can reuse it.
+			w.writeBit(false)
+			w.writeBits(xor>>prevTrail, width-prevLeading-prevTrail)
+			continue
+		}
+		w.writeBit(true)
+		sigBits := width - leading - trailing
+		w.writeBits(uint64(leading), 6)
+		w.writeBits(uint64(sigBits-1), 6)
+		w.writeBits(xor>>trailing, sigBits)
+		prevLeading, prevTrail = leading, trailing
+	}
+	return w.buf, nil
+}
+
+// Decompress implements Decompressor.
+func (x XOR) Decompress(src []byte) ([]byte, error) {
+	if err := x.validate(0); err != nil {
+		return nil, err
+	}
+	if len(src) < 4 {
+		return nil, errShortBuffer
+	}
+	count := int(binary.LittleEndian.Uint32(src))
+	dst := make([]byte, 0, count*x.Width)
+	if count == 0 {
+		return dst, nil
+	}
+	var (
+		r                      = bitReader{buf: src[4:]}
+		width                  = uint8(x.Width * 8)
+		prevLeading, prevTrail uint8
+	)
+	prev, err := r.readBits(width)
+	if err != nil {
+		return nil, err
+	}
+	dst = x.store(dst, prev)
+	for i := 1; i < count; i++ {
+		changed, err := r.readBit()
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			dst = x.store(dst, prev)
+			continue
+		}
+		newBlock, err := r.readBit()
+		if err != nil {
+			return nil, err
+		}
+		if newBlock {
+			leading, err := r.readBits(6)
+			if err != nil {
+				return nil, err
+			}
+			sigBits, err := r.readBits(6)
+			if err != nil {
+				return nil, err
+			}
+			prevLeading = uint8(leading)
+			prevTrail = width - prevLeading - uint8(sigBits+1)
+		}
+		meaningful, err := r.readBits(width - prevLeading - prevTrail)
+		if err != nil {
+			return nil, err
+		}
+		prev ^= meaningful << prevTrail
+		dst = x.store(dst, prev)
+	}
+	return dst, nil
+}
@@ -11,6 +11,8 @@
 package computron
 
 import (
+	"strings"
+
 	"github.com/synnaxlabs/x/errors"
 	"github.com/synnaxlabs/x/telem"
 	lua "github.com/yuin/gopher-lua"
@@ -23,6 +25,21 @@ type Calculator struct {
 	luaState *lua.LState
 	// compiledExpr is the compiled lua function that performs the calculation.
 	compiledExpr *lua.LFunction
+	// states holds the state of each call to a stateful function in the expression,
+	// indexed by the call site.
+	states []any
+	// time is the time of the sample being calculated, as set by SetTime.
+	time telem.TimeStamp
+	// timed is true if the time of the sample being calculated has been set.
+	timed bool
+	// period is the time between consecutive samples when no time is set, as set by
+	// SetPeriod.
+	period telem.TimeSpan
+	// vector is the vectorized form of the expression, or nil if the expression
+	// cannot be vectorized.
+	vector *vector
+	// series are the series set by SetSeries for the next call to RunSeries.
+	series map[string]telem.Series
 }
 
 // LValueFromSeries converts a numeric series value at an index to a lua value.
@@ -127,7 +144,11 @@ func parseSyntaxError(err error) error {
 
 // Open creates a new calculator with the given expression as the calculation.
 func Open(expr string) (calc *Calculator, err error) {
-	calc = &Calculator{luaState: lua.NewState(luaOptions)}
+	calc = &Calculator{
+		luaState: lua.NewState(luaOptions),
+		series:   make(map[string]telem.Series),
+		period:   telem.Second,
+	}
 
 	// Register the get function to access hyphenated variable names
 	calc.luaState.SetGlobal("get", calc.luaState.NewFunction(func(L *lua.LState) int {
@@ -136,9 +157,24 @@ func Open(expr string) (calc *Calculator, err error) {
 		L.Push(value)
 		return 1
 	}))
+	calc.registerStatefulFunctions()
 
-	calc.compiledExpr, err = calc.luaState.LoadString(expr)
-	return calc, parseSyntaxError(err)
+	chunk, err := parse.Parse(strings.NewReader(expr), "<string>")
+	if err != nil {
+		return calc, parseSyntaxError(&lua.ApiError{
+			Type:   lua.ApiErrorSyntax,
+			Object: lua.LString(err.Error()),
+			Cause:  err,
+		})
+	}
+	calc.vector, _ = vectorize(chunk)
+	calc.states = make([]any, bindCallSites(chunk))
+	proto, err := lua.Compile(chunk, "<string>")
+	if err != nil {
+		return calc, err
+	}
+	calc.compiledExpr = calc.luaState.NewFunctionFromProto(proto)
+	return calc, nil
 }
 
 // Set sets a variable in the calculator's lua state. This variable will be available
@@ -146,6 +182,28 @@ func Open(expr string) (calc *Calculator, err error) {
 // set in the state.
 func (c *Calculator) Set(name string, value lua.LValue) { c.luaState.SetGlobal(name, value) }
 
+// SetTime sets the time of the sample being calculated. Stateful functions that depend
+// on the time elapsed between samples, such as integrate and derivative, use the time
+// set before each call to Run. If no time is set, consecutive samples are treated as
+// being one period apart.
+func (c *Calculator) SetTime(ts telem.TimeStamp) { c.time, c.timed = ts, true }
+
+// SetPeriod sets the time between consecutive samples used by stateful functions when
+// no time is set by SetTime. The period defaults to one second.
+func (c *Calculator) SetPeriod(period telem.TimeSpan) { c.period = period }
+
+// Stateful returns true if the calculator's expression calls any stateful functions,
+// meaning that the result of each calculation depends on the samples calculated
+// before it.
+func (c *Calculator) Stateful() bool { return len(c.states) > 0 }
+
+// Reset clears the state of every stateful function in the expression, so that the
+// next calculation behaves as if it were the first.
+func (c *Calculator) Reset() {
+	clear(c.states)
+	c.timed = false
+}
+
 // Run evaluates the calculator's expression and returns the result. If an error occurs
 // during evaluation, the error is returned.
 func (c *Calculator) Run() (result lua.LValue, err error) {
@@ -157,6 +215,48 @@ func (c *Calculator) Run() (result lua.LValue, err error) {
 	return result, nil
 }
 
+// SetSeries sets a variable to the samples of a series for the next call to RunSeries.
+// A series with no samples sets the variable to nil.
+func (c *Calculator) SetSeries(name string, s telem.Series) { c.series[name] = s }
+
+// Vectorized returns true if the calculator's expression is simple enough to be
+// evaluated by RunSeries without calling into lua for each sample.
+func (c *Calculator) Vectorized() bool { return c.vector != nil }
+
+// RunSeries evaluates the calculator's expression once for each of n samples and
+// returns a series of the given data type holding the results. For the ith sample,
+// each variable set by SetSeries holds the ith sample of its series, or the last
+// sample if the series is shorter. Variables not set by SetSeries keep their current
+// values. The series set by SetSeries are cleared once RunSeries returns.
+//
+// Expressions that return arithmetic on numeric variables and literals are evaluated
+// in typed loops over whole series, while all other expressions are evaluated sample
+// by sample in lua, with the same results.
+func (c *Calculator) RunSeries(dt telem.DataType, n int64) (telem.Series, error) {
+	defer clear(c.series)
+	if c.vector != nil {
+		if out, ok := c.vector.run(c.series, dt, n); ok {
+			return out, nil
+		}
+	}
+	out := telem.AllocSeries(dt, n)
+	for i := range n {
+		for name, s := range c.series {
+			if len(s.Data) == 0 {
+				c.Set(name, lua.LNil)
+				continue
+			}
+			c.Set(name, LValueFromSeries(s, min(i, s.Len()-1)))
+		}
+		res, err := c.Run()
+		if err != nil {
+			return out, err
+		}
+		SetLValueOnSeries(res, out, i)
+	}
+	return out, nil
+}
+
 // Close clears all calculation resources. Once Close is called, no other methods
 // should be called on the calculator.
 func (c *Calculator) Close() { c.luaState.Close() }
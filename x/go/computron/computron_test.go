@@ -10,6 +10,8 @@
 package computron_test
 
 import (
+	"math"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/synnaxlabs/x/computron"
@@ -112,4 +114,320 @@ var _ = Describe("Computron", func() {
 			Expect(v.(lua.LNumber)).To(Equal(lua.LNumber(42)))
 		})
 	})
+
+	Describe("Stateful Functions", func() {
+		run := func(c *computron.Calculator, x float64) float64 {
+			c.Set("x", lua.LNumber(x))
+			return float64(MustSucceed(c.Run()).(lua.LNumber))
+		}
+		runTimed := func(c *computron.Calculator, ts telem.TimeStamp, x float64) float64 {
+			c.SetTime(ts)
+			return run(c, x)
+		}
+
+		It("Should calculate a moving average over a window of samples", func() {
+			c := MustSucceed(computron.Open("return moving_avg(x, 3)"))
+			defer c.Close()
+			Expect(c.Stateful()).To(BeTrue())
+			Expect(run(c, 3)).To(Equal(3.0))
+			Expect(run(c, 6)).To(Equal(4.5))
+			Expect(run(c, 9)).To(Equal(6.0))
+			Expect(run(c, 12)).To(Equal(9.0))
+		})
+
+		It("Should calculate the sum, minimum, and maximum over a window", func() {
+			c := MustSucceed(computron.Open(
+				"return moving_sum(x, 2) + 10 * moving_min(x, 2) + 100 * moving_max(x, 2)",
+			))
+			defer c.Close()
+			Expect(run(c, 1)).To(Equal(1.0 + 10 + 100))
+			Expect(run(c, 2)).To(Equal(3.0 + 10 + 200))
+			Expect(run(c, 0)).To(Equal(2.0 + 0 + 200))
+		})
+
+		It("Should integrate a value over the time between samples", func() {
+			c := MustSucceed(computron.Open("return integrate(x)"))
+			defer c.Close()
+			Expect(runTimed(c, 0, 2)).To(Equal(0.0))
+			Expect(runTimed(c, telem.SecondTS, 2)).To(Equal(2.0))
+			Expect(runTimed(c, 3*telem.SecondTS, 4)).To(Equal(8.0))
+		})
+
+		It("Should treat samples as one second apart when no time is set", func() {
+			c := MustSucceed(computron.Open("return integrate(x)"))
+			defer c.Close()
+			Expect(run(c, 1)).To(Equal(0.0))
+			Expect(run(c, 1)).To(Equal(1.0))
+			Expect(run(c, 1)).To(Equal(2.0))
+		})
+
+		It("Should treat samples as one period apart when a period is set", func() {
+			c := MustSucceed(computron.Open("return integrate(x)"))
+			defer c.Close()
+			c.SetPeriod(500 * telem.Millisecond)
+			Expect(run(c, 1)).To(Equal(0.0))
+			Expect(run(c, 1)).To(Equal(0.5))
+			Expect(run(c, 1)).To(Equal(1.0))
+		})
+
+		It("Should calculate the rate of change of a value", func() {
+			c := MustSucceed(computron.Open("return derivative(x)"))
+			defer c.Close()
+			Expect(runTimed(c, 0, 5)).To(Equal(0.0))
+			Expect(runTimed(c, 2*telem.SecondTS, 9)).To(Equal(2.0))
+			Expect(runTimed(c, 2*telem.SecondTS, 20)).To(Equal(2.0))
+			Expect(runTimed(c, 3*telem.SecondTS, 6)).To(Equal(-14.0))
+		})
+
+		It("Should calculate an exponential moving average", func() {
+			c := MustSucceed(computron.Open("return ema(x, 0.5)"))
+			defer c.Close()
+			Expect(run(c, 4)).To(Equal(4.0))
+			Expect(run(c, 8)).To(Equal(6.0))
+			Expect(run(c, 0)).To(Equal(3.0))
+		})
+
+		It("Should attenuate frequencies above the cutoff of a butterworth filter", func() {
+			c := MustSucceed(computron.Open("return butterworth(x, 1, 100)"))
+			defer c.Close()
+			var peak float64
+			for i := range 1000 {
+				// A 25 Hz signal sampled at 100 Hz, well above the 1 Hz cutoff.
+				y := run(c, math.Sin(2*math.Pi*25*float64(i)/100))
+				if i > 500 {
+					peak = max(peak, math.Abs(y))
+				}
+			}
+			Expect(peak).To(BeNumerically("<", 0.01))
+		})
+
+		It("Should pass frequencies below the cutoff of a butterworth filter", func() {
+			c := MustSucceed(computron.Open("return butterworth(x, 1)"))
+			defer c.Close()
+			var y float64
+			for i := range 500 {
+				y = runTimed(c, telem.TimeStamp(i)*10*telem.MillisecondTS, 5)
+			}
+			Expect(y).To(BeNumerically("~", 5, 1e-9))
+		})
+
+		It("Should keep separate state for each call in the expression", func() {
+			c := MustSucceed(computron.Open("return integrate(x) - integrate(2 * x)"))
+			defer c.Close()
+			Expect(run(c, 1)).To(Equal(0.0))
+			Expect(run(c, 1)).To(Equal(-1.0))
+			Expect(run(c, 1)).To(Equal(-2.0))
+		})
+
+		It("Should keep state for calls inside conditional branches", func() {
+			c := MustSucceed(computron.Open(`
+				if x > 0 then
+					return moving_sum(x, 10)
+				end
+				return moving_sum(-x, 10) * -1
+			`))
+			defer c.Close()
+			Expect(run(c, 1)).To(Equal(1.0))
+			Expect(run(c, -1)).To(Equal(-1.0))
+			Expect(run(c, 2)).To(Equal(3.0))
+			Expect(run(c, -3)).To(Equal(-4.0))
+		})
+
+		It("Should return nil and keep the state when called with nil", func() {
+			c := MustSucceed(computron.Open("return moving_sum(x, 10)"))
+			defer c.Close()
+			Expect(run(c, 1)).To(Equal(1.0))
+			c.Set("x", lua.LNil)
+			Expect(MustSucceed(c.Run())).To(Equal(lua.LNil))
+			Expect(run(c, 2)).To(Equal(3.0))
+		})
+
+		It("Should clear the state when reset", func() {
+			c := MustSucceed(computron.Open("return moving_sum(x, 10)"))
+			defer c.Close()
+			Expect(run(c, 1)).To(Equal(1.0))
+			Expect(run(c, 2)).To(Equal(3.0))
+			c.Reset()
+			Expect(run(c, 4)).To(Equal(4.0))
+		})
+
+		It("Should return an error for invalid arguments", func() {
+			c := MustSucceed(computron.Open("return ema(x, 2)"))
+			defer c.Close()
+			c.Set("x", lua.LNumber(1))
+			_, err := c.Run()
+			Expect(err).To(MatchError(ContainSubstring("smoothing factor")))
+			c = MustSucceed(computron.Open("return moving_avg(x, 'a')"))
+			defer c.Close()
+			c.Set("x", lua.LNumber(1))
+			_, err = c.Run()
+			Expect(err).To(MatchError(ContainSubstring("bad argument #2 to 'moving_avg'")))
+		})
+
+		It("Should not bind calls to local functions with the name of a stateful function", func() {
+			c := MustSucceed(computron.Open(`
+				local function integrate(v)
+					return v * 2
+				end
+				return integrate(x)
+			`))
+			defer c.Close()
+			Expect(c.Stateful()).To(BeFalse())
+			Expect(run(c, 3)).To(Equal(6.0))
+		})
+
+		It("Should not bind calls to local variables with the name of a stateful function", func() {
+			c := MustSucceed(computron.Open(`
+				local ema = function(v, alpha) return v + alpha end
+				return ema(x, 1) + moving_sum(x, 2)
+			`))
+			defer c.Close()
+			Expect(run(c, 1)).To(Equal(3.0))
+			Expect(run(c, 2)).To(Equal(6.0))
+		})
+
+		It("Should bind calls outside of the scope of a shadowing local", func() {
+			c := MustSucceed(computron.Open(`
+				local function double(integrate)
+					return integrate * 2
+				end
+				return double(x) + integrate(x)
+			`))
+			defer c.Close()
+			Expect(c.Stateful()).To(BeTrue())
+			Expect(run(c, 1)).To(Equal(2.0))
+			Expect(run(c, 1)).To(Equal(3.0))
+		})
+
+		It("Should not bind calls to user-defined global functions", func() {
+			c := MustSucceed(computron.Open(`
+				function derivative(v)
+					return v + 1
+				end
+				return derivative(x)
+			`))
+			defer c.Close()
+			Expect(c.Stateful()).To(BeFalse())
+			Expect(run(c, 3)).To(Equal(4.0))
+		})
+
+		It("Should not be stateful when no stateful functions are called", func() {
+			c := MustSucceed(computron.Open("return x * 2"))
+			defer c.Close()
+			Expect(c.Stateful()).To(BeFalse())
+		})
+	})
+	Describe("RunSeries", func() {
+		// runEach evaluates the expression sample by sample using Set and Run, to
+		// compare against the results of RunSeries.
+		runEach := func(
+			expr string,
+			vars map[string]telem.Series,
+			dt telem.DataType,
+			n int64,
+		) telem.Series {
+			c := MustSucceed(computron.Open(expr))
+			defer c.Close()
+			out := telem.AllocSeries(dt, n)
+			for i := range n {
+				for name, s := range vars {
+					c.Set(name, computron.LValueFromSeries(s, min(i, s.Len()-1)))
+				}
+				computron.SetLValueOnSeries(MustSucceed(c.Run()), out, i)
+			}
+			return out
+		}
+
+		DescribeTable("Vectorized expressions", func(expr string) {
+			vars := map[string]telem.Series{
+				"a": telem.NewSeriesV[int64](-3, 0, 7, 12),
+				"b": telem.NewSeriesV[float32](0.5, -2.25, 4, 1e-3),
+				"c": telem.NewSeriesV[uint8](1, 2, 255, 9),
+			}
+			for _, dt := range []telem.DataType{telem.Float64T, telem.Float32T, telem.Int32T, telem.Uint16T} {
+				c := MustSucceed(computron.Open(expr))
+				Expect(c.Vectorized()).To(BeTrue())
+				for name, s := range vars {
+					c.SetSeries(name, s)
+				}
+				out := MustSucceed(c.RunSeries(dt, 4))
+				c.Close()
+				Expect(out.DataType).To(Equal(dt))
+				Expect(out.Data).To(Equal(runEach(expr, vars, dt, 4).Data))
+			}
+		},
+			Entry("addition", "return a + b"),
+			Entry("precedence", "return a + b * c - 2 / c"),
+			Entry("parentheses", "return (a + b) * (c - 2)"),
+			Entry("exponentiation", "return 2 ^ c ^ 0.5"),
+			Entry("unary minus", "return -a ^ 2 - -b"),
+			Entry("constants", "return a * (2 + 3) / 1.5e2"),
+			Entry("division by zero", "return b / (a - a)"),
+			Entry("a single variable", "return c"),
+			Entry("a negated variable", "return -a"),
+		)
+
+		It("Should evaluate series of different lengths and data types on each run", func() {
+			c := MustSucceed(computron.Open("return a * 2 - b"))
+			defer c.Close()
+			c.SetSeries("a", telem.NewSeriesV[int64](1, 2, 3, 4))
+			c.SetSeries("b", telem.NewSeriesV[float64](0.5, 1))
+			out := MustSucceed(c.RunSeries(telem.Float64T, 4))
+			Expect(telem.Unmarshal[float64](out)).To(Equal([]float64{1.5, 3, 5, 7}))
+			c.SetSeries("a", telem.NewSeriesV[float32](1.5, 2.5))
+			c.SetSeries("b", telem.NewSeriesV[uint8](1, 2))
+			out = MustSucceed(c.RunSeries(telem.Float64T, 2))
+			Expect(telem.Unmarshal[float64](out)).To(Equal([]float64{2, 3}))
+		})
+
+		It("Should extend series shorter than the number of samples with their last value", func() {
+			c := MustSucceed(computron.Open("return a + b"))
+			defer c.Close()
+			c.SetSeries("a", telem.NewSeriesV[int64](1, 2, 3))
+			c.SetSeries("b", telem.NewSeriesV[int64](10))
+			out := MustSucceed(c.RunSeries(telem.Int64T, 3))
+			Expect(telem.Unmarshal[int64](out)).To(Equal([]int64{11, 12, 13}))
+		})
+
+		DescribeTable("Expressions evaluated by lua", func(expr string, expected []int64) {
+			c := MustSucceed(computron.Open(expr))
+			defer c.Close()
+			Expect(c.Vectorized()).To(BeFalse())
+			c.SetSeries("a", telem.NewSeriesV[int64](1, 2, 3))
+			out := MustSucceed(c.RunSeries(telem.Int64T, 3))
+			Expect(telem.Unmarshal[int64](out)).To(Equal(expected))
+		},
+			Entry("modulo", "return a % 2", []int64{1, 0, 1}),
+			Entry("function calls", "return math.max(a, 2)", []int64{2, 2, 3}),
+			Entry("stateful functions", "return moving_sum(a, 2)", []int64{1, 3, 5}),
+			Entry("multiple statements", "local b = a * 2 \n return b", []int64{2, 4, 6}),
+			Entry("conditionals", "if a > 1 then return a end return 0", []int64{0, 2, 3}),
+		)
+
+		It("Should fall back to lua for series that are not numeric", func() {
+			c := MustSucceed(computron.Open("return a * 2"))
+			defer c.Close()
+			Expect(c.Vectorized()).To(BeTrue())
+			c.SetSeries("a", telem.NewStringsV("1", "2"))
+			out := MustSucceed(c.RunSeries(telem.Int64T, 2))
+			Expect(telem.Unmarshal[int64](out)).To(Equal([]int64{2, 4}))
+		})
+
+		It("Should set variables with an empty series to nil", func() {
+			c := MustSucceed(computron.Open("return a + b"))
+			defer c.Close()
+			c.SetSeries("a", telem.NewSeriesV[int64](1))
+			c.SetSeries("b", telem.Series{})
+			_, err := c.RunSeries(telem.Int64T, 1)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should return an error when a variable is not set", func() {
+			c := MustSucceed(computron.Open("return a + b"))
+			defer c.Close()
+			c.SetSeries("a", telem.NewSeriesV[int64](1))
+			_, err := c.RunSeries(telem.Int64T, 1)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
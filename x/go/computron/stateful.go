@@ -0,0 +1,423 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package computron
+
+import (
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/synnaxlabs/x/telem"
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/ast"
+)
+
+// statefulFunction is a built-in function whose result depends on the values it was
+// called with for previous samples. Each call to a stateful function in an expression
+// keeps its own state, which persists across calls to Run until the calculator is
+// Reset or closed.
+type statefulFunction func(c *Calculator, state *any, a args) lua.LValue
+
+// statefulFunctions are the stateful functions available to expressions, keyed by
+// name.
+//
+//   - moving_avg(x, n), moving_sum(x, n), moving_min(x, n), and moving_max(x, n)
+//     return the average, sum, minimum, and maximum of the last n values of x.
+//   - integrate(x) returns the integral of x over time in seconds, using the
+//     trapezoidal rule.
+//   - derivative(x) returns the rate of change of x per second.
+//   - ema(x, alpha) returns the exponential moving average of x with the smoothing
+//     factor alpha, which must be in (0, 1].
+//   - butterworth(x, cutoff, rate) returns x filtered by a second order low-pass
+//     Butterworth filter with the given cutoff frequency in Hz. rate is the sample
+//     rate of x in Hz, and defaults to the rate derived from the time elapsed since
+//     the previous sample.
+//
+// Stateful functions called with nil, such as when a value is missing, return nil
+// and leave their state unchanged.
+var statefulFunctions = map[string]statefulFunction{
+	"moving_avg": movingWindow(func(values []float64) float64 {
+		return sum(values) / float64(len(values))
+	}),
+	"moving_sum":  movingWindow(sum),
+	"moving_min":  movingWindow(slices.Min[[]float64]),
+	"moving_max":  movingWindow(slices.Max[[]float64]),
+	"integrate":   integrate,
+	"derivative":  derivative,
+	"ema":         ema,
+	"butterworth": butterworth,
+}
+
+func (c *Calculator) registerStatefulFunctions() {
+	for name, f := range statefulFunctions {
+		c.luaState.SetGlobal(name, c.luaState.NewFunction(func(L *lua.LState) int {
+			site := int(L.CheckNumber(1))
+			a := args{L: L, name: name}
+			if L.Get(2) == lua.LNil {
+				L.Push(lua.LNil)
+				return 1
+			}
+			L.Push(f(c, &c.states[site], a))
+			return 1
+		}))
+	}
+}
+
+// args provides access to the arguments of a call to a stateful function, excluding
+// the call site index bound to the call when the expression was compiled.
+type args struct {
+	L    *lua.LState
+	name string
+}
+
+// number returns the nth argument as a number, raising an error if it is not one.
+func (a args) number(n int) float64 {
+	v, ok := a.L.Get(n + 1).(lua.LNumber)
+	if !ok {
+		a.L.RaiseError(
+			"bad argument #%d to '%s' (number expected, got %s)",
+			n,
+			a.name,
+			a.L.Get(n+1).Type(),
+		)
+	}
+	return float64(v)
+}
+
+// optNumber returns the nth argument as a number, or false if it was not provided.
+func (a args) optNumber(n int) (float64, bool) {
+	if a.L.Get(n+1) == lua.LNil {
+		return 0, false
+	}
+	return a.number(n), true
+}
+
+// stateOf returns the state of a call to a stateful function, allocating it on the
+// first call.
+func stateOf[T any](state *any) *T {
+	if *state == nil {
+		*state = new(T)
+	}
+	return (*state).(*T)
+}
+
+// previous is the previous value a stateful function was called with.
+type previous struct {
+	value float64
+	time  telem.TimeStamp
+	ok    bool
+}
+
+// elapsed returns the time in seconds from the previous sample to the sample being
+// calculated.
+func (c *Calculator) elapsed(p previous) float64 {
+	if !c.timed {
+		return c.period.Seconds()
+	}
+	return p.time.Span(c.time).Seconds()
+}
+
+func (c *Calculator) previous(v float64) previous {
+	return previous{value: v, time: c.time, ok: true}
+}
+
+func sum(values []float64) (s float64) {
+	for _, v := range values {
+		s += v
+	}
+	return s
+}
+
+func movingWindow(agg func([]float64) float64) statefulFunction {
+	type window struct{ values []float64 }
+	return func(c *Calculator, state *any, a args) lua.LValue {
+		x, n := a.number(1), int(a.number(2))
+		if n < 1 {
+			a.L.RaiseError("%s window size must be at least 1, got %d", a.name, n)
+		}
+		w := stateOf[window](state)
+		w.values = append(w.values, x)
+		if len(w.values) > n {
+			w.values = w.values[len(w.values)-n:]
+		}
+		return lua.LNumber(agg(w.values))
+	}
+}
+
+func integrate(c *Calculator, state *any, a args) lua.LValue {
+	type integral struct {
+		prev  previous
+		total float64
+	}
+	x := a.number(1)
+	s := stateOf[integral](state)
+	if s.prev.ok {
+		s.total += (x + s.prev.value) / 2 * c.elapsed(s.prev)
+	}
+	s.prev = c.previous(x)
+	return lua.LNumber(s.total)
+}
+
+func derivative(c *Calculator, state *any, a args) lua.LValue {
+	type rate struct {
+		prev previous
+		rate float64
+	}
+	x := a.number(1)
+	s := stateOf[rate](state)
+	// Samples at the same time as the previous sample keep the previous rate, as the
+	// rate of change between them is undefined.
+	if dt := c.elapsed(s.prev); s.prev.ok && dt > 0 {
+		s.rate = (x - s.prev.value) / dt
+	}
+	s.prev = c.previous(x)
+	return lua.LNumber(s.rate)
+}
+
+func ema(_ *Calculator, state *any, a args) lua.LValue {
+	type average struct {
+		value float64
+		ok    bool
+	}
+	x, alpha := a.number(1), a.number(2)
+	if alpha <= 0 || alpha > 1 {
+		a.L.RaiseError("ema smoothing factor must be in (0, 1], got %v", alpha)
+	}
+	s := stateOf[average](state)
+	if !s.ok {
+		s.value, s.ok = x, true
+	} else {
+		s.value = alpha*x + (1-alpha)*s.value
+	}
+	return lua.LNumber(s.value)
+}
+
+func butterworth(c *Calculator, state *any, a args) lua.LValue {
+	type filter struct {
+		prev   previous
+		x1, x2 float64
+		y1, y2 float64
+	}
+	x, cutoff := a.number(1), a.number(2)
+	s := stateOf[filter](state)
+	// Start the filter at rest at the first value to avoid a transient from zero.
+	if !s.prev.ok {
+		s.x1, s.x2, s.y1, s.y2 = x, x, x, x
+		s.prev = c.previous(x)
+		return lua.LNumber(x)
+	}
+	rate, ok := a.optNumber(3)
+	if !ok {
+		dt := c.elapsed(s.prev)
+		if dt <= 0 {
+			return lua.LNumber(s.y1)
+		}
+		rate = 1 / dt
+	}
+	if cutoff <= 0 || cutoff >= rate/2 {
+		a.L.RaiseError(
+			"butterworth cutoff must be between 0 and half the sample rate (%v Hz), got %v",
+			rate/2,
+			cutoff,
+		)
+	}
+	// Coefficients of a second order low-pass filter from the bilinear transform.
+	k := math.Tan(math.Pi * cutoff / rate)
+	norm := 1 / (1 + math.Sqrt2*k + k*k)
+	b0 := k * k * norm
+	a1 := 2 * (k*k - 1) * norm
+	a2 := (1 - math.Sqrt2*k + k*k) * norm
+	y := b0*x + 2*b0*s.x1 + b0*s.x2 - a1*s.y1 - a2*s.y2
+	s.x1, s.x2, s.y1, s.y2 = x, s.x1, y, s.y1
+	s.prev = c.previous(x)
+	return lua.LNumber(y)
+}
+
+// bindCallSites binds a unique call site index to every call to a stateful function
+// in the chunk by prepending it to the call's arguments, so that each call keeps its
+// own state. Calls to names that the chunk declares as local variables or functions
+// in scope, or assigns as global variables anywhere, do not call stateful functions
+// and are left unchanged. It returns the number of call sites.
+func bindCallSites(chunk []ast.Stmt) int {
+	globals := &binder{globals: make(map[string]bool), collect: true}
+	globals.block(chunk)
+	b := &binder{globals: globals.globals}
+	b.block(chunk)
+	return b.sites
+}
+
+// binder walks a chunk, tracking the local variables in scope. When collect is true,
+// it records the names of the global variables assigned in the chunk. Otherwise, it
+// binds call sites to the calls to stateful functions.
+type binder struct {
+	sites   int
+	collect bool
+	globals map[string]bool
+	// scopes are the names of the local variables declared in each enclosing block,
+	// innermost last.
+	scopes []map[string]bool
+}
+
+// block walks the statements of a block in a new scope that declares the given
+// local variables.
+func (b *binder) block(stmts []ast.Stmt, locals ...string) {
+	b.push(locals...)
+	b.stmts(stmts)
+	b.pop()
+}
+
+func (b *binder) push(locals ...string) {
+	b.scopes = append(b.scopes, make(map[string]bool, len(locals)))
+	b.declare(locals...)
+}
+
+func (b *binder) pop() { b.scopes = b.scopes[:len(b.scopes)-1] }
+
+func (b *binder) declare(names ...string) {
+	for _, name := range names {
+		b.scopes[len(b.scopes)-1][name] = true
+	}
+}
+
+func (b *binder) isLocal(name string) bool {
+	for _, scope := range b.scopes {
+		if scope[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// assign records an assignment to the variable identified by expr.
+func (b *binder) assign(expr ast.Expr) {
+	if ident, ok := expr.(*ast.IdentExpr); ok && b.collect && !b.isLocal(ident.Value) {
+		b.globals[ident.Value] = true
+	}
+}
+
+func (b *binder) stmts(stmts []ast.Stmt) {
+	for _, stmt := range stmts {
+		b.stmt(stmt)
+	}
+}
+
+func (b *binder) stmt(stmt ast.Stmt) {
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		for _, lhs := range s.Lhs {
+			b.assign(lhs)
+		}
+		b.exprs(s.Lhs)
+		b.exprs(s.Rhs)
+	case *ast.LocalAssignStmt:
+		// Local functions are in scope in their own body, which the parser does not
+		// distinguish from a local variable assigned a function.
+		if len(s.Exprs) == 1 {
+			if _, ok := s.Exprs[0].(*ast.FunctionExpr); ok {
+				b.declare(s.Names...)
+			}
+		}
+		b.exprs(s.Exprs)
+		b.declare(s.Names...)
+	case *ast.FuncCallStmt:
+		b.expr(s.Expr)
+	case *ast.DoBlockStmt:
+		b.block(s.Stmts)
+	case *ast.WhileStmt:
+		b.expr(s.Condition)
+		b.block(s.Stmts)
+	case *ast.RepeatStmt:
+		// The condition of a repeat statement is in the scope of its body.
+		b.push()
+		b.stmts(s.Stmts)
+		b.expr(s.Condition)
+		b.pop()
+	case *ast.IfStmt:
+		b.expr(s.Condition)
+		b.block(s.Then)
+		b.block(s.Else)
+	case *ast.NumberForStmt:
+		b.expr(s.Init)
+		b.expr(s.Limit)
+		b.expr(s.Step)
+		b.block(s.Stmts, s.Name)
+	case *ast.GenericForStmt:
+		b.exprs(s.Exprs)
+		b.block(s.Stmts, s.Names...)
+	case *ast.FuncDefStmt:
+		b.assign(s.Name.Func)
+		b.expr(s.Name.Func)
+		b.expr(s.Name.Receiver)
+		b.expr(s.Func)
+	case *ast.ReturnStmt:
+		b.exprs(s.Exprs)
+	}
+}
+
+func (b *binder) exprs(exprs []ast.Expr) {
+	for _, expr := range exprs {
+		b.expr(expr)
+	}
+}
+
+func (b *binder) expr(expr ast.Expr) {
+	switch e := expr.(type) {
+	case *ast.AttrGetExpr:
+		b.expr(e.Object)
+		b.expr(e.Key)
+	case *ast.TableExpr:
+		for _, f := range e.Fields {
+			b.expr(f.Key)
+			b.expr(f.Value)
+		}
+	case *ast.FuncCallExpr:
+		b.expr(e.Func)
+		b.expr(e.Receiver)
+		b.exprs(e.Args)
+		if b.collect {
+			return
+		}
+		if ident, ok := e.Func.(*ast.IdentExpr); ok && e.Receiver == nil && b.isStateful(ident.Value) {
+			site := &ast.NumberExpr{Value: strconv.Itoa(b.sites)}
+			e.Args = append([]ast.Expr{site}, e.Args...)
+			b.sites++
+		}
+	case *ast.LogicalOpExpr:
+		b.expr(e.Lhs)
+		b.expr(e.Rhs)
+	case *ast.RelationalOpExpr:
+		b.expr(e.Lhs)
+		b.expr(e.Rhs)
+	case *ast.StringConcatOpExpr:
+		b.expr(e.Lhs)
+		b.expr(e.Rhs)
+	case *ast.ArithmeticOpExpr:
+		b.expr(e.Lhs)
+		b.expr(e.Rhs)
+	case *ast.UnaryMinusOpExpr:
+		b.expr(e.Expr)
+	case *ast.UnaryNotOpExpr:
+		b.expr(e.Expr)
+	case *ast.UnaryLenOpExpr:
+		b.expr(e.Expr)
+	case *ast.FunctionExpr:
+		var params []string
+		if e.ParList != nil {
+			params = e.ParList.Names
+		}
+		b.block(e.Stmts, params...)
+	}
+}
+
+// isStateful returns true if name refers to a stateful function in the current scope.
+func (b *binder) isStateful(name string) bool {
+	_, ok := statefulFunctions[name]
+	return ok && !b.isLocal(name) && !b.globals[name]
+}
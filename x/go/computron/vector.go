@@ -0,0 +1,552 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package computron
+
+import (
+	goast "go/ast"
+	"go/token"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/synnaxlabs/x/calc"
+	"github.com/synnaxlabs/x/telem"
+	"github.com/synnaxlabs/x/types"
+	"github.com/yuin/gopher-lua/ast"
+)
+
+// vector is an arithmetic expression compiled to evaluate every sample of a series in
+// a single typed loop per operation, instead of calling into lua once per sample. A
+// vector keeps the buffers of its operations between runs, so it must not be run
+// concurrently.
+type vector struct {
+	root vectorNode
+	// values holds the results of the expression as float64 values, as lua calculates
+	// them, before they are converted to the data type of the output series.
+	values []float64
+}
+
+// vectorize compiles the expression in the chunk to a vector. Only expressions that
+// return a single arithmetic expression of variables and number literals using the
+// +, -, *, /, and ^ operators can be vectorized. The arithmetic is translated to the
+// syntax of the calc package, and the syntax tree it builds is compiled to typed
+// loops. vectorize returns false if the expression cannot be vectorized.
+func vectorize(chunk []ast.Stmt) (*vector, bool) {
+	if len(chunk) != 1 {
+		return nil, false
+	}
+	ret, ok := chunk[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Exprs) != 1 {
+		return nil, false
+	}
+	var b strings.Builder
+	if !writeArithmetic(&b, ret.Exprs[0]) {
+		return nil, false
+	}
+	var expr calc.Expression
+	if err := expr.Build(b.String()); err != nil {
+		return nil, false
+	}
+	root, ok := compileVector(expr.Tree())
+	if !ok {
+		return nil, false
+	}
+	return &vector{root: root}, true
+}
+
+// writeArithmetic writes the lua expression to b in the syntax of the calc package,
+// returning false if the expression is not supported. Every operation is enclosed in
+// parentheses so that the precedence of the operators in lua is preserved.
+func writeArithmetic(b *strings.Builder, expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.NumberExpr:
+		v, err := strconv.ParseFloat(e.Value, 64)
+		if err != nil || math.IsInf(v, 0) {
+			return false
+		}
+		b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+		return true
+	case *ast.IdentExpr:
+		// Names such as inf and nan would be read as number literals by calc.
+		if _, err := strconv.ParseFloat(e.Value, 64); err == nil {
+			return false
+		}
+		b.WriteString(e.Value)
+		return true
+	case *ast.ArithmeticOpExpr:
+		if _, ok := luaOperators[e.Operator]; !ok {
+			return false
+		}
+		b.WriteString("(")
+		if !writeArithmetic(b, e.Lhs) {
+			return false
+		}
+		b.WriteString(e.Operator)
+		if !writeArithmetic(b, e.Rhs) {
+			return false
+		}
+		b.WriteString(")")
+		return true
+	case *ast.UnaryMinusOpExpr:
+		// calc negates an operand that follows an opening parenthesis by multiplying
+		// it by -1.
+		b.WriteString("(-")
+		if !writeArithmetic(b, e.Expr) {
+			return false
+		}
+		b.WriteString(")")
+		return true
+	default:
+		return false
+	}
+}
+
+// compileVector compiles a syntax tree built by the calc package to a vector node,
+// returning false if the tree holds an unsupported construct. Operations on constants
+// are folded into a single constant, as the lua compiler does.
+func compileVector(expr goast.Expr) (vectorNode, bool) {
+	switch e := expr.(type) {
+	case *goast.BasicLit:
+		v, err := strconv.ParseFloat(e.Value, 64)
+		return constantNode(v), err == nil && e.Kind == token.FLOAT
+	case *goast.Ident:
+		return &variableNode{name: e.Name}, true
+	case *goast.BinaryExpr:
+		// Number literals are written without a sign, so a literal -1 is the factor
+		// calc multiplies a negated operand by. It is compiled as a negation, which
+		// unlike arithmetic in lua keeps the sign of a negated zero.
+		if lit, ok := e.X.(*goast.BasicLit); ok && e.Op == token.MUL && lit.Value == "-1" {
+			operand, ok := compileVector(e.Y)
+			if !ok {
+				return nil, false
+			}
+			if c, ok := operand.(constantNode); ok {
+				return -c, true
+			}
+			return &negateNode{operand: operand}, true
+		}
+		op, ok := operators[e.Op]
+		if !ok {
+			return nil, false
+		}
+		lhs, ok := compileVector(e.X)
+		if !ok {
+			return nil, false
+		}
+		rhs, ok := compileVector(e.Y)
+		if !ok {
+			return nil, false
+		}
+		l, lConst := lhs.(constantNode)
+		r, rConst := rhs.(constantNode)
+		if lConst && rConst {
+			return constantNode(op.apply(float64(l), float64(r))), true
+		}
+		return &binaryNode{op: op, lhs: lhs, rhs: rhs}, true
+	default:
+		return nil, false
+	}
+}
+
+// run evaluates the vector over n samples of the given series, returning a series of
+// the given data type. run returns false if the data type or any series referenced by
+// the expression is not numeric or empty, or if the expression references a variable
+// without a series, in which case the expression must be evaluated by lua instead.
+func (v *vector) run(
+	series map[string]telem.Series,
+	dt telem.DataType,
+	n int64,
+) (telem.Series, bool) {
+	switch dt {
+	case telem.Int8T, telem.Int16T, telem.Int32T, telem.Int64T,
+		telem.Uint8T, telem.Uint16T, telem.Uint32T, telem.Uint64T,
+		telem.Float32T, telem.Float64T:
+	default:
+		return telem.Series{}, false
+	}
+	res, ok := v.root.eval(series, int(n))
+	if !ok {
+		return telem.Series{}, false
+	}
+	if res.column != nil {
+		v.values = res.column.float64s(v.values)
+	}
+	switch dt {
+	case telem.Int8T:
+		return encode[int8](res, v.values, dt, n), true
+	case telem.Int16T:
+		return encode[int16](res, v.values, dt, n), true
+	case telem.Int32T:
+		return encode[int32](res, v.values, dt, n), true
+	case telem.Int64T:
+		return encode[int64](res, v.values, dt, n), true
+	case telem.Uint8T:
+		return encode[uint8](res, v.values, dt, n), true
+	case telem.Uint16T:
+		return encode[uint16](res, v.values, dt, n), true
+	case telem.Uint32T:
+		return encode[uint32](res, v.values, dt, n), true
+	case telem.Uint64T:
+		return encode[uint64](res, v.values, dt, n), true
+	case telem.Float32T:
+		return encode[float32](res, v.values, dt, n), true
+	default:
+		return encode[float64](res, v.values, dt, n), true
+	}
+}
+
+// encode returns a series of n samples of the data type dt, whose values are of type
+// T. The samples hold the constant of res if it has no column, and values otherwise.
+func encode[T types.Numeric](
+	res operand,
+	values []float64,
+	dt telem.DataType,
+	n int64,
+) telem.Series {
+	var (
+		density = int(dt.Density())
+		data    = make([]byte, int(n)*density)
+		put     = telem.MarshalF[T](dt)
+	)
+	if res.column == nil {
+		v := T(res.value)
+		for i := range int(n) {
+			put(data[i*density:], v)
+		}
+	} else {
+		for i, v := range values {
+			put(data[i*density:], T(v))
+		}
+	}
+	return telem.Series{DataType: dt, Data: data}
+}
+
+// vectorNode is a node of a vectorized expression.
+type vectorNode interface {
+	// eval evaluates the node over n samples of the given series, returning false if
+	// the node references a variable without a numeric series.
+	eval(series map[string]telem.Series, n int) (operand, bool)
+}
+
+// operand is the value of a vector node. Constants apply to every sample and have no
+// column, while all other nodes hold one value per sample in their column.
+type operand struct {
+	column column
+	value  float64
+}
+
+// constantNode is a number literal, or an operation on number literals.
+type constantNode float64
+
+func (c constantNode) eval(map[string]telem.Series, int) (operand, bool) {
+	return operand{value: float64(c)}, true
+}
+
+// variableNode reads the samples of the series of a variable into a column of the
+// data type of the series.
+type variableNode struct {
+	name   string
+	values column
+}
+
+func (v *variableNode) eval(series map[string]telem.Series, n int) (operand, bool) {
+	s, ok := series[v.name]
+	if !ok || len(s.Data) == 0 {
+		return operand{}, false
+	}
+	switch s.DataType {
+	case telem.Int8T:
+		v.values = decode[int8](s, n, v.values)
+	case telem.Int16T:
+		v.values = decode[int16](s, n, v.values)
+	case telem.Int32T:
+		v.values = decode[int32](s, n, v.values)
+	case telem.Int64T:
+		v.values = decode[int64](s, n, v.values)
+	case telem.Uint8T:
+		v.values = decode[uint8](s, n, v.values)
+	case telem.Uint16T:
+		v.values = decode[uint16](s, n, v.values)
+	case telem.Uint32T:
+		v.values = decode[uint32](s, n, v.values)
+	case telem.Uint64T:
+		v.values = decode[uint64](s, n, v.values)
+	case telem.Float32T:
+		v.values = decode[float32](s, n, v.values)
+	case telem.Float64T:
+		v.values = decode[float64](s, n, v.values)
+	default:
+		return operand{}, false
+	}
+	return operand{column: v.values}, true
+}
+
+// decode reads the first n samples of the series into a column of type T, reusing the
+// previous column if it has the same type. Series shorter than n are extended with
+// their last sample.
+func decode[T types.Numeric](s telem.Series, n int, prev column) column {
+	values, _ := prev.(typedColumn[T])
+	values = grow(values, n)
+	var (
+		density = int(s.DataType.Density())
+		last    = int(s.Len()) - 1
+		get     = telem.UnmarshalF[T](s.DataType)
+	)
+	for i := range values {
+		values[i] = get(s.Data[min(i, last)*density:])
+	}
+	return values
+}
+
+// negateNode negates the values of its operand.
+type negateNode struct {
+	operand vectorNode
+	values  []float64
+}
+
+func (u *negateNode) eval(series map[string]telem.Series, n int) (operand, bool) {
+	o, ok := u.operand.eval(series, n)
+	if !ok {
+		return operand{}, false
+	}
+	u.values = grow(u.values, n)
+	o.column.negate(u.values)
+	return operand{column: typedColumn[float64](u.values)}, true
+}
+
+// binaryNode applies an arithmetic operator to the values of its operands. At most one
+// of the operands is constant.
+type binaryNode struct {
+	op       operator
+	lhs, rhs vectorNode
+	values   []float64
+}
+
+func (b *binaryNode) eval(series map[string]telem.Series, n int) (operand, bool) {
+	l, ok := b.lhs.eval(series, n)
+	if !ok {
+		return operand{}, false
+	}
+	r, ok := b.rhs.eval(series, n)
+	if !ok {
+		return operand{}, false
+	}
+	b.values = grow(b.values, n)
+	if l.column == nil {
+		r.column.applyRight(b.op, l.value, b.values)
+	} else {
+		l.column.applyLeft(b.op, r, b.values)
+	}
+	// Lua stores the results of arithmetic that are equal to zero as positive zero.
+	for i, v := range b.values {
+		if v == 0 {
+			b.values[i] = 0
+		}
+	}
+	return operand{column: typedColumn[float64](b.values)}, true
+}
+
+// grow returns a slice of length n, reusing the memory of values if it is large
+// enough.
+func grow[T any](values []T, n int) []T { return slices.Grow(values[:0], n)[:n] }
+
+// operator is an arithmetic operator supported by vectorized expressions.
+type operator uint8
+
+const (
+	add operator = iota
+	sub
+	mul
+	div
+	pow
+)
+
+// luaOperators are the lua arithmetic operators that can be vectorized.
+var luaOperators = map[string]struct{}{"+": {}, "-": {}, "*": {}, "/": {}, "^": {}}
+
+// operators maps the tokens of the calc operators that can be vectorized to their
+// operator.
+var operators = map[token.Token]operator{
+	token.ADD: add,
+	token.SUB: sub,
+	token.MUL: mul,
+	token.QUO: div,
+	token.XOR: pow,
+}
+
+// apply applies the operator to l and r with the semantics of lua.
+func (o operator) apply(l, r float64) float64 {
+	switch o {
+	case add:
+		return l + r
+	case sub:
+		return l - r
+	case mul:
+		return l * r
+	case div:
+		return l / r
+	default:
+		return math.Pow(l, r)
+	}
+}
+
+// column holds one value per sample of the operand of an operation, in the type the
+// values were read or calculated in.
+type column interface {
+	// applyLeft stores the result of applying the operator to each value of the
+	// column and the matching value of r in out.
+	applyLeft(op operator, r operand, out []float64)
+	// applyRight stores the result of applying the operator to l and each value of
+	// the column in out.
+	applyRight(op operator, l float64, out []float64)
+	// negate stores the negation of each value of the column in out.
+	negate(out []float64)
+	// float64s returns the values of the column as float64 values, converting them
+	// into buf if they are of another type.
+	float64s(buf []float64) []float64
+}
+
+// typedColumn is a column of values of type T.
+type typedColumn[T types.Numeric] []T
+
+var _ column = typedColumn[float64](nil)
+
+func (c typedColumn[T]) applyLeft(op operator, r operand, out []float64) {
+	switch rc := r.column.(type) {
+	case nil:
+		applyScalarRight(op, c, r.value, out)
+	case typedColumn[int8]:
+		applyColumns(op, c, rc, out)
+	case typedColumn[int16]:
+		applyColumns(op, c, rc, out)
+	case typedColumn[int32]:
+		applyColumns(op, c, rc, out)
+	case typedColumn[int64]:
+		applyColumns(op, c, rc, out)
+	case typedColumn[uint8]:
+		applyColumns(op, c, rc, out)
+	case typedColumn[uint16]:
+		applyColumns(op, c, rc, out)
+	case typedColumn[uint32]:
+		applyColumns(op, c, rc, out)
+	case typedColumn[uint64]:
+		applyColumns(op, c, rc, out)
+	case typedColumn[float32]:
+		applyColumns(op, c, rc, out)
+	case typedColumn[float64]:
+		applyColumns(op, c, rc, out)
+	}
+}
+
+func (c typedColumn[T]) applyRight(op operator, l float64, out []float64) {
+	applyScalarLeft(op, l, c, out)
+}
+
+func (c typedColumn[T]) negate(out []float64) {
+	for i, v := range c {
+		out[i] = -float64(v)
+	}
+}
+
+func (c typedColumn[T]) float64s(buf []float64) []float64 {
+	if values, ok := any(c).(typedColumn[float64]); ok {
+		return values
+	}
+	buf = grow(buf, len(c))
+	for i, v := range c {
+		buf[i] = float64(v)
+	}
+	return buf
+}
+
+// applyColumns stores the result of applying the operator to each pair of values in
+// l and r in out.
+func applyColumns[L, R types.Numeric](op operator, l []L, r []R, out []float64) {
+	l, r = l[:len(out)], r[:len(out)]
+	switch op {
+	case add:
+		for i := range out {
+			out[i] = float64(l[i]) + float64(r[i])
+		}
+	case sub:
+		for i := range out {
+			out[i] = float64(l[i]) - float64(r[i])
+		}
+	case mul:
+		for i := range out {
+			out[i] = float64(l[i]) * float64(r[i])
+		}
+	case div:
+		for i := range out {
+			out[i] = float64(l[i]) / float64(r[i])
+		}
+	case pow:
+		for i := range out {
+			out[i] = math.Pow(float64(l[i]), float64(r[i]))
+		}
+	}
+}
+
+// applyScalarLeft stores the result of applying the operator to l and each value of r
+// in out.
+func applyScalarLeft[R types.Numeric](op operator, l float64, r []R, out []float64) {
+	r = r[:len(out)]
+	switch op {
+	case add:
+		for i := range out {
+			out[i] = l + float64(r[i])
+		}
+	case sub:
+		for i := range out {
+			out[i] = l - float64(r[i])
+		}
+	case mul:
+		for i := range out {
+			out[i] = l * float64(r[i])
+		}
+	case div:
+		for i := range out {
+			out[i] = l / float64(r[i])
+		}
+	case pow:
+		for i := range out {
+			out[i] = math.Pow(l, float64(r[i]))
+		}
+	}
+}
+
+// applyScalarRight stores the result of applying the operator to each value of l and
+// r in out.
+func applyScalarRight[L types.Numeric](op operator, l []L, r float64, out []float64) {
+	l = l[:len(out)]
+	switch op {
+	case add:
+		for i := range out {
+			out[i] = float64(l[i]) + r
+		}
+	case sub:
+		for i := range out {
+			out[i] = float64(l[i]) - r
+		}
+	case mul:
+		for i := range out {
+			out[i] = float64(l[i]) * r
+		}
+	case div:
+		for i := range out {
+			out[i] = float64(l[i]) / r
+		}
+	case pow:
+		for i := range out {
+			out[i] = math.Pow(float64(l[i]), r)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package fs
+
+import (
+	"io"
+	"os"
+	goPath "path"
+
+	"github.com/synnaxlabs/x/errors"
+)
+
+// CopyFile copies the file with the given name in src to the file with the given name
+// in dst, which may be a different file system. The destination file is first written
+// to a temporary file and then renamed, so a partially copied file is never visible
+// under dstName. If dstName already exists, it is replaced. Both the file and the
+// directory that holds it are synced before CopyFile returns, so the copy survives a
+// crash.
+func CopyFile(src FS, srcName string, dst FS, dstName string) (err error) {
+	r, err := src.Open(srcName, os.O_RDONLY)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Combine(err, r.Close()) }()
+	tmpName := dstName + ".tmp"
+	// Remove any temporary file left behind by a previous failed copy.
+	if err = dst.Remove(tmpName); err != nil {
+		return err
+	}
+	w, err := dst.Open(tmpName, os.O_CREATE|os.O_EXCL|os.O_WRONLY)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(w, r); err != nil {
+		return errors.Combine(err, w.Close())
+	}
+	if err = w.Sync(); err != nil {
+		return errors.Combine(err, w.Close())
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	if err = dst.Rename(tmpName, dstName); err != nil {
+		return err
+	}
+	return SyncDir(dst, goPath.Dir(dstName))
+}
+
+// SyncDir flushes the entries of the directory with the given name to stable storage,
+// so that files created in, removed from, or renamed within the directory are durable.
+func SyncDir(fs FS, name string) error {
+	// MemFS keeps nothing on stable storage, and cannot open its root directory.
+	if _, ok := fs.(*MemFS); ok {
+		return nil
+	}
+	d, err := fs.Open(name, os.O_RDONLY)
+	if err != nil {
+		return err
+	}
+	return errors.Combine(d.Sync(), d.Close())
+}
+
+// CopyDir recursively copies all files and directories in src to dst, which may be a
+// different file system. Each file is copied using CopyFile.
+func CopyDir(src FS, dst FS) error {
+	entries, err := src.List("")
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			if err = CopyFile(src, e.Name(), dst, e.Name()); err != nil {
+				return err
+			}
+			continue
+		}
+		subSrc, err := src.Sub(e.Name())
+		if err != nil {
+			return err
+		}
+		subDst, err := dst.Sub(e.Name())
+		if err != nil {
+			return err
+		}
+		if err = CopyDir(subSrc, subDst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
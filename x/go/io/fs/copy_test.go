@@ -0,0 +1,92 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package fs_test
+
+import (
+	"io"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	xfs "github.com/synnaxlabs/x/io/fs"
+	. "github.com/synnaxlabs/x/testutil"
+)
+
+var _ = Describe("CopyFile", func() {
+	var src, dst xfs.FS
+	BeforeEach(func() {
+		src = xfs.NewMem()
+		dst = MustSucceed(xfs.NewMem().Sub("dst"))
+		f := MustSucceed(src.Open("a.txt", os.O_CREATE|os.O_WRONLY))
+		MustSucceed(f.Write([]byte("hello world")))
+		Expect(f.Close()).To(Succeed())
+	})
+
+	readFile := func(fs xfs.FS, name string) string {
+		f := MustSucceed(fs.Open(name, os.O_RDONLY))
+		b := MustSucceed(io.ReadAll(f))
+		Expect(f.Close()).To(Succeed())
+		return string(b)
+	}
+
+	It("Should copy a file to another file system", func() {
+		Expect(xfs.CopyFile(src, "a.txt", dst, "b.txt")).To(Succeed())
+		Expect(readFile(dst, "b.txt")).To(Equal("hello world"))
+		Expect(MustSucceed(dst.Exists("b.txt.tmp"))).To(BeFalse())
+	})
+
+	It("Should replace an existing destination file", func() {
+		f := MustSucceed(dst.Open("b.txt", os.O_CREATE|os.O_WRONLY))
+		MustSucceed(f.Write([]byte("a much longer string than the source")))
+		Expect(f.Close()).To(Succeed())
+		Expect(xfs.CopyFile(src, "a.txt", dst, "b.txt")).To(Succeed())
+		Expect(readFile(dst, "b.txt")).To(Equal("hello world"))
+	})
+
+	It("Should return an error if the source file does not exist", func() {
+		Expect(xfs.CopyFile(src, "c.txt", dst, "b.txt")).To(MatchError(os.ErrNotExist))
+	})
+})
+
+var _ = Describe("CopyDir", func() {
+	It("Should recursively copy a directory to another file system", func() {
+		src := xfs.NewMem()
+		nested := MustSucceed(src.Sub("a/b"))
+		for _, fs := range []xfs.FS{src, nested} {
+			f := MustSucceed(fs.Open("c.txt", os.O_CREATE|os.O_WRONLY))
+			MustSucceed(f.Write([]byte("hello world")))
+			Expect(f.Close()).To(Succeed())
+		}
+		dst := MustSucceed(xfs.NewMem().Sub("dst"))
+		Expect(xfs.CopyDir(src, dst)).To(Succeed())
+		Expect(MustSucceed(dst.Exists("c.txt"))).To(BeTrue())
+		f := MustSucceed(dst.Open("a/b/c.txt", os.O_RDONLY))
+		Expect(MustSucceed(io.ReadAll(f))).To(Equal([]byte("hello world")))
+		Expect(f.Close()).To(Succeed())
+	})
+})
+
+var _ = Describe("SyncDir", func() {
+	It("Should sync a directory on disk", func() {
+		fs := MustSucceed(xfs.Default.Sub(GinkgoT().TempDir()))
+		f := MustSucceed(fs.Open("a.txt", os.O_CREATE|os.O_WRONLY))
+		Expect(f.Close()).To(Succeed())
+		Expect(xfs.SyncDir(fs, ".")).To(Succeed())
+	})
+
+	It("Should return an error if the directory does not exist", func() {
+		fs := MustSucceed(xfs.Default.Sub(GinkgoT().TempDir()))
+		Expect(xfs.SyncDir(fs, "missing")).To(MatchError(os.ErrNotExist))
+	})
+
+	It("Should do nothing for an in-memory file system", func() {
+		Expect(xfs.SyncDir(xfs.NewMem(), ".")).To(Succeed())
+	})
+})
@@ -0,0 +1,102 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package telem
+
+import "sort"
+
+// Replay tracks the samples of channels replayed from storage before their live
+// samples are streamed, so that the live samples that were already replayed can be
+// dropped.
+//
+// Live series are written with leading alignments that are only replaced by the
+// position of their domain once the domain is persisted, so live samples cannot be
+// matched to replayed samples by their alignment. They are instead matched by their
+// timestamps, which are held by the series of their index with the same alignment in
+// the same live frame.
+type Replay[K comparable] struct {
+	// indexes maps the key of each replayed channel to the key of its index. Index
+	// channels map to themselves.
+	indexes map[K]K
+	// ends maps the key of each replayed channel to the end of the time range of its
+	// replayed samples. Channels are removed once a live series with samples after
+	// the replayed samples is deduplicated.
+	ends map[K]TimeStamp
+}
+
+// NewReplay returns a Replay for channels with the given indexes, which map the key of
+// each replayed channel to the key of its index. Index channels must map to
+// themselves.
+func NewReplay[K comparable](indexes map[K]K) *Replay[K] {
+	return &Replay[K]{indexes: indexes, ends: make(map[K]TimeStamp, len(indexes))}
+}
+
+// Add records a series of replayed samples of the channel with the given key.
+func (r *Replay[K]) Add(key K, s Series) {
+	if end := s.TimeRange.End; end > r.ends[key] {
+		r.ends[key] = end
+	}
+}
+
+// Dedupe returns the keys and series of a live frame without the samples that were
+// already replayed. Series whose samples were all replayed are removed, and series
+// whose leading samples were replayed are sliced to the samples after them. Series
+// that are written without their index in the frame cannot be matched to replayed
+// samples, and are returned unchanged.
+func (r *Replay[K]) Dedupe(keys []K, series []Series) ([]K, []Series) {
+	if len(r.ends) == 0 {
+		return keys, series
+	}
+	dedupedKeys := make([]K, 0, len(keys))
+	deduped := make([]Series, 0, len(series))
+	for i, key := range keys {
+		s := series[i]
+		if end, replayed := r.ends[key]; replayed {
+			if stamps, ok := r.timeStamps(keys, series, key, s); ok {
+				n := sort.Search(len(stamps), func(i int) bool { return stamps[i] >= end })
+				if n == len(stamps) {
+					continue
+				}
+				// Samples are written in order, so every subsequent live sample of the
+				// channel is after the replayed samples.
+				delete(r.ends, key)
+				if n > 0 {
+					s = s.Slice(int64(n), int64(len(stamps)))
+					s.Alignment = s.Alignment.AddSamples(uint32(n))
+					s.TimeRange.Start = stamps[n]
+				}
+			}
+		}
+		dedupedKeys = append(dedupedKeys, key)
+		deduped = append(deduped, s)
+	}
+	return dedupedKeys, deduped
+}
+
+// timeStamps returns the timestamps of the samples in the live series of the channel
+// with the given key, which are held by the series of its index in the frame with the
+// same alignment.
+func (r *Replay[K]) timeStamps(
+	keys []K,
+	series []Series,
+	key K,
+	s Series,
+) ([]TimeStamp, bool) {
+	idx, ok := r.indexes[key]
+	if !ok {
+		return nil, false
+	}
+	for i, k := range keys {
+		idxSeries := series[i]
+		if k == idx && idxSeries.Alignment == s.Alignment && idxSeries.Len() == s.Len() {
+			return UnmarshalSlice[TimeStamp](idxSeries.Data, TimeStampT), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Synnax Labs, Inc.
+//
+// Use of this software is governed by the Business Source License included in the file
+// licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with the Business Source
+// License, use of this software will be governed by the Apache License, Version 2.0,
+// included in the file licenses/APL.txt.
+
+package telem_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/synnaxlabs/x/telem"
+)
+
+var _ = Describe("Replay", func() {
+	const (
+		idx   = 1
+		data  = 2
+		other = 3
+	)
+	var r *telem.Replay[int]
+	BeforeEach(func() {
+		r = telem.NewReplay(map[int]int{idx: idx, data: idx, other: 4})
+		replayed := telem.NewSecondsTSV(10, 11, 12)
+		replayed.TimeRange = (10 * telem.SecondTS).Range(12*telem.SecondTS + 1)
+		r.Add(idx, replayed)
+		r.Add(data, telem.Series{TimeRange: replayed.TimeRange})
+		r.Add(other, telem.Series{TimeRange: replayed.TimeRange})
+	})
+	live := func(alignment telem.AlignmentPair, stamps ...telem.TimeStamp) ([]int, []telem.Series) {
+		idxSeries := telem.NewSeries(stamps)
+		idxSeries.Alignment = alignment
+		values := make([]string, len(stamps))
+		for i, ts := range stamps {
+			values[i] = ts.String()
+		}
+		dataSeries := telem.NewStringsV(values...)
+		dataSeries.Alignment = alignment
+		return []int{idx, data}, []telem.Series{idxSeries, dataSeries}
+	}
+
+	It("Should drop live series whose samples were all replayed", func() {
+		keys, series := r.Dedupe(live(telem.NewAlignmentPair(1, 0), 11*telem.SecondTS, 12*telem.SecondTS))
+		Expect(keys).To(BeEmpty())
+		Expect(series).To(BeEmpty())
+	})
+
+	It("Should slice live series whose leading samples were replayed", func() {
+		keys, series := r.Dedupe(live(
+			telem.NewAlignmentPair(1, 0),
+			12*telem.SecondTS,
+			13*telem.SecondTS,
+			14*telem.SecondTS,
+		))
+		Expect(keys).To(Equal([]int{idx, data}))
+		Expect(telem.UnmarshalSlice[telem.TimeStamp](series[0].Data, telem.TimeStampT)).
+			To(Equal([]telem.TimeStamp{13 * telem.SecondTS, 14 * telem.SecondTS}))
+		Expect(series[1].Split()).To(HaveLen(2))
+		for _, s := range series {
+			Expect(s.Alignment).To(Equal(telem.NewAlignmentPair(1, 1)))
+			Expect(s.TimeRange.Start).To(Equal(13 * telem.SecondTS))
+		}
+	})
+
+	It("Should send every live sample after the first live series that was not replayed", func() {
+		_, series := r.Dedupe(live(telem.NewAlignmentPair(1, 0), 13*telem.SecondTS))
+		Expect(series).To(HaveLen(2))
+		keys, _ := r.Dedupe(live(telem.NewAlignmentPair(1, 1), 5*telem.SecondTS))
+		Expect(keys).To(Equal([]int{idx, data}))
+	})
+
+	It("Should not match live series written without their index", func() {
+		s := telem.NewSeriesV[int64](1)
+		keys, series := r.Dedupe([]int{other}, []telem.Series{s})
+		Expect(keys).To(Equal([]int{other}))
+		Expect(series).To(Equal([]telem.Series{s}))
+	})
+})
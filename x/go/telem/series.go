@@ -70,6 +70,24 @@ func (s Series) Split() [][]byte {
 	return o
 }
 
+// Slice returns the samples of the series in the range [start, end). The samples of
+// series with a fixed density data type share the data of the series. The time range
+// and alignment of the returned series are the same as those of the series.
+func (s Series) Slice(start, end int64) Series {
+	s.cachedLength = nil
+	if s.DataType.IsVariable() {
+		samples := s.Split()[start:end]
+		s.Data = make([]byte, 0, len(s.Data))
+		for _, sample := range samples {
+			s.Data = append(append(s.Data, sample...), '\n')
+		}
+		return s
+	}
+	d := int64(s.DataType.Density())
+	s.Data = s.Data[start*d : end*d]
+	return s
+}
+
 // ValueAt returns the numeric value at the given index in the series. ValueAt supports
 // negative indices, which will be wrapped around the end of the series. This function
 // cannot be used for variable density series.
@@ -223,3 +223,22 @@ func UnmarshalF[T types.Numeric](dt DataType) func(b []byte) (res T) {
 	}
 	panic("unsupported data type")
 }
+
+// UnmarshalFloat64F returns a function that unmarshals a single value of the specified
+// numeric DataType from a byte slice as a float64, preserving the sign of signed
+// integers. Returns nil if the DataType is not numeric.
+func UnmarshalFloat64F(dt DataType) func(b []byte) float64 {
+	switch dt {
+	case Float64T, Float32T, Uint64T, Uint32T, Uint16T, Uint8T:
+		return UnmarshalF[float64](dt)
+	case Int64T, TimeStampT:
+		return func(b []byte) float64 { return float64(int64(ByteOrder.Uint64(b))) }
+	case Int32T:
+		return func(b []byte) float64 { return float64(int32(ByteOrder.Uint32(b))) }
+	case Int16T:
+		return func(b []byte) float64 { return float64(int16(ByteOrder.Uint16(b))) }
+	case Int8T:
+		return func(b []byte) float64 { return float64(int8(b[0])) }
+	}
+	return nil
+}
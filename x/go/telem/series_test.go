@@ -97,6 +97,21 @@ var _ = Describe("Series", func() {
 			Expect(s.Len()).To(Equal(int64(3)))
 			Expect(telem.Unmarshal[uint8](s)).To(Equal(d))
 		})
+		It("Should unmarshal the samples of numeric series as float64 values", func() {
+			for _, s := range []telem.Series{
+				telem.NewSeriesV[int64](-3),
+				telem.NewSeriesV[int32](-3),
+				telem.NewSeriesV[int16](-3),
+				telem.NewSeriesV[int8](-3),
+				telem.NewSeriesV[float32](-3),
+				telem.NewSeriesV[float64](-3),
+			} {
+				Expect(telem.UnmarshalFloat64F(s.DataType)(s.Data)).To(Equal(float64(-3)))
+			}
+			s := telem.NewSeriesV[uint8](255)
+			Expect(telem.UnmarshalFloat64F(s.DataType)(s.Data)).To(Equal(float64(255)))
+			Expect(telem.UnmarshalFloat64F(telem.StringT)).To(BeNil())
+		})
 
 	})
 	Describe("ValueAt", func() {